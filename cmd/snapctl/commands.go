@@ -35,7 +35,7 @@ var (
 				{
 					Name:        "create",
 					Description: "Creates a new task in the snap scheduler",
-					Usage:       "There are two ways to create a task.\n\t1) Use a task manifest with [--task-manifest]\n\t2) Provide a workflow manifest and schedule details.\n\n\t* Note: Start and stop date/time are optional.\n",
+					Usage:       "There are two ways to create a task.\n\t1) Use a task manifest with [--task-manifest] and its parameters with [--param]\n\t2) Provide a workflow manifest and schedule details.\n\n\t* Note: Start and stop date/time are optional.\n",
 					Action:      createTask,
 					Flags: []cli.Flag{
 						flTaskManifest,
@@ -47,9 +47,52 @@ var (
 						flTaskSchedStopTime,
 						flTaskName,
 						flTaskSchedDuration,
+						flTaskSchedCatchUp,
+						flTaskSchedSplay,
+						flTaskSchedExclude,
 						flTaskSchedNoStart,
 						flTaskDeadline,
 						flTaskMaxFailures,
+						flTaskOverlap,
+						flTaskMaxConcurrent,
+						flTaskPriority,
+						flTaskParam,
+					},
+				},
+				{
+					Name:        "update",
+					Description: "Updates the schedule, workflow or options of an existing task in place",
+					Usage:       "update <task_id>\n\tThe task keeps its id; only the given parts are changed.\n\t1) Use a task manifest with [--task-manifest] and its parameters with [--param]\n\t2) Provide a workflow manifest with [--workflow-manifest]\n\t3) Provide schedule details and/or [--name], [--deadline], [--max-failures], [--overlap], [--priority].\n",
+					Action:      updateTask,
+					Flags: []cli.Flag{
+						flTaskManifest,
+						flWorkfowManifest,
+						flTaskSchedInterval,
+						flTaskSchedStartDate,
+						flTaskSchedStartTime,
+						flTaskSchedStopDate,
+						flTaskSchedStopTime,
+						flTaskName,
+						flTaskSchedDuration,
+						flTaskSchedCatchUp,
+						flTaskSchedSplay,
+						flTaskSchedExclude,
+						flTaskDeadline,
+						flTaskMaxFailures,
+						flTaskOverlap,
+						flTaskMaxConcurrent,
+						flTaskPriority,
+						flTaskParam,
+					},
+				},
+				{
+					Name:        "validate",
+					Description: "Validates a task manifest against the snap agent without creating the task",
+					Usage:       "validate [--task-manifest] [--param]",
+					Action:      validateTaskManifest,
+					Flags: []cli.Flag{
+						flTaskManifest,
+						flTaskParam,
 					},
 				},
 				{
@@ -93,6 +136,11 @@ var (
 					Usage:  "enable <task_id>",
 					Action: enableTask,
 				},
+				{
+					Name:   "history",
+					Usage:  "history <task_id>",
+					Action: taskHistory,
+				},
 			},
 		},
 		{
@@ -106,6 +154,11 @@ var (
 						flPluginAsc,
 					},
 				},
+				{
+					Name:   "install",
+					Usage:  "install <plugin_type>/<plugin_name>[@<plugin_version>]",
+					Action: installPlugin,
+				},
 				{
 					Name:   "unload",
 					Usage:  "unload <plugin_type> <plugin_name> <plugin_version>",
@@ -171,6 +224,29 @@ var (
 				},
 			},
 		},
+		{
+			Name: "trust",
+			Subcommands: []cli.Command{
+				{
+					Name:   "list",
+					Usage:  "list",
+					Action: listTrustedKeys,
+				},
+				{
+					Name:   "add",
+					Usage:  "add <openpgp|x509|ed25519> <key_path>",
+					Action: addTrustedKey,
+					Flags: []cli.Flag{
+						flKeyName,
+					},
+				},
+				{
+					Name:   "revoke",
+					Usage:  "revoke <key_id>",
+					Action: revokeTrustedKey,
+				},
+			},
+		},
 	}
 	tribeWarning  = "Can only be used when tribe mode is enabled."
 	tribeCommands = []cli.Command{
@@ -225,6 +301,11 @@ var (
 					Usage:  "members <agreement_name>",
 					Action: agreementMembers,
 				},
+				{
+					Name:   "status",
+					Usage:  "status <agreement_name>",
+					Action: agreementStatus,
+				},
 			},
 		},
 	}
@@ -25,7 +25,9 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/codegangsta/cli"
@@ -101,8 +103,34 @@ func getConfig(ctx *cli.Context) error {
 			printFields(w, false, 0, k, t.Value, t.Type())
 		case ctypes.ConfigValueStr:
 			printFields(w, false, 0, k, t.Value, t.Type())
+		case ctypes.ConfigValueStrList:
+			printFields(w, false, 0, k, strings.Join(t.Value, ","), t.Type())
+		case ctypes.ConfigValueFloatList:
+			printFields(w, false, 0, k, t.Value, t.Type())
+		case ctypes.ConfigValueMap:
+			printFields(w, false, 0, k, formatMap(t.Value), t.Type())
 		}
 	}
 
+	// Show the rules values are validated against so that users can find
+	// the valid values before a task fails
+	p := pClient.GetPlugin(ptyp, pname, pver)
+	if p.Err == nil && len(p.ReturnedPlugin.ConfigPolicy) > 0 {
+		w.Flush()
+		fmt.Printf("\n  Rules for %s:\n\n", pname)
+		printPolicy(w, 4, p.ReturnedPlugin.ConfigPolicy)
+	}
+
 	return nil
 }
+
+// formatMap returns the entries of the map as comma separated key=value
+// pairs sorted by key
+func formatMap(m map[string]string) string {
+	entries := make([]string, 0, len(m))
+	for k, v := range m {
+		entries = append(entries, k+"="+v)
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
@@ -114,6 +114,19 @@ var (
 		Name:  "duration, d",
 		Usage: "The amount of time to run the task [appends to start or creates a start time before a stop]",
 	}
+	flTaskSchedCatchUp = cli.StringFlag{
+		Name:  "catch-up",
+		Usage: "What to do about the missed intervals of the task schedule: skip, once or backfill [defaults to skip]",
+	}
+	flTaskSchedSplay = cli.StringFlag{
+		Name:  "splay",
+		Usage: "Bound of a random delay added to each interval of a simple or cron schedule [ex: 30s]",
+	}
+	flTaskSchedExclude = cli.StringSliceFlag{
+		Name:  "exclude",
+		Usage: "Period during which a simple or cron schedule does not fire as <start>/<stop> in RFC 3339 (may be repeated)",
+		Value: &cli.StringSlice{},
+	}
 	flTaskSchedNoStart = cli.BoolFlag{
 		Name:  "no-start",
 		Usage: "Do not start task on creation [normally started on creation]",
@@ -126,6 +139,23 @@ var (
 		Name:  "max-failures",
 		Usage: "The number of consecutive failures before snap disables the task",
 	}
+	flTaskOverlap = cli.StringFlag{
+		Name:  "overlap",
+		Usage: "What to do when the task is due to fire while it is still firing: skip, concurrent or cancel [defaults to skip]",
+	}
+	flTaskMaxConcurrent = cli.StringFlag{
+		Name:  "max-concurrent",
+		Usage: "The number of times the task can fire at once with the concurrent overlap policy",
+	}
+	flTaskPriority = cli.StringFlag{
+		Name:  "priority",
+		Usage: "The priority class the jobs of the task are queued with: high, normal or low [defaults to normal]",
+	}
+	flTaskParam = cli.StringSliceFlag{
+		Name:  "param",
+		Usage: "Value of a parameter declared by the task manifest as key=value (may be repeated)",
+		Value: &cli.StringSlice{},
+	}
 
 	// metric
 	flMetricVersion = cli.IntFlag{
@@ -137,6 +167,12 @@ var (
 		Usage: "A metric namespace",
 	}
 
+	// trust
+	flKeyName = cli.StringFlag{
+		Name:  "key-name, n",
+		Usage: "The name of an Ed25519 key, the identity of its signer",
+	}
+
 	// general
 	flVerbose = cli.BoolFlag{
 		Name:  "verbose",
@@ -138,7 +138,7 @@ func printMetric(metric *client.GetMetricResult, idx int) error {
 		//
 		//	  Rules for collecting /intel/mock/[host]/baz:
 		//
-		//	     NAME        TYPE            DEFAULT         REQUIRED     MINIMUM   MAXIMUM
+		//	     NAME        TYPE            DEFAULT         REQUIRED     MINIMUM   MAXIMUM   CONSTRAINTS
 
 		fmt.Printf("\n  Dynamic elements of namespace: %s\n\n", namespace)
 		printFields(w, true, 6, "NAME", "DESCRIPTION")
@@ -148,10 +148,7 @@ func printMetric(metric *client.GetMetricResult, idx int) error {
 		w.Flush()
 	}
 	fmt.Printf("\n  Rules for collecting %s:\n\n", namespace)
-	printFields(w, true, 6, "NAME", "TYPE", "DEFAULT", "REQUIRED", "MINIMUM", "MAXIMUM")
-	for _, rule := range metric.Metric.Policy {
-		printFields(w, true, 6, rule.Name, rule.Type, rule.Default, rule.Required, rule.Minimum, rule.Maximum)
-	}
+	printPolicy(w, 6, metric.Metric.Policy)
 	w.Flush()
 	return nil
 }
@@ -200,3 +197,33 @@ func getNamespace(mt *rbody.Metric) string {
 	}
 	return ns
 }
+
+// printPolicy prints the rules of a config policy indented by indent
+func printPolicy(w *tabwriter.Writer, indent int, policy []rbody.PolicyTable) {
+	printFields(w, true, indent, "NAME", "TYPE", "DEFAULT", "REQUIRED", "MINIMUM", "MAXIMUM", "CONSTRAINTS")
+	for _, rule := range policy {
+		printFields(w, true, indent, rule.Name, rule.Type, rule.Default, rule.Required, rule.Minimum, rule.Maximum, formatConstraints(rule))
+	}
+}
+
+// formatConstraints describes the constraints of a rule which are not
+// covered by its minimum and maximum
+func formatConstraints(rule rbody.PolicyTable) string {
+	var c []string
+	if len(rule.Allowed) > 0 {
+		c = append(c, "one of "+strings.Join(rule.Allowed, "|"))
+	}
+	if rule.Pattern != "" {
+		c = append(c, "matches "+rule.Pattern)
+	}
+	if rule.MinLength != nil {
+		c = append(c, fmt.Sprintf("min length %d", *rule.MinLength))
+	}
+	if rule.MaxLength != nil {
+		c = append(c, fmt.Sprintf("max length %d", *rule.MaxLength))
+	}
+	if len(rule.RequiredKeys) > 0 {
+		c = append(c, "keys "+strings.Join(rule.RequiredKeys, ","))
+	}
+	return strings.Join(c, "; ")
+}
@@ -63,6 +63,48 @@ func loadPlugin(ctx *cli.Context) error {
 	return nil
 }
 
+func installPlugin(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return newUsageError("Incorrect usage:", ctx)
+	}
+	// <plugin_type>/<plugin_name>[@<plugin_version>]
+	ref := ctx.Args().First()
+	pVer := 0
+	if i := strings.LastIndex(ref, "@"); i >= 0 {
+		v, err := strconv.Atoi(ref[i+1:])
+		if err != nil || v < 1 {
+			return newUsageError("Can't convert version string to integer", ctx)
+		}
+		pVer = v
+		ref = ref[:i]
+	}
+	parts := strings.SplitN(ref, "/", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return newUsageError("Must provide plugin type", ctx)
+	}
+	if parts[1] == "" {
+		return newUsageError("Must provide plugin name", ctx)
+	}
+
+	r := pClient.InstallPlugin(parts[0], parts[1], pVer)
+	if r.Err != nil {
+		if r.Err.Fields()["error"] != nil {
+			return fmt.Errorf("Error installing plugin:\n%v\n%v\n", r.Err.Error(), r.Err.Fields()["error"])
+		}
+		return fmt.Errorf("Error installing plugin:\n%v\n", r.Err.Error())
+	}
+	for _, p := range r.LoadedPlugins {
+		fmt.Println("Plugin installed")
+		fmt.Printf("Name: %s\n", p.Name)
+		fmt.Printf("Version: %d\n", p.Version)
+		fmt.Printf("Type: %s\n", p.Type)
+		fmt.Printf("Signed: %v\n", p.Signed)
+		fmt.Printf("Loaded Time: %s\n\n", p.LoadedTime().Format(timeFormat))
+	}
+
+	return nil
+}
+
 func unloadPlugin(ctx *cli.Context) error {
 	pType := ctx.Args().Get(0)
 	pName := ctx.Args().Get(1)
@@ -182,9 +224,13 @@ func listPlugins(ctx *cli.Context) error {
 			fmt.Println("No plugins found. Have you loaded a plugin?")
 			return nil
 		}
-		printFields(w, false, 0, "NAME", "VERSION", "TYPE", "SIGNED", "STATUS", "LOADED TIME")
+		printFields(w, false, 0, "NAME", "VERSION", "TYPE", "SIGNED", "SIGNER", "STATUS", "LOADED TIME")
 		for _, lp := range plugins.LoadedPlugins {
-			printFields(w, false, 0, lp.Name, lp.Version, lp.Type, lp.Signed, lp.Status, lp.LoadedTime().Format(timeFormat))
+			signer := lp.Signer
+			if lp.SignerRevoked {
+				signer += " (revoked)"
+			}
+			printFields(w, false, 0, lp.Name, lp.Version, lp.Type, lp.Signed, signer, lp.Status, lp.LoadedTime().Format(timeFormat))
 		}
 	}
 	w.Flush()
@@ -34,9 +34,10 @@ import (
 	"time"
 
 	"github.com/codegangsta/cli"
+	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/mgmt/rest/client"
+	"github.com/intelsdi-x/snap/pkg/schedule"
 	"github.com/intelsdi-x/snap/scheduler/wmap"
-	"github.com/robfig/cron"
 	"golang.org/x/crypto/ssh/terminal"
 
 	"github.com/ghodss/yaml"
@@ -84,7 +85,47 @@ type task struct {
 	Workflow    *wmap.WorkflowMap
 	Name        string
 	Deadline    string
-	MaxFailures int `json:"max-failures"`
+	MaxFailures *int `json:"max-failures"`
+	// Overlap is what the task does when it is due to fire while it is still
+	// firing
+	Overlap       string `json:"overlap"`
+	MaxConcurrent int    `json:"max-concurrent"`
+	Priority      string `json:"priority"`
+}
+
+// maxFailures returns the max-failures of the task or 0 if it has none
+func (t *task) maxFailures() int {
+	if t.MaxFailures == nil {
+		return 0
+	}
+	return *t.MaxFailures
+}
+
+// overlap returns the overlap policy of the task or nil if it has none
+func (t *task) overlap() *client.Overlap {
+	if t.Overlap == "" && t.MaxConcurrent == 0 {
+		return nil
+	}
+	return &client.Overlap{Policy: t.Overlap, MaxConcurrent: t.MaxConcurrent}
+}
+
+// setOverlapFromCliOptions sets the overlap policy of the task (if an
+// 'overlap' or a 'max-concurrent' value was provided in the CLI options)
+func (t *task) setOverlapFromCliOptions(ctx *cli.Context) error {
+	if ctx.IsSet("overlap") {
+		t.Overlap = ctx.String("overlap")
+	}
+	if ctx.IsSet("max-concurrent") {
+		maxConcurrent, err := stringValToInt(ctx.String("max-concurrent"))
+		if err != nil {
+			return err
+		}
+		t.MaxConcurrent = maxConcurrent
+		if t.Overlap == "" {
+			t.Overlap = core.TaskOverlapConcurrent
+		}
+	}
+	return nil
 }
 
 func createTask(ctx *cli.Context) error {
@@ -207,6 +248,54 @@ func (t *task) setWindowedSchedule(start *time.Time, stop *time.Time, duration *
 
 // parse the command-line options and use them to setup a new schedule for this task
 func (t *task) setScheduleFromCliOptions(ctx *cli.Context) error {
+	// a 'triggered' schedule fires on the events of other tasks, so none of the
+	// schedule options apply to it
+	if t.Schedule.Type == "triggered" {
+		for _, f := range []string{"interval", "start-date", "start-time", "stop-date", "stop-time", "duration", "catch-up", "splay", "exclude"} {
+			if ctx.IsSet(f) {
+				return fmt.Errorf("Usage error; cannot use --%s with a 'triggered' schedule", f)
+			}
+		}
+		return nil
+	}
+	// set the catch-up policy of the schedule (if a 'catch-up' value was provided in the CLI options)
+	catchUp := ctx.String("catch-up")
+	if ctx.IsSet("catch-up") || catchUp != "" {
+		t.Schedule.CatchUp = catchUp
+	}
+	// set the splay of the schedule (if a 'splay' value was provided in the CLI options)
+	splay := ctx.String("splay")
+	if ctx.IsSet("splay") || splay != "" {
+		if _, err := time.ParseDuration(splay); err != nil {
+			return fmt.Errorf("Usage error (bad splay format); %v", err)
+		}
+		t.Schedule.Splay = splay
+	}
+	// add the exclusions given in the CLI options to the schedule
+	for _, e := range ctx.StringSlice("exclude") {
+		excl, err := parseExclusion(e)
+		if err != nil {
+			return err
+		}
+		t.Schedule.Exclusions = append(t.Schedule.Exclusions, excl)
+	}
+	// a 'recurring' schedule fires on its interval within the windows of the
+	// task manifest, so only the interval option applies to it
+	if t.Schedule.Type == "recurring" {
+		for _, f := range []string{"start-date", "start-time", "stop-date", "stop-time", "duration"} {
+			if ctx.IsSet(f) {
+				return fmt.Errorf("Usage error; cannot use --%s with a 'recurring' schedule", f)
+			}
+		}
+		interval := ctx.String("interval")
+		if ctx.IsSet("interval") || interval != "" {
+			if _, err := time.ParseDuration(interval); err != nil {
+				return fmt.Errorf("Usage error (bad interval value); %v", err)
+			}
+			t.Schedule.Interval = interval
+		}
+		return nil
+	}
 	// check the start, stop, and duration values to see if we're looking at a windowed schedule (or not)
 	// first, get the parameters that define the windowed schedule
 	start := mergeDateTime(
@@ -245,7 +334,7 @@ func (t *task) setScheduleFromCliOptions(ctx *cli.Context) error {
 		_, err := time.ParseDuration(interval)
 		if err != nil {
 			// if that didn't work, then try parsing the interval as cron job entry
-			_, e := cron.Parse(interval)
+			_, _, e := schedule.ParseCronEntry(interval)
 			if e != nil {
 				return fmt.Errorf("Usage error (bad interval value): cannot parse interval value '%v' either as a duration or a cron entry", interval)
 			}
@@ -287,6 +376,24 @@ func (t *task) setScheduleFromCliOptions(ctx *cli.Context) error {
 	return nil
 }
 
+// parse an exclusion given as <start>/<stop> where start and stop are RFC 3339
+// times (for instance 2016-10-01T02:00:00Z/2016-10-01T04:00:00Z)
+func parseExclusion(s string) (core.ScheduleExclusion, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return core.ScheduleExclusion{}, fmt.Errorf("Usage error (bad exclusion format); expected <start>/<stop> but got '%v'", s)
+	}
+	start, err := time.Parse(time.RFC3339, parts[0])
+	if err != nil {
+		return core.ScheduleExclusion{}, fmt.Errorf("Usage error (bad exclusion start); %v", err)
+	}
+	stop, err := time.Parse(time.RFC3339, parts[1])
+	if err != nil {
+		return core.ScheduleExclusion{}, fmt.Errorf("Usage error (bad exclusion stop); %v", err)
+	}
+	return core.ScheduleExclusion{StartTimestamp: start.Unix(), StopTimestamp: stop.Unix()}, nil
+}
+
 // merge the command-line options into the current task
 func (t *task) mergeCliOptions(ctx *cli.Context) error {
 	// set the name of the task (if a 'name' was provided in the CLI options)
@@ -306,7 +413,13 @@ func (t *task) mergeCliOptions(ctx *cli.Context) error {
 		if err != nil {
 			return err
 		}
-		t.MaxFailures = maxFailures
+		t.MaxFailures = &maxFailures
+	}
+	if err := t.setOverlapFromCliOptions(ctx); err != nil {
+		return err
+	}
+	if ctx.IsSet("priority") {
+		t.Priority = ctx.String("priority")
 	}
 	// set the schedule for the task from the CLI options (and return the results
 	// of that method call, indicating whether or not an error was encountered while
@@ -314,30 +427,53 @@ func (t *task) mergeCliOptions(ctx *cli.Context) error {
 	return t.setScheduleFromCliOptions(ctx)
 }
 
-func createTaskUsingTaskManifest(ctx *cli.Context) error {
-	// get the task manifest file to use
+// readTaskManifest reads the task manifest given with --task-manifest as
+// JSON and expands the parameters it declares with the values given with
+// --param or else their defaults.  The format the manifest is written in is
+// returned with it.
+func readTaskManifest(ctx *cli.Context) ([]byte, string, error) {
 	path := ctx.String("task-manifest")
 	ext := filepath.Ext(path)
 	file, e := ioutil.ReadFile(path)
 	if e != nil {
-		return fmt.Errorf("File error [%s] - %v\n", ext, e)
+		return nil, "", fmt.Errorf("File error [%s] - %v\n", ext, e)
 	}
 
-	// create an empty task struct and unmarshal the contents of the file into that object
-	t := task{}
+	// convert YAML to JSON so the parameters of the manifest can be expanded
+	format := "JSON"
 	switch ext {
 	case ".yaml", ".yml":
-		e = yaml.Unmarshal(file, &t)
+		format = "YAML"
+		file, e = yaml.YAMLToJSON(file)
 		if e != nil {
-			return fmt.Errorf("Error parsing YAML file input - %v\n", e)
+			return nil, "", fmt.Errorf("Error parsing YAML file input - %v\n", e)
 		}
 	case ".json":
-		e = json.Unmarshal(file, &t)
-		if e != nil {
-			return fmt.Errorf("Error parsing JSON file input - %v\n", e)
-		}
 	default:
-		return fmt.Errorf("Unsupported file type %s\n", ext)
+		return nil, "", fmt.Errorf("Unsupported file type %s\n", ext)
+	}
+	params, e := core.ParseTaskParams(ctx.StringSlice("param"))
+	if e != nil {
+		return nil, "", e
+	}
+	file, e = core.ExpandTaskTemplate(file, params)
+	if e != nil {
+		return nil, "", fmt.Errorf("Error expanding task manifest - %v\n", e)
+	}
+	return file, format, nil
+}
+
+func createTaskUsingTaskManifest(ctx *cli.Context) error {
+	file, format, e := readTaskManifest(ctx)
+	if e != nil {
+		return e
+	}
+
+	// create an empty task struct and unmarshal the contents of the file into that object
+	t := task{}
+	e = json.Unmarshal(file, &t)
+	if e != nil {
+		return fmt.Errorf("Error parsing %s file input - %v\n", format, e)
 	}
 
 	// Validate task manifest includes schedule, workflow, and version
@@ -352,7 +488,7 @@ func createTaskUsingTaskManifest(ctx *cli.Context) error {
 	}
 
 	// and use the resulting struct to create a new task
-	r := pClient.CreateTask(t.Schedule, t.Workflow, t.Name, t.Deadline, !ctx.IsSet("no-start"), t.MaxFailures)
+	r := pClient.CreateTask(t.Schedule, t.Workflow, t.Name, t.Deadline, !ctx.IsSet("no-start"), t.maxFailures(), t.overlap(), t.Priority)
 
 	if r.Err != nil {
 		errors := strings.Split(r.Err.Error(), " -- ")
@@ -371,6 +507,9 @@ func createTaskUsingTaskManifest(ctx *cli.Context) error {
 }
 
 func createTaskUsingWFManifest(ctx *cli.Context) error {
+	if len(ctx.StringSlice("param")) > 0 {
+		return newUsageError("Parameters can only be used with a task manifest", ctx)
+	}
 	// Get the workflow manifest filename from the command-line
 	path := ctx.String("workflow-manifest")
 	ext := filepath.Ext(path)
@@ -415,7 +554,7 @@ func createTaskUsingWFManifest(ctx *cli.Context) error {
 	}
 
 	// and use the resulting struct (along with the workflow map we constructed, above) to create a new task
-	r := pClient.CreateTask(t.Schedule, wf, t.Name, t.Deadline, !ctx.IsSet("no-start"), t.MaxFailures)
+	r := pClient.CreateTask(t.Schedule, wf, t.Name, t.Deadline, !ctx.IsSet("no-start"), t.maxFailures(), t.overlap(), t.Priority)
 	if r.Err != nil {
 		errors := strings.Split(r.Err.Error(), " -- ")
 		errString := "Error creating task:"
@@ -432,6 +571,135 @@ func createTaskUsingWFManifest(ctx *cli.Context) error {
 	return nil
 }
 
+func updateTask(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return newUsageError("Incorrect usage", ctx)
+	}
+	id := ctx.Args().First()
+
+	if len(ctx.StringSlice("param")) > 0 && !ctx.IsSet("task-manifest") {
+		return newUsageError("Parameters can only be used with a task manifest", ctx)
+	}
+
+	// start from an empty task; only the parts that are provided are changed
+	t := task{}
+	if ctx.IsSet("task-manifest") {
+		file, format, e := readTaskManifest(ctx)
+		if e != nil {
+			return e
+		}
+		if e := json.Unmarshal(file, &t); e != nil {
+			return fmt.Errorf("Error parsing %s file input - %v\n", format, e)
+		}
+		if err := validateTask(t); err != nil {
+			return err
+		}
+	} else if ctx.IsSet("workflow-manifest") {
+		path := ctx.String("workflow-manifest")
+		ext := filepath.Ext(path)
+		file, e := ioutil.ReadFile(path)
+		if e != nil {
+			return fmt.Errorf("File error [%s] - %v\n", ext, e)
+		}
+		switch ext {
+		case ".yaml", ".yml":
+			t.Workflow, e = wmap.FromYaml(file)
+			if e != nil {
+				return fmt.Errorf("Error parsing YAML file input - %v\n", e)
+			}
+		case ".json":
+			t.Workflow, e = wmap.FromJson(file)
+			if e != nil {
+				return fmt.Errorf("Error parsing JSON file input - %v\n", e)
+			}
+		default:
+			return fmt.Errorf("Unsupported file type %s\n", ext)
+		}
+	}
+
+	// a new schedule is only sent when the manifest has one or the
+	// schedule flags were given on the command-line
+	scheduleSet := false
+	for _, f := range []string{"interval", "start-date", "start-time", "stop-date", "stop-time", "duration", "catch-up", "splay", "exclude"} {
+		if ctx.IsSet(f) {
+			scheduleSet = true
+		}
+	}
+	if t.Schedule == nil && scheduleSet {
+		t.Schedule = &client.Schedule{}
+	}
+	if t.Schedule != nil {
+		if err := t.mergeCliOptions(ctx); err != nil {
+			return err
+		}
+	} else {
+		// no schedule so only the name, deadline, max-failures, overlap and
+		// priority are merged
+		t.Name = ctx.String("name")
+		t.Deadline = ctx.String("deadline")
+		if ctx.IsSet("max-failures") {
+			maxFailures, err := stringValToInt(ctx.String("max-failures"))
+			if err != nil {
+				return err
+			}
+			t.MaxFailures = &maxFailures
+		}
+		if err := t.setOverlapFromCliOptions(ctx); err != nil {
+			return err
+		}
+		if ctx.IsSet("priority") {
+			t.Priority = ctx.String("priority")
+		}
+	}
+	if t.Schedule == nil && t.Workflow == nil && t.Name == "" && t.Deadline == "" && t.MaxFailures == nil && t.overlap() == nil && t.Priority == "" {
+		return newUsageError("Must provide a manifest, a schedule or at least one of --name, --deadline, --max-failures, --overlap or --priority", ctx)
+	}
+
+	r := pClient.UpdateTask(id, t.Schedule, t.Workflow, t.Name, t.Deadline, t.MaxFailures, t.overlap(), t.Priority)
+	if r.Err != nil {
+		errors := strings.Split(r.Err.Error(), " -- ")
+		errString := "Error updating task:"
+		for _, err := range errors {
+			errString += fmt.Sprintf("%v\n", err)
+		}
+		return fmt.Errorf(errString)
+	}
+	fmt.Println("Task updated")
+	fmt.Printf("ID: %s\n", r.ID)
+	fmt.Printf("Name: %s\n", r.Name)
+	fmt.Printf("State: %s\n", r.State)
+
+	return nil
+}
+
+func validateTaskManifest(ctx *cli.Context) error {
+	if !ctx.IsSet("task-manifest") {
+		return newUsageError("Must provide --task-manifest argument", ctx)
+	}
+	// the manifest is sent expanded like it is on creation; the expansion
+	// keeps the fields in place so the paths of the errors point into it
+	file, _, e := readTaskManifest(ctx)
+	if e != nil {
+		return e
+	}
+
+	r := pClient.ValidateTask(file)
+	if r.Err != nil {
+		return fmt.Errorf("Error validating task:\n%v\n", r.Err)
+	}
+	if r.Valid {
+		fmt.Println("Task is valid")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	printFields(w, false, 0, "PATH", "ERROR")
+	for _, e := range r.Errors {
+		printFields(w, false, 0, e.Path, e.Message)
+	}
+	w.Flush()
+	return fmt.Errorf("Task is not valid (%d errors)", len(r.Errors))
+}
+
 func mergeDateTime(tm, dt string) *time.Time {
 	reTm := time.Now().Add(createTaskNowPad)
 	if dt == "" && tm == "" {
@@ -490,10 +758,14 @@ func listTask(ctx *cli.Context) error {
 		if termWidth < 165 {
 			verbose = true
 		}
+		state := task.State
+		if task.Dormant {
+			state = fmt.Sprintf("Dormant until %s", time.Unix(task.DormantUntilTimestamp, 0).Format(unionParseFormat))
+		}
 		printFields(w, false, 0,
 			task.ID,
 			fixSize(verbose, task.Name, 41),
-			task.State,
+			state,
 			trunc(task.HitCount),
 			trunc(task.MissCount),
 			trunc(task.FailedCount),
@@ -690,6 +962,73 @@ func exportTask(ctx *cli.Context) error {
 	return nil
 }
 
+// taskHistory prints the records of the last fires of a task from the most
+// recent, with a row for each stage of the workflow of a fire
+func taskHistory(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return newUsageError("Incorrect usage", ctx)
+	}
+	id := ctx.Args().First()
+	r := pClient.GetTaskHistory(id)
+	if r.Err != nil {
+		return fmt.Errorf("Error getting task history:\n%v\n", r.Err)
+	}
+	if len(r.Runs) == 0 {
+		fmt.Println("Task has not fired yet.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	printFields(w, false, 0,
+		"FIRED",
+		"DURATION",
+		"STATUS",
+		"NODE",
+		"NODE DURATION",
+		"METRICS",
+		"ERRORS",
+	)
+	for _, run := range r.Runs {
+		status := "ok"
+		switch {
+		case run.Canceled:
+			status = "canceled"
+		case run.Failed:
+			status = "failed"
+		}
+		fired := time.Unix(run.FireTimestamp, 0).Format(unionParseFormat)
+		duration := msDuration(run.DurationMs)
+		if len(run.Nodes) == 0 {
+			printFields(w, false, 0, fired, duration, status, "", "", "", "")
+		}
+		for i, n := range run.Nodes {
+			node := n.Type
+			if n.Name != "" {
+				node = fmt.Sprintf("%s:%s:%d", n.Type, n.Name, n.Version)
+			}
+			printFields(w, false, 0,
+				fired,
+				duration,
+				status,
+				node,
+				msDuration(n.DurationMs),
+				n.MetricCount,
+				strings.Join(n.Errors, "; "),
+			)
+			if i == 0 {
+				fired, duration, status = "", "", ""
+			}
+		}
+	}
+	w.Flush()
+	return nil
+}
+
+// msDuration returns a duration given in milliseconds as a string
+func msDuration(ms float64) string {
+	return (time.Duration(ms * float64(time.Millisecond))).String()
+}
+
 func enableTask(ctx *cli.Context) error {
 	if len(ctx.Args()) != 1 {
 		return newUsageError("Incorrect usage", ctx)
@@ -739,7 +1078,7 @@ func validateScheduleExists(schedule *client.Schedule) error {
 	if schedule == nil {
 		return fmt.Errorf("Error: Task manifest did not include a schedule")
 	}
-	if *schedule == (client.Schedule{}) {
+	if schedule.Type == "" && schedule.Interval == "" && schedule.StartTime == nil && schedule.StopTime == nil && len(schedule.Triggers) == 0 {
 		return fmt.Errorf("Error: Task manifest included an empty schedule. Task manifests need to include a schedule.")
 	}
 	return nil
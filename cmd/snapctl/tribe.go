@@ -26,6 +26,7 @@ import (
 	"os"
 	"sort"
 	"text/tabwriter"
+	"time"
 
 	"github.com/codegangsta/cli"
 	"github.com/intelsdi-x/snap/mgmt/tribe/agreement"
@@ -175,6 +176,66 @@ func agreementMembers(ctx *cli.Context) error {
 	return nil
 }
 
+func agreementStatus(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return newUsageError("Incorrect usage:", ctx)
+	}
+	name := ctx.Args().First()
+
+	plugins := pClient.GetAgreementPlugins(name)
+	if plugins.Err != nil {
+		return fmt.Errorf("Error: %v\n", plugins.Err)
+	}
+	tasks := pClient.GetAgreementTasks(name)
+	if tasks.Err != nil {
+		return fmt.Errorf("Error: %v\n", tasks.Err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	fmt.Println("Plugins:")
+	printFields(w, false, 0, "MEMBER", "NAME", "VERSION", "TYPE", "STATUS", "LOADED TIME")
+	for _, m := range plugins.Members {
+		if m.Error != "" {
+			printFields(w, false, 0, m.Member, "", "", "", m.Error, "")
+			continue
+		}
+		for _, p := range m.Plugins {
+			status, loaded := "not loaded", ""
+			if p.Loaded {
+				status = p.Status
+				loaded = time.Unix(p.LoadedTimestamp, 0).Format(timeFormat)
+			}
+			printFields(w, false, 0, m.Member, p.Name, p.Version, p.Type, status, loaded)
+		}
+	}
+	w.Flush()
+
+	fmt.Println("\nTasks:")
+	printFields(w, false, 0, "MEMBER", "ID", "NAME", "STATE", "HIT", "MISS", "FAIL", "LAST RUN", "LAST FAILURE")
+	for _, m := range tasks.Members {
+		if m.Error != "" {
+			printFields(w, false, 0, m.Member, "", "", m.Error, "", "", "", "", "")
+			continue
+		}
+		for _, t := range m.Tasks {
+			if t.Missing {
+				printFields(w, false, 0, m.Member, t.ID, "", "missing", "", "", "", "", "")
+				continue
+			}
+			lastRun := ""
+			if t.LastRunTimestamp > 0 {
+				lastRun = time.Unix(t.LastRunTimestamp, 0).Format(timeFormat)
+			}
+			printFields(w, false, 0, m.Member, t.ID, t.Name, t.State,
+				trunc(t.HitCount), trunc(t.MissCount), trunc(t.FailedCount),
+				lastRun, t.LastFailureMessage)
+		}
+	}
+	w.Flush()
+
+	return nil
+}
+
 func printAgreements(agreements map[string]*agreement.Agreement) {
 	if len(agreements) > 0 {
 		w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
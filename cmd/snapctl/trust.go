@@ -0,0 +1,87 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"text/tabwriter"
+
+	"github.com/codegangsta/cli"
+)
+
+func listTrustedKeys(ctx *cli.Context) error {
+	resp := pClient.GetTrustedKeys()
+	if resp.Err != nil {
+		return fmt.Errorf("Error getting trusted keys:\n%v\n", resp.Err)
+	}
+	if len(resp.Keys) == 0 {
+		fmt.Println("No trusted keys found.")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	printFields(w, false, 0, "ID", "SCHEME", "IDENTITY", "SOURCE", "REVOKED")
+	for _, k := range resp.Keys {
+		printFields(w, false, 0, k.ID, k.Scheme, k.Identity, k.Source, k.Revoked)
+	}
+	w.Flush()
+	return nil
+}
+
+func addTrustedKey(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		return newUsageError("Incorrect usage:", ctx)
+	}
+	b, err := ioutil.ReadFile(ctx.Args().Get(1))
+	if err != nil {
+		return fmt.Errorf("Error reading key file:\n%v\n", err)
+	}
+	resp := pClient.AddTrustedKey(ctx.Args().First(), ctx.String("key-name"), b)
+	if resp.Err != nil {
+		return fmt.Errorf("Error adding trusted key:\n%v\n", resp.Err)
+	}
+	for _, k := range resp.Keys {
+		fmt.Println("Trusted key added")
+		fmt.Printf("ID: %s\n", k.ID)
+		fmt.Printf("Scheme: %s\n", k.Scheme)
+		fmt.Printf("Identity: %s\n\n", k.Identity)
+	}
+	return nil
+}
+
+func revokeTrustedKey(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return newUsageError("Incorrect usage:", ctx)
+	}
+	resp := pClient.RevokeTrustedKey(ctx.Args().First())
+	if resp.Err != nil {
+		return fmt.Errorf("Error revoking trusted key:\n%v\n", resp.Err)
+	}
+	fmt.Println("Trusted key revoked")
+	fmt.Printf("ID: %s\n", resp.ID)
+	for _, p := range resp.UnloadedPlugins {
+		fmt.Printf("Unloaded plugin: %s:%s:%d\n", p.Type, p.Name, p.Version)
+	}
+	for _, p := range resp.FlaggedPlugins {
+		fmt.Printf("Flagged plugin: %s:%s:%d\n", p.Type, p.Name, p.Version)
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ limitations under the License.
 package control
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"os"
@@ -75,11 +76,14 @@ type availablePlugin struct {
 	exec               string
 	execPath           string
 	fromPackage        bool
+	// socketDir is the directory of the Unix socket of the plugin
+	socketDir string
 }
 
 // newAvailablePlugin returns an availablePlugin with information from a
-// plugin.Response
-func newAvailablePlugin(resp plugin.Response, emitter gomit.Emitter, ep executablePlugin) (*availablePlugin, error) {
+// plugin.Response.  The RPC to the plugin is secured with the TLS
+// configuration when it is not nil.
+func newAvailablePlugin(resp plugin.Response, emitter gomit.Emitter, ep executablePlugin, tlsConfig *tls.Config) (*availablePlugin, error) {
 	if resp.Type != plugin.CollectorPluginType && resp.Type != plugin.ProcessorPluginType && resp.Type != plugin.PublisherPluginType {
 		return nil, strategy.ErrBadType
 	}
@@ -95,10 +99,13 @@ func newAvailablePlugin(resp plugin.Response, emitter gomit.Emitter, ep executab
 	}
 	ap.key = fmt.Sprintf("%s"+core.Separator+"%s"+core.Separator+"%d", ap.pluginType.String(), ap.name, ap.version)
 
-	listenURL := fmt.Sprintf("http://%v/rpc", resp.ListenAddress)
+	// An embedded plugin is called directly
+	if e, ok := ep.(*embeddedPlugin); ok {
+		ap.client = e.client()
+		return ap, nil
+	}
 	// Create RPC Client
-	switch resp.Type {
-	case plugin.CollectorPluginType:
+	if resp.Type == plugin.CollectorPluginType {
 		switch resp.Meta.RPCType {
 		case plugin.JSONRPC:
 			log.WithFields(log.Fields{
@@ -106,68 +113,19 @@ func newAvailablePlugin(resp plugin.Response, emitter gomit.Emitter, ep executab
 				"_block":      "newAvailablePlugin",
 				"plugin_name": ap.name,
 			}).Warning("This plugin is using a deprecated JSON RPC protocol. Find more information here: https://github.com/intelsdi-x/snap/issues/1296 ")
-			c, e := client.NewCollectorHttpJSONRPCClient(listenURL, DefaultClientTimeout, resp.PublicKey, !resp.Meta.Unsecure)
-			if e != nil {
-				return nil, errors.New("error while creating client connection: " + e.Error())
-			}
-			ap.client = c
 		case plugin.NativeRPC:
 			log.WithFields(log.Fields{
 				"_module":     "control-aplugin",
 				"_block":      "newAvailablePlugin",
 				"plugin_name": ap.name,
 			}).Warning("This plugin is using a deprecated RPC protocol. Find more information here: https://github.com/intelsdi-x/snap/issues/1289 ")
-			c, e := client.NewCollectorNativeClient(resp.ListenAddress, DefaultClientTimeout, resp.PublicKey, !resp.Meta.Unsecure)
-			if e != nil {
-				return nil, errors.New("error while creating client connection: " + e.Error())
-			}
-			ap.client = c
-		case plugin.GRPC:
-			c, e := client.NewCollectorGrpcClient(resp.ListenAddress, DefaultClientTimeout, resp.PublicKey, !resp.Meta.Unsecure)
-			if e != nil {
-				return nil, errors.New("error while creating client connection: " + e.Error())
-			}
-			ap.client = c
-		default:
-			return nil, errors.New("Invalid RPCTYPE")
 		}
-	case plugin.PublisherPluginType:
-		switch resp.Meta.RPCType {
-		case plugin.NativeRPC:
-			c, e := client.NewPublisherNativeClient(resp.ListenAddress, DefaultClientTimeout, resp.PublicKey, !resp.Meta.Unsecure)
-			if e != nil {
-				return nil, errors.New("error while creating client connection: " + e.Error())
-			}
-			ap.client = c
-		case plugin.GRPC:
-			c, e := client.NewPublisherGrpcClient(resp.ListenAddress, DefaultClientTimeout, resp.PublicKey, !resp.Meta.Unsecure)
-			if e != nil {
-				return nil, errors.New("error while creating client connection: " + e.Error())
-			}
-			ap.client = c
-		default:
-			return nil, errors.New("Invalid RPCTYPE")
-		}
-	case plugin.ProcessorPluginType:
-		switch resp.Meta.RPCType {
-		case plugin.NativeRPC:
-			c, e := client.NewProcessorNativeClient(resp.ListenAddress, DefaultClientTimeout, resp.PublicKey, !resp.Meta.Unsecure)
-			if e != nil {
-				return nil, errors.New("error while creating client connection: " + e.Error())
-			}
-			ap.client = c
-		case plugin.GRPC:
-			c, e := client.NewProcessorGrpcClient(resp.ListenAddress, DefaultClientTimeout, resp.PublicKey, !resp.Meta.Unsecure)
-			if e != nil {
-				return nil, errors.New("error while creating client connection: " + e.Error())
-			}
-			ap.client = c
-		default:
-			return nil, errors.New("Invalid RPCTYPE")
-		}
-	default:
-		return nil, errors.New("Cannot create a client for a plugin of the type: " + resp.Type.String())
 	}
+	c, err := client.New(resp, DefaultClientTimeout, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	ap.client = c
 
 	return ap, nil
 }
@@ -250,7 +208,9 @@ func (a *availablePlugin) Kill(r string) error {
 		}).Debug("deleting available plugin path")
 		os.RemoveAll(filepath.Dir(a.execPath))
 	}
-	return a.ePlugin.Kill()
+	err := a.ePlugin.Kill()
+	removePluginSocketDir(a.socketDir)
+	return err
 }
 
 // CheckHealth checks the health of a plugin and updates
@@ -319,12 +279,16 @@ type availablePlugins struct {
 	// The Pools' primary keys are equal to
 	// {plugin_type}:{plugin_name}:{plugin_version}
 	table map[string]strategy.Pool
+	// contentTypes holds the converters for the content types accepted by
+	// processors and publishers.
+	contentTypes *contentTypeRegistry
 }
 
 func newAvailablePlugins() *availablePlugins {
 	return &availablePlugins{
-		RWMutex: &sync.RWMutex{},
-		table:   make(map[string]strategy.Pool),
+		RWMutex:      &sync.RWMutex{},
+		table:        make(map[string]strategy.Pool),
+		contentTypes: newContentTypeRegistry(),
 	}
 }
 
@@ -474,7 +438,18 @@ func (ap *availablePlugins) publishMetrics(metrics []core.Metric, pluginName str
 		return []error{errors.New("unable to cast client to PluginPublisherClient")}
 	}
 
-	errp := cli.Publish(metrics, config)
+	var errp error
+	ccli, ok := cli.(client.PluginContentPublisherClient)
+	conv := ap.contentTypes.negotiate(p.(*availablePlugin).meta.AcceptedContentTypes)
+	if ok && conv != nil && len(metrics) > 0 {
+		var content []byte
+		content, errp = conv.Convert(metrics)
+		if errp == nil {
+			errp = ccli.PublishContent(conv.ContentType(), content, config)
+		}
+	} else {
+		errp = cli.Publish(metrics, config)
+	}
 	if errp != nil {
 		return []error{errp}
 	}
@@ -508,7 +483,19 @@ func (ap *availablePlugins) processMetrics(metrics []core.Metric, pluginName str
 		return nil, []error{errors.New("unable to cast client to PluginProcessorClient")}
 	}
 
-	mts, errp := cli.Process(metrics, config)
+	var mts []core.Metric
+	var errp error
+	ccli, ok := cli.(client.PluginContentProcessorClient)
+	conv := ap.contentTypes.negotiate(p.(*availablePlugin).meta.AcceptedContentTypes)
+	if ok && conv != nil && len(metrics) > 0 {
+		var content []byte
+		content, errp = conv.Convert(metrics)
+		if errp == nil {
+			mts, errp = ccli.ProcessContent(conv.ContentType(), content, config)
+		}
+	} else {
+		mts, errp = cli.Process(metrics, config)
+	}
 	if errp != nil {
 		return nil, []error{errp}
 	}
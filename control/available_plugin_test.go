@@ -45,7 +45,7 @@ func TestAvailablePlugin(t *testing.T) {
 				Type:          plugin.CollectorPluginType,
 				ListenAddress: "127.0.0.1:4000",
 			}
-			ap, err := newAvailablePlugin(resp, nil, nil)
+			ap, err := newAvailablePlugin(resp, nil, nil, nil)
 			So(ap, ShouldHaveSameTypeAs, new(availablePlugin))
 			So(err, ShouldBeNil)
 		})
@@ -58,7 +58,7 @@ func TestAvailablePlugin(t *testing.T) {
 			a := plugin.Arg{}
 
 			exPlugin, _ := plugin.NewExecutablePlugin(a, fixtures.PluginPath)
-			ap, err := r.startPlugin(exPlugin)
+			ap, err := r.startPlugin(exPlugin, nil)
 			So(err, ShouldBeNil)
 
 			err = ap.Stop("testing")
@@ -112,7 +112,7 @@ func TestAvailablePlugins(t *testing.T) {
 			Type:          plugin.CollectorPluginType,
 			ListenAddress: "localhost:asdf",
 		}
-		ap, err := newAvailablePlugin(resp, nil, nil)
+		ap, err := newAvailablePlugin(resp, nil, nil, nil)
 		So(ap, ShouldBeNil)
 		So(err, ShouldNotBeNil)
 	})
@@ -44,6 +44,13 @@ const (
 	defaultPluginTrust       int           = 1
 	defaultAutoDiscoverPath  string        = ""
 	defaultKeyringPaths      string        = ""
+	defaultPluginIndex       string        = ""
+	defaultX509CAFiles       string        = ""
+	defaultX509CRLFiles      string        = ""
+	defaultEd25519KeyPaths   string        = ""
+	defaultRevokedKeyAction  string        = RevokedKeyActionUnload
+	defaultPluginTLS         string        = PluginTLSEnabled
+	defaultPluginTransport   string        = PluginTransportTCP
 	defaultCacheExpiration   time.Duration = 500 * time.Millisecond
 )
 
@@ -66,19 +73,28 @@ type pluginConfigItem struct {
 }
 
 // holds the configuration passed in through the SNAP config file
-//   Note: if this struct is modified, then the switch statement in the
-//         UnmarshalJSON method in this same file needs to be modified to
-//         match the field mapping that is defined here
+//
+//	Note: if this struct is modified, then the switch statement in the
+//	      UnmarshalJSON method in this same file needs to be modified to
+//	      match the field mapping that is defined here
 type Config struct {
-	MaxRunningPlugins int               `json:"max_running_plugins"yaml:"max_running_plugins"`
-	PluginLoadTimeout int               `json:"plugin_load_timeout"yaml:"plugin_load_timeout"`
-	PluginTrust       int               `json:"plugin_trust_level"yaml:"plugin_trust_level"`
-	AutoDiscoverPath  string            `json:"auto_discover_path"yaml:"auto_discover_path"`
-	KeyringPaths      string            `json:"keyring_paths"yaml:"keyring_paths"`
-	CacheExpiration   jsonutil.Duration `json:"cache_expiration"yaml:"cache_expiration"`
-	Plugins           *pluginConfig     `json:"plugins"yaml:"plugins"`
-	ListenAddr        string            `json:"listen_addr,omitempty"yaml:"listen_addr"`
-	ListenPort        int               `json:"listen_port,omitempty"yaml:"listen_port"`
+	MaxRunningPlugins int                 `json:"max_running_plugins"yaml:"max_running_plugins"`
+	PluginLoadTimeout int                 `json:"plugin_load_timeout"yaml:"plugin_load_timeout"`
+	PluginTrust       int                 `json:"plugin_trust_level"yaml:"plugin_trust_level"`
+	AutoDiscoverPath  string              `json:"auto_discover_path"yaml:"auto_discover_path"`
+	KeyringPaths      string              `json:"keyring_paths"yaml:"keyring_paths"`
+	PluginIndex       string              `json:"plugin_index" yaml:"plugin_index"`
+	X509CAFiles       string              `json:"x509_ca_files" yaml:"x509_ca_files"`
+	X509CRLFiles      string              `json:"x509_crl_files" yaml:"x509_crl_files"`
+	Ed25519KeyPaths   string              `json:"ed25519_key_paths" yaml:"ed25519_key_paths"`
+	TrustPolicy       map[string][]string `json:"trust_policy" yaml:"trust_policy"`
+	RevokedKeyAction  string              `json:"revoked_key_action" yaml:"revoked_key_action"`
+	PluginTLS         string              `json:"plugin_tls" yaml:"plugin_tls"`
+	PluginTransport   string              `json:"plugin_transport" yaml:"plugin_transport"`
+	CacheExpiration   jsonutil.Duration   `json:"cache_expiration"yaml:"cache_expiration"`
+	Plugins           *pluginConfig       `json:"plugins"yaml:"plugins"`
+	ListenAddr        string              `json:"listen_addr,omitempty"yaml:"listen_addr"`
+	ListenPort        int                 `json:"listen_port,omitempty"yaml:"listen_port"`
 }
 
 const (
@@ -109,6 +125,39 @@ const (
 					"keyring_paths" : {
 						"type": "string"
 					},
+					"plugin_index" : {
+						"type": "string"
+					},
+					"x509_ca_files" : {
+						"type": "string"
+					},
+					"x509_crl_files" : {
+						"type": "string"
+					},
+					"ed25519_key_paths" : {
+						"type": "string"
+					},
+					"trust_policy" : {
+						"type": ["object", "null"],
+						"additionalProperties": {
+							"type": "array",
+							"items": {
+								"type": "string"
+							}
+						}
+					},
+					"revoked_key_action" : {
+						"type": "string",
+						"enum": ["unload", "flag"]
+					},
+					"plugin_tls" : {
+						"type": "string",
+						"enum": ["disabled", "enabled", "required"]
+					},
+					"plugin_transport" : {
+						"type": "string",
+						"enum": ["tcp", "unix"]
+					},
 					"plugins": {
 						"type": ["object", "null"],
 						"properties" : {},
@@ -136,6 +185,13 @@ func GetDefaultConfig() *Config {
 		PluginTrust:       defaultPluginTrust,
 		AutoDiscoverPath:  defaultAutoDiscoverPath,
 		KeyringPaths:      defaultKeyringPaths,
+		PluginIndex:       defaultPluginIndex,
+		X509CAFiles:       defaultX509CAFiles,
+		X509CRLFiles:      defaultX509CRLFiles,
+		Ed25519KeyPaths:   defaultEd25519KeyPaths,
+		RevokedKeyAction:  defaultRevokedKeyAction,
+		PluginTLS:         defaultPluginTLS,
+		PluginTransport:   defaultPluginTransport,
 		CacheExpiration:   jsonutil.Duration{defaultCacheExpiration},
 		Plugins:           newPluginConfig(),
 	}
@@ -175,6 +231,38 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 			if err := json.Unmarshal(v, &(c.KeyringPaths)); err != nil {
 				return fmt.Errorf("%v (while parsing 'control::keyring_paths')", err)
 			}
+		case "plugin_index":
+			if err := json.Unmarshal(v, &(c.PluginIndex)); err != nil {
+				return fmt.Errorf("%v (while parsing 'control::plugin_index')", err)
+			}
+		case "x509_ca_files":
+			if err := json.Unmarshal(v, &(c.X509CAFiles)); err != nil {
+				return fmt.Errorf("%v (while parsing 'control::x509_ca_files')", err)
+			}
+		case "x509_crl_files":
+			if err := json.Unmarshal(v, &(c.X509CRLFiles)); err != nil {
+				return fmt.Errorf("%v (while parsing 'control::x509_crl_files')", err)
+			}
+		case "ed25519_key_paths":
+			if err := json.Unmarshal(v, &(c.Ed25519KeyPaths)); err != nil {
+				return fmt.Errorf("%v (while parsing 'control::ed25519_key_paths')", err)
+			}
+		case "trust_policy":
+			if err := json.Unmarshal(v, &(c.TrustPolicy)); err != nil {
+				return fmt.Errorf("%v (while parsing 'control::trust_policy')", err)
+			}
+		case "revoked_key_action":
+			if err := json.Unmarshal(v, &(c.RevokedKeyAction)); err != nil {
+				return fmt.Errorf("%v (while parsing 'control::revoked_key_action')", err)
+			}
+		case "plugin_tls":
+			if err := json.Unmarshal(v, &(c.PluginTLS)); err != nil {
+				return fmt.Errorf("%v (while parsing 'control::plugin_tls')", err)
+			}
+		case "plugin_transport":
+			if err := json.Unmarshal(v, &(c.PluginTransport)); err != nil {
+				return fmt.Errorf("%v (while parsing 'control::plugin_transport')", err)
+			}
 		case "cache_expiration":
 			if err := json.Unmarshal(v, &(c.CacheExpiration)); err != nil {
 				return fmt.Errorf("%v (while parsing 'control::cache_expiration')", err)
@@ -171,6 +171,9 @@ func TestControlConfigJSON(t *testing.T) {
 		Convey("KeyringPaths should be set to /some/path/with/keyring/files", func() {
 			So(cfg.KeyringPaths, ShouldEqual, "/some/path/with/keyring/files")
 		})
+		Convey("PluginIndex should be set to /some/directory/with/plugin/index", func() {
+			So(cfg.PluginIndex, ShouldEqual, "/some/directory/with/plugin/index")
+		})
 		Convey("PluginTrust should be set to 0", func() {
 			So(cfg.PluginTrust, ShouldEqual, 0)
 		})
@@ -240,6 +243,9 @@ func TestControlConfigYaml(t *testing.T) {
 		Convey("KeyringPaths should be set to /some/path/with/keyring/files", func() {
 			So(cfg.KeyringPaths, ShouldEqual, "/some/path/with/keyring/files")
 		})
+		Convey("PluginIndex should be set to /some/directory/with/plugin/index", func() {
+			So(cfg.PluginIndex, ShouldEqual, "/some/directory/with/plugin/index")
+		})
 		Convey("PluginTrust should be set to 0", func() {
 			So(cfg.PluginTrust, ShouldEqual, 0)
 		})
@@ -298,6 +304,9 @@ func TestControlDefaultConfig(t *testing.T) {
 		Convey("KeyringPaths should be empty", func() {
 			So(cfg.KeyringPaths, ShouldEqual, "")
 		})
+		Convey("PluginIndex should be empty", func() {
+			So(cfg.PluginIndex, ShouldEqual, "")
+		})
 		Convey("PluginTrust should equal 1", func() {
 			So(cfg.PluginTrust, ShouldEqual, 1)
 		})
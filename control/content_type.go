@@ -0,0 +1,147 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2015 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/core"
+)
+
+var (
+	// ErrContentTypeRegistered - Error message when a converter for a content
+	// type is already registered
+	ErrContentTypeRegistered = errors.New("Content type already registered")
+)
+
+// ContentTypeConverter serializes metrics into a content type plugins can
+// list in their accepted content types.
+type ContentTypeConverter interface {
+	// ContentType returns the content type the converter produces
+	ContentType() string
+	// Convert serializes metrics into the content type
+	Convert([]core.Metric) ([]byte, error)
+}
+
+// contentTypeRegistry holds the converters used to send metrics to
+// processors and publishers in the content type they accept.
+type contentTypeRegistry struct {
+	sync.RWMutex
+	converters map[string]ContentTypeConverter
+}
+
+func newContentTypeRegistry() *contentTypeRegistry {
+	r := &contentTypeRegistry{converters: map[string]ContentTypeConverter{}}
+	for _, c := range []ContentTypeConverter{
+		&snapConverter{plugin.SnapGOBContentType},
+		&snapConverter{plugin.SnapJSONContentType},
+		&snapConverter{plugin.SnapProtobufContentType},
+		&snapConverter{plugin.SnapMsgpackContentType},
+		&influxLineConverter{},
+		&openMetricsConverter{},
+	} {
+		r.register(c)
+	}
+	return r
+}
+
+func (r *contentTypeRegistry) register(c ContentTypeConverter) error {
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.converters[c.ContentType()]; ok {
+		return ErrContentTypeRegistered
+	}
+	r.converters[c.ContentType()] = c
+	return nil
+}
+
+func (r *contentTypeRegistry) contentTypes() []string {
+	r.RLock()
+	defer r.RUnlock()
+	cts := make([]string, 0, len(r.converters))
+	for ct := range r.converters {
+		cts = append(cts, ct)
+	}
+	sort.Strings(cts)
+	return cts
+}
+
+// negotiate returns the converter for the first of the accepted content
+// types (in the plugin's priority order) there is a converter for. The snap
+// wildcard is served with GOB. Nil is returned when nothing matches.
+func (r *contentTypeRegistry) negotiate(accepted []string) ContentTypeConverter {
+	r.RLock()
+	defer r.RUnlock()
+	for _, ct := range accepted {
+		if ct == plugin.SnapAllContentType {
+			ct = plugin.SnapGOBContentType
+		}
+		if c, ok := r.converters[ct]; ok {
+			return c
+		}
+	}
+	return nil
+}
+
+// RegisterContentType adds a converter for a content type processors and
+// publishers can accept.
+func (p *pluginControl) RegisterContentType(c ContentTypeConverter) error {
+	return p.pluginRunner.AvailablePlugins().contentTypes.register(c)
+}
+
+// ContentTypes returns the content types metrics can be converted into
+func (p *pluginControl) ContentTypes() []string {
+	return p.pluginRunner.AvailablePlugins().contentTypes.contentTypes()
+}
+
+// snapConverter serializes metrics into one of the snap content types
+type snapConverter struct {
+	contentType string
+}
+
+func (s *snapConverter) ContentType() string {
+	return s.contentType
+}
+
+func (s *snapConverter) Convert(metrics []core.Metric) ([]byte, error) {
+	b, _, err := plugin.MarshalMetricTypes(s.contentType, toMetricTypes(metrics))
+	return b, err
+}
+
+func toMetricTypes(metrics []core.Metric) []plugin.MetricType {
+	mts := make([]plugin.MetricType, len(metrics))
+	for i, m := range metrics {
+		mts[i] = plugin.MetricType{
+			Namespace_:          m.Namespace(),
+			LastAdvertisedTime_: m.LastAdvertisedTime(),
+			Version_:            m.Version(),
+			Config_:             m.Config(),
+			Data_:               m.Data(),
+			Tags_:               m.Tags(),
+			Unit_:               m.Unit(),
+			Description_:        m.Description(),
+			Timestamp_:          m.Timestamp(),
+		}
+	}
+	return mts
+}
@@ -0,0 +1,107 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2015 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/core"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type mockConverter struct{}
+
+func (mockConverter) ContentType() string {
+	return "mock.text"
+}
+
+func (mockConverter) Convert(metrics []core.Metric) ([]byte, error) {
+	return []byte("mock"), nil
+}
+
+func TestContentTypeRegistry(t *testing.T) {
+	Convey("Given a content type registry", t, func() {
+		r := newContentTypeRegistry()
+		So(r.contentTypes(), ShouldResemble, []string{"influx.line", "openmetrics.text", "snap.gob", "snap.json", "snap.msgpack", "snap.protobuf"})
+
+		Convey("it negotiates the first accepted content type it can convert into", func() {
+			So(r.negotiate([]string{"foo", "snap.json", "snap.gob"}).ContentType(), ShouldEqual, "snap.json")
+			So(r.negotiate([]string{"snap.*"}).ContentType(), ShouldEqual, "snap.gob")
+			So(r.negotiate([]string{"foo"}), ShouldBeNil)
+			So(r.negotiate(nil), ShouldBeNil)
+		})
+		Convey("it registers new content types once", func() {
+			So(r.register(mockConverter{}), ShouldBeNil)
+			So(r.register(mockConverter{}), ShouldEqual, ErrContentTypeRegistered)
+			So(r.negotiate([]string{"mock.text"}).ContentType(), ShouldEqual, "mock.text")
+		})
+		Convey("snap content types can be decoded back into metrics", func() {
+			m := plugin.NewMetricType(core.NewNamespace("intel", "mock", "foo"), time.Now(), nil, "", int64(1))
+			b, err := r.negotiate([]string{"snap.protobuf"}).Convert([]core.Metric{*m})
+			So(err, ShouldBeNil)
+			mts, err := plugin.UnmarshallMetricTypes("snap.protobuf", b)
+			So(err, ShouldBeNil)
+			So(mts, ShouldHaveLength, 1)
+			So(mts[0].Data(), ShouldEqual, int64(1))
+		})
+	})
+}
+
+func TestTextConverters(t *testing.T) {
+	Convey("Given metrics", t, func() {
+		ts := time.Unix(1480000000, 500000000)
+		ns := core.NewNamespace("intel", "disk").AddDynamicElement("device", "disk device").AddStaticElement("read bytes")
+		ns[2].Value = "sda"
+		metrics := []core.Metric{
+			*plugin.NewMetricType(ns, ts, map[string]string{"host": "a,b"}, "B", uint64(42)),
+			*plugin.NewMetricType(core.NewNamespace("intel", "mock", "status"), ts, nil, "", "up \"ok\""),
+			*plugin.NewMetricType(core.NewNamespace("intel", "mock", "load"), ts, nil, "", 1.5),
+			*plugin.NewMetricType(core.NewNamespace("intel", "mock", "none"), ts, nil, "", nil),
+		}
+
+		Convey("they are converted into the influx line protocol", func() {
+			b, err := (&influxLineConverter{}).Convert(metrics)
+			So(err, ShouldBeNil)
+			So(string(b), ShouldEqual,
+				`intel/disk/device/read\ bytes,device=sda,host=a\,b value=42i 1480000000500000000`+"\n"+
+					`intel/mock/status value="up \"ok\"" 1480000000500000000`+"\n"+
+					`intel/mock/load value=1.5 1480000000500000000`+"\n")
+		})
+		Convey("they are converted into the OpenMetrics text format", func() {
+			m := metrics[2].(plugin.MetricType)
+			m.Description_ = "load average"
+			metrics[2] = m
+			b, err := (&openMetricsConverter{}).Convert(metrics)
+			So(err, ShouldBeNil)
+			So(string(b), ShouldEqual,
+				"# TYPE intel_disk_device_read_bytes unknown\n"+
+					`intel_disk_device_read_bytes{device="sda",host="a,b"} 42 1480000000.5`+"\n"+
+					"# TYPE intel_mock_load unknown\n"+
+					"# HELP intel_mock_load load average\n"+
+					"intel_mock_load 1.5 1480000000.5\n"+
+					"# EOF\n")
+		})
+	})
+}
@@ -0,0 +1,240 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2015 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/core"
+)
+
+// textMetric holds the name and labels of a metric for the text formats. The
+// values of dynamic namespace elements are moved from the name to labels.
+type textMetric struct {
+	parts  []string
+	labels map[string]string
+	metric core.Metric
+}
+
+func newTextMetric(m core.Metric) textMetric {
+	tm := textMetric{labels: map[string]string{}, metric: m}
+	for _, e := range m.Namespace() {
+		if e.IsDynamic() {
+			tm.parts = append(tm.parts, e.Name)
+			tm.labels[e.Name] = e.Value
+			continue
+		}
+		tm.parts = append(tm.parts, e.Value)
+	}
+	for k, v := range m.Tags() {
+		tm.labels[k] = v
+	}
+	return tm
+}
+
+func (t textMetric) labelNames() []string {
+	names := make([]string, 0, len(t.labels))
+	for k := range t.labels {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// influxLineConverter serializes metrics into the InfluxDB line protocol. The
+// measurement is the namespace joined with "/" and the data is the "value"
+// field. Metrics without data are left out.
+type influxLineConverter struct{}
+
+var (
+	influxMeasurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	influxTagEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+	influxStringEscaper      = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+)
+
+func (*influxLineConverter) ContentType() string {
+	return plugin.InfluxLineContentType
+}
+
+func (*influxLineConverter) Convert(metrics []core.Metric) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, m := range metrics {
+		value, ok := influxValue(m.Data())
+		if !ok {
+			continue
+		}
+		tm := newTextMetric(m)
+		buf.WriteString(influxMeasurementEscaper.Replace(strings.Join(tm.parts, "/")))
+		for _, k := range tm.labelNames() {
+			if k == "" || tm.labels[k] == "" {
+				continue
+			}
+			fmt.Fprintf(&buf, ",%s=%s", influxTagEscaper.Replace(k), influxTagEscaper.Replace(tm.labels[k]))
+		}
+		fmt.Fprintf(&buf, " value=%s %d\n", value, m.Timestamp().UnixNano())
+	}
+	return buf.Bytes(), nil
+}
+
+func influxValue(data interface{}) (string, bool) {
+	switch v := data.(type) {
+	case float32:
+		return influxValue(float64(v))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return "", false
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64), true
+	case int, int8, int16, int32, int64, uint8, uint16, uint32:
+		return fmt.Sprintf("%di", v), true
+	case uint:
+		return influxValue(uint64(v))
+	case uint64:
+		if v > math.MaxInt64 {
+			return fmt.Sprintf("%du", v), true
+		}
+		return fmt.Sprintf("%di", v), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case string:
+		return `"` + influxStringEscaper.Replace(v) + `"`, true
+	case []byte:
+		return influxValue(string(v))
+	case nil:
+		return "", false
+	default:
+		return influxValue(fmt.Sprint(v))
+	}
+}
+
+// openMetricsConverter serializes metrics into the OpenMetrics text format.
+// The metric name is the namespace joined with "_", tags and dynamic
+// namespace elements become labels. Metrics without numeric or boolean data
+// are left out as OpenMetrics samples can't hold other values.
+type openMetricsConverter struct{}
+
+var openMetricsLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func (*openMetricsConverter) ContentType() string {
+	return plugin.OpenMetricsContentType
+}
+
+type openMetricsSample struct {
+	name  string
+	value string
+	tm    textMetric
+}
+
+type byOpenMetricsName []openMetricsSample
+
+func (s byOpenMetricsName) Len() int           { return len(s) }
+func (s byOpenMetricsName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byOpenMetricsName) Less(i, j int) bool { return s[i].name < s[j].name }
+
+func (*openMetricsConverter) Convert(metrics []core.Metric) ([]byte, error) {
+	samples := []openMetricsSample{}
+	for _, m := range metrics {
+		value, ok := openMetricsValue(m.Data())
+		if !ok {
+			continue
+		}
+		tm := newTextMetric(m)
+		samples = append(samples, openMetricsSample{
+			name:  openMetricsName(strings.Join(tm.parts, "_"), true),
+			value: value,
+			tm:    tm,
+		})
+	}
+	// the samples of a metric family have to be grouped together
+	sort.Stable(byOpenMetricsName(samples))
+
+	var buf bytes.Buffer
+	for i, s := range samples {
+		if i == 0 || samples[i-1].name != s.name {
+			fmt.Fprintf(&buf, "# TYPE %s unknown\n", s.name)
+			if desc := s.tm.metric.Description(); desc != "" {
+				fmt.Fprintf(&buf, "# HELP %s %s\n", s.name, openMetricsLabelEscaper.Replace(desc))
+			}
+		}
+		buf.WriteString(s.name)
+		if len(s.tm.labels) > 0 {
+			labels := make([]string, 0, len(s.tm.labels))
+			for _, k := range s.tm.labelNames() {
+				labels = append(labels, fmt.Sprintf(`%s="%s"`, openMetricsName(k, false), openMetricsLabelEscaper.Replace(s.tm.labels[k])))
+			}
+			fmt.Fprintf(&buf, "{%s}", strings.Join(labels, ","))
+		}
+		ts := s.tm.metric.Timestamp()
+		fmt.Fprintf(&buf, " %s %s\n", s.value, strconv.FormatFloat(float64(ts.UnixNano())/1e9, 'f', -1, 64))
+	}
+	buf.WriteString("# EOF\n")
+	return buf.Bytes(), nil
+}
+
+// openMetricsName replaces the characters not allowed in metric (colons
+// allowed) or label names with underscores.
+func openMetricsName(s string, colons bool) string {
+	b := []byte(s)
+	for i, c := range b {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_':
+		case c >= '0' && c <= '9' && i > 0:
+		case c == ':' && colons:
+		default:
+			b[i] = '_'
+		}
+	}
+	if len(b) == 0 {
+		return "_"
+	}
+	return string(b)
+}
+
+func openMetricsValue(data interface{}) (string, bool) {
+	switch v := data.(type) {
+	case float32:
+		return openMetricsValue(float64(v))
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return "NaN", true
+		case math.IsInf(v, 1):
+			return "+Inf", true
+		case math.IsInf(v, -1):
+			return "-Inf", true
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64), true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v), true
+	case bool:
+		if v {
+			return "1", true
+		}
+		return "0", true
+	default:
+		return "", false
+	}
+}
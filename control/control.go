@@ -71,6 +71,17 @@ var (
 	ErrControllerNotStarted = errors.New("Must start Controller before use")
 )
 
+// rpcTypes maps the RPC types of snap manifests to plugin RPC types
+// pluginTypes are the types a plugin whose type is not declared before it
+// runs may turn out to be
+var pluginTypes = []plugin.PluginType{plugin.CollectorPluginType, plugin.ProcessorPluginType, plugin.PublisherPluginType}
+
+var rpcTypes = map[string]plugin.RPCType{
+	"native":  plugin.NativeRPC,
+	"jsonrpc": plugin.JSONRPC,
+	"grpc":    plugin.GRPC,
+}
+
 type pluginControl struct {
 	// TODO, going to need coordination on changing of these
 	Started bool
@@ -79,13 +90,19 @@ type pluginControl struct {
 	autodiscoverPaths []string
 	eventManager      *gomit.EventController
 
-	pluginManager  managesPlugins
-	metricCatalog  catalogsMetrics
-	pluginRunner   runsPlugins
-	signingManager managesSigning
-
-	pluginTrust  int
-	keyringFiles []string
+	pluginManager managesPlugins
+	metricCatalog catalogsMetrics
+	pluginRunner  runsPlugins
+	// verifiers verify the signatures of plugins, the OpenPGP verifier
+	// checking the keyring files comes first
+	verifiers      []psigning.Verifier
+	verifiersMutex sync.RWMutex
+	openPGP        *psigning.OpenPGPVerifier
+
+	pluginTrust int
+	// snapdVersion is checked against the minimum snapd version of plugin
+	// packages
+	snapdVersion string
 	// used to cleanly shutdown the GRPC server
 	grpcServer  *grpc.Server
 	closingChan chan bool
@@ -128,6 +145,8 @@ type managesPlugins interface {
 	GenerateArgs(logLevel int) plugin.Arg
 	SetPluginConfig(*pluginConfig)
 	SetPluginLoadTimeout(int)
+	SecureChannel() *secureChannel
+	PluginTransport() string
 }
 
 type catalogsMetrics interface {
@@ -144,10 +163,6 @@ type catalogsMetrics interface {
 	GetPlugin(core.Namespace, int) (*loadedPlugin, error)
 }
 
-type managesSigning interface {
-	ValidateSignature([]string, string, []byte) error
-}
-
 // PluginControlOpt is used to set optional parameters on the pluginControl struct
 type PluginControlOpt func(*pluginControl)
 
@@ -198,19 +213,33 @@ func New(cfg *Config) *pluginControl {
 		"_block": "new",
 	}).Debug("metric catalog created")
 
+	// Secure channel to plugins
+	channel, err := newSecureChannel(cfg.PluginTLS)
+	if err != nil {
+		panic(err)
+	}
+	controlLogger.WithFields(log.Fields{
+		"_block":     "new",
+		"plugin-tls": cfg.PluginTLS,
+	}).Debug("secure channel created")
+
 	// Plugin Manager
-	c.pluginManager = newPluginManager()
+	c.pluginManager = newPluginManager(OptSetSecureChannel(channel), OptSetPluginTransport(cfg.PluginTransport))
 	controlLogger.WithFields(log.Fields{
 		"_block": "new",
 	}).Debug("plugin manager created")
 	// Plugin Manager needs a reference to the metric catalog
 	c.pluginManager.SetMetricCatalog(c.metricCatalog)
 
-	// Signing Manager
-	c.signingManager = &psigning.SigningManager{}
+	// Signature verifiers
+	c.openPGP, err = psigning.NewOpenPGPVerifier()
+	if err != nil {
+		panic(err)
+	}
+	c.verifiers = []psigning.Verifier{c.openPGP}
 	controlLogger.WithFields(log.Fields{
 		"_block": "new",
-	}).Debug("signing manager created")
+	}).Debug("signature verifiers created")
 
 	// Plugin Runner
 	c.pluginRunner = newRunner()
@@ -229,7 +258,7 @@ func New(cfg *Config) *pluginControl {
 	c.subscriptionGroups = newSubscriptionGroups(c)
 
 	// Start stuff
-	err := c.pluginRunner.Start()
+	err = c.pluginRunner.Start()
 	if err != nil {
 		panic(err)
 	}
@@ -390,6 +419,8 @@ func (p *pluginControl) Start() error {
 		}).Info("auto discover path is disabled")
 	}
 
+	p.loadEmbeddedPlugins()
+
 	lis, err := net.Listen("tcp", fmt.Sprintf("%v:%v", p.Config.ListenAddr, p.Config.ListenPort))
 	if err != nil {
 		controlLogger.WithField("error", err.Error()).Error("Failed to start control grpc listener")
@@ -480,6 +511,12 @@ func (p *pluginControl) Load(rp *core.RequestedPlugin) (core.CatalogedPlugin, se
 	if se != nil {
 		return nil, se
 	}
+	if se := p.checkLoadedPlugin(pl); se != nil {
+		if _, err := p.pluginManager.UnloadPlugin(pl); err != nil {
+			controlLogger.WithFields(f).Error(err)
+		}
+		return nil, se
+	}
 
 	// If plugin was loaded from a package, remove ExecPath for
 	// the temporary plugin that was used for load
@@ -498,74 +535,261 @@ func (p *pluginControl) Load(rp *core.RequestedPlugin) (core.CatalogedPlugin, se
 	return pl, nil
 }
 
-func (p *pluginControl) verifySignature(rp *core.RequestedPlugin) (bool, serror.SnapError) {
+// verifySignature verifies the signature of the file according to the plugin
+// trust level, returning the signer of the file or nil when the signature was
+// not verified
+func (p *pluginControl) verifySignature(file string, signature []byte) (*psigning.Signer, serror.SnapError) {
 	f := map[string]interface{}{
 		"_block": "verifySignature",
 	}
 	switch p.pluginTrust {
 	case PluginTrustDisabled:
-		return false, nil
-	case PluginTrustEnabled:
-		err := p.signingManager.ValidateSignature(p.keyringFiles, rp.Path(), rp.Signature())
-		if err != nil {
-			return false, serror.New(err)
-		}
+		return nil, nil
 	case PluginTrustWarn:
-		if rp.Signature() == nil {
-			controlLogger.WithFields(f).Warn("Loading unsigned plugin ", rp.Path())
-			return false, nil
-		}
-		err := p.signingManager.ValidateSignature(p.keyringFiles, rp.Path(), rp.Signature())
-		if err != nil {
-			return false, serror.New(err)
+		if signature == nil {
+			controlLogger.WithFields(f).Warn("Loading unsigned plugin ", file)
+			return nil, nil
 		}
 	}
-	return true, nil
-
+	signer, err := psigning.Verify(p.signatureVerifiers(), file, signature)
+	if err != nil {
+		return nil, serror.New(err)
+	}
+	f["signer"] = signer.String()
+	controlLogger.WithFields(f).Info("verified the signature of ", file)
+	return signer, nil
 }
 
 func (p *pluginControl) returnPluginDetails(rp *core.RequestedPlugin) (*pluginDetails, serror.SnapError) {
 	details := &pluginDetails{}
 	var serr serror.SnapError
-	//Check plugin signing
-	details.Signed, serr = p.verifySignature(rp)
-	if serr != nil {
-		return nil, serr
-	}
 
 	details.Path = rp.Path()
 	details.CheckSum = rp.CheckSum()
 	details.Signature = rp.Signature()
 	details.IsAutoLoaded = rp.AutoLoaded()
 
-	if filepath.Ext(rp.Path()) == ".aci" {
-		f, err := os.Open(rp.Path())
-		if err != nil {
-			return nil, serror.New(err)
+	if filepath.Ext(rp.Path()) != ".aci" {
+		//Check plugin signing
+		details.Signer, serr = p.verifySignature(rp.Path(), rp.Signature())
+		if serr != nil {
+			return nil, serr
 		}
-		defer f.Close()
-		if err := aci.Validate(f); err != nil {
-			return nil, serror.New(err)
+		details.Signed = details.Signer != nil
+		// the signer is checked before the plugin runs
+		if serr := p.checkTrustPolicy(details); serr != nil {
+			return nil, serr
 		}
-		tempPath, err := aci.Extract(f)
-		if err != nil {
+		details.IsPackage = false
+		details.Exec = filepath.Base(rp.Path())
+		details.ExecPath = filepath.Dir(rp.Path())
+		return details, nil
+	}
+
+	f, err := os.Open(rp.Path())
+	if err != nil {
+		return nil, serror.New(err)
+	}
+	defer f.Close()
+	if err := aci.Validate(f); err != nil {
+		return nil, serror.New(err)
+	}
+	// the signature is verified before the package is extracted
+	rawManifest, embedded, err := aci.SnapManifestFromImage(f)
+	if err != nil {
+		return nil, serror.New(err)
+	}
+	var manifest *aci.SnapManifest
+	if rawManifest != nil {
+		if manifest, err = aci.ParseSnapManifest(rawManifest); err != nil {
 			return nil, serror.New(err)
 		}
-		details.ExecPath = path.Join(tempPath, "rootfs")
-		if details.Manifest, err = aci.Manifest(f); err != nil {
+	}
+	if rp.Signature() == nil && embedded != nil && manifest != nil {
+		// the manifest lists the checksum of every file of the package and
+		// the exec of the image manifest so the signature of the manifest
+		// signs the package
+		details.Signer, serr = p.verifyManifestSignature(rawManifest, embedded)
+		details.ManifestSigned = true
+	} else {
+		details.Signer, serr = p.verifySignature(rp.Path(), rp.Signature())
+	}
+	if serr != nil {
+		return nil, serr
+	}
+	details.Signed = details.Signer != nil
+	details.SnapManifest = manifest
+	// the signer is checked before the package is extracted and run
+	if serr := p.checkTrustPolicy(details); serr != nil {
+		return nil, serr
+	}
+	if details.Manifest, err = aci.Manifest(f); err != nil {
+		return nil, serror.New(err)
+	}
+	details.Exec = details.Manifest.App.Exec[0]
+	if manifest != nil && (details.ManifestSigned || manifest.Exec != "") {
+		if err := manifest.CheckExec(details.Exec); err != nil {
 			return nil, serror.New(err)
 		}
-		details.Exec = details.Manifest.App.Exec[0]
-		details.IsPackage = true
-	} else {
-		details.IsPackage = false
-		details.Exec = filepath.Base(rp.Path())
-		details.ExecPath = filepath.Dir(rp.Path())
 	}
 
+	tempPath, err := aci.Extract(f)
+	if err != nil {
+		return nil, serror.New(err)
+	}
+	rootfs := path.Join(tempPath, "rootfs")
+	if manifest != nil {
+		// the files of the package are checked against the snap manifest
+		// before anything in the package is run
+		fail := func(err error) (*pluginDetails, serror.SnapError) {
+			os.RemoveAll(tempPath)
+			return nil, serror.New(err)
+		}
+		if err := manifest.Verify(rootfs); err != nil {
+			return fail(err)
+		}
+		if err := manifest.CheckSnapdVersion(p.snapdVersion); err != nil {
+			return fail(err)
+		}
+	}
+	details.ExecPath = rootfs
+	details.IsPackage = true
 	return details, nil
 }
 
+// verifyManifestSignature verifies the signature embedded in a plugin package
+// of the snap manifest read from the package
+func (p *pluginControl) verifyManifestSignature(manifest, signature []byte) (*psigning.Signer, serror.SnapError) {
+	tmp, err := ioutil.TempFile("", aci.SnapManifestFile)
+	if err != nil {
+		return nil, serror.New(err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(manifest)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, serror.New(err)
+	}
+	return p.verifySignature(tmp.Name(), signature)
+}
+
+// checkLoadedPlugin returns an error when the plugin loaded does not match its
+// snap manifest or the type declared by its file name
+func (p *pluginControl) checkLoadedPlugin(lp *loadedPlugin) serror.SnapError {
+	if se := p.checkSnapManifest(lp); se != nil {
+		return se
+	}
+	if typ := declaredPluginType(lp.Details); typ != "" && typ != lp.TypeName() {
+		se := serror.New(fmt.Errorf("Plugin type %s does not match the type declared for the plugin (%s)", lp.TypeName(), typ))
+		se.SetFields(map[string]interface{}{
+			"_block":         "check-loaded-plugin",
+			"plugin-name":    lp.Name(),
+			"plugin-version": lp.Version(),
+			"plugin-type":    lp.TypeName(),
+		})
+		return se
+	}
+	return nil
+}
+
+// declaredPluginType returns the type of the plugin known before it runs: the
+// type of the snap manifest of a package or the type in the name of a file
+// named snap-plugin-<type>-<name>.  An empty string is returned otherwise.
+func declaredPluginType(details *pluginDetails) string {
+	if details.SnapManifest != nil {
+		return details.SnapManifest.Type
+	}
+	if details.IsPackage {
+		return ""
+	}
+	parts := strings.SplitN(filepath.Base(details.Path), "-", 4)
+	if len(parts) < 4 || parts[0] != "snap" || parts[1] != "plugin" {
+		return ""
+	}
+	for _, t := range pluginTypes {
+		if parts[2] == t.String() {
+			return parts[2]
+		}
+	}
+	return ""
+}
+
+// checkTrustPolicy returns an error when the signer of the plugin may not sign
+// plugins of its type.  It is called before the plugin runs so the type is
+// the declared one, and a plugin without a declared type is only allowed when
+// its signer may sign plugins of every type.  Plugins whose signature was not
+// verified, under the disabled or warning plugin trust levels, are not
+// checked.
+func (p *pluginControl) checkTrustPolicy(details *pluginDetails) serror.SnapError {
+	if !details.Signed || p.Config == nil {
+		return nil
+	}
+	policy := psigning.TrustPolicy(p.Config.TrustPolicy)
+	declared := declaredPluginType(details)
+	types := []string{declared}
+	if declared == "" {
+		types = types[:0]
+		for _, t := range pluginTypes {
+			types = append(types, t.String())
+		}
+	}
+	for _, t := range types {
+		if policy.Allows(t, details.Signer) {
+			continue
+		}
+		se := serror.New(fmt.Errorf("Signer %v may not sign %s plugins", details.Signer, t))
+		se.SetFields(map[string]interface{}{
+			"_block":        "check-trust-policy",
+			"plugin-path":   details.Path,
+			"plugin-type":   t,
+			"declared-type": declared,
+		})
+		return se
+	}
+	return nil
+}
+
+// checkSnapManifest returns an error when the plugin loaded from a package
+// does not match the snap manifest of the package
+func (p *pluginControl) checkSnapManifest(lp *loadedPlugin) serror.SnapError {
+	m := lp.Details.SnapManifest
+	if m == nil {
+		return nil
+	}
+	f := map[string]interface{}{
+		"_block":         "check-snap-manifest",
+		"plugin-name":    lp.Name(),
+		"plugin-version": lp.Version(),
+		"plugin-type":    lp.TypeName(),
+	}
+	if m.Type != lp.TypeName() || m.Name != lp.Name() || m.Version != lp.Version() {
+		se := serror.New(fmt.Errorf("Plugin %s:%s:%d does not match its snap manifest (%s:%s:%d)",
+			lp.TypeName(), lp.Name(), lp.Version(), m.Type, m.Name, m.Version))
+		se.SetFields(f)
+		return se
+	}
+	if m.RPCType != "" {
+		rpcType, ok := rpcTypes[strings.ToLower(m.RPCType)]
+		if !ok || rpcType != lp.Meta.RPCType {
+			se := serror.New(fmt.Errorf("Plugin RPC type does not match its snap manifest (%s)", m.RPCType))
+			se.SetFields(f)
+			return se
+		}
+	}
+	if len(m.RequiredConfig) > 0 && p.Config != nil && p.Config.Plugins != nil {
+		cfg := p.Config.GetPluginConfigDataNode(core.PluginType(lp.Type), lp.Name(), lp.Version())
+		table := cfg.Table()
+		for _, item := range m.RequiredConfig {
+			if _, ok := table[item]; !ok {
+				controlLogger.WithFields(f).Warn("required config item not set in the global config, it must be set in the task config: ", item)
+			}
+		}
+	}
+	return nil
+}
+
 func (p *pluginControl) Unload(pl core.Plugin) (core.CatalogedPlugin, serror.SnapError) {
 	up, err := p.pluginManager.UnloadPlugin(pl)
 	if err != nil {
@@ -594,6 +818,17 @@ func (p *pluginControl) SwapPlugins(in *core.RequestedPlugin, out core.Cataloged
 	if err != nil {
 		return err
 	}
+	if serr := p.checkLoadedPlugin(lp); serr != nil {
+		if _, err := p.pluginManager.UnloadPlugin(lp); err != nil {
+			se := serror.New(errors.New("Failed to rollback after error"))
+			se.SetFields(map[string]interface{}{
+				"original-unload-error": serr.Error(),
+				"rollback-unload-error": err.Error(),
+			})
+			return se
+		}
+		return serr
+	}
 
 	// Make sure plugin types and names are the same
 	if lp.TypeName() != out.TypeName() || lp.Name() != out.Name() {
@@ -653,6 +888,13 @@ func (p *pluginControl) SubscribeDeps(id string, requested []core.RequestedMetri
 	return p.subscriptionGroups.Add(id, requested, configTree, plugins)
 }
 
+// UpdateDeps replaces the dependencies of the subscription group with the given
+// ID.  Plugins are only subscribed and unsubscribed where the dependencies
+// differ and the previous subscription is kept if the update fails.
+func (p *pluginControl) UpdateDeps(id string, requested []core.RequestedMetric, plugins []core.SubscribedPlugin, configTree *cdata.ConfigDataTree) []serror.SnapError {
+	return p.subscriptionGroups.Update(id, requested, configTree, plugins)
+}
+
 // UnsubscribeDeps unsubscribes a group of dependencies provided the subscription group ID
 func (p *pluginControl) UnsubscribeDeps(id string) []serror.SnapError {
 	// update view and unsubscribe to plugins
@@ -660,6 +902,13 @@ func (p *pluginControl) UnsubscribeDeps(id string) []serror.SnapError {
 }
 
 func (p *pluginControl) verifyPlugin(lp *loadedPlugin) error {
+	if lp.Details.SignerRevoked {
+		return fmt.Errorf("The key of the signer of the plugin (%v) was revoked", lp.Details.Signer)
+	}
+	// embedded plugins are compiled into snapd and have no file to verify
+	if lp.Details.Embedded != nil {
+		return nil
+	}
 	b, err := ioutil.ReadFile(lp.Details.Path)
 	if err != nil {
 		return err
@@ -668,8 +917,11 @@ func (p *pluginControl) verifyPlugin(lp *loadedPlugin) error {
 	if lp.Details.CheckSum != cs {
 		return fmt.Errorf(fmt.Sprintf("Current plugin checksum (%x) does not match checksum when plugin was first loaded (%x).", cs, lp.Details.CheckSum))
 	}
-	if lp.Details.Signed {
-		return p.signingManager.ValidateSignature(p.keyringFiles, lp.Details.Path, lp.Details.Signature)
+	// the checksum of a package signed through its snap manifest matching
+	// the one verified on load is enough
+	if lp.Details.Signed && !lp.Details.ManifestSigned {
+		_, err := psigning.Verify(p.signatureVerifiers(), lp.Details.Path, lp.Details.Signature)
+		return err
 	}
 	return nil
 }
@@ -1020,8 +1272,28 @@ func (p *pluginControl) SetPluginTrustLevel(trust int) {
 	p.pluginTrust = trust
 }
 
-func (p *pluginControl) SetKeyringFile(keyring string) {
-	p.keyringFiles = append(p.keyringFiles, keyring)
+// SetKeyringFile adds the keys of the keyring file to the OpenPGP verifier.
+// The file is read again each time a plugin is verified.
+func (p *pluginControl) SetKeyringFile(keyring string) error {
+	return p.openPGP.AddKeyringFile(keyring)
+}
+
+// AddSignatureVerifier adds a verifier of the signatures of plugins
+func (p *pluginControl) AddSignatureVerifier(v psigning.Verifier) {
+	p.verifiersMutex.Lock()
+	defer p.verifiersMutex.Unlock()
+	p.verifiers = append(p.verifiers, v)
+}
+
+func (p *pluginControl) signatureVerifiers() []psigning.Verifier {
+	p.verifiersMutex.RLock()
+	defer p.verifiersMutex.RUnlock()
+	return p.verifiers
+}
+
+// SetSnapdVersion sets the version of snapd plugin packages are checked against
+func (p *pluginControl) SetSnapdVersion(version string) {
+	p.snapdVersion = version
 }
 
 type requestedPlugin struct {
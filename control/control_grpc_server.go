@@ -97,6 +97,14 @@ func (pc *ControlGRPCServer) SubscribeDeps(ctx context.Context, r *rpc.Subscribe
 	return &rpc.SubscribeDepsReply{Errors: common.NewErrors(serrors)}, nil
 }
 
+func (pc *ControlGRPCServer) UpdateDeps(ctx context.Context, r *rpc.SubscribeDepsRequest) (*rpc.SubscribeDepsReply, error) {
+	plugins := common.ToSubPlugins(r.Plugins)
+	configTree := cdata.NewTree()
+	requested := common.MetricToRequested(r.Requested)
+	serrors := pc.control.UpdateDeps(r.TaskId, requested, plugins, configTree)
+	return &rpc.SubscribeDepsReply{Errors: common.NewErrors(serrors)}, nil
+}
+
 func (pc *ControlGRPCServer) UnsubscribeDeps(ctx context.Context, r *rpc.UnsubscribeDepsRequest) (*rpc.UnsubscribeDepsReply, error) {
 	serrors := pc.control.UnsubscribeDeps(r.TaskId)
 	return &rpc.UnsubscribeDepsReply{Errors: common.NewErrors(serrors)}, nil
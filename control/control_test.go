@@ -46,6 +46,7 @@ import (
 	"github.com/intelsdi-x/snap/core/control_event"
 	"github.com/intelsdi-x/snap/core/ctypes"
 	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/pkg/psigning"
 	"github.com/intelsdi-x/snap/plugin/helper"
 )
 
@@ -78,6 +79,8 @@ func (m *MockPluginManagerBadSwap) SetPluginLoadTimeout(int)          {}
 func (m *MockPluginManagerBadSwap) SetMetricCatalog(catalogsMetrics)  {}
 func (m *MockPluginManagerBadSwap) SetEmitter(gomit.Emitter)          {}
 func (m *MockPluginManagerBadSwap) GenerateArgs(int) plugin.Arg       { return plugin.Arg{} }
+func (m *MockPluginManagerBadSwap) SecureChannel() *secureChannel     { return nil }
+func (m *MockPluginManagerBadSwap) PluginTransport() string           { return PluginTransportTCP }
 
 func (m *MockPluginManagerBadSwap) all() map[string]*loadedPlugin {
 	return m.loadedPlugins.table
@@ -336,15 +339,18 @@ var (
 	AciFile = "snap-collector-plugin-mock1.darwin-x86_64.aci"
 )
 
-type mocksigningManager struct {
+type mockVerifier struct {
 	signed bool
 }
 
-func (ps *mocksigningManager) ValidateSignature([]string, string, []byte) error {
+func (ps *mockVerifier) Scheme() string           { return psigning.SchemeOpenPGP }
+func (ps *mockVerifier) Supports(sig []byte) bool { return true }
+
+func (ps *mockVerifier) Verify(string, []byte) (*psigning.Signer, error) {
 	if ps.signed {
-		return nil
+		return &psigning.Signer{Scheme: psigning.SchemeOpenPGP, Identity: "mock"}, nil
 	}
-	return errors.New("fake")
+	return nil, errors.New("fake")
 }
 
 // Uses the mock collector plugin to simulate Loading
@@ -409,7 +415,7 @@ func TestLoadWithSignedPlugins(t *testing.T) {
 		Convey("pluginControl.Load should successufully load a signed plugin with trust enabled", t, func() {
 			c := New(getTestConfig())
 			c.pluginTrust = PluginTrustEnabled
-			c.signingManager = &mocksigningManager{signed: true}
+			c.verifiers = []psigning.Verifier{&mockVerifier{signed: true}}
 			lpe := newListenToPluginEvent()
 			c.eventManager.RegisterHandler("Control.PluginLoaded", lpe)
 			c.Start()
@@ -425,7 +431,7 @@ func TestLoadWithSignedPlugins(t *testing.T) {
 		Convey("pluginControl.Load should successfully load unsigned plugin when trust level set to warning", t, func() {
 			c := New(getTestConfig())
 			c.pluginTrust = PluginTrustWarn
-			c.signingManager = &mocksigningManager{signed: false}
+			c.verifiers = []psigning.Verifier{&mockVerifier{signed: false}}
 			lpe := newListenToPluginEvent()
 			c.eventManager.RegisterHandler("Control.PluginLoaded", lpe)
 			c.Start()
@@ -440,7 +446,7 @@ func TestLoadWithSignedPlugins(t *testing.T) {
 		Convey("pluginControl.Load returns error with trust enabled and signing not validated", t, func() {
 			c := New(getTestConfig())
 			c.pluginTrust = PluginTrustEnabled
-			c.signingManager = &mocksigningManager{signed: false}
+			c.verifiers = []psigning.Verifier{&mockVerifier{signed: false}}
 			c.Start()
 			time.Sleep(100 * time.Millisecond)
 			_, err := load(c, fixtures.PluginPath)
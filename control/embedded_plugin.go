@@ -0,0 +1,322 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/control/plugin/cpolicy"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/control_event"
+	"github.com/intelsdi-x/snap/core/ctypes"
+	"github.com/intelsdi-x/snap/core/serror"
+)
+
+var (
+	// ErrBadEmbeddedPlugin - Error message for an embedded plugin not
+	// implementing the plugin interface of its type
+	ErrBadEmbeddedPlugin = errors.New("Embedded plugin does not implement the interface of its type")
+
+	embeddedLog = log.WithField("_module", "control-embedded")
+
+	embeddedPlugins = struct {
+		sync.Mutex
+		plugins []*embeddedPlugin
+	}{}
+)
+
+// MetricProcessor can be implemented by embedded processors to process the
+// metrics as they are, instead of serialized into a content type
+type MetricProcessor interface {
+	ProcessMetrics([]core.Metric, map[string]ctypes.ConfigValue) ([]core.Metric, error)
+}
+
+// MetricPublisher can be implemented by embedded publishers to publish the
+// metrics as they are, instead of serialized into a content type
+type MetricPublisher interface {
+	PublishMetrics([]core.Metric, map[string]ctypes.ConfigValue) error
+}
+
+// RegisterEmbeddedPlugin registers a plugin compiled into snapd: a
+// plugin.CollectorPlugin, plugin.ProcessorPlugin or plugin.PublisherPlugin of
+// the type of its meta. The registered plugins are loaded when control
+// starts, so a custom snapd build registers its plugins from the init
+// function of a package it imports:
+//
+//	func init() {
+//		if err := control.RegisterEmbeddedPlugin(hostmetrics.Meta(), hostmetrics.New()); err != nil {
+//			panic(err)
+//		}
+//	}
+func RegisterEmbeddedPlugin(meta *plugin.PluginMeta, p plugin.Plugin) error {
+	ep, err := newEmbeddedPlugin(meta, p)
+	if err != nil {
+		return err
+	}
+	embeddedPlugins.Lock()
+	defer embeddedPlugins.Unlock()
+	embeddedPlugins.plugins = append(embeddedPlugins.plugins, ep)
+	return nil
+}
+
+// LoadEmbeddedPlugin loads a plugin running within snapd, see
+// RegisterEmbeddedPlugin. Its metrics are added to the catalog and it is
+// started on subscription like any plugin, but calls to it are function calls
+// instead of RPC to a subprocess.
+func (p *pluginControl) LoadEmbeddedPlugin(meta *plugin.PluginMeta, pl plugin.Plugin) (core.CatalogedPlugin, serror.SnapError) {
+	ep, err := newEmbeddedPlugin(meta, pl)
+	if err != nil {
+		return nil, serror.New(err)
+	}
+	return p.loadEmbeddedPlugin(ep)
+}
+
+func (p *pluginControl) loadEmbeddedPlugin(ep *embeddedPlugin) (core.CatalogedPlugin, serror.SnapError) {
+	f := map[string]interface{}{
+		"_block":         "load-embedded",
+		"plugin-name":    ep.meta.Name,
+		"plugin-version": ep.meta.Version,
+		"plugin-type":    ep.meta.Type.String(),
+	}
+	if !p.Started {
+		se := serror.New(ErrControllerNotStarted)
+		se.SetFields(f)
+		return nil, se
+	}
+	embeddedLog.WithFields(f).Info("embedded plugin load called")
+	details := &pluginDetails{
+		Exec:     ep.meta.Name,
+		Embedded: ep,
+	}
+	// embedded plugins are part of snapd so they are not subject to the
+	// signature checks and the trust policy
+	pl, se := p.pluginManager.LoadPlugin(details, p.eventManager)
+	if se != nil {
+		return nil, se
+	}
+	event := &control_event.LoadPluginEvent{
+		Name:    pl.Meta.Name,
+		Version: pl.Meta.Version,
+		Type:    int(pl.Meta.Type),
+	}
+	defer p.eventManager.Emit(event)
+	return pl, nil
+}
+
+// loadEmbeddedPlugins loads the registered embedded plugins
+func (p *pluginControl) loadEmbeddedPlugins() {
+	embeddedPlugins.Lock()
+	eps := append([]*embeddedPlugin{}, embeddedPlugins.plugins...)
+	embeddedPlugins.Unlock()
+	for _, ep := range eps {
+		if _, err := p.loadEmbeddedPlugin(ep); err != nil {
+			embeddedLog.WithFields(log.Fields{
+				"_block":         "load-embedded",
+				"plugin-name":    ep.meta.Name,
+				"plugin-version": ep.meta.Version,
+				"error":          err.Error(),
+			}).Error("error loading embedded plugin")
+		}
+	}
+}
+
+// embeddedPlugin is a plugin running within snapd. It is the executablePlugin
+// of the plugin, starting it without a subprocess.
+type embeddedPlugin struct {
+	meta   plugin.PluginMeta
+	plugin plugin.Plugin
+}
+
+func newEmbeddedPlugin(meta *plugin.PluginMeta, p plugin.Plugin) (*embeddedPlugin, error) {
+	var ok bool
+	switch meta.Type {
+	case plugin.CollectorPluginType:
+		_, ok = p.(plugin.CollectorPlugin)
+	case plugin.ProcessorPluginType:
+		_, ok = p.(plugin.ProcessorPlugin)
+		if !ok {
+			_, ok = p.(MetricProcessor)
+		}
+	case plugin.PublisherPluginType:
+		_, ok = p.(plugin.PublisherPlugin)
+		if !ok {
+			_, ok = p.(MetricPublisher)
+		}
+	}
+	if !ok {
+		return nil, ErrBadEmbeddedPlugin
+	}
+	// embedded plugins are only reached through function calls
+	m := *meta
+	m.Unsecure = true
+	return &embeddedPlugin{meta: m, plugin: p}, nil
+}
+
+// Run returns the response of the plugin, which is always ready
+func (e *embeddedPlugin) Run(time.Duration) (plugin.Response, error) {
+	return plugin.Response{
+		Meta:  e.meta,
+		Type:  e.meta.Type,
+		State: plugin.PluginSuccess,
+	}, nil
+}
+
+// Kill does nothing as the plugin lives as long as snapd
+func (e *embeddedPlugin) Kill() error {
+	return nil
+}
+
+// client returns the client calling the plugin
+func (e *embeddedPlugin) client() *embeddedClient {
+	return &embeddedClient{
+		plugin:      e.plugin,
+		contentType: plugin.NegotiateContentType(e.meta.AcceptedContentTypes),
+	}
+}
+
+// isEmbedded returns whether the executable plugin runs within snapd
+func isEmbedded(ep executablePlugin) bool {
+	_, ok := ep.(*embeddedPlugin)
+	return ok
+}
+
+// embeddedClient is the client of an embedded plugin. It implements the
+// clients of all the plugin types by calling the plugin directly. A panic of
+// the plugin is returned as an error.
+type embeddedClient struct {
+	plugin plugin.Plugin
+	// contentType is used to send metrics to the plugins processing and
+	// publishing serialized metrics
+	contentType string
+}
+
+func (e *embeddedClient) SetKey() error     { return nil }
+func (e *embeddedClient) Ping() error       { return nil }
+func (e *embeddedClient) Kill(string) error { return nil }
+func (e *embeddedClient) GetType() string   { return "Embedded" }
+
+func (e *embeddedClient) GetConfigPolicy() (cp *cpolicy.ConfigPolicy, err error) {
+	defer recoverEmbeddedPanic(&err)
+	return e.plugin.GetConfigPolicy()
+}
+
+func (e *embeddedClient) GetMetricTypes(config plugin.ConfigType) (metrics []core.Metric, err error) {
+	defer recoverEmbeddedPanic(&err)
+	mts, err := e.plugin.(plugin.CollectorPlugin).GetMetricTypes(config)
+	if err != nil {
+		return nil, err
+	}
+	metrics = make([]core.Metric, len(mts))
+	for i, mt := range mts {
+		// Set the advertised time
+		mt.LastAdvertisedTime_ = time.Now()
+		metrics[i] = mt
+	}
+	return metrics, nil
+}
+
+func (e *embeddedClient) CollectMetrics(mts []core.Metric) (metrics []core.Metric, err error) {
+	defer recoverEmbeddedPanic(&err)
+	if len(mts) == 0 {
+		return nil, errors.New("no metrics to collect")
+	}
+	metricsToCollect := make([]plugin.MetricType, len(mts))
+	for i, mt := range mts {
+		metricsToCollect[i] = plugin.MetricType{
+			Namespace_:          mt.Namespace(),
+			LastAdvertisedTime_: mt.LastAdvertisedTime(),
+			Version_:            mt.Version(),
+			Tags_:               mt.Tags(),
+			Config_:             mt.Config(),
+		}
+	}
+	collected, err := e.plugin.(plugin.CollectorPlugin).CollectMetrics(metricsToCollect)
+	if err != nil {
+		return nil, err
+	}
+	metrics = make([]core.Metric, len(collected))
+	for i, mt := range collected {
+		metrics[i] = mt
+	}
+	return metrics, nil
+}
+
+func (e *embeddedClient) Process(mts []core.Metric, config map[string]ctypes.ConfigValue) (metrics []core.Metric, err error) {
+	defer recoverEmbeddedPanic(&err)
+	if p, ok := e.plugin.(MetricProcessor); ok {
+		return p.ProcessMetrics(mts, config)
+	}
+	if len(mts) == 0 {
+		return mts, nil
+	}
+	content, contentType, err := plugin.MarshalMetricTypes(e.contentType, toMetricTypes(mts))
+	if err != nil {
+		return nil, err
+	}
+	contentType, content, err = e.plugin.(plugin.ProcessorPlugin).Process(contentType, content, config)
+	if err != nil {
+		return nil, err
+	}
+	if contentType == "" || contentType == plugin.SnapAllContentType {
+		contentType = plugin.SnapGOBContentType
+	}
+	processed, err := plugin.UnmarshallMetricTypes(contentType, content)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding metrics: %v", err)
+	}
+	metrics = make([]core.Metric, len(processed))
+	for i, mt := range processed {
+		metrics[i] = mt
+	}
+	return metrics, nil
+}
+
+func (e *embeddedClient) Publish(mts []core.Metric, config map[string]ctypes.ConfigValue) (err error) {
+	defer recoverEmbeddedPanic(&err)
+	if p, ok := e.plugin.(MetricPublisher); ok {
+		return p.PublishMetrics(mts, config)
+	}
+	if len(mts) == 0 {
+		return nil
+	}
+	content, contentType, err := plugin.MarshalMetricTypes(e.contentType, toMetricTypes(mts))
+	if err != nil {
+		return err
+	}
+	return e.plugin.(plugin.PublisherPlugin).Publish(contentType, content, config)
+}
+
+// recoverEmbeddedPanic recovers from a panic of an embedded plugin, which
+// would otherwise stop snapd, and sets it as the error of the call
+func recoverEmbeddedPanic(err *error) {
+	if r := recover(); r != nil {
+		embeddedLog.WithFields(log.Fields{
+			"_block": "recover",
+			"panic":  r,
+		}).Error("embedded plugin panicked")
+		*err = fmt.Errorf("embedded plugin panic: %v", r)
+	}
+}
@@ -0,0 +1,184 @@
+// +build medium
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/control/plugin/cpolicy"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/cdata"
+	"github.com/intelsdi-x/snap/core/ctypes"
+
+	"github.com/pborman/uuid"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type embeddedCollector struct{}
+
+func (e *embeddedCollector) GetConfigPolicy() (*cpolicy.ConfigPolicy, error) {
+	cp := cpolicy.New()
+	node := cpolicy.NewPolicyNode()
+	r, err := cpolicy.NewIntegerRule("scale", false, 1)
+	if err != nil {
+		return nil, err
+	}
+	node.Add(r)
+	cp.Add([]string{"intel", "embedded"}, node)
+	return cp, nil
+}
+
+func (e *embeddedCollector) GetMetricTypes(plugin.ConfigType) ([]plugin.MetricType, error) {
+	return []plugin.MetricType{
+		{Namespace_: core.NewNamespace("intel", "embedded", "foo")},
+		{Namespace_: core.NewNamespace("intel", "embedded", "bar")},
+	}, nil
+}
+
+func (e *embeddedCollector) CollectMetrics(mts []plugin.MetricType) ([]plugin.MetricType, error) {
+	for i := range mts {
+		scale := mts[i].Config().Table()["scale"].(ctypes.ConfigValueInt).Value
+		if scale < 0 {
+			panic("negative scale")
+		}
+		mts[i].Data_ = 21 * scale
+		mts[i].Timestamp_ = time.Now()
+	}
+	return mts, nil
+}
+
+// embeddedProcessor processes the metrics without serializing them
+type embeddedProcessor struct{}
+
+func (e *embeddedProcessor) GetConfigPolicy() (*cpolicy.ConfigPolicy, error) {
+	return cpolicy.New(), nil
+}
+
+func (e *embeddedProcessor) ProcessMetrics(mts []core.Metric, _ map[string]ctypes.ConfigValue) ([]core.Metric, error) {
+	processed := make([]core.Metric, len(mts))
+	for i, m := range mts {
+		processed[i] = plugin.MetricType{
+			Namespace_: m.Namespace(),
+			Data_:      m.Data().(int) * 2,
+			Timestamp_: m.Timestamp(),
+		}
+	}
+	return processed, nil
+}
+
+// embeddedPublisher is given the metrics serialized as plugins are
+type embeddedPublisher struct {
+	published []plugin.MetricType
+}
+
+func (e *embeddedPublisher) GetConfigPolicy() (*cpolicy.ConfigPolicy, error) {
+	return cpolicy.New(), nil
+}
+
+func (e *embeddedPublisher) Publish(contentType string, content []byte, _ map[string]ctypes.ConfigValue) error {
+	if contentType != plugin.SnapGOBContentType {
+		return errors.New("unexpected content type " + contentType)
+	}
+	return gob.NewDecoder(bytes.NewBuffer(content)).Decode(&e.published)
+}
+
+func TestEmbeddedPlugins(t *testing.T) {
+	Convey("Registering an embedded plugin not implementing its type fails", t, func() {
+		meta := plugin.NewPluginMeta("embedded", 1, plugin.PublisherPluginType, nil, nil)
+		So(RegisterEmbeddedPlugin(meta, &embeddedCollector{}), ShouldEqual, ErrBadEmbeddedPlugin)
+	})
+	Convey("Given a control with embedded plugins", t, func() {
+		So(RegisterEmbeddedPlugin(plugin.NewPluginMeta("embedded", 1, plugin.CollectorPluginType, nil, nil), &embeddedCollector{}), ShouldBeNil)
+		defer func() { embeddedPlugins.plugins = nil }()
+		c := New(getTestSGConfig())
+		So(c.Start(), ShouldBeNil)
+		defer c.Stop()
+		_, err := c.LoadEmbeddedPlugin(plugin.NewPluginMeta("embedded", 1, plugin.ProcessorPluginType, []string{plugin.SnapGOBContentType}, []string{plugin.SnapGOBContentType}), &embeddedProcessor{})
+		So(err, ShouldBeNil)
+		publisher := &embeddedPublisher{}
+		_, err = c.LoadEmbeddedPlugin(plugin.NewPluginMeta("embedded", 1, plugin.PublisherPluginType, []string{plugin.SnapGOBContentType}, nil), publisher)
+		So(err, ShouldBeNil)
+
+		Convey("they are loaded", func() {
+			So(c.PluginCatalog(), ShouldHaveLength, 3)
+			mts, err := c.MetricCatalog()
+			So(err, ShouldBeNil)
+			So(mts, ShouldHaveLength, 2)
+			mt, err := c.GetMetric(core.NewNamespace("intel", "embedded", "foo"), 1)
+			So(err, ShouldBeNil)
+			So(mt.Namespace().String(), ShouldEqual, "/intel/embedded/foo")
+		})
+		Convey("they collect, process and publish metrics", func() {
+			taskID := uuid.New()
+			cdt := cdata.NewTree()
+			node := cdata.NewNode()
+			node.AddItem("scale", ctypes.ConfigValueInt{Value: 2})
+			cdt.Add([]string{"intel", "embedded"}, node)
+			requested := mockRequestedMetric{namespace: core.NewNamespace("intel", "embedded", "foo"), version: -1}
+			serrs := c.SubscribeDeps(taskID, []core.RequestedMetric{requested}, []core.SubscribedPlugin{
+				mockSubscribedPlugin{typeName: core.ProcessorPluginType, name: "embedded", version: 1, config: cdata.NewNode()},
+				mockSubscribedPlugin{typeName: core.PublisherPluginType, name: "embedded", version: 1, config: cdata.NewNode()},
+			}, cdt)
+			So(serrs, ShouldBeEmpty)
+			defer c.UnsubscribeDeps(taskID)
+			So(c.AvailablePlugins(), ShouldHaveLength, 3)
+
+			mts, errs := c.CollectMetrics(taskID, nil)
+			So(errs, ShouldBeEmpty)
+			So(mts, ShouldHaveLength, 1)
+			So(mts[0].Data(), ShouldEqual, 42)
+
+			mts, errs = c.ProcessMetrics(mts, nil, taskID, "embedded", 1)
+			So(errs, ShouldBeEmpty)
+			So(mts, ShouldHaveLength, 1)
+			So(mts[0].Data(), ShouldEqual, 84)
+
+			errs = c.PublishMetrics(mts, nil, taskID, "embedded", 1)
+			So(errs, ShouldBeEmpty)
+			So(publisher.published, ShouldHaveLength, 1)
+			So(publisher.published[0].Namespace().String(), ShouldEqual, "/intel/embedded/foo")
+			So(publisher.published[0].Data(), ShouldEqual, 84)
+		})
+		Convey("a panic of a plugin is returned as an error", func() {
+			ep, err := newEmbeddedPlugin(plugin.NewPluginMeta("embedded", 1, plugin.CollectorPluginType, nil, nil), &embeddedCollector{})
+			So(err, ShouldBeNil)
+			cfg := cdata.NewNode()
+			cfg.AddItem("scale", ctypes.ConfigValueInt{Value: -1})
+			_, err = ep.client().CollectMetrics([]core.Metric{
+				plugin.MetricType{Namespace_: core.NewNamespace("intel", "embedded", "foo"), Config_: cfg},
+			})
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "negative scale")
+		})
+		Convey("they are unloaded", func() {
+			_, err := c.Unload(mockSubscribedPlugin{typeName: core.CollectorPluginType, name: "embedded", version: 1})
+			So(err, ShouldBeNil)
+			So(c.PluginCatalog(), ShouldHaveLength, 2)
+			So(c.MetricExists(core.NewNamespace("intel", "embedded", "foo"), 1), ShouldBeFalse)
+		})
+	})
+}
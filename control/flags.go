@@ -52,6 +52,26 @@ var (
 		Usage:  "Keyring paths for signing verification separated by colons",
 		EnvVar: "SNAP_KEYRING_PATHS",
 	}
+	flPluginIndex = cli.StringFlag{
+		Name:   "plugin-index",
+		Usage:  "Plugin index locations (URLs, files or directories) separated by commas",
+		EnvVar: "SNAP_PLUGIN_INDEX",
+	}
+	flX509CAFiles = cli.StringFlag{
+		Name:   "x509-ca-files",
+		Usage:  "CA certificate files (PEM) for X.509 signing verification separated by colons",
+		EnvVar: "SNAP_X509_CA_FILES",
+	}
+	flX509CRLFiles = cli.StringFlag{
+		Name:   "x509-crl-files",
+		Usage:  "CRL files for X.509 signing verification separated by colons",
+		EnvVar: "SNAP_X509_CRL_FILES",
+	}
+	flEd25519KeyPaths = cli.StringFlag{
+		Name:   "ed25519-key-paths",
+		Usage:  "Ed25519 public key files or directories for signing verification separated by colons",
+		EnvVar: "SNAP_ED25519_KEY_PATHS",
+	}
 	flCache = cli.StringFlag{
 		Name:   "cache-expiration",
 		Usage:  fmt.Sprintf("The time limit for which a metric cache entry is valid (default: %v)", defaultCacheExpiration),
@@ -70,5 +90,5 @@ var (
 		EnvVar: "SNAP_CONTROL_LISTEN_ADDR",
 	}
 
-	Flags = []cli.Flag{flNumberOfPLs, flPluginLoadTimeout, flAutoDiscover, flPluginTrust, flKeyringPaths, flCache, flControlRpcPort, flControlRpcAddr}
+	Flags = []cli.Flag{flNumberOfPLs, flPluginLoadTimeout, flAutoDiscover, flPluginTrust, flKeyringPaths, flX509CAFiles, flX509CRLFiles, flEd25519KeyPaths, flPluginIndex, flCache, flControlRpcPort, flControlRpcAddr}
 )
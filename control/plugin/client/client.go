@@ -20,6 +20,11 @@ limitations under the License.
 package client
 
 import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/intelsdi-x/snap/control/plugin"
 	"github.com/intelsdi-x/snap/control/plugin/cpolicy"
 	"github.com/intelsdi-x/snap/core"
@@ -52,3 +57,75 @@ type PluginPublisherClient interface {
 	PluginClient
 	Publish([]core.Metric, map[string]ctypes.ConfigValue) error
 }
+
+// PluginContentProcessorClient A processor client able to send metrics already
+// serialized into a content type accepted by the plugin.
+type PluginContentProcessorClient interface {
+	ProcessContent(string, []byte, map[string]ctypes.ConfigValue) ([]core.Metric, error)
+}
+
+// PluginContentPublisherClient A publisher client able to send metrics already
+// serialized into a content type accepted by the plugin.
+type PluginContentPublisherClient interface {
+	PublishContent(string, []byte, map[string]ctypes.ConfigValue) error
+}
+
+// New returns the client of the RPC type of a started plugin from its response,
+// the PluginCollectorClient, PluginProcessorClient or PluginPublisherClient of
+// its type. The RPC to the plugin is secured with the TLS configuration when
+// it is not nil.
+func New(resp plugin.Response, timeout time.Duration, tlsConfig *tls.Config) (PluginClient, error) {
+	var (
+		c   PluginClient
+		err error
+	)
+	switch resp.Type {
+	case plugin.CollectorPluginType:
+		switch resp.Meta.RPCType {
+		case plugin.JSONRPC:
+			c, err = NewCollectorHttpJSONRPCClient(listenURL(resp.ListenAddress, tlsConfig), timeout, resp.PublicKey, !resp.Meta.Unsecure, resp.Meta.AcceptedContentTypes, tlsConfig)
+		case plugin.NativeRPC:
+			c, err = NewCollectorNativeClient(resp.ListenAddress, timeout, resp.PublicKey, !resp.Meta.Unsecure, resp.Meta.AcceptedContentTypes, tlsConfig)
+		case plugin.GRPC:
+			c, err = NewCollectorGrpcClient(resp.ListenAddress, timeout, resp.PublicKey, !resp.Meta.Unsecure, tlsConfig)
+		default:
+			return nil, errors.New("Invalid RPCTYPE")
+		}
+	case plugin.PublisherPluginType:
+		switch resp.Meta.RPCType {
+		case plugin.NativeRPC:
+			c, err = NewPublisherNativeClient(resp.ListenAddress, timeout, resp.PublicKey, !resp.Meta.Unsecure, resp.Meta.AcceptedContentTypes, tlsConfig)
+		case plugin.GRPC:
+			c, err = NewPublisherGrpcClient(resp.ListenAddress, timeout, resp.PublicKey, !resp.Meta.Unsecure, tlsConfig)
+		default:
+			return nil, errors.New("Invalid RPCTYPE")
+		}
+	case plugin.ProcessorPluginType:
+		switch resp.Meta.RPCType {
+		case plugin.NativeRPC:
+			c, err = NewProcessorNativeClient(resp.ListenAddress, timeout, resp.PublicKey, !resp.Meta.Unsecure, resp.Meta.AcceptedContentTypes, tlsConfig)
+		case plugin.GRPC:
+			c, err = NewProcessorGrpcClient(resp.ListenAddress, timeout, resp.PublicKey, !resp.Meta.Unsecure, tlsConfig)
+		default:
+			return nil, errors.New("Invalid RPCTYPE")
+		}
+	default:
+		return nil, errors.New("Cannot create a client for a plugin of the type: " + resp.Type.String())
+	}
+	if err != nil {
+		return nil, errors.New("error while creating client connection: " + err.Error())
+	}
+	return c, nil
+}
+
+// listenURL returns the URL of a JSON-RPC plugin from its listen address
+func listenURL(address string, tlsConfig *tls.Config) string {
+	if network, _ := plugin.ParseListenAddress(address); network == "unix" {
+		// the JSON-RPC client dials the socket of the listen address
+		return address
+	}
+	if tlsConfig != nil {
+		return fmt.Sprintf("https://%v/rpc", address)
+	}
+	return fmt.Sprintf("http://%v/rpc", address)
+}
@@ -21,6 +21,7 @@ package client
 
 import (
 	"crypto/rsa"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"strconv"
@@ -62,12 +63,8 @@ type grpcClient struct {
 }
 
 // NewCollectorGrpcClient returns a collector gRPC Client.
-func NewCollectorGrpcClient(address string, timeout time.Duration, pub *rsa.PublicKey, secure bool) (PluginCollectorClient, error) {
-	address, port, err := parseAddress(address)
-	if err != nil {
-		return nil, err
-	}
-	p, err := newGrpcClient(address, int(port), timeout, plugin.CollectorPluginType)
+func NewCollectorGrpcClient(address string, timeout time.Duration, pub *rsa.PublicKey, secure bool, tlsConfig *tls.Config) (PluginCollectorClient, error) {
+	p, err := newGrpcClient(address, timeout, plugin.CollectorPluginType, tlsConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -76,12 +73,8 @@ func NewCollectorGrpcClient(address string, timeout time.Duration, pub *rsa.Publ
 }
 
 // NewProcessorGrpcClient returns a processor gRPC Client.
-func NewProcessorGrpcClient(address string, timeout time.Duration, pub *rsa.PublicKey, secure bool) (PluginProcessorClient, error) {
-	address, port, err := parseAddress(address)
-	if err != nil {
-		return nil, err
-	}
-	p, err := newGrpcClient(address, int(port), timeout, plugin.ProcessorPluginType)
+func NewProcessorGrpcClient(address string, timeout time.Duration, pub *rsa.PublicKey, secure bool, tlsConfig *tls.Config) (PluginProcessorClient, error) {
+	p, err := newGrpcClient(address, timeout, plugin.ProcessorPluginType, tlsConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -99,12 +92,8 @@ func NewProcessorGrpcClient(address string, timeout time.Duration, pub *rsa.Publ
 }
 
 // NewPublisherGrpcClient returns a publisher gRPC Client.
-func NewPublisherGrpcClient(address string, timeout time.Duration, pub *rsa.PublicKey, secure bool) (PluginPublisherClient, error) {
-	address, port, err := parseAddress(address)
-	if err != nil {
-		return nil, err
-	}
-	p, err := newGrpcClient(address, int(port), timeout, plugin.PublisherPluginType)
+func NewPublisherGrpcClient(address string, timeout time.Duration, pub *rsa.PublicKey, secure bool, tlsConfig *tls.Config) (PluginPublisherClient, error) {
+	p, err := newGrpcClient(address, timeout, plugin.PublisherPluginType, tlsConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -134,8 +123,22 @@ func parseAddress(address string) (string, int64, error) {
 	return address, port, nil
 }
 
-func newGrpcClient(addr string, port int, timeout time.Duration, typ plugin.PluginType) (*grpcClient, error) {
-	conn, err := rpcutil.GetClientConnection(addr, port)
+// dial returns a connection to the plugin of the listen address, a TCP
+// address or a Unix socket
+func dial(address string, tlsConfig *tls.Config) (*grpc.ClientConn, error) {
+	network, addr := plugin.ParseListenAddress(address)
+	if network == "unix" {
+		return rpcutil.GetUnixClientConnectionWithTLS(addr, tlsConfig)
+	}
+	host, port, err := parseAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+	return rpcutil.GetClientConnectionWithTLS(host, int(port), tlsConfig)
+}
+
+func newGrpcClient(address string, timeout time.Duration, typ plugin.PluginType, tlsConfig *tls.Config) (*grpcClient, error) {
+	conn, err := dial(address, tlsConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -409,10 +412,13 @@ func ConfigMapToConfig(cfg *rpc.ConfigMap) *cdata.ConfigDataNode {
 
 func ToConfigMap(cv map[string]ctypes.ConfigValue) *rpc.ConfigMap {
 	newConfig := &rpc.ConfigMap{
-		IntMap:    make(map[string]int64),
-		FloatMap:  make(map[string]float64),
-		StringMap: make(map[string]string),
-		BoolMap:   make(map[string]bool),
+		IntMap:        make(map[string]int64),
+		FloatMap:      make(map[string]float64),
+		StringMap:     make(map[string]string),
+		BoolMap:       make(map[string]bool),
+		StringListMap: make(map[string]*rpc.StringList),
+		FloatListMap:  make(map[string]*rpc.FloatList),
+		StringMapMap:  make(map[string]*rpc.StringMap),
 	}
 	for k, v := range cv {
 		switch v.Type() {
@@ -424,6 +430,12 @@ func ToConfigMap(cv map[string]ctypes.ConfigValue) *rpc.ConfigMap {
 			newConfig.StringMap[k] = v.(ctypes.ConfigValueStr).Value
 		case "bool":
 			newConfig.BoolMap[k] = v.(ctypes.ConfigValueBool).Value
+		case "string_list":
+			newConfig.StringListMap[k] = &rpc.StringList{Values: v.(ctypes.ConfigValueStrList).Value}
+		case "float_list":
+			newConfig.FloatListMap[k] = &rpc.FloatList{Values: v.(ctypes.ConfigValueFloatList).Value}
+		case "map":
+			newConfig.StringMapMap[k] = &rpc.StringMap{Values: v.(ctypes.ConfigValueMap).Value}
 		}
 	}
 	return newConfig
@@ -467,6 +479,21 @@ func ParseConfig(config *rpc.ConfigMap) map[string]ctypes.ConfigValue {
 		bval := ctypes.ConfigValueBool{Value: v}
 		c[k] = bval
 	}
+	for k, v := range config.StringListMap {
+		if v != nil {
+			c[k] = ctypes.ConfigValueStrList{Value: v.Values}
+		}
+	}
+	for k, v := range config.FloatListMap {
+		if v != nil {
+			c[k] = ctypes.ConfigValueFloatList{Value: v.Values}
+		}
+	}
+	for k, v := range config.StringMapMap {
+		if v != nil {
+			c[k] = ctypes.ConfigValueMap{Value: v.Values}
+		}
+	}
 	return c
 }
 
@@ -27,10 +27,12 @@ package client
 import (
 	"bytes"
 	"crypto/rsa"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"sync/atomic"
 	"time"
@@ -47,6 +49,9 @@ import (
 
 var logger = log.WithField("_module", "client-httpjsonrpc")
 
+// unixSocketHost is the host of the URL of a plugin listening on a Unix socket
+const unixSocketHost = "plugin"
+
 type httpJSONRPCClient struct {
 	url        string
 	id         uint64
@@ -54,16 +59,26 @@ type httpJSONRPCClient struct {
 	pluginType plugin.PluginType
 	encrypter  *encrypter.Encrypter
 	encoder    encoding.Encoder
+	// collectContentType is requested from collectors for returned metrics
+	collectContentType string
+	// transport secures the HTTPS connections to the plugin and dials the
+	// Unix socket of a plugin listening on one
+	transport http.RoundTripper
 }
 
-// NewCollectorHttpJSONRPCClient returns CollectorHttpJSONRPCClient
-func NewCollectorHttpJSONRPCClient(u string, timeout time.Duration, pub *rsa.PublicKey, secure bool) (PluginCollectorClient, error) {
+// NewCollectorHttpJSONRPCClient returns CollectorHttpJSONRPCClient. The content
+// types accepted by the plugin are used to negotiate how collected metrics are
+// returned. The tlsConfig is used for an https URL. The URL of a plugin
+// listening on a Unix socket is its listen address, as it is for the other
+// JSON-RPC clients.
+func NewCollectorHttpJSONRPCClient(u string, timeout time.Duration, pub *rsa.PublicKey, secure bool, contentTypes []string, tlsConfig *tls.Config) (PluginCollectorClient, error) {
 	hjr := &httpJSONRPCClient{
-		url:        u,
-		timeout:    timeout,
-		pluginType: plugin.CollectorPluginType,
-		encoder:    encoding.NewJsonEncoder(),
+		timeout:            timeout,
+		pluginType:         plugin.CollectorPluginType,
+		encoder:            encoding.NewJsonEncoder(),
+		collectContentType: plugin.NegotiateCollectContentType(contentTypes),
 	}
+	hjr.setURL(u, tlsConfig)
 	if secure {
 		key, err := encrypter.GenerateKey()
 		if err != nil {
@@ -79,11 +94,11 @@ func NewCollectorHttpJSONRPCClient(u string, timeout time.Duration, pub *rsa.Pub
 
 func NewProcessorHttpJSONRPCClient(u string, timeout time.Duration, pub *rsa.PublicKey, secure bool) (PluginProcessorClient, error) {
 	hjr := &httpJSONRPCClient{
-		url:        u,
 		timeout:    timeout,
 		pluginType: plugin.ProcessorPluginType,
 		encoder:    encoding.NewJsonEncoder(),
 	}
+	hjr.setURL(u, nil)
 	if secure {
 		key, err := encrypter.GenerateKey()
 		if err != nil {
@@ -99,11 +114,11 @@ func NewProcessorHttpJSONRPCClient(u string, timeout time.Duration, pub *rsa.Pub
 
 func NewPublisherHttpJSONRPCClient(u string, timeout time.Duration, pub *rsa.PublicKey, secure bool) (PluginPublisherClient, error) {
 	hjr := &httpJSONRPCClient{
-		url:        u,
 		timeout:    timeout,
 		pluginType: plugin.PublisherPluginType,
 		encoder:    encoding.NewJsonEncoder(),
 	}
+	hjr.setURL(u, nil)
 	if secure {
 		key, err := encrypter.GenerateKey()
 		if err != nil {
@@ -117,6 +132,30 @@ func NewPublisherHttpJSONRPCClient(u string, timeout time.Duration, pub *rsa.Pub
 	return hjr, nil
 }
 
+// setURL sets the URL requests are posted to. A plugin listening on a Unix
+// socket is reached through a transport dialing its socket.
+func (h *httpJSONRPCClient) setURL(u string, tlsConfig *tls.Config) {
+	network, addr := plugin.ParseListenAddress(u)
+	if network != "unix" {
+		h.url = u
+		if tlsConfig != nil {
+			h.transport = &http.Transport{TLSClientConfig: tlsConfig}
+		}
+		return
+	}
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+	}
+	h.url = fmt.Sprintf("%s://%s/rpc", scheme, unixSocketHost)
+	h.transport = &http.Transport{
+		TLSClientConfig: tlsConfig,
+		Dial: func(_, _ string) (net.Conn, error) {
+			return net.DialTimeout("unix", addr, h.timeout)
+		},
+	}
+}
+
 // Ping
 func (h *httpJSONRPCClient) Ping() error {
 	_, err := h.call("SessionState.Ping", []interface{}{})
@@ -163,7 +202,10 @@ func (h *httpJSONRPCClient) CollectMetrics(mts []core.Metric) ([]core.Metric, er
 		}
 	}
 
-	args := &plugin.CollectMetricsArgs{MetricTypes: metricsToCollect}
+	args := &plugin.CollectMetricsArgs{
+		MetricTypes: metricsToCollect,
+		ContentType: h.collectContentType,
+	}
 
 	out, err := h.encoder.Encode(args)
 	if err != nil {
@@ -187,6 +229,9 @@ func (h *httpJSONRPCClient) CollectMetrics(mts []core.Metric) ([]core.Metric, er
 	if err != nil {
 		return nil, err
 	}
+	if r.ContentType != "" {
+		return decodeContent(r.ContentType, r.Content)
+	}
 
 	results = make([]core.Metric, len(r.PluginMetrics))
 	idx := 0
@@ -281,7 +326,7 @@ func (h *httpJSONRPCClient) call(method string, args []interface{}) (*jsonRpcRes
 		}).Error("error encoding request to json")
 		return nil, err
 	}
-	client := http.Client{Timeout: h.timeout}
+	client := http.Client{Timeout: h.timeout, Transport: h.transport}
 	resp, err := client.Post(h.url, "application/json", bytes.NewReader(data))
 	if err != nil {
 		logger.WithFields(log.Fields{
@@ -29,10 +29,13 @@ import (
 	"crypto/rsa"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math/rand"
 	"net"
 	"net/http"
 	"net/rpc"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -186,7 +189,7 @@ func TestHTTPJSONRPC(t *testing.T) {
 
 	Convey("Collector Client", t, func() {
 		session.c = true
-		c, err := NewCollectorHttpJSONRPCClient(fmt.Sprintf("http://%v", addr), 1*time.Second, &key.PublicKey, true)
+		c, err := NewCollectorHttpJSONRPCClient(fmt.Sprintf("http://%v", addr), 1*time.Second, &key.PublicKey, true, nil, nil)
 		So(err, ShouldBeNil)
 		So(c, ShouldNotBeNil)
 		cl := c.(*httpJSONRPCClient)
@@ -280,4 +283,25 @@ func TestHTTPJSONRPC(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Collector Client of a plugin listening on a Unix socket", t, func() {
+		dir, err := ioutil.TempDir("", "snap-plugin-socket")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		l, err := net.Listen("unix", filepath.Join(dir, plugin.UnixSocketName))
+		So(err, ShouldBeNil)
+		defer l.Close()
+		go http.Serve(l, nil)
+
+		session.c = true
+		c, err := NewCollectorHttpJSONRPCClient(plugin.UnixListenAddress(l.Addr().String()), 1*time.Second, &key.PublicKey, true, nil, nil)
+		So(err, ShouldBeNil)
+		cl := c.(*httpJSONRPCClient)
+		cl.encrypter.Key = symkey
+
+		Convey("Ping", func() {
+			err := c.Ping()
+			So(err, ShouldBeNil)
+		})
+	})
 }
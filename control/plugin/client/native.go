@@ -22,6 +22,7 @@ package client
 import (
 	"bytes"
 	"crypto/rsa"
+	"crypto/tls"
 	"encoding/gob"
 	"errors"
 	"fmt"
@@ -53,18 +54,32 @@ type PluginNativeClient struct {
 	encoder    encoding.Encoder
 	encrypter  *encrypter.Encrypter
 	timeout    time.Duration
+	// contentType is used to send metrics to publishers and processors
+	contentType string
+	// collectContentType is requested from collectors for returned metrics
+	collectContentType string
 }
 
-func NewCollectorNativeClient(address string, timeout time.Duration, pub *rsa.PublicKey, secure bool) (PluginCollectorClient, error) {
-	return newNativeClient(address, timeout, plugin.CollectorPluginType, pub, secure)
+// NewCollectorNativeClient returns a client for a native collector. The
+// content types accepted by the plugin are used to negotiate how collected
+// metrics are returned. The connection is secured with TLS when tlsConfig
+// isn't nil, as it is for the other native clients.
+func NewCollectorNativeClient(address string, timeout time.Duration, pub *rsa.PublicKey, secure bool, contentTypes []string, tlsConfig *tls.Config) (PluginCollectorClient, error) {
+	return newNativeClient(address, timeout, plugin.CollectorPluginType, pub, secure, contentTypes, tlsConfig)
 }
 
-func NewPublisherNativeClient(address string, timeout time.Duration, pub *rsa.PublicKey, secure bool) (PluginPublisherClient, error) {
-	return newNativeClient(address, timeout, plugin.PublisherPluginType, pub, secure)
+// NewPublisherNativeClient returns a client for a native publisher. The
+// content types accepted by the plugin are used to negotiate how metrics are
+// sent to it.
+func NewPublisherNativeClient(address string, timeout time.Duration, pub *rsa.PublicKey, secure bool, contentTypes []string, tlsConfig *tls.Config) (PluginPublisherClient, error) {
+	return newNativeClient(address, timeout, plugin.PublisherPluginType, pub, secure, contentTypes, tlsConfig)
 }
 
-func NewProcessorNativeClient(address string, timeout time.Duration, pub *rsa.PublicKey, secure bool) (PluginProcessorClient, error) {
-	return newNativeClient(address, timeout, plugin.ProcessorPluginType, pub, secure)
+// NewProcessorNativeClient returns a client for a native processor. The
+// content types accepted by the plugin are used to negotiate how metrics are
+// sent to it.
+func NewProcessorNativeClient(address string, timeout time.Duration, pub *rsa.PublicKey, secure bool, contentTypes []string, tlsConfig *tls.Config) (PluginProcessorClient, error) {
+	return newNativeClient(address, timeout, plugin.ProcessorPluginType, pub, secure, contentTypes, tlsConfig)
 }
 
 func (p *PluginNativeClient) Ping() error {
@@ -105,7 +120,7 @@ func checkTime(in time.Time) time.Time {
 	return in
 }
 
-func encodeMetrics(metrics []core.Metric) []byte {
+func toMetricTypes(metrics []core.Metric) []plugin.MetricType {
 	mts := make([]plugin.MetricType, len(metrics))
 	for i, m := range metrics {
 		mts[i] = plugin.MetricType{
@@ -120,10 +135,43 @@ func encodeMetrics(metrics []core.Metric) []byte {
 			Data_:               m.Data(),
 		}
 	}
-	var buf bytes.Buffer
-	enc := gob.NewEncoder(&buf)
-	enc.Encode(mts)
-	return buf.Bytes()
+	return mts
+}
+
+// encodeMetrics serializes metrics using the given content type. GOB is used
+// for the snap wildcard or when no metrics are given.
+func encodeMetrics(contentType string, metrics []core.Metric) ([]byte, string, error) {
+	mts := toMetricTypes(metrics)
+	if len(mts) == 0 || contentType == plugin.SnapGOBContentType || contentType == plugin.SnapAllContentType {
+		var buf bytes.Buffer
+		enc := gob.NewEncoder(&buf)
+		err := enc.Encode(mts)
+		return buf.Bytes(), plugin.SnapGOBContentType, err
+	}
+	return plugin.MarshalMetricTypes(contentType, mts)
+}
+
+// decodeContent deserializes metrics returned with the given content type.
+// An empty content type is decoded as GOB.
+func decodeContent(contentType string, content []byte) ([]core.Metric, error) {
+	switch contentType {
+	case plugin.SnapJSONContentType, plugin.SnapProtobufContentType, plugin.SnapMsgpackContentType:
+		mts, err := plugin.UnmarshallMetricTypes(contentType, content)
+		if err != nil {
+			return nil, fmt.Errorf("Error decoding metrics: %v", err)
+		}
+		cmetrics := make([]core.Metric, len(mts))
+		for i, mt := range mts {
+			mt.Timestamp_ = checkTime(mt.Timestamp())
+			mt.LastAdvertisedTime_ = checkTime(mt.LastAdvertisedTime())
+			cmetrics[i] = mt
+		}
+		return cmetrics, nil
+	case "", plugin.SnapGOBContentType, plugin.SnapAllContentType:
+		return decodeMetrics(content)
+	default:
+		return nil, fmt.Errorf("Error decoding metrics: unsupported content type %s", contentType)
+	}
 }
 
 func decodeMetrics(bts []byte) ([]core.Metric, error) {
@@ -152,9 +200,19 @@ func enforceTimeout(p *PluginNativeClient, dl time.Duration, done chan int) {
 
 func (p *PluginNativeClient) Publish(metrics []core.Metric, config map[string]ctypes.ConfigValue) error {
 
+	content, contentType, err := encodeMetrics(p.contentType, metrics)
+	if err != nil {
+		return err
+	}
+	return p.PublishContent(contentType, content, config)
+}
+
+// PublishContent sends metrics serialized into the given content type to the
+// publisher.
+func (p *PluginNativeClient) PublishContent(contentType string, content []byte, config map[string]ctypes.ConfigValue) error {
 	args := plugin.PublishArgs{
-		ContentType: plugin.SnapGOBContentType,
-		Content:     encodeMetrics(metrics),
+		ContentType: contentType,
+		Content:     content,
 		Config:      config,
 	}
 
@@ -172,9 +230,19 @@ func (p *PluginNativeClient) Publish(metrics []core.Metric, config map[string]ct
 
 func (p *PluginNativeClient) Process(metrics []core.Metric, config map[string]ctypes.ConfigValue) ([]core.Metric, error) {
 
+	content, contentType, err := encodeMetrics(p.contentType, metrics)
+	if err != nil {
+		return nil, err
+	}
+	return p.ProcessContent(contentType, content, config)
+}
+
+// ProcessContent sends metrics serialized into the given content type to the
+// processor. The processor has to reply with a snap content type.
+func (p *PluginNativeClient) ProcessContent(contentType string, content []byte, config map[string]ctypes.ConfigValue) ([]core.Metric, error) {
 	args := plugin.ProcessorArgs{
-		ContentType: plugin.SnapGOBContentType,
-		Content:     encodeMetrics(metrics),
+		ContentType: contentType,
+		Content:     content,
 		Config:      config,
 	}
 
@@ -197,7 +265,7 @@ func (p *PluginNativeClient) Process(metrics []core.Metric, config map[string]ct
 	if err != nil {
 		return nil, err
 	}
-	mts, err := decodeMetrics(r.Content)
+	mts, err := decodeContent(r.ContentType, r.Content)
 	if err != nil {
 		return nil, err
 	}
@@ -224,7 +292,10 @@ func (p *PluginNativeClient) CollectMetrics(mts []core.Metric) ([]core.Metric, e
 		}
 	}
 
-	args := plugin.CollectMetricsArgs{MetricTypes: metricsToCollect}
+	args := plugin.CollectMetricsArgs{
+		MetricTypes: metricsToCollect,
+		ContentType: p.collectContentType,
+	}
 	out, err := p.encoder.Encode(args)
 	if err != nil {
 		return nil, err
@@ -244,6 +315,9 @@ func (p *PluginNativeClient) CollectMetrics(mts []core.Metric) ([]core.Metric, e
 	if err != nil {
 		return nil, err
 	}
+	if r.ContentType != "" {
+		return decodeContent(r.ContentType, r.Content)
+	}
 
 	results = make([]core.Metric, len(r.PluginMetrics))
 	idx := 0
@@ -308,18 +382,27 @@ func (p *PluginNativeClient) GetType() string {
 	return upcaseInitial(p.pluginType.String())
 }
 
-func newNativeClient(address string, timeout time.Duration, t plugin.PluginType, pub *rsa.PublicKey, secure bool) (*PluginNativeClient, error) {
+func newNativeClient(address string, timeout time.Duration, t plugin.PluginType, pub *rsa.PublicKey, secure bool, contentTypes []string, tlsConfig *tls.Config) (*PluginNativeClient, error) {
 	// Attempt to dial address error on timeout or problem
-	conn, err := net.DialTimeout("tcp", address, timeout)
+	var conn net.Conn
+	var err error
+	network, addr := plugin.ParseListenAddress(address)
+	if tlsConfig != nil {
+		conn, err = tls.DialWithDialer(&net.Dialer{Timeout: timeout}, network, addr, tlsConfig)
+	} else {
+		conn, err = net.DialTimeout(network, addr, timeout)
+	}
 	// Return nil RPCClient and err if encoutered
 	if err != nil {
 		return nil, err
 	}
 	r := rpc.NewClient(conn)
 	p := &PluginNativeClient{
-		connection: r,
-		pluginType: t,
-		timeout:    timeout,
+		connection:         r,
+		pluginType:         t,
+		timeout:            timeout,
+		contentType:        plugin.NegotiateContentType(contentTypes),
+		collectContentType: plugin.NegotiateCollectContentType(contentTypes),
 	}
 
 	p.encoder = encoding.NewGobEncoder()
@@ -343,6 +426,9 @@ func init() {
 	gob.RegisterName("conf_value_int", *(&ctypes.ConfigValueInt{}))
 	gob.RegisterName("conf_value_float", *(&ctypes.ConfigValueFloat{}))
 	gob.RegisterName("conf_value_bool", *(&ctypes.ConfigValueBool{}))
+	gob.RegisterName("conf_value_string_list", *(&ctypes.ConfigValueStrList{}))
+	gob.RegisterName("conf_value_float_list", *(&ctypes.ConfigValueFloatList{}))
+	gob.RegisterName("conf_value_map", *(&ctypes.ConfigValueMap{}))
 
 	gob.RegisterName("conf_policy_node", cpolicy.NewPolicyNode())
 	gob.RegisterName("conf_data_node", &cdata.ConfigDataNode{})
@@ -350,6 +436,10 @@ func init() {
 	gob.RegisterName("conf_policy_int", &cpolicy.IntRule{})
 	gob.RegisterName("conf_policy_float", &cpolicy.FloatRule{})
 	gob.RegisterName("conf_policy_bool", &cpolicy.BoolRule{})
+	gob.RegisterName("conf_policy_string_list", &cpolicy.StringListRule{})
+	gob.RegisterName("conf_policy_float_list", &cpolicy.FloatListRule{})
+	gob.RegisterName("conf_policy_map", &cpolicy.MapRule{})
+	gob.RegisterName("conf_policy_duration", &cpolicy.DurationRule{})
 }
 
 func upcaseInitial(str string) string {
@@ -22,11 +22,18 @@ package plugin
 // Arguments passed to CollectMetrics() for a Collector implementation
 type CollectMetricsArgs struct {
 	MetricTypes []MetricType
+	// ContentType requests that collected metrics are returned serialized
+	// into Content using this content type instead of PluginMetrics.
+	ContentType string
 }
 
 // Reply assigned by a Collector implementation using CollectMetrics()
 type CollectMetricsReply struct {
 	PluginMetrics []MetricType
+	// ContentType and Content hold the serialized metrics when the content
+	// type requested in CollectMetricsArgs could be used.
+	ContentType string
+	Content     []byte
 }
 
 // GetMetricTypesArgs args passed to GetMetricTypes
@@ -75,6 +75,13 @@ func (c *collectorPluginProxy) CollectMetrics(args []byte, reply *[]byte) error
 	}
 
 	r := CollectMetricsReply{PluginMetrics: ms}
+	if dargs.ContentType != "" && len(ms) > 0 {
+		// Fall back to PluginMetrics if the metrics can't be serialized
+		// using the requested content type.
+		if content, ct, err := MarshalMetricTypes(dargs.ContentType, ms); err == nil {
+			r = CollectMetricsReply{ContentType: ct, Content: content}
+		}
+	}
 	*reply, err = c.Session.Encode(r)
 	if err != nil {
 		return err
@@ -143,6 +143,26 @@ func TestCollectorProxy(t *testing.T) {
 			So(mtr.PluginMetrics[0].Namespace().String(), ShouldResemble, "/foo/test/bar")
 			So(mtr.PluginMetrics[0].Namespace()[1].Name, ShouldEqual, "test")
 
+			Convey("Collect Metric using a requested content type", func() {
+				args := CollectMetricsArgs{
+					MetricTypes: mockMetricType,
+					ContentType: SnapProtobufContentType,
+				}
+				out, err := c.Session.Encode(args)
+				So(err, ShouldBeNil)
+				var reply []byte
+				c.CollectMetrics(out, &reply)
+				var mtr CollectMetricsReply
+				err = c.Session.Decode(reply, &mtr)
+				So(err, ShouldBeNil)
+				So(mtr.PluginMetrics, ShouldBeEmpty)
+				So(mtr.ContentType, ShouldEqual, SnapProtobufContentType)
+				mts, err := UnmarshallMetricTypes(mtr.ContentType, mtr.Content)
+				So(err, ShouldBeNil)
+				So(mts[0].Namespace().String(), ShouldResemble, "/foo/test/bar")
+				So(mts[0].Data(), ShouldEqual, "data")
+			})
+
 			Convey("Get error in Collect Metric ", func() {
 				args := CollectMetricsArgs{
 					MetricTypes: mockMetricType,
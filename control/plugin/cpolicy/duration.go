@@ -0,0 +1,221 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2015 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpolicy
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/intelsdi-x/snap/core/ctypes"
+)
+
+const (
+	DurationType = "duration"
+)
+
+// A rule validating against duration config. Durations are provided as
+// strings parseable by time.ParseDuration, e.g. "1m30s".
+type DurationRule struct {
+	rule
+
+	key      string
+	required bool
+	default_ *time.Duration
+	minimum  *time.Duration
+	maximum  *time.Duration
+}
+
+// NewDurationRule returns a new duration rule. Arguments are key(string),
+// required(bool), default(time.Duration).
+func NewDurationRule(key string, req bool, opts ...time.Duration) (*DurationRule, error) {
+	// Return error if key is empty
+	if key == "" {
+		return nil, EmptyKeyError
+	}
+
+	d := &DurationRule{
+		key:      key,
+		required: req,
+	}
+
+	if len(opts) > 0 {
+		d.default_ = &opts[0]
+	}
+	return d, nil
+}
+
+func (d *DurationRule) Type() string {
+	return DurationType
+}
+
+// MarshalJSON marshals a DurationRule into JSON
+func (d *DurationRule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Key      string             `json:"key"`
+		Required bool               `json:"required"`
+		Default  ctypes.ConfigValue `json:"default,omitempty"`
+		Minimum  ctypes.ConfigValue `json:"minimum,omitempty"`
+		Maximum  ctypes.ConfigValue `json:"maximum,omitempty"`
+		Type     string             `json:"type"`
+	}{
+		Key:      d.key,
+		Required: d.required,
+		Default:  d.Default(),
+		Minimum:  d.Minimum(),
+		Maximum:  d.Maximum(),
+		Type:     DurationType,
+	})
+}
+
+// GobEncode encodes a DurationRule into a GOB
+func (d *DurationRule) GobEncode() ([]byte, error) {
+	w := new(bytes.Buffer)
+	encoder := gob.NewEncoder(w)
+	if err := encoder.Encode(d.key); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(d.required); err != nil {
+		return nil, err
+	}
+	for _, v := range []*time.Duration{d.default_, d.minimum, d.maximum} {
+		if err := encodeOptionalDuration(encoder, v); err != nil {
+			return nil, err
+		}
+	}
+	return w.Bytes(), nil
+}
+
+// GobDecode decodes a GOB into a DurationRule
+func (d *DurationRule) GobDecode(buf []byte) error {
+	r := bytes.NewBuffer(buf)
+	decoder := gob.NewDecoder(r)
+	if err := decoder.Decode(&d.key); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&d.required); err != nil {
+		return err
+	}
+	for _, v := range []**time.Duration{&d.default_, &d.minimum, &d.maximum} {
+		var err error
+		if *v, err = decodeOptionalDuration(decoder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Key returns the key
+func (d *DurationRule) Key() string {
+	return d.key
+}
+
+// Validate validates a config value against this rule.
+func (d *DurationRule) Validate(cv ctypes.ConfigValue) error {
+	// Durations are carried as strings in config data
+	if cv.Type() != StringType {
+		return wrongType(d.key, cv.Type(), DurationType)
+	}
+	v := cv.(ctypes.ConfigValueStr).Value
+	dur, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("value is not a valid duration (%s value '%s')", d.key, v)
+	}
+	// Check minimum
+	if d.minimum != nil && dur < *d.minimum {
+		return fmt.Errorf("value is under minimum (%s value %v < %v)", d.key, dur, *d.minimum)
+	}
+	// Check maximum
+	if d.maximum != nil && dur > *d.maximum {
+		return fmt.Errorf("value is over maximum (%s value %v > %v)", d.key, dur, *d.maximum)
+	}
+	return nil
+}
+
+// Default returns this rule's default value
+func (d *DurationRule) Default() ctypes.ConfigValue {
+	return durationValue(d.default_)
+}
+
+// Required returns a boolean indicating if this rule is required
+func (d *DurationRule) Required() bool {
+	return d.required
+}
+
+// SetMinimum sets the minimum allowed duration
+func (d *DurationRule) SetMinimum(m time.Duration) {
+	d.minimum = &m
+}
+
+// SetMaximum sets the maximum allowed duration
+func (d *DurationRule) SetMaximum(m time.Duration) {
+	d.maximum = &m
+}
+
+func (d *DurationRule) Minimum() ctypes.ConfigValue {
+	return durationValue(d.minimum)
+}
+
+func (d *DurationRule) Maximum() ctypes.ConfigValue {
+	return durationValue(d.maximum)
+}
+
+// durationValue returns the config value of an optional duration
+func durationValue(d *time.Duration) ctypes.ConfigValue {
+	if d == nil {
+		return nil
+	}
+	return ctypes.ConfigValueStr{Value: d.String()}
+}
+
+// durationFromJSON parses a duration of a decoded JSON rule
+func durationFromJSON(i interface{}) *time.Duration {
+	s, _ := i.(string)
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return nil
+	}
+	return &d
+}
+
+func encodeOptionalDuration(encoder *gob.Encoder, d *time.Duration) error {
+	if d == nil {
+		return encoder.Encode(false)
+	}
+	if err := encoder.Encode(true); err != nil {
+		return err
+	}
+	return encoder.Encode(int64(*d))
+}
+
+func decodeOptionalDuration(decoder *gob.Decoder) (*time.Duration, error) {
+	var isSet bool
+	if err := decoder.Decode(&isSet); err != nil || !isSet {
+		return nil, err
+	}
+	var i int64
+	if err := decoder.Decode(&i); err != nil {
+		return nil, err
+	}
+	d := time.Duration(i)
+	return &d, nil
+}
@@ -0,0 +1,110 @@
+// +build legacy
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2015 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpolicy
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/intelsdi-x/snap/core/ctypes"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConfigPolicyRuleDuration(t *testing.T) {
+	Convey("NewDurationRule", t, func() {
+
+		Convey("empty key", func() {
+			r, e := NewDurationRule("", true)
+			So(r, ShouldBeNil)
+			So(e, ShouldResemble, EmptyKeyError)
+		})
+
+		Convey("default is set", func() {
+			r, e := NewDurationRule("timeout", false, 90*time.Second)
+			So(e, ShouldBeNil)
+			So(r.Type(), ShouldEqual, "duration")
+			So(r.Default(), ShouldResemble, ctypes.ConfigValueStr{Value: "1m30s"})
+		})
+
+		Convey("default is unset", func() {
+			r, e := NewDurationRule("timeout", true)
+			So(e, ShouldBeNil)
+			So(r.Default(), ShouldBeNil)
+		})
+
+		Convey("processing", func() {
+			r, _ := NewDurationRule("timeout", true)
+			r.SetMinimum(time.Second)
+			r.SetMaximum(time.Minute)
+
+			Convey("passes with a valid duration", func() {
+				So(r.Validate(ctypes.ConfigValueStr{Value: "30s"}), ShouldBeNil)
+			})
+
+			Convey("errors with non-string config value", func() {
+				e := r.Validate(ctypes.ConfigValueInt{Value: 30})
+				So(e, ShouldResemble, errors.New("type mismatch (timeout wanted type 'duration' but provided type 'integer')"))
+			})
+
+			Convey("errors with an invalid duration", func() {
+				e := r.Validate(ctypes.ConfigValueStr{Value: "soon"})
+				So(e, ShouldResemble, errors.New("value is not a valid duration (timeout value 'soon')"))
+			})
+
+			Convey("errors with a duration under the minimum", func() {
+				e := r.Validate(ctypes.ConfigValueStr{Value: "10ms"})
+				So(e, ShouldResemble, errors.New("value is under minimum (timeout value 10ms < 1s)"))
+			})
+
+			Convey("errors with a duration over the maximum", func() {
+				e := r.Validate(ctypes.ConfigValueStr{Value: "2m"})
+				So(e, ShouldResemble, errors.New("value is over maximum (timeout value 2m0s > 1m0s)"))
+			})
+		})
+
+		Convey("encoding", func() {
+			r, _ := NewDurationRule("timeout", false, 5*time.Second)
+			r.SetMaximum(time.Minute)
+
+			Convey("JSON", func() {
+				b, e := json.Marshal(r)
+				So(e, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"key":"timeout","required":false,"default":"5s","maximum":"1m0s","type":"duration"}`)
+				n := NewPolicyNode()
+				So(json.Unmarshal([]byte(`{"rules":{"timeout":`+string(b)+`}}`), n), ShouldBeNil)
+				So(n.rules["timeout"], ShouldResemble, r)
+			})
+
+			Convey("GOB", func() {
+				buf := new(bytes.Buffer)
+				So(gob.NewEncoder(buf).Encode(r), ShouldBeNil)
+				r2 := &DurationRule{}
+				So(gob.NewDecoder(buf).Decode(r2), ShouldBeNil)
+				So(r2, ShouldResemble, r)
+			})
+		})
+	})
+}
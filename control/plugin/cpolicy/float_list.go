@@ -0,0 +1,266 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2015 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpolicy
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+
+	"github.com/intelsdi-x/snap/core/ctypes"
+)
+
+const (
+	FloatListType = "float_list"
+)
+
+// A rule validating against numeric list-typed config
+type FloatListRule struct {
+	rule
+
+	key       string
+	required  bool
+	default_  []float64
+	minLength *int
+	maxLength *int
+	minimum   *float64
+	maximum   *float64
+}
+
+// NewFloatListRule returns a new numeric list-typed rule. Arguments are key(string), required(bool), default([]float64)
+func NewFloatListRule(key string, req bool, opts ...[]float64) (*FloatListRule, error) {
+	// Return error if key is empty
+	if key == "" {
+		return nil, EmptyKeyError
+	}
+
+	f := &FloatListRule{
+		key:      key,
+		required: req,
+	}
+
+	if len(opts) > 0 {
+		f.default_ = opts[0]
+	}
+	return f, nil
+}
+
+func (f *FloatListRule) Type() string {
+	return FloatListType
+}
+
+// MarshalJSON marshals a FloatListRule into JSON
+func (f *FloatListRule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Key       string             `json:"key"`
+		Required  bool               `json:"required"`
+		Default   ctypes.ConfigValue `json:"default,omitempty"`
+		MinLength *int               `json:"min_length,omitempty"`
+		MaxLength *int               `json:"max_length,omitempty"`
+		Minimum   ctypes.ConfigValue `json:"minimum,omitempty"`
+		Maximum   ctypes.ConfigValue `json:"maximum,omitempty"`
+		Type      string             `json:"type"`
+	}{
+		Key:       f.key,
+		Required:  f.required,
+		Default:   f.Default(),
+		MinLength: f.minLength,
+		MaxLength: f.maxLength,
+		Minimum:   f.Minimum(),
+		Maximum:   f.Maximum(),
+		Type:      FloatListType,
+	})
+}
+
+// GobEncode encodes a FloatListRule into a GOB
+func (f *FloatListRule) GobEncode() ([]byte, error) {
+	w := new(bytes.Buffer)
+	encoder := gob.NewEncoder(w)
+	if err := encoder.Encode(f.key); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(f.required); err != nil {
+		return nil, err
+	}
+	if f.default_ == nil {
+		encoder.Encode(false)
+	} else {
+		encoder.Encode(true)
+		if err := encoder.Encode(f.default_); err != nil {
+			return nil, err
+		}
+	}
+	if err := encodeOptionalInt(encoder, f.minLength); err != nil {
+		return nil, err
+	}
+	if err := encodeOptionalInt(encoder, f.maxLength); err != nil {
+		return nil, err
+	}
+	if f.minimum == nil {
+		encoder.Encode(false)
+	} else {
+		encoder.Encode(true)
+		if err := encoder.Encode(*f.minimum); err != nil {
+			return nil, err
+		}
+	}
+	if f.maximum == nil {
+		encoder.Encode(false)
+	} else {
+		encoder.Encode(true)
+		if err := encoder.Encode(*f.maximum); err != nil {
+			return nil, err
+		}
+	}
+	return w.Bytes(), nil
+}
+
+// GobDecode decodes a GOB into a FloatListRule
+func (f *FloatListRule) GobDecode(buf []byte) error {
+	r := bytes.NewBuffer(buf)
+	decoder := gob.NewDecoder(r)
+	if err := decoder.Decode(&f.key); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&f.required); err != nil {
+		return err
+	}
+	var is_default_set bool
+	decoder.Decode(&is_default_set)
+	if is_default_set {
+		f.default_ = []float64{}
+		if err := decoder.Decode(&f.default_); err != nil {
+			return err
+		}
+	}
+	var err error
+	if f.minLength, err = decodeOptionalInt(decoder); err != nil {
+		return err
+	}
+	if f.maxLength, err = decodeOptionalInt(decoder); err != nil {
+		return err
+	}
+	var is_minimum_set bool
+	decoder.Decode(&is_minimum_set)
+	if is_minimum_set {
+		if err := decoder.Decode(&f.minimum); err != nil {
+			return err
+		}
+	}
+	var is_maximum_set bool
+	decoder.Decode(&is_maximum_set)
+	if is_maximum_set {
+		if err := decoder.Decode(&f.maximum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Key returns the key
+func (f *FloatListRule) Key() string {
+	return f.key
+}
+
+// Validate validates a config value against this rule.
+func (f *FloatListRule) Validate(cv ctypes.ConfigValue) error {
+	// an empty list can not be told apart from an empty string list when
+	// decoding JSON which is the reason we are accepting it below.
+	if sl, ok := cv.(ctypes.ConfigValueStrList); ok && len(sl.Value) == 0 {
+		return checkLength(f.key, 0, f.minLength, f.maxLength)
+	}
+	// Check that type is correct
+	if cv.Type() != FloatListType {
+		return wrongType(f.key, cv.Type(), FloatListType)
+	}
+	l := cv.(ctypes.ConfigValueFloatList).Value
+	if err := checkLength(f.key, len(l), f.minLength, f.maxLength); err != nil {
+		return err
+	}
+	for _, e := range l {
+		if f.minimum != nil && e < *f.minimum {
+			return fmt.Errorf("element is under minimum (%s element %v < %v)", f.key, e, *f.minimum)
+		}
+		if f.maximum != nil && e > *f.maximum {
+			return fmt.Errorf("element is over maximum (%s element %v > %v)", f.key, e, *f.maximum)
+		}
+	}
+	return nil
+}
+
+// Default returns this rules default value
+func (f *FloatListRule) Default() ctypes.ConfigValue {
+	if f.default_ != nil {
+		return ctypes.ConfigValueFloatList{Value: f.default_}
+	}
+	return nil
+}
+
+// Required returns a boolean indicating if this rule is required
+func (f *FloatListRule) Required() bool {
+	return f.required
+}
+
+// SetMinLength sets the minimum number of elements
+func (f *FloatListRule) SetMinLength(m int) {
+	f.minLength = &m
+}
+
+// SetMaxLength sets the maximum number of elements
+func (f *FloatListRule) SetMaxLength(m int) {
+	f.maxLength = &m
+}
+
+// SetMinimum sets the minimum allowed value of the elements
+func (f *FloatListRule) SetMinimum(m float64) {
+	f.minimum = &m
+}
+
+// SetMaximum sets the maximum allowed value of the elements
+func (f *FloatListRule) SetMaximum(m float64) {
+	f.maximum = &m
+}
+
+// MinLength returns the minimum number of elements or nil if it is not set
+func (f *FloatListRule) MinLength() *int {
+	return f.minLength
+}
+
+// MaxLength returns the maximum number of elements or nil if it is not set
+func (f *FloatListRule) MaxLength() *int {
+	return f.maxLength
+}
+
+// Minimum returns the minimum allowed value of the elements
+func (f *FloatListRule) Minimum() ctypes.ConfigValue {
+	if f.minimum != nil {
+		return ctypes.ConfigValueFloat{Value: *f.minimum}
+	}
+	return nil
+}
+
+// Maximum returns the maximum allowed value of the elements
+func (f *FloatListRule) Maximum() ctypes.ConfigValue {
+	if f.maximum != nil {
+		return ctypes.ConfigValueFloat{Value: *f.maximum}
+	}
+	return nil
+}
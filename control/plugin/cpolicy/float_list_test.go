@@ -0,0 +1,109 @@
+// +build legacy
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2015 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpolicy
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/intelsdi-x/snap/core/ctypes"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConfigPolicyRuleFloatList(t *testing.T) {
+	Convey("NewFloatListRule", t, func() {
+
+		Convey("empty key", func() {
+			r, e := NewFloatListRule("", true)
+			So(r, ShouldBeNil)
+			So(e, ShouldResemble, EmptyKeyError)
+		})
+
+		Convey("default is set", func() {
+			r, e := NewFloatListRule("ports", false, []float64{8080, 8081})
+			So(e, ShouldBeNil)
+			So(r.Default().Type(), ShouldEqual, "float_list")
+			So(r.Default().(ctypes.ConfigValueFloatList).Value, ShouldResemble, []float64{8080, 8081})
+		})
+
+		Convey("processing", func() {
+			r, _ := NewFloatListRule("ports", true)
+			r.SetMaxLength(2)
+			r.SetMinimum(1)
+			r.SetMaximum(65535)
+
+			Convey("passes with a valid list", func() {
+				So(r.Validate(ctypes.ConfigValueFloatList{Value: []float64{80, 443}}), ShouldBeNil)
+			})
+
+			Convey("passes with an empty list", func() {
+				So(r.Validate(ctypes.ConfigValueStrList{Value: []string{}}), ShouldBeNil)
+			})
+
+			Convey("errors with a list of strings", func() {
+				e := r.Validate(ctypes.ConfigValueStrList{Value: []string{"80"}})
+				So(e, ShouldResemble, errors.New("type mismatch (ports wanted type 'float_list' but provided type 'string_list')"))
+			})
+
+			Convey("errors with too many elements", func() {
+				e := r.Validate(ctypes.ConfigValueFloatList{Value: []float64{1, 2, 3}})
+				So(e, ShouldResemble, errors.New("length is over maximum (ports length 3 > 2)"))
+			})
+
+			Convey("errors with an element under the minimum", func() {
+				e := r.Validate(ctypes.ConfigValueFloatList{Value: []float64{0}})
+				So(e, ShouldResemble, errors.New("element is under minimum (ports element 0 < 1)"))
+			})
+
+			Convey("errors with an element over the maximum", func() {
+				e := r.Validate(ctypes.ConfigValueFloatList{Value: []float64{70000}})
+				So(e, ShouldResemble, errors.New("element is over maximum (ports element 70000 > 65535)"))
+			})
+		})
+
+		Convey("encoding", func() {
+			r, _ := NewFloatListRule("ports", false, []float64{80})
+			r.SetMinLength(1)
+			r.SetMinimum(1)
+
+			Convey("JSON", func() {
+				b, e := json.Marshal(r)
+				So(e, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"key":"ports","required":false,"default":[80],"min_length":1,"minimum":1,"type":"float_list"}`)
+				n := NewPolicyNode()
+				So(json.Unmarshal([]byte(`{"rules":{"ports":`+string(b)+`}}`), n), ShouldBeNil)
+				So(n.rules["ports"], ShouldResemble, r)
+			})
+
+			Convey("GOB", func() {
+				buf := new(bytes.Buffer)
+				So(gob.NewEncoder(buf).Encode(r), ShouldBeNil)
+				r2 := &FloatListRule{}
+				So(gob.NewDecoder(buf).Decode(r2), ShouldBeNil)
+				So(r2, ShouldResemble, r)
+			})
+		})
+	})
+}
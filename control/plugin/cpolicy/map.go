@@ -0,0 +1,222 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2015 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpolicy
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/intelsdi-x/snap/core/ctypes"
+)
+
+const (
+	MapType = "map"
+)
+
+// A rule validating against map-typed config
+type MapRule struct {
+	rule
+
+	key          string
+	required     bool
+	default_     map[string]string
+	minLength    *int
+	maxLength    *int
+	requiredKeys []string
+}
+
+// NewMapRule returns a new map-typed rule. Arguments are key(string), required(bool), default(map[string]string)
+func NewMapRule(key string, req bool, opts ...map[string]string) (*MapRule, error) {
+	// Return error if key is empty
+	if key == "" {
+		return nil, EmptyKeyError
+	}
+
+	m := &MapRule{
+		key:      key,
+		required: req,
+	}
+
+	if len(opts) > 0 {
+		m.default_ = opts[0]
+	}
+	return m, nil
+}
+
+func (m *MapRule) Type() string {
+	return MapType
+}
+
+// MarshalJSON marshals a MapRule into JSON
+func (m *MapRule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Key          string             `json:"key"`
+		Required     bool               `json:"required"`
+		Default      ctypes.ConfigValue `json:"default,omitempty"`
+		MinLength    *int               `json:"min_length,omitempty"`
+		MaxLength    *int               `json:"max_length,omitempty"`
+		RequiredKeys []string           `json:"required_keys,omitempty"`
+		Type         string             `json:"type"`
+	}{
+		Key:          m.key,
+		Required:     m.required,
+		Default:      m.Default(),
+		MinLength:    m.minLength,
+		MaxLength:    m.maxLength,
+		RequiredKeys: m.requiredKeys,
+		Type:         MapType,
+	})
+}
+
+// GobEncode encodes a MapRule into a GOB
+func (m *MapRule) GobEncode() ([]byte, error) {
+	w := new(bytes.Buffer)
+	encoder := gob.NewEncoder(w)
+	if err := encoder.Encode(m.key); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(m.required); err != nil {
+		return nil, err
+	}
+	if m.default_ == nil {
+		encoder.Encode(false)
+	} else {
+		encoder.Encode(true)
+		if err := encoder.Encode(m.default_); err != nil {
+			return nil, err
+		}
+	}
+	if err := encodeOptionalInt(encoder, m.minLength); err != nil {
+		return nil, err
+	}
+	if err := encodeOptionalInt(encoder, m.maxLength); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(m.requiredKeys); err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// GobDecode decodes a GOB into a MapRule
+func (m *MapRule) GobDecode(buf []byte) error {
+	r := bytes.NewBuffer(buf)
+	decoder := gob.NewDecoder(r)
+	if err := decoder.Decode(&m.key); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&m.required); err != nil {
+		return err
+	}
+	var is_default_set bool
+	decoder.Decode(&is_default_set)
+	if is_default_set {
+		m.default_ = map[string]string{}
+		if err := decoder.Decode(&m.default_); err != nil {
+			return err
+		}
+	}
+	var err error
+	if m.minLength, err = decodeOptionalInt(decoder); err != nil {
+		return err
+	}
+	if m.maxLength, err = decodeOptionalInt(decoder); err != nil {
+		return err
+	}
+	return decoder.Decode(&m.requiredKeys)
+}
+
+// Key returns the key
+func (m *MapRule) Key() string {
+	return m.key
+}
+
+// Validate validates a config value against this rule.
+func (m *MapRule) Validate(cv ctypes.ConfigValue) error {
+	// Check that type is correct
+	if cv.Type() != MapType {
+		return wrongType(m.key, cv.Type(), MapType)
+	}
+	v := cv.(ctypes.ConfigValueMap).Value
+	if err := checkLength(m.key, len(v), m.minLength, m.maxLength); err != nil {
+		return err
+	}
+	for _, k := range m.requiredKeys {
+		if _, ok := v[k]; !ok {
+			return fmt.Errorf("required key missing from map (%s key %s)", m.key, k)
+		}
+	}
+	return nil
+}
+
+// Default returns this rules default value
+func (m *MapRule) Default() ctypes.ConfigValue {
+	if m.default_ != nil {
+		return ctypes.ConfigValueMap{Value: m.default_}
+	}
+	return nil
+}
+
+// Required returns a boolean indicating if this rule is required
+func (m *MapRule) Required() bool {
+	return m.required
+}
+
+// SetMinLength sets the minimum number of entries
+func (m *MapRule) SetMinLength(l int) {
+	m.minLength = &l
+}
+
+// SetMaxLength sets the maximum number of entries
+func (m *MapRule) SetMaxLength(l int) {
+	m.maxLength = &l
+}
+
+// SetRequiredKeys sets the keys the map must contain
+func (m *MapRule) SetRequiredKeys(keys ...string) {
+	sort.Strings(keys)
+	m.requiredKeys = keys
+}
+
+// MinLength returns the minimum number of entries or nil if it is not set
+func (m *MapRule) MinLength() *int {
+	return m.minLength
+}
+
+// MaxLength returns the maximum number of entries or nil if it is not set
+func (m *MapRule) MaxLength() *int {
+	return m.maxLength
+}
+
+// RequiredKeys returns the keys the map must contain
+func (m *MapRule) RequiredKeys() []string {
+	return m.requiredKeys
+}
+
+func (m *MapRule) Minimum() ctypes.ConfigValue {
+	return nil
+}
+
+func (m *MapRule) Maximum() ctypes.ConfigValue {
+	return nil
+}
@@ -0,0 +1,99 @@
+// +build legacy
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2015 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpolicy
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/intelsdi-x/snap/core/ctypes"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConfigPolicyRuleMap(t *testing.T) {
+	Convey("NewMapRule", t, func() {
+
+		Convey("empty key", func() {
+			r, e := NewMapRule("", true)
+			So(r, ShouldBeNil)
+			So(e, ShouldResemble, EmptyKeyError)
+		})
+
+		Convey("default is set", func() {
+			r, e := NewMapRule("labels", false, map[string]string{"env": "prod"})
+			So(e, ShouldBeNil)
+			So(r.Default().Type(), ShouldEqual, "map")
+			So(r.Default().(ctypes.ConfigValueMap).Value, ShouldResemble, map[string]string{"env": "prod"})
+		})
+
+		Convey("processing", func() {
+			r, _ := NewMapRule("labels", true)
+			r.SetMaxLength(2)
+			r.SetRequiredKeys("env")
+
+			Convey("passes with a valid map", func() {
+				So(r.Validate(ctypes.ConfigValueMap{Value: map[string]string{"env": "prod", "dc": "east"}}), ShouldBeNil)
+			})
+
+			Convey("errors with non-map config value", func() {
+				e := r.Validate(ctypes.ConfigValueStr{Value: "env=prod"})
+				So(e, ShouldResemble, errors.New("type mismatch (labels wanted type 'map' but provided type 'string')"))
+			})
+
+			Convey("errors with too many entries", func() {
+				e := r.Validate(ctypes.ConfigValueMap{Value: map[string]string{"env": "prod", "dc": "east", "rack": "1"}})
+				So(e, ShouldResemble, errors.New("length is over maximum (labels length 3 > 2)"))
+			})
+
+			Convey("errors when a required key is missing", func() {
+				e := r.Validate(ctypes.ConfigValueMap{Value: map[string]string{"dc": "east"}})
+				So(e, ShouldResemble, errors.New("required key missing from map (labels key env)"))
+			})
+		})
+
+		Convey("encoding", func() {
+			r, _ := NewMapRule("labels", false, map[string]string{"env": "prod"})
+			r.SetMinLength(1)
+			r.SetRequiredKeys("env")
+
+			Convey("JSON", func() {
+				b, e := json.Marshal(r)
+				So(e, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"key":"labels","required":false,"default":{"env":"prod"},"min_length":1,"required_keys":["env"],"type":"map"}`)
+				n := NewPolicyNode()
+				So(json.Unmarshal([]byte(`{"rules":{"labels":`+string(b)+`}}`), n), ShouldBeNil)
+				So(n.rules["labels"], ShouldResemble, r)
+			})
+
+			Convey("GOB", func() {
+				buf := new(bytes.Buffer)
+				So(gob.NewEncoder(buf).Encode(r), ShouldBeNil)
+				r2 := &MapRule{}
+				So(gob.NewDecoder(buf).Decode(r2), ShouldBeNil)
+				So(r2, ShouldResemble, r)
+			})
+		})
+	})
+}
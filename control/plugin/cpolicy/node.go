@@ -120,12 +120,23 @@ func (p *ConfigPolicyNode) Add(rules ...Rule) {
 }
 
 type RuleTable struct {
-	Name     string
-	Type     string
-	Default  interface{}
-	Required bool
-	Minimum  interface{}
-	Maximum  interface{}
+	Name         string
+	Type         string
+	Default      interface{}
+	Required     bool
+	Minimum      interface{}
+	Maximum      interface{}
+	MinLength    *int
+	MaxLength    *int
+	Allowed      []string
+	Pattern      string
+	RequiredKeys []string
+}
+
+// lengthRule is implemented by the rules of list and map values
+type lengthRule interface {
+	MinLength() *int
+	MaxLength() *int
 }
 
 func (p *ConfigPolicyNode) RulesAsTable() []RuleTable {
@@ -134,14 +145,28 @@ func (p *ConfigPolicyNode) RulesAsTable() []RuleTable {
 
 	rt := make([]RuleTable, 0, len(p.rules))
 	for _, r := range p.rules {
-		rt = append(rt, RuleTable{
+		t := RuleTable{
 			Name:     r.Key(),
 			Type:     r.Type(),
 			Default:  r.Default(),
 			Required: r.Required(),
 			Minimum:  r.Minimum(),
 			Maximum:  r.Maximum(),
-		})
+		}
+		if l, ok := r.(lengthRule); ok {
+			t.MinLength = l.MinLength()
+			t.MaxLength = l.MaxLength()
+		}
+		switch rule := r.(type) {
+		case *StringRule:
+			t.Allowed = rule.Allowed()
+			t.Pattern = rule.Pattern()
+		case *StringListRule:
+			t.Allowed = rule.Allowed()
+		case *MapRule:
+			t.RequiredKeys = rule.RequiredKeys()
+		}
+		rt = append(rt, t)
 	}
 	return rt
 }
@@ -285,7 +310,14 @@ func addRulesToConfigPolicyNode(rules map[string]interface{}, cpn *ConfigPolicyN
 						r.default_ = &def
 					}
 				}
-
+				if a, ok := rule["allowed"]; ok {
+					r.allowed = toStringSlice(a)
+				}
+				if p, ok := rule["pattern"].(string); ok && p != "" {
+					if err := r.SetPattern(p); err != nil {
+						return err
+					}
+				}
 				cpn.Add(r)
 			case "bool":
 				r, _ := NewBoolRule(k, req)
@@ -310,6 +342,56 @@ func addRulesToConfigPolicyNode(rules map[string]interface{}, cpn *ConfigPolicyN
 					r.maximum = &max
 				}
 				cpn.Add(r)
+			case DurationType:
+				r, _ := NewDurationRule(k, req)
+				r.default_ = durationFromJSON(rule["default"])
+				r.minimum = durationFromJSON(rule["minimum"])
+				r.maximum = durationFromJSON(rule["maximum"])
+				cpn.Add(r)
+			case StringListType:
+				r, _ := NewStringListRule(k, req)
+				if d, ok := rule["default"]; ok {
+					r.default_ = toStringSlice(d)
+				}
+				r.minLength, r.maxLength = lengthsFromJSON(rule)
+				if a, ok := rule["allowed"]; ok {
+					r.allowed = toStringSlice(a)
+				}
+				cpn.Add(r)
+			case FloatListType:
+				r, _ := NewFloatListRule(k, req)
+				if d, ok := rule["default"].([]interface{}); ok {
+					def := []float64{}
+					for _, e := range d {
+						f, _ := e.(float64)
+						def = append(def, f)
+					}
+					r.default_ = def
+				}
+				r.minLength, r.maxLength = lengthsFromJSON(rule)
+				if m, ok := rule["minimum"]; ok {
+					min, _ := m.(float64)
+					r.minimum = &min
+				}
+				if m, ok := rule["maximum"]; ok {
+					max, _ := m.(float64)
+					r.maximum = &max
+				}
+				cpn.Add(r)
+			case MapType:
+				r, _ := NewMapRule(k, req)
+				if d, ok := rule["default"].(map[string]interface{}); ok {
+					def := map[string]string{}
+					for dk, dv := range d {
+						def[dk], _ = dv.(string)
+					}
+					r.default_ = def
+				}
+				r.minLength, r.maxLength = lengthsFromJSON(rule)
+				if rk, ok := rule["required_keys"]; ok {
+					r.requiredKeys = toStringSlice(rk)
+				}
+				cpn.Add(r)
 			default:
 				return errors.New("unknown type")
 			}
@@ -317,3 +399,30 @@ func addRulesToConfigPolicyNode(rules map[string]interface{}, cpn *ConfigPolicyN
 	}
 	return nil
 }
+
+// toStringSlice converts a decoded JSON array into a slice of strings
+func toStringSlice(i interface{}) []string {
+	l, _ := i.([]interface{})
+	res := make([]string, 0, len(l))
+	for _, e := range l {
+		s, _ := e.(string)
+		res = append(res, s)
+	}
+	return res
+}
+
+// lengthsFromJSON returns the min_length and max_length of a decoded JSON
+// rule
+func lengthsFromJSON(rule map[string]interface{}) (*int, *int) {
+	var min, max *int
+	// json encoding an int results in a float when decoding
+	if m, ok := rule["min_length"].(float64); ok {
+		l := int(m)
+		min = &l
+	}
+	if m, ok := rule["max_length"].(float64); ok {
+		l := int(m)
+		max = &l
+	}
+	return min, max
+}
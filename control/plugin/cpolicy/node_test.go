@@ -23,6 +23,7 @@ package cpolicy
 
 import (
 	"testing"
+	"time"
 
 	"github.com/intelsdi-x/snap/core/ctypes"
 	. "github.com/smartystreets/goconvey/convey"
@@ -105,6 +106,29 @@ func TestConfigPolicyNode(t *testing.T) {
 		So(errorsMsg(pe.Errors()), ShouldContain, "type mismatch (nova wanted type 'bool' but provided type 'string')")
 	})
 
+	Convey("returns errors for values violating constraints", t, func() {
+		n := NewPolicyNode()
+
+		m := map[string]ctypes.ConfigValue{}
+		m["level"] = ctypes.ConfigValueStr{Value: "trace"}
+		m["timeout"] = ctypes.ConfigValueStr{Value: "1h"}
+
+		r1, _ := NewStringRule("level", true)
+		r1.SetAllowed("debug", "info")
+		r2, _ := NewDurationRule("timeout", true)
+		r2.SetMaximum(time.Minute)
+		r3, _ := NewDurationRule("interval", false, 10*time.Second)
+
+		n.Add(r1, r2, r3)
+
+		_, pe := n.Process(m)
+
+		So(len(pe.Errors()), ShouldEqual, 2)
+		So(errorsMsg(pe.Errors()), ShouldContain, "value is not allowed (level value 'trace' not in [debug info])")
+		So(errorsMsg(pe.Errors()), ShouldContain, "value is over maximum (timeout value 1h0m0s > 1m0s)")
+		So(m["interval"], ShouldResemble, ctypes.ConfigValueStr{Value: "10s"})
+	})
+
 	Convey("adds defaults to only missing values that should have them", t, func() {
 		n := NewPolicyNode()
 		So(n, ShouldNotBeNil)
@@ -22,6 +22,7 @@ package cpolicy
 // TODO, make second opts value in New<>Rule be description for rule (used in documentation)
 
 import (
+	"encoding/gob"
 	"errors"
 	"fmt"
 
@@ -50,3 +51,39 @@ type rule struct {
 func wrongType(key, inType, reqType string) error {
 	return errors.New(fmt.Sprintf("type mismatch (%s wanted type '%s' but provided type '%s')", key, reqType, inType))
 }
+
+// checkLength validates the number of elements n of a list or map value
+// against the optional minimum and maximum lengths of a rule.
+func checkLength(key string, n int, min, max *int) error {
+	if min != nil && n < *min {
+		return fmt.Errorf("length is under minimum (%s length %d < %d)", key, n, *min)
+	}
+	if max != nil && n > *max {
+		return fmt.Errorf("length is over maximum (%s length %d > %d)", key, n, *max)
+	}
+	return nil
+}
+
+// encodeOptionalInt encodes whether the value is set followed by the value
+func encodeOptionalInt(encoder *gob.Encoder, i *int) error {
+	if i == nil {
+		return encoder.Encode(false)
+	}
+	if err := encoder.Encode(true); err != nil {
+		return err
+	}
+	return encoder.Encode(*i)
+}
+
+// decodeOptionalInt decodes a value encoded by encodeOptionalInt
+func decodeOptionalInt(decoder *gob.Decoder) (*int, error) {
+	var isSet bool
+	if err := decoder.Decode(&isSet); err != nil || !isSet {
+		return nil, err
+	}
+	var i int
+	if err := decoder.Decode(&i); err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
@@ -23,6 +23,9 @@ import (
 	"bytes"
 	"encoding/gob"
 	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
 
 	"github.com/intelsdi-x/snap/core/ctypes"
 )
@@ -38,6 +41,10 @@ type StringRule struct {
 	key      string
 	required bool
 	default_ *string
+	allowed  []string
+	pattern  string
+	// re is the pattern anchored to match the whole value
+	re *regexp.Regexp
 }
 
 // Returns a new string-typed rule. Arguments are key(string), required(bool), default(string).
@@ -69,11 +76,15 @@ func (s *StringRule) MarshalJSON() ([]byte, error) {
 		Key      string             `json:"key"`
 		Required bool               `json:"required"`
 		Default  ctypes.ConfigValue `json:"default"`
+		Allowed  []string           `json:"allowed,omitempty"`
+		Pattern  string             `json:"pattern,omitempty"`
 		Type     string             `json:"type"`
 	}{
 		Key:      s.key,
 		Required: s.required,
 		Default:  s.Default(),
+		Allowed:  s.allowed,
+		Pattern:  s.Pattern(),
 		Type:     StringType,
 	})
 }
@@ -96,6 +107,12 @@ func (s *StringRule) GobEncode() ([]byte, error) {
 			return nil, err
 		}
 	}
+	if err := encoder.Encode(s.allowed); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(s.Pattern()); err != nil {
+		return nil, err
+	}
 	return w.Bytes(), nil
 }
 
@@ -112,7 +129,23 @@ func (s *StringRule) GobDecode(buf []byte) error {
 	var is_default_set bool
 	decoder.Decode(&is_default_set)
 	if is_default_set {
-		return decoder.Decode(&s.default_)
+		if err := decoder.Decode(&s.default_); err != nil {
+			return err
+		}
+	}
+	// rules encoded before allowed values and patterns were supported end here
+	if err := decoder.Decode(&s.allowed); err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+	var pattern string
+	if err := decoder.Decode(&pattern); err != nil {
+		return err
+	}
+	if pattern != "" {
+		return s.SetPattern(pattern)
 	}
 	return nil
 }
@@ -128,6 +161,15 @@ func (s *StringRule) Validate(cv ctypes.ConfigValue) error {
 	if cv.Type() != StringType {
 		return wrongType(s.key, cv.Type(), StringType)
 	}
+	v := cv.(ctypes.ConfigValueStr).Value
+	// Check that the value is one of the allowed values
+	if len(s.allowed) > 0 && !contains(s.allowed, v) {
+		return fmt.Errorf("value is not allowed (%s value '%s' not in %v)", s.key, v, s.allowed)
+	}
+	// Check that the value matches the pattern
+	if s.re != nil && !s.re.MatchString(v) {
+		return fmt.Errorf("value does not match pattern (%s value '%s' does not match '%s')", s.key, v, s.pattern)
+	}
 	return nil
 }
 
@@ -151,3 +193,32 @@ func (s *StringRule) Minimum() ctypes.ConfigValue {
 func (s *StringRule) Maximum() ctypes.ConfigValue {
 	return nil
 }
+
+// SetAllowed restricts the value to one of the given values.
+func (s *StringRule) SetAllowed(values ...string) {
+	s.allowed = values
+}
+
+// SetPattern restricts the value to strings matching the regular expression
+// p as a whole, as if it was written ^(?:p)$. An error is returned if p does
+// not compile.
+func (s *StringRule) SetPattern(p string) error {
+	re, err := regexp.Compile("^(?:" + p + ")$")
+	if err != nil {
+		return err
+	}
+	s.pattern = p
+	s.re = re
+	return nil
+}
+
+// Allowed returns the values this rule is restricted to.
+func (s *StringRule) Allowed() []string {
+	return s.allowed
+}
+
+// Pattern returns the regular expression values must match or an empty
+// string if there is none.
+func (s *StringRule) Pattern() string {
+	return s.pattern
+}
@@ -0,0 +1,232 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2015 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpolicy
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+
+	"github.com/intelsdi-x/snap/core/ctypes"
+)
+
+const (
+	StringListType = "string_list"
+)
+
+// A rule validating against string list-typed config
+type StringListRule struct {
+	rule
+
+	key       string
+	required  bool
+	default_  []string
+	minLength *int
+	maxLength *int
+	allowed   []string
+}
+
+// NewStringListRule returns a new string list-typed rule. Arguments are key(string), required(bool), default([]string)
+func NewStringListRule(key string, req bool, opts ...[]string) (*StringListRule, error) {
+	// Return error if key is empty
+	if key == "" {
+		return nil, EmptyKeyError
+	}
+
+	s := &StringListRule{
+		key:      key,
+		required: req,
+	}
+
+	if len(opts) > 0 {
+		s.default_ = opts[0]
+	}
+	return s, nil
+}
+
+func (s *StringListRule) Type() string {
+	return StringListType
+}
+
+// MarshalJSON marshals a StringListRule into JSON
+func (s *StringListRule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Key       string             `json:"key"`
+		Required  bool               `json:"required"`
+		Default   ctypes.ConfigValue `json:"default,omitempty"`
+		MinLength *int               `json:"min_length,omitempty"`
+		MaxLength *int               `json:"max_length,omitempty"`
+		Allowed   []string           `json:"allowed,omitempty"`
+		Type      string             `json:"type"`
+	}{
+		Key:       s.key,
+		Required:  s.required,
+		Default:   s.Default(),
+		MinLength: s.minLength,
+		MaxLength: s.maxLength,
+		Allowed:   s.allowed,
+		Type:      StringListType,
+	})
+}
+
+// GobEncode encodes a StringListRule into a GOB
+func (s *StringListRule) GobEncode() ([]byte, error) {
+	w := new(bytes.Buffer)
+	encoder := gob.NewEncoder(w)
+	if err := encoder.Encode(s.key); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(s.required); err != nil {
+		return nil, err
+	}
+	if s.default_ == nil {
+		encoder.Encode(false)
+	} else {
+		encoder.Encode(true)
+		if err := encoder.Encode(s.default_); err != nil {
+			return nil, err
+		}
+	}
+	if err := encodeOptionalInt(encoder, s.minLength); err != nil {
+		return nil, err
+	}
+	if err := encodeOptionalInt(encoder, s.maxLength); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(s.allowed); err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// GobDecode decodes a GOB into a StringListRule
+func (s *StringListRule) GobDecode(buf []byte) error {
+	r := bytes.NewBuffer(buf)
+	decoder := gob.NewDecoder(r)
+	if err := decoder.Decode(&s.key); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&s.required); err != nil {
+		return err
+	}
+	var is_default_set bool
+	decoder.Decode(&is_default_set)
+	if is_default_set {
+		s.default_ = []string{}
+		if err := decoder.Decode(&s.default_); err != nil {
+			return err
+		}
+	}
+	var err error
+	if s.minLength, err = decodeOptionalInt(decoder); err != nil {
+		return err
+	}
+	if s.maxLength, err = decodeOptionalInt(decoder); err != nil {
+		return err
+	}
+	return decoder.Decode(&s.allowed)
+}
+
+// Key returns the key
+func (s *StringListRule) Key() string {
+	return s.key
+}
+
+// Validate validates a config value against this rule.
+func (s *StringListRule) Validate(cv ctypes.ConfigValue) error {
+	// Check that type is correct
+	if cv.Type() != StringListType {
+		return wrongType(s.key, cv.Type(), StringListType)
+	}
+	l := cv.(ctypes.ConfigValueStrList).Value
+	if err := checkLength(s.key, len(l), s.minLength, s.maxLength); err != nil {
+		return err
+	}
+	if len(s.allowed) == 0 {
+		return nil
+	}
+	for _, e := range l {
+		if !contains(s.allowed, e) {
+			return fmt.Errorf("element is not allowed (%s element '%s' not in %v)", s.key, e, s.allowed)
+		}
+	}
+	return nil
+}
+
+// Default returns this rules default value
+func (s *StringListRule) Default() ctypes.ConfigValue {
+	if s.default_ != nil {
+		return ctypes.ConfigValueStrList{Value: s.default_}
+	}
+	return nil
+}
+
+// Required returns a boolean indicating if this rule is required
+func (s *StringListRule) Required() bool {
+	return s.required
+}
+
+// SetMinLength sets the minimum number of elements
+func (s *StringListRule) SetMinLength(m int) {
+	s.minLength = &m
+}
+
+// SetMaxLength sets the maximum number of elements
+func (s *StringListRule) SetMaxLength(m int) {
+	s.maxLength = &m
+}
+
+// SetAllowed restricts the elements of the list to the given values
+func (s *StringListRule) SetAllowed(values ...string) {
+	s.allowed = values
+}
+
+// MinLength returns the minimum number of elements or nil if it is not set
+func (s *StringListRule) MinLength() *int {
+	return s.minLength
+}
+
+// MaxLength returns the maximum number of elements or nil if it is not set
+func (s *StringListRule) MaxLength() *int {
+	return s.maxLength
+}
+
+// Allowed returns the values the elements are restricted to
+func (s *StringListRule) Allowed() []string {
+	return s.allowed
+}
+
+func (s *StringListRule) Minimum() ctypes.ConfigValue {
+	return nil
+}
+
+func (s *StringListRule) Maximum() ctypes.ConfigValue {
+	return nil
+}
+
+func contains(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+// +build legacy
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2015 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cpolicy
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/intelsdi-x/snap/core/ctypes"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConfigPolicyRuleStringList(t *testing.T) {
+	Convey("NewStringListRule", t, func() {
+
+		Convey("empty key", func() {
+			r, e := NewStringListRule("", true)
+			So(r, ShouldBeNil)
+			So(e, ShouldResemble, EmptyKeyError)
+		})
+
+		Convey("default is set", func() {
+			r, e := NewStringListRule("hosts", false, []string{"a", "b"})
+			So(e, ShouldBeNil)
+			So(r.Default().Type(), ShouldEqual, "string_list")
+			So(r.Default().(ctypes.ConfigValueStrList).Value, ShouldResemble, []string{"a", "b"})
+		})
+
+		Convey("default is unset", func() {
+			r, e := NewStringListRule("hosts", true)
+			So(e, ShouldBeNil)
+			So(r.Default(), ShouldBeNil)
+		})
+
+		Convey("processing", func() {
+			r, _ := NewStringListRule("hosts", true)
+			r.SetMinLength(1)
+			r.SetMaxLength(2)
+			r.SetAllowed("a", "b", "c")
+
+			Convey("passes with a valid list", func() {
+				So(r.Validate(ctypes.ConfigValueStrList{Value: []string{"a", "c"}}), ShouldBeNil)
+			})
+
+			Convey("errors with non-list config value", func() {
+				e := r.Validate(ctypes.ConfigValueStr{Value: "a"})
+				So(e, ShouldResemble, errors.New("type mismatch (hosts wanted type 'string_list' but provided type 'string')"))
+			})
+
+			Convey("errors with too few elements", func() {
+				e := r.Validate(ctypes.ConfigValueStrList{Value: []string{}})
+				So(e, ShouldResemble, errors.New("length is under minimum (hosts length 0 < 1)"))
+			})
+
+			Convey("errors with too many elements", func() {
+				e := r.Validate(ctypes.ConfigValueStrList{Value: []string{"a", "b", "c"}})
+				So(e, ShouldResemble, errors.New("length is over maximum (hosts length 3 > 2)"))
+			})
+
+			Convey("errors with an element that is not allowed", func() {
+				e := r.Validate(ctypes.ConfigValueStrList{Value: []string{"a", "d"}})
+				So(e, ShouldResemble, errors.New("element is not allowed (hosts element 'd' not in [a b c])"))
+			})
+		})
+
+		Convey("encoding", func() {
+			r, _ := NewStringListRule("hosts", true, []string{"a"})
+			r.SetMaxLength(2)
+			r.SetAllowed("a", "b")
+
+			Convey("JSON", func() {
+				b, e := json.Marshal(r)
+				So(e, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"key":"hosts","required":true,"default":["a"],"max_length":2,"allowed":["a","b"],"type":"string_list"}`)
+				n := NewPolicyNode()
+				So(json.Unmarshal([]byte(`{"rules":{"hosts":`+string(b)+`}}`), n), ShouldBeNil)
+				So(n.rules["hosts"], ShouldResemble, r)
+			})
+
+			Convey("GOB", func() {
+				buf := new(bytes.Buffer)
+				So(gob.NewEncoder(buf).Encode(r), ShouldBeNil)
+				r2 := &StringListRule{}
+				So(gob.NewDecoder(buf).Decode(r2), ShouldBeNil)
+				So(r2, ShouldResemble, r)
+			})
+		})
+	})
+}
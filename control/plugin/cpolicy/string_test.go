@@ -22,6 +22,9 @@ limitations under the License.
 package cpolicy
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -90,6 +93,64 @@ func TestConfigPolicyRuleString(t *testing.T) {
 				So(e, ShouldResemble, errors.New("type mismatch (thekey wanted type 'string' but provided type 'integer')"))
 			})
 
+			Convey("errors with a value that is not allowed", func() {
+				r, _ := NewStringRule("level", true)
+				r.SetAllowed("debug", "info")
+				So(r.Validate(ctypes.ConfigValueStr{Value: "info"}), ShouldBeNil)
+				e := r.Validate(ctypes.ConfigValueStr{Value: "trace"})
+				So(e, ShouldResemble, errors.New("value is not allowed (level value 'trace' not in [debug info])"))
+			})
+
+			Convey("errors with a value not matching the pattern", func() {
+				r, _ := NewStringRule("host", true)
+				So(r.SetPattern("^[a-z]+$"), ShouldBeNil)
+				So(r.Validate(ctypes.ConfigValueStr{Value: "localhost"}), ShouldBeNil)
+				e := r.Validate(ctypes.ConfigValueStr{Value: "local host"})
+				So(e, ShouldResemble, errors.New("value does not match pattern (host value 'local host' does not match '^[a-z]+$')"))
+			})
+
+			Convey("errors with a value only partly matching the pattern", func() {
+				r, _ := NewStringRule("host", true)
+				So(r.SetPattern("[a-z]+|[0-9]+"), ShouldBeNil)
+				So(r.Validate(ctypes.ConfigValueStr{Value: "123"}), ShouldBeNil)
+				So(r.Validate(ctypes.ConfigValueStr{Value: "host1"}), ShouldNotBeNil)
+				So(r.Pattern(), ShouldEqual, "[a-z]+|[0-9]+")
+			})
+
+		})
+
+		Convey("invalid pattern", func() {
+			r, _ := NewStringRule("host", true)
+			So(r.SetPattern("("), ShouldNotBeNil)
+			So(r.Pattern(), ShouldEqual, "")
+		})
+
+		Convey("encoding", func() {
+			r, _ := NewStringRule("level", false, "info")
+			r.SetAllowed("debug", "info")
+			r.SetPattern("^[a-z]+$")
+
+			Convey("JSON", func() {
+				b, e := json.Marshal(r)
+				So(e, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"key":"level","required":false,"default":"info","allowed":["debug","info"],"pattern":"^[a-z]+$","type":"string"}`)
+				n := NewPolicyNode()
+				So(json.Unmarshal([]byte(`{"rules":{"level":`+string(b)+`}}`), n), ShouldBeNil)
+				r2 := n.rules["level"].(*StringRule)
+				So(r2.Allowed(), ShouldResemble, r.Allowed())
+				So(r2.Pattern(), ShouldEqual, r.Pattern())
+				So(r2.Default(), ShouldResemble, r.Default())
+			})
+
+			Convey("GOB", func() {
+				buf := new(bytes.Buffer)
+				So(gob.NewEncoder(buf).Encode(r), ShouldBeNil)
+				r2 := &StringRule{}
+				So(gob.NewDecoder(buf).Decode(r2), ShouldBeNil)
+				So(r2.Allowed(), ShouldResemble, r.Allowed())
+				So(r2.Pattern(), ShouldEqual, r.Pattern())
+				So(r2.Default(), ShouldResemble, r.Default())
+			})
 		})
 
 	})
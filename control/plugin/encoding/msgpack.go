@@ -0,0 +1,67 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2015 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encoding
+
+import (
+	"bytes"
+
+	"github.com/hashicorp/go-msgpack/codec"
+
+	"github.com/intelsdi-x/snap/control/plugin/encrypter"
+)
+
+// msgpackHandle decodes msgpack raw strings into Go strings and writes
+// []byte values using the binary format so they survive a round trip.
+var msgpackHandle = &codec.MsgpackHandle{RawToString: true, WriteExt: true}
+
+type msgpackEncoder struct {
+	e *encrypter.Encrypter
+}
+
+func NewMsgpackEncoder() *msgpackEncoder {
+	return &msgpackEncoder{}
+}
+
+func (m *msgpackEncoder) SetEncrypter(e *encrypter.Encrypter) {
+	m.e = e
+}
+
+func (m *msgpackEncoder) Encode(in interface{}) ([]byte, error) {
+	var out []byte
+	err := codec.NewEncoderBytes(&out, msgpackHandle).Encode(in)
+	if err != nil {
+		return nil, err
+	}
+	if m.e != nil {
+		out, err = m.e.Encrypt(bytes.NewReader(out))
+	}
+	return out, err
+}
+
+func (m *msgpackEncoder) Decode(in []byte, out interface{}) error {
+	var err error
+	if m.e != nil {
+		in, err = m.e.Decrypt(bytes.NewReader(in))
+		if err != nil {
+			return err
+		}
+	}
+	return codec.NewDecoderBytes(in, msgpackHandle).Decode(out)
+}
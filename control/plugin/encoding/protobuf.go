@@ -0,0 +1,75 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2015 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encoding
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/intelsdi-x/snap/control/plugin/encrypter"
+)
+
+// ErrNotProtoMessage is returned when the protobuf encoder is given a value
+// that is not a protocol buffer message.
+var ErrNotProtoMessage = errors.New("protobuf encoder requires a proto.Message")
+
+type protobufEncoder struct {
+	e *encrypter.Encrypter
+}
+
+func NewProtobufEncoder() *protobufEncoder {
+	return &protobufEncoder{}
+}
+
+func (p *protobufEncoder) SetEncrypter(e *encrypter.Encrypter) {
+	p.e = e
+}
+
+func (p *protobufEncoder) Encode(in interface{}) ([]byte, error) {
+	msg, ok := in.(proto.Message)
+	if !ok {
+		return nil, ErrNotProtoMessage
+	}
+	out, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	if p.e != nil {
+		out, err = p.e.Encrypt(bytes.NewReader(out))
+	}
+	return out, err
+}
+
+func (p *protobufEncoder) Decode(in []byte, out interface{}) error {
+	msg, ok := out.(proto.Message)
+	if !ok {
+		return ErrNotProtoMessage
+	}
+	var err error
+	if p.e != nil {
+		in, err = p.e.Decrypt(bytes.NewReader(in))
+		if err != nil {
+			return err
+		}
+	}
+	return proto.Unmarshal(in, msg)
+}
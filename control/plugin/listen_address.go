@@ -0,0 +1,66 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// UnixSocketScheme prefixes the listen address of a plugin listening on a
+	// Unix socket, e.g. unix:///tmp/snap-plugin-socket123/plugin.sock
+	UnixSocketScheme = "unix://"
+	// UnixSocketName is the name of the socket a plugin places in the
+	// SocketDir of its Arg
+	UnixSocketName = "plugin.sock"
+)
+
+// UnixListenAddress returns the listen address of a plugin listening on the
+// Unix socket of the path
+func UnixListenAddress(path string) string {
+	return UnixSocketScheme + path
+}
+
+// ParseListenAddress returns the network ("tcp" or "unix") and the address to
+// dial a plugin on from its listen address
+func ParseListenAddress(address string) (network, addr string) {
+	if strings.HasPrefix(address, UnixSocketScheme) {
+		return "unix", strings.TrimPrefix(address, UnixSocketScheme)
+	}
+	return "tcp", address
+}
+
+// listenUnix listens on the Unix socket of the plugin in the directory. The
+// socket is only accessible by the user running the plugin.
+func listenUnix(dir string) (net.Listener, error) {
+	path := filepath.Join(dir, UnixSocketName)
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		l.Close()
+		return nil, err
+	}
+	return l, nil
+}
@@ -0,0 +1,73 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestListenAddress(t *testing.T) {
+	Convey("ParseListenAddress", t, func() {
+		Convey("returns the path of a Unix socket", func() {
+			network, addr := ParseListenAddress(UnixListenAddress("/tmp/plugin.sock"))
+			So(network, ShouldEqual, "unix")
+			So(addr, ShouldEqual, "/tmp/plugin.sock")
+		})
+		Convey("returns a TCP address", func() {
+			network, addr := ParseListenAddress("127.0.0.1:8183")
+			So(network, ShouldEqual, "tcp")
+			So(addr, ShouldEqual, "127.0.0.1:8183")
+		})
+	})
+	Convey("listenUnix", t, func() {
+		dir, err := ioutil.TempDir("", "snap-plugin-socket")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		l, err := listenUnix(dir)
+		So(err, ShouldBeNil)
+		defer l.Close()
+
+		Convey("listens on a socket only accessible by its user", func() {
+			So(l.Addr().String(), ShouldEqual, filepath.Join(dir, UnixSocketName))
+			fi, err := os.Stat(l.Addr().String())
+			So(err, ShouldBeNil)
+			So(fi.Mode()&os.ModeSocket, ShouldNotEqual, 0)
+			So(fi.Mode().Perm(), ShouldEqual, os.FileMode(0600))
+		})
+		Convey("accepts connections", func() {
+			go func() {
+				if conn, err := l.Accept(); err == nil {
+					conn.Close()
+				}
+			}()
+			conn, err := net.Dial("unix", l.Addr().String())
+			So(err, ShouldBeNil)
+			conn.Close()
+		})
+	})
+}
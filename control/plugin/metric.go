@@ -38,6 +38,19 @@ const (
 	SnapGOBContentType = "snap.gob"
 	// SnapJSON snap metrics serialized into json
 	SnapJSONContentType = "snap.json"
+	// SnapProtobuf snap metrics serialized into protocol buffers using the
+	// rpc.Metric message shared with gRPC plugins
+	SnapProtobufContentType = "snap.protobuf"
+	// SnapMsgpack snap metrics serialized into msgpack
+	SnapMsgpackContentType = "snap.msgpack"
+
+	// These are content types snapd can convert metrics into for publishers
+	// and processors, they can't be decoded back into metrics
+
+	// InfluxLine metrics serialized into the InfluxDB line protocol
+	InfluxLineContentType = "influx.line"
+	// OpenMetrics metrics serialized into the OpenMetrics text format
+	OpenMetricsContentType = "openmetrics.text"
 )
 
 type ConfigType struct {
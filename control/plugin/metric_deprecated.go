@@ -96,6 +96,23 @@ func MarshalMetricTypes(contentType string, metrics []MetricType) ([]byte, strin
 			return nil, "", err
 		}
 		return b, SnapJSONContentType, nil
+	case SnapProtobufContentType, SnapMsgpackContentType:
+		var b []byte
+		var err error
+		if contentType == SnapProtobufContentType {
+			b, err = marshalProtobufMetrics(metrics)
+		} else {
+			b, err = marshalMsgpackMetrics(metrics)
+		}
+		if err != nil {
+			log.WithFields(log.Fields{
+				"_module": "control-plugin",
+				"block":   "marshal-content-type",
+				"error":   err.Error(),
+			}).Error("error while marshalling")
+			return nil, "", err
+		}
+		return b, contentType, nil
 	default:
 		// We don't recognize this content type. Log and return error.
 		es := fmt.Sprintf("invalid snap content type: %s", contentType)
@@ -136,6 +153,23 @@ func UnmarshallMetricTypes(contentType string, payload []byte) ([]MetricType, er
 			return nil, err
 		}
 		return metrics, nil
+	case SnapProtobufContentType, SnapMsgpackContentType:
+		var metrics []MetricType
+		var err error
+		if contentType == SnapProtobufContentType {
+			metrics, err = unmarshalProtobufMetrics(payload)
+		} else {
+			metrics, err = unmarshalMsgpackMetrics(payload)
+		}
+		if err != nil {
+			log.WithFields(log.Fields{
+				"_module": "control-plugin",
+				"block":   "unmarshal-content-type",
+				"error":   err.Error(),
+			}).Error("error while unmarshalling")
+			return nil, err
+		}
+		return metrics, nil
 	default:
 		// We don't recognize this content type as one we can unmarshal. Log and return error.
 		es := fmt.Sprintf("invalid snap content type for unmarshalling: %s", contentType)
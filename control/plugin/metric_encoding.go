@@ -0,0 +1,361 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2015 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/intelsdi-x/snap/control/plugin/encoding"
+	"github.com/intelsdi-x/snap/control/plugin/rpc"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/cdata"
+	"github.com/intelsdi-x/snap/core/ctypes"
+)
+
+// wireContentTypes are the content types, in order of preference, that the
+// native and JSON-RPC clients can use to exchange metrics with a plugin.
+var wireContentTypes = []string{
+	SnapProtobufContentType,
+	SnapMsgpackContentType,
+	SnapGOBContentType,
+	SnapJSONContentType,
+}
+
+// NegotiateContentType returns the first of the content types accepted by a
+// plugin (in the plugin's priority order) that snap can encode metrics with.
+// A plugin accepting the snap wildcard, or none of the known types, gets GOB.
+func NegotiateContentType(accepted []string) string {
+	for _, ct := range accepted {
+		if ct == SnapAllContentType {
+			return SnapGOBContentType
+		}
+		for _, wct := range wireContentTypes {
+			if ct == wct {
+				return ct
+			}
+		}
+	}
+	return SnapGOBContentType
+}
+
+// NegotiateCollectContentType returns the content type a collector should use
+// to return collected metrics, or an empty string if the collector should
+// keep replying with GOB/JSON encoded MetricTypes.
+func NegotiateCollectContentType(accepted []string) string {
+	for _, ct := range accepted {
+		switch ct {
+		case SnapProtobufContentType, SnapMsgpackContentType:
+			return ct
+		}
+	}
+	return ""
+}
+
+func marshalProtobufMetrics(metrics []MetricType) ([]byte, error) {
+	arg := &rpc.MetricsArg{Metrics: make([]*rpc.Metric, len(metrics))}
+	for i, mt := range metrics {
+		m, err := toRPCMetric(mt)
+		if err != nil {
+			return nil, err
+		}
+		arg.Metrics[i] = m
+	}
+	return encoding.NewProtobufEncoder().Encode(arg)
+}
+
+func unmarshalProtobufMetrics(payload []byte) ([]MetricType, error) {
+	arg := &rpc.MetricsArg{}
+	if err := encoding.NewProtobufEncoder().Decode(payload, arg); err != nil {
+		return nil, err
+	}
+	metrics := make([]MetricType, len(arg.Metrics))
+	for i, m := range arg.Metrics {
+		metrics[i] = fromRPCMetric(m)
+	}
+	return metrics, nil
+}
+
+func toRPCMetric(mt MetricType) (*rpc.Metric, error) {
+	m := &rpc.Metric{
+		Namespace:          toRPCNamespace(mt.Namespace_),
+		Version:            int64(mt.Version_),
+		Config:             toRPCConfig(mt.Config_),
+		LastAdvertisedTime: toRPCTime(mt.LastAdvertisedTime_),
+		Tags:               mt.Tags_,
+		Timestamp:          toRPCTime(mt.Timestamp_),
+		Unit:               mt.Unit_,
+		Description:        mt.Description_,
+	}
+	switch t := mt.Data_.(type) {
+	case string:
+		m.Data = &rpc.Metric_StringData{StringData: t}
+	case float32:
+		m.Data = &rpc.Metric_Float32Data{Float32Data: t}
+	case float64:
+		m.Data = &rpc.Metric_Float64Data{Float64Data: t}
+	case int8:
+		m.Data = &rpc.Metric_Int32Data{Int32Data: int32(t)}
+	case int16:
+		m.Data = &rpc.Metric_Int32Data{Int32Data: int32(t)}
+	case int32:
+		m.Data = &rpc.Metric_Int32Data{Int32Data: t}
+	case int:
+		m.Data = &rpc.Metric_Int64Data{Int64Data: int64(t)}
+	case int64:
+		m.Data = &rpc.Metric_Int64Data{Int64Data: t}
+	case uint8:
+		m.Data = &rpc.Metric_Int32Data{Int32Data: int32(t)}
+	case uint16:
+		m.Data = &rpc.Metric_Int32Data{Int32Data: int32(t)}
+	case uint32:
+		m.Data = &rpc.Metric_Int64Data{Int64Data: int64(t)}
+	case []byte:
+		m.Data = &rpc.Metric_BytesData{BytesData: t}
+	case bool:
+		m.Data = &rpc.Metric_BoolData{BoolData: t}
+	case nil:
+	default:
+		return nil, fmt.Errorf("unsupported data type %T for content type %s in metric %s", t, SnapProtobufContentType, mt.Namespace().String())
+	}
+	return m, nil
+}
+
+func fromRPCMetric(m *rpc.Metric) MetricType {
+	mt := MetricType{
+		Namespace_:          fromRPCNamespace(m.Namespace),
+		Version_:            int(m.Version),
+		Config_:             fromRPCConfig(m.Config),
+		LastAdvertisedTime_: fromRPCTime(m.LastAdvertisedTime),
+		Tags_:               m.Tags,
+		Timestamp_:          fromRPCTime(m.Timestamp),
+		Unit_:               m.Unit,
+		Description_:        m.Description,
+	}
+	switch d := m.Data.(type) {
+	case *rpc.Metric_StringData:
+		mt.Data_ = d.StringData
+	case *rpc.Metric_Float32Data:
+		mt.Data_ = d.Float32Data
+	case *rpc.Metric_Float64Data:
+		mt.Data_ = d.Float64Data
+	case *rpc.Metric_Int32Data:
+		mt.Data_ = d.Int32Data
+	case *rpc.Metric_Int64Data:
+		mt.Data_ = d.Int64Data
+	case *rpc.Metric_BytesData:
+		mt.Data_ = d.BytesData
+	case *rpc.Metric_BoolData:
+		mt.Data_ = d.BoolData
+	}
+	return mt
+}
+
+func toRPCNamespace(ns core.Namespace) []*rpc.NamespaceElement {
+	elements := make([]*rpc.NamespaceElement, len(ns))
+	for i, e := range ns {
+		elements[i] = &rpc.NamespaceElement{
+			Value:       e.Value,
+			Description: e.Description,
+			Name:        e.Name,
+		}
+	}
+	return elements
+}
+
+func fromRPCNamespace(elements []*rpc.NamespaceElement) core.Namespace {
+	ns := make(core.Namespace, len(elements))
+	for i, e := range elements {
+		ns[i] = core.NamespaceElement{
+			Value:       e.Value,
+			Description: e.Description,
+			Name:        e.Name,
+		}
+	}
+	return ns
+}
+
+func toRPCTime(t time.Time) *rpc.Time {
+	return &rpc.Time{Sec: t.Unix(), Nsec: int64(t.Nanosecond())}
+}
+
+func fromRPCTime(t *rpc.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return time.Unix(t.Sec, t.Nsec)
+}
+
+func toRPCConfig(cfg *cdata.ConfigDataNode) *rpc.ConfigMap {
+	if cfg == nil {
+		return nil
+	}
+	cm := &rpc.ConfigMap{
+		IntMap:        make(map[string]int64),
+		StringMap:     make(map[string]string),
+		FloatMap:      make(map[string]float64),
+		BoolMap:       make(map[string]bool),
+		StringListMap: make(map[string]*rpc.StringList),
+		FloatListMap:  make(map[string]*rpc.FloatList),
+		StringMapMap:  make(map[string]*rpc.StringMap),
+	}
+	for k, v := range cfg.Table() {
+		switch t := v.(type) {
+		case ctypes.ConfigValueInt:
+			cm.IntMap[k] = int64(t.Value)
+		case ctypes.ConfigValueStr:
+			cm.StringMap[k] = t.Value
+		case ctypes.ConfigValueFloat:
+			cm.FloatMap[k] = t.Value
+		case ctypes.ConfigValueBool:
+			cm.BoolMap[k] = t.Value
+		case ctypes.ConfigValueStrList:
+			cm.StringListMap[k] = &rpc.StringList{Values: t.Value}
+		case ctypes.ConfigValueFloatList:
+			cm.FloatListMap[k] = &rpc.FloatList{Values: t.Value}
+		case ctypes.ConfigValueMap:
+			cm.StringMapMap[k] = &rpc.StringMap{Values: t.Value}
+		}
+	}
+	return cm
+}
+
+func fromRPCConfig(cm *rpc.ConfigMap) *cdata.ConfigDataNode {
+	if cm == nil {
+		return nil
+	}
+	table := make(map[string]ctypes.ConfigValue)
+	for k, v := range cm.IntMap {
+		table[k] = ctypes.ConfigValueInt{Value: int(v)}
+	}
+	for k, v := range cm.StringMap {
+		table[k] = ctypes.ConfigValueStr{Value: v}
+	}
+	for k, v := range cm.FloatMap {
+		table[k] = ctypes.ConfigValueFloat{Value: v}
+	}
+	for k, v := range cm.BoolMap {
+		table[k] = ctypes.ConfigValueBool{Value: v}
+	}
+	for k, v := range cm.StringListMap {
+		if v != nil {
+			table[k] = ctypes.ConfigValueStrList{Value: v.Values}
+		}
+	}
+	for k, v := range cm.FloatListMap {
+		if v != nil {
+			table[k] = ctypes.ConfigValueFloatList{Value: v.Values}
+		}
+	}
+	for k, v := range cm.StringMapMap {
+		if v != nil {
+			table[k] = ctypes.ConfigValueMap{Value: v.Values}
+		}
+	}
+	return cdata.FromTable(table)
+}
+
+// msgpackMetric is the msgpack representation of a MetricType. Msgpack only
+// knows about a handful of number widths, so the Go type of the data is sent
+// along with it to be restored when decoding.
+type msgpackMetric struct {
+	Namespace          core.Namespace
+	LastAdvertisedTime *rpc.Time
+	Version            int
+	Config             *rpc.ConfigMap
+	Tags               map[string]string
+	Unit               string
+	Description        string
+	Timestamp          *rpc.Time
+	DataType           string
+	Data               interface{}
+}
+
+// msgpackDataTypes are the data types that can be sent using msgpack.
+var msgpackDataTypes = map[string]reflect.Type{}
+
+func init() {
+	for _, v := range []interface{}{
+		"", []byte{}, true,
+		float32(0), float64(0),
+		int(0), int8(0), int16(0), int32(0), int64(0),
+		uint(0), uint8(0), uint16(0), uint32(0), uint64(0),
+	} {
+		t := reflect.TypeOf(v)
+		msgpackDataTypes[t.String()] = t
+	}
+}
+
+func marshalMsgpackMetrics(metrics []MetricType) ([]byte, error) {
+	mms := make([]msgpackMetric, len(metrics))
+	for i, mt := range metrics {
+		mms[i] = msgpackMetric{
+			Namespace:          mt.Namespace_,
+			LastAdvertisedTime: toRPCTime(mt.LastAdvertisedTime_),
+			Version:            mt.Version_,
+			Config:             toRPCConfig(mt.Config_),
+			Tags:               mt.Tags_,
+			Unit:               mt.Unit_,
+			Description:        mt.Description_,
+			Timestamp:          toRPCTime(mt.Timestamp_),
+			Data:               mt.Data_,
+		}
+		if mt.Data_ == nil {
+			continue
+		}
+		t := reflect.TypeOf(mt.Data_)
+		if _, ok := msgpackDataTypes[t.String()]; !ok {
+			return nil, fmt.Errorf("unsupported data type %T for content type %s in metric %s", mt.Data_, SnapMsgpackContentType, mt.Namespace().String())
+		}
+		mms[i].DataType = t.String()
+	}
+	return encoding.NewMsgpackEncoder().Encode(mms)
+}
+
+func unmarshalMsgpackMetrics(payload []byte) ([]MetricType, error) {
+	var mms []msgpackMetric
+	if err := encoding.NewMsgpackEncoder().Decode(payload, &mms); err != nil {
+		return nil, err
+	}
+	metrics := make([]MetricType, len(mms))
+	for i, mm := range mms {
+		metrics[i] = MetricType{
+			Namespace_:          mm.Namespace,
+			LastAdvertisedTime_: fromRPCTime(mm.LastAdvertisedTime),
+			Version_:            mm.Version,
+			Config_:             fromRPCConfig(mm.Config),
+			Tags_:               mm.Tags,
+			Unit_:               mm.Unit,
+			Description_:        mm.Description,
+			Timestamp_:          fromRPCTime(mm.Timestamp),
+		}
+		if mm.Data == nil {
+			continue
+		}
+		t, ok := msgpackDataTypes[mm.DataType]
+		v := reflect.ValueOf(mm.Data)
+		if !ok || !v.Type().ConvertibleTo(t) {
+			return nil, fmt.Errorf("unable to decode data of type %q in metric %s", mm.DataType, metrics[i].Namespace().String())
+		}
+		metrics[i].Data_ = v.Convert(t).Interface()
+	}
+	return metrics, nil
+}
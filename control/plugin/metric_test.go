@@ -139,6 +139,91 @@ func TestMetric(t *testing.T) {
 		})
 	})
 
+	Convey("marshall using snap.protobuf", t, func() {
+		cfg := cdata.NewNode()
+		cfg.AddItem("user", ctypes.ConfigValueStr{Value: "foo"})
+		cfg.AddItem("port", ctypes.ConfigValueInt{Value: 8080})
+		now := time.Now()
+		m := []MetricType{
+			*NewMetricType(core.NewNamespace("foo", "bar"), now, map[string]string{"a": "b"}, "B", int64(1)<<40),
+			*NewMetricType(core.NewNamespace("foo", "baz"), now, nil, "", []byte{0, 1, 2}),
+			*NewMetricType(core.NewNamespace("foo", "qux"), now, nil, "", float32(1.5)),
+		}
+		m[0].Config_ = cfg
+		a, c, e := MarshalMetricTypes("snap.protobuf", m)
+		So(e, ShouldBeNil)
+		So(len(a), ShouldBeGreaterThan, 0)
+		So(c, ShouldEqual, "snap.protobuf")
+
+		Convey("unmarshal snap.protobuf", func() {
+			m, e = UnmarshallMetricTypes("snap.protobuf", a)
+			So(e, ShouldBeNil)
+			So(m, ShouldHaveLength, 3)
+			So(m[0].Namespace().String(), ShouldResemble, "/foo/bar")
+			So(m[0].Data(), ShouldResemble, int64(1)<<40)
+			So(m[0].Tags(), ShouldResemble, map[string]string{"a": "b"})
+			So(m[0].Unit(), ShouldEqual, "B")
+			So(m[0].Timestamp().Equal(now), ShouldBeTrue)
+			So(m[0].Config().Table()["user"], ShouldResemble, ctypes.ConfigValueStr{Value: "foo"})
+			So(m[0].Config().Table()["port"], ShouldResemble, ctypes.ConfigValueInt{Value: 8080})
+			So(m[1].Data(), ShouldResemble, []byte{0, 1, 2})
+			So(m[2].Data(), ShouldResemble, float32(1.5))
+		})
+
+		Convey("error on unsupported data type", func() {
+			m := []MetricType{
+				*NewMetricType(core.NewNamespace("foo", "bar"), now, nil, "", []string{"a"}),
+			}
+			_, _, e := MarshalMetricTypes("snap.protobuf", m)
+			So(e, ShouldNotBeNil)
+			So(e.Error(), ShouldContainSubstring, "unsupported data type []string")
+		})
+	})
+
+	Convey("marshall using snap.msgpack", t, func() {
+		now := time.Now()
+		m := []MetricType{
+			*NewMetricType(core.NewNamespace("foo", "bar"), now, nil, "", int64(1)<<40),
+			*NewMetricType(core.NewNamespace("foo", "baz"), now, nil, "", []byte{0, 1, 2}),
+			*NewMetricType(core.NewNamespace("foo", "qux"), now, nil, "", uint64(1)<<63),
+			*NewMetricType(core.NewNamespace("foo", "quux"), now, nil, "", float32(1.5)),
+			*NewMetricType(core.NewNamespace("foo", "corge"), now, nil, "", "2"),
+			*NewMetricType(core.NewNamespace("foo", "grault"), now, nil, "", nil),
+		}
+		a, c, e := MarshalMetricTypes("snap.msgpack", m)
+		So(e, ShouldBeNil)
+		So(len(a), ShouldBeGreaterThan, 0)
+		So(c, ShouldEqual, "snap.msgpack")
+
+		Convey("unmarshal snap.msgpack", func() {
+			m, e = UnmarshallMetricTypes("snap.msgpack", a)
+			So(e, ShouldBeNil)
+			So(m, ShouldHaveLength, 6)
+			So(m[0].Namespace().String(), ShouldResemble, "/foo/bar")
+			So(m[0].Data(), ShouldResemble, int64(1)<<40)
+			So(m[0].Timestamp().Equal(now), ShouldBeTrue)
+			So(m[1].Data(), ShouldResemble, []byte{0, 1, 2})
+			So(m[2].Data(), ShouldResemble, uint64(1)<<63)
+			So(m[3].Data(), ShouldResemble, float32(1.5))
+			So(m[4].Data(), ShouldResemble, "2")
+			So(m[5].Data(), ShouldBeNil)
+		})
+
+		Convey("error on bad corrupt data", func() {
+			a = []byte{1, 0, 1, 1, 1, 1, 1, 0, 0, 1}
+			m, e = UnmarshallMetricTypes("snap.msgpack", a)
+			So(e, ShouldNotBeNil)
+		})
+	})
+
+	Convey("negotiate content types", t, func() {
+		So(NegotiateContentType(nil), ShouldEqual, "snap.gob")
+		So(NegotiateContentType([]string{"snap.*"}), ShouldEqual, "snap.gob")
+		So(NegotiateContentType([]string{"foo", "snap.msgpack", "snap.protobuf"}), ShouldEqual, "snap.msgpack")
+		So(NegotiateCollectContentType([]string{"snap.*"}), ShouldEqual, "")
+		So(NegotiateCollectContentType([]string{"snap.gob", "snap.protobuf"}), ShouldEqual, "snap.protobuf")
+	})
+
 	Convey("error on unmarshall using bad content type", t, func() {
 		m := []MetricType{
 			*NewMetricType(core.NewNamespace("foo", "bar"), time.Now(), nil, "", 1),
@@ -128,6 +128,9 @@ type PluginMeta struct {
 	// RoutingStrategy will override the routing strategy this plugin requires.
 	// The default routing strategy round-robin.
 	RoutingStrategy RoutingStrategyType
+	// TLSEnabled is set in the response of a plugin serving RPC over TLS with
+	// the certificate given in its Arg.
+	TLSEnabled bool
 }
 
 // Arguments passed to startup of Plugin
@@ -140,6 +143,19 @@ type Arg struct {
 	NoDaemon bool
 	// The listen port
 	listenPort string
+
+	// TLSEnabled requests the plugin to serve RPC over TLS, presenting the
+	// certificate of CertPath and KeyPath and requiring clients to present a
+	// certificate issued by the CA of CACertPath.
+	TLSEnabled bool
+	CertPath   string
+	KeyPath    string
+	CACertPath string
+
+	// SocketDir requests the plugin to listen on a Unix socket named
+	// UnixSocketName in the directory instead of a TCP port. The directory is
+	// created by snapd for the plugin and only accessible by its user.
+	SocketDir string
 }
 
 func NewArg(logLevel int) Arg {
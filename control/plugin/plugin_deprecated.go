@@ -26,6 +26,7 @@ package plugin
 
 import (
 	"bytes"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -38,6 +39,8 @@ import (
 	"time"
 
 	log "github.com/Sirupsen/logrus"
+
+	"github.com/intelsdi-x/snap/pkg/tlsca"
 )
 
 var (
@@ -204,12 +207,34 @@ func Start(m *PluginMeta, c Plugin, requestString string) (error, int) {
 		}
 	}
 
-	l, err := net.Listen("tcp", "127.0.0.1:"+s.ListenPort())
+	var (
+		l   net.Listener
+		err error
+	)
+	if s.Arg.SocketDir != "" {
+		l, err = listenUnix(s.Arg.SocketDir)
+	} else {
+		l, err = net.Listen("tcp", "127.0.0.1:"+s.ListenPort())
+	}
 	if err != nil {
 		s.Logger().Error(err.Error())
 		panic(err)
 	}
-	s.SetListenAddress(l.Addr().String())
+	listenAddress := l.Addr().String()
+	if s.Arg.SocketDir != "" {
+		listenAddress = UnixListenAddress(listenAddress)
+	}
+	if s.Arg.TLSEnabled {
+		tlsConfig, err := tlsca.ServerConfig(s.Arg.CertPath, s.Arg.KeyPath, s.Arg.CACertPath)
+		if err != nil {
+			s.Logger().Error(err.Error())
+			l.Close()
+			return err, 2
+		}
+		l = tls.NewListener(l, tlsConfig)
+		r.Meta.TLSEnabled = true
+	}
+	s.SetListenAddress(listenAddress)
 	s.Logger().Debugf("Listening %s\n", l.Addr())
 	s.Logger().Debugf("Session token %s\n", s.Token())
 
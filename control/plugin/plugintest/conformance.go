@@ -0,0 +1,185 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugintest
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/ctypes"
+)
+
+// KillTimeout is how long a plugin is given to stop once asked to
+const KillTimeout = 5 * time.Second
+
+// CheckError is a failed conformance check
+type CheckError struct {
+	Check string
+	Err   error
+}
+
+func (c *CheckError) Error() string {
+	return c.Check + ": " + c.Err.Error()
+}
+
+// Conformance starts the plugin binary of the path and checks it behaves the
+// way snapd expects. The checks are:
+//   - ping: the plugin answers pings
+//   - config policy: the plugin returns its config policy
+//   - metric types: a collector returns metric types for the config
+//   - collect, process or publish: the plugin handles metrics with the
+//     config, which satisfies the plugin's config policy
+//   - encoding: the metrics returned by the plugin survive the encoding in
+//     the content types negotiated with it
+//   - kill: the plugin stops once asked to
+//
+// Processors and publishers are given the metrics, or a sample metric when
+// there are none. The failed checks are returned as CheckErrors.
+func Conformance(path string, config map[string]ctypes.ConfigValue, mts []core.Metric) []error {
+	h, err := Start(path)
+	if err != nil {
+		return []error{&CheckError{Check: "start", Err: err}}
+	}
+	var errs []error
+	check := func(name string, err error) bool {
+		if err != nil {
+			errs = append(errs, &CheckError{Check: name, Err: err})
+			return false
+		}
+		return true
+	}
+
+	check("ping", h.Ping())
+	if _, err := h.ConfigPolicy(); check("config policy", err) {
+		var returned []core.Metric
+		if len(mts) == 0 {
+			mts = sampleMetrics()
+		}
+		switch h.Response.Type {
+		case plugin.CollectorPluginType:
+			mts, err := h.GetMetricTypes(config)
+			if check("metric types", err) && check("metric types", checkNamespaces(mts)) {
+				returned, err = h.CollectMetrics(mts, config)
+				check("collect", err)
+			}
+		case plugin.ProcessorPluginType:
+			returned, err = h.Process(mts, config)
+			check("process", err)
+		case plugin.PublisherPluginType:
+			check("publish", h.Publish(mts, config))
+		}
+		if len(returned) > 0 {
+			check("encoding", checkEncoding(h.Response.Meta, returned))
+		}
+	}
+	check("kill", h.checkKill())
+	return errs
+}
+
+func sampleMetrics() []core.Metric {
+	return []core.Metric{
+		plugin.MetricType{
+			Namespace_: core.NewNamespace("intel", "plugintest", "sample"),
+			Data_:      1,
+			Timestamp_: time.Now(),
+		},
+	}
+}
+
+// checkNamespaces checks there are metrics and all have a namespace
+func checkNamespaces(mts []core.Metric) error {
+	if len(mts) == 0 {
+		return errors.New("no metrics returned")
+	}
+	for _, mt := range mts {
+		ns := mt.Namespace()
+		if len(ns) == 0 {
+			return errors.New("metric returned without a namespace")
+		}
+		for _, e := range ns {
+			if e.Value == "" {
+				return fmt.Errorf("metric %s returned with an empty namespace element", ns)
+			}
+		}
+	}
+	return nil
+}
+
+// checkEncoding encodes and decodes the metrics in the content types snapd
+// negotiates with the plugin of the meta, checking the namespaces survive it
+func checkEncoding(meta plugin.PluginMeta, mts []core.Metric) error {
+	contentTypes := []string{plugin.NegotiateContentType(meta.AcceptedContentTypes)}
+	if ct := plugin.NegotiateCollectContentType(meta.AcceptedContentTypes); ct != "" {
+		contentTypes = append(contentTypes, ct)
+	}
+	metrics := make([]plugin.MetricType, len(mts))
+	for i, m := range mts {
+		metrics[i] = plugin.MetricType{
+			Namespace_:          m.Namespace(),
+			LastAdvertisedTime_: m.LastAdvertisedTime(),
+			Version_:            m.Version(),
+			Config_:             m.Config(),
+			Data_:               m.Data(),
+			Tags_:               m.Tags(),
+			Unit_:               m.Unit(),
+			Description_:        m.Description(),
+			Timestamp_:          m.Timestamp(),
+		}
+	}
+	for _, ct := range contentTypes {
+		b, _, err := plugin.MarshalMetricTypes(ct, metrics)
+		if err != nil {
+			return fmt.Errorf("%s: %v", ct, err)
+		}
+		decoded, err := plugin.UnmarshallMetricTypes(ct, b)
+		if err != nil {
+			return fmt.Errorf("%s: %v", ct, err)
+		}
+		if len(decoded) != len(metrics) {
+			return fmt.Errorf("%s: %d metrics decoded out of %d", ct, len(decoded), len(metrics))
+		}
+		for i := range decoded {
+			if decoded[i].Namespace().String() != metrics[i].Namespace().String() {
+				return fmt.Errorf("%s: metric %s decoded as %s", ct, metrics[i].Namespace(), decoded[i].Namespace())
+			}
+		}
+	}
+	return nil
+}
+
+// checkKill asks the plugin to stop and waits for it to stop answering pings
+func (h *Harness) checkKill() error {
+	// the process is killed in any case, it is an error when it exited
+	defer h.ePlugin.Kill()
+	if err := h.client.Kill("conformance check"); err != nil {
+		return err
+	}
+	deadline := time.Now().Add(KillTimeout)
+	for time.Now().Before(deadline) {
+		if h.client.Ping() != nil {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("plugin still running %v after being killed", KillTimeout)
+}
@@ -0,0 +1,89 @@
+// +build legacy
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugintest
+
+import (
+	"testing"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/ctypes"
+	"github.com/intelsdi-x/snap/plugin/helper"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	PluginNameProcessor = "snap-plugin-processor-passthru"
+	PluginNamePublisher = "snap-plugin-publisher-mock-file"
+)
+
+func TestConformance(t *testing.T) {
+	// These tests only work if SNAP_PATH is known.
+	// It is the responsibility of the testing framework to
+	// build the plugins first into the build dir.
+
+	Convey("make sure the processor has been built", t, func() {
+		err := helper.PluginFileCheck(PluginNameProcessor)
+		So(err, ShouldBeNil)
+
+		Convey("it conforms", func() {
+			errs := Conformance(helper.PluginFilePath(PluginNameProcessor), nil, nil)
+			So(errs, ShouldBeEmpty)
+		})
+		Convey("it processes metrics", func() {
+			h, err := Start(helper.PluginFilePath(PluginNameProcessor))
+			So(err, ShouldBeNil)
+			defer h.Stop()
+			So(h.Response.Type, ShouldEqual, plugin.ProcessorPluginType)
+			mts, err := h.Process(sampleMetrics(), nil)
+			So(err, ShouldBeNil)
+			So(mts, ShouldHaveLength, 1)
+			So(mts[0].Namespace(), ShouldResemble, sampleMetrics()[0].Namespace())
+
+			Convey("and rejects an invalid config", func() {
+				_, err := h.Process(sampleMetrics(), map[string]ctypes.ConfigValue{"debug": ctypes.ConfigValueStr{Value: "yes"}})
+				So(err, ShouldHaveSameTypeAs, &ConfigError{})
+			})
+			Convey("but doesn't collect", func() {
+				_, err := h.CollectMetrics([]core.Metric{}, nil)
+				So(err, ShouldEqual, ErrNotCollector)
+			})
+		})
+	})
+	Convey("make sure the publisher has been built", t, func() {
+		err := helper.PluginFileCheck(PluginNamePublisher)
+		So(err, ShouldBeNil)
+
+		Convey("it conforms", func() {
+			errs := Conformance(helper.PluginFilePath(PluginNamePublisher), map[string]ctypes.ConfigValue{
+				"file": ctypes.ConfigValueStr{Value: "/dev/null"},
+			}, nil)
+			So(errs, ShouldBeEmpty)
+		})
+		Convey("its config is checked against its policy", func() {
+			errs := Conformance(helper.PluginFilePath(PluginNamePublisher), nil, nil)
+			So(errs, ShouldHaveLength, 1)
+			So(errs[0].(*CheckError).Check, ShouldEqual, "publish")
+		})
+	})
+}
@@ -0,0 +1,237 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugintest provides a harness testing plugin binaries without a
+// running snapd. The harness starts a plugin the way snapd does, performs the
+// handshake and drives the plugin through its client, checking the configs it
+// is given against the plugin's config policy.
+package plugintest
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/control/plugin/client"
+	"github.com/intelsdi-x/snap/control/plugin/cpolicy"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/cdata"
+	"github.com/intelsdi-x/snap/core/ctypes"
+)
+
+const (
+	// StartTimeout is how long a plugin is given to respond when started, as
+	// it is by snapd
+	StartTimeout = 5 * time.Second
+	// ClientTimeout is the timeout of the calls to a plugin
+	ClientTimeout = 10 * time.Second
+)
+
+var (
+	// ErrNotCollector - Error message for a collector call to another plugin type
+	ErrNotCollector = errors.New("Plugin is not a collector")
+	// ErrNotProcessor - Error message for a processor call to another plugin type
+	ErrNotProcessor = errors.New("Plugin is not a processor")
+	// ErrNotPublisher - Error message for a publisher call to another plugin type
+	ErrNotPublisher = errors.New("Plugin is not a publisher")
+)
+
+// ConfigError is returned when a config doesn't satisfy the config policy of
+// the plugin
+type ConfigError struct {
+	Errors []error
+}
+
+func (c *ConfigError) Error() string {
+	msgs := make([]string, len(c.Errors))
+	for i, e := range c.Errors {
+		msgs[i] = e.Error()
+	}
+	return "invalid config: " + strings.Join(msgs, ", ")
+}
+
+// Harness is a started plugin and its client
+type Harness struct {
+	// Response is the response of the plugin to its start
+	Response plugin.Response
+
+	ePlugin *plugin.ExecutablePlugin
+	client  client.PluginClient
+	policy  *cpolicy.ConfigPolicy
+}
+
+// Start starts the plugin binary of the path with default args
+func Start(path string) (*Harness, error) {
+	return StartWithArg(path, plugin.NewArg(int(log.GetLevel())))
+}
+
+// StartWithArg starts the plugin binary of the path with the args and
+// performs the handshake snapd performs with a started plugin: the plugin
+// must respond successfully, then it is pinged or, unless it is unsecure,
+// given the key of its client.
+func StartWithArg(path string, args plugin.Arg) (*Harness, error) {
+	ePlugin, err := plugin.NewExecutablePlugin(args, path)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ePlugin.Run(StartTimeout)
+	if err != nil {
+		return nil, errors.New("error starting plugin: " + err.Error())
+	}
+	if resp.State != plugin.PluginSuccess {
+		ePlugin.Kill()
+		return nil, errors.New("plugin could not start error: " + resp.ErrorMessage)
+	}
+	c, err := client.New(resp, ClientTimeout, nil)
+	if err != nil {
+		ePlugin.Kill()
+		return nil, err
+	}
+	if resp.Meta.Unsecure {
+		err = c.Ping()
+	} else {
+		err = c.SetKey()
+	}
+	if err != nil {
+		ePlugin.Kill()
+		return nil, err
+	}
+	return &Harness{
+		Response: resp,
+		ePlugin:  ePlugin,
+		client:   c,
+	}, nil
+}
+
+// Client returns the client of the plugin
+func (h *Harness) Client() client.PluginClient {
+	return h.client
+}
+
+// Ping pings the plugin
+func (h *Harness) Ping() error {
+	return h.client.Ping()
+}
+
+// Stop asks the plugin to stop and kills its process
+func (h *Harness) Stop() error {
+	h.client.Kill("plugin test done")
+	return h.ePlugin.Kill()
+}
+
+// ConfigPolicy returns the config policy of the plugin
+func (h *Harness) ConfigPolicy() (*cpolicy.ConfigPolicy, error) {
+	if h.policy != nil {
+		return h.policy, nil
+	}
+	policy, err := h.client.GetConfigPolicy()
+	if err != nil {
+		return nil, err
+	}
+	h.policy = policy
+	return policy, nil
+}
+
+// ProcessConfig checks the config against the policy of the plugin for the
+// namespace and returns it with the defaults of the policy applied. The
+// policy for the processors and publishers is the one of the [""] namespace.
+func (h *Harness) ProcessConfig(ns []string, config map[string]ctypes.ConfigValue) (map[string]ctypes.ConfigValue, error) {
+	policy, err := h.ConfigPolicy()
+	if err != nil {
+		return nil, err
+	}
+	// the policy adds its defaults to the config it processes
+	cfg := make(map[string]ctypes.ConfigValue, len(config))
+	for k, v := range config {
+		cfg[k] = v
+	}
+	node := policy.Get(ns)
+	if node == nil || !node.HasRules() {
+		return cfg, nil
+	}
+	processed, errs := node.Process(cfg)
+	if errs != nil && errs.HasErrors() {
+		return nil, &ConfigError{Errors: errs.Errors()}
+	}
+	return *processed, nil
+}
+
+// GetMetricTypes returns the metric types of a collector for the global
+// config
+func (h *Harness) GetMetricTypes(config map[string]ctypes.ConfigValue) ([]core.Metric, error) {
+	c, ok := h.client.(client.PluginCollectorClient)
+	if !ok || h.Response.Type != plugin.CollectorPluginType {
+		return nil, ErrNotCollector
+	}
+	return c.GetMetricTypes(plugin.ConfigType{ConfigDataNode: cdata.FromTable(config)})
+}
+
+// CollectMetrics collects the metrics of the metric types from a collector.
+// The config is checked against the policy of each metric type, as snapd does
+// when a task subscribes to it, and the metrics are requested with it.
+func (h *Harness) CollectMetrics(mts []core.Metric, config map[string]ctypes.ConfigValue) ([]core.Metric, error) {
+	c, ok := h.client.(client.PluginCollectorClient)
+	if !ok || h.Response.Type != plugin.CollectorPluginType {
+		return nil, ErrNotCollector
+	}
+	requested := make([]core.Metric, len(mts))
+	for i, mt := range mts {
+		cfg, err := h.ProcessConfig(mt.Namespace().Strings(), config)
+		if err != nil {
+			return nil, err
+		}
+		requested[i] = plugin.MetricType{
+			Namespace_: mt.Namespace(),
+			Version_:   mt.Version(),
+			Config_:    cdata.FromTable(cfg),
+		}
+	}
+	return c.CollectMetrics(requested)
+}
+
+// Process processes the metrics with a processor. The config is checked
+// against the policy of the plugin and given to it with its defaults applied.
+func (h *Harness) Process(mts []core.Metric, config map[string]ctypes.ConfigValue) ([]core.Metric, error) {
+	c, ok := h.client.(client.PluginProcessorClient)
+	if !ok || h.Response.Type != plugin.ProcessorPluginType {
+		return nil, ErrNotProcessor
+	}
+	cfg, err := h.ProcessConfig([]string{""}, config)
+	if err != nil {
+		return nil, err
+	}
+	return c.Process(mts, cfg)
+}
+
+// Publish publishes the metrics with a publisher. The config is checked
+// against the policy of the plugin and given to it with its defaults applied.
+func (h *Harness) Publish(mts []core.Metric, config map[string]ctypes.ConfigValue) error {
+	c, ok := h.client.(client.PluginPublisherClient)
+	if !ok || h.Response.Type != plugin.PublisherPluginType {
+		return ErrNotPublisher
+	}
+	cfg, err := h.ProcessConfig([]string{""}, config)
+	if err != nil {
+		return err
+	}
+	return c.Publish(mts, cfg)
+}
@@ -0,0 +1,79 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugintest
+
+import (
+	"testing"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/control/plugin/cpolicy"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/ctypes"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProcessConfig(t *testing.T) {
+	Convey("Given a harness of a plugin with a config policy", t, func() {
+		policy := cpolicy.New()
+		node := cpolicy.NewPolicyNode()
+		r1, err := cpolicy.NewStringRule("path", true)
+		So(err, ShouldBeNil)
+		r2, err := cpolicy.NewBoolRule("debug", false, false)
+		So(err, ShouldBeNil)
+		node.Add(r1, r2)
+		policy.Add([]string{"intel", "mock"}, node)
+		h := &Harness{policy: policy}
+
+		Convey("a config satisfying the policy gets its defaults", func() {
+			config := map[string]ctypes.ConfigValue{"path": ctypes.ConfigValueStr{Value: "/tmp"}}
+			cfg, err := h.ProcessConfig([]string{"intel", "mock", "foo"}, config)
+			So(err, ShouldBeNil)
+			So(cfg["path"], ShouldResemble, ctypes.ConfigValueStr{Value: "/tmp"})
+			So(cfg["debug"], ShouldResemble, ctypes.ConfigValueBool{Value: false})
+			So(config, ShouldNotContainKey, "debug")
+		})
+		Convey("a config missing a required key is rejected", func() {
+			_, err := h.ProcessConfig([]string{"intel", "mock", "foo"}, nil)
+			So(err, ShouldHaveSameTypeAs, &ConfigError{})
+			So(err.Error(), ShouldContainSubstring, "required key missing (path)")
+		})
+		Convey("a config of a namespace without rules is unchanged", func() {
+			config := map[string]ctypes.ConfigValue{"user": ctypes.ConfigValueStr{Value: "root"}}
+			cfg, err := h.ProcessConfig([]string{""}, config)
+			So(err, ShouldBeNil)
+			So(cfg, ShouldResemble, config)
+		})
+	})
+}
+
+func TestChecks(t *testing.T) {
+	Convey("checkNamespaces", t, func() {
+		So(checkNamespaces(nil), ShouldNotBeNil)
+		So(checkNamespaces(sampleMetrics()), ShouldBeNil)
+		So(checkNamespaces([]core.Metric{plugin.MetricType{Namespace_: core.NewNamespace("intel", "")}}), ShouldNotBeNil)
+	})
+	Convey("checkEncoding", t, func() {
+		meta := plugin.PluginMeta{AcceptedContentTypes: []string{plugin.SnapGOBContentType, plugin.SnapMsgpackContentType}}
+		So(checkEncoding(meta, sampleMetrics()), ShouldBeNil)
+	})
+}
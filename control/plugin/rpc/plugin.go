@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"strings"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 
@@ -18,10 +19,14 @@ var (
 // NewGetConfigPolicyReply given a config *cpolicy.ConfigPolicy returns a GetConfigPolicyReply.
 func NewGetConfigPolicyReply(policy *cpolicy.ConfigPolicy) (*GetConfigPolicyReply, error) {
 	ret := &GetConfigPolicyReply{
-		BoolPolicy:    map[string]*BoolPolicy{},
-		FloatPolicy:   map[string]*FloatPolicy{},
-		IntegerPolicy: map[string]*IntegerPolicy{},
-		StringPolicy:  map[string]*StringPolicy{},
+		BoolPolicy:       map[string]*BoolPolicy{},
+		FloatPolicy:      map[string]*FloatPolicy{},
+		IntegerPolicy:    map[string]*IntegerPolicy{},
+		StringPolicy:     map[string]*StringPolicy{},
+		StringListPolicy: map[string]*StringListPolicy{},
+		FloatListPolicy:  map[string]*FloatListPolicy{},
+		MapPolicy:        map[string]*MapPolicy{},
+		DurationPolicy:   map[string]*DurationPolicy{},
 	}
 
 	for _, node := range policy.GetAll() {
@@ -46,6 +51,8 @@ func NewGetConfigPolicyReply(policy *cpolicy.ConfigPolicy) (*GetConfigPolicyRepl
 			case cpolicy.StringType:
 				r := &StringRule{
 					Required: rule.Required,
+					Allowed:  rule.Allowed,
+					Pattern:  rule.Pattern,
 				}
 				if rule.Default != nil {
 					r.Default = rule.Default.(ctypes.ConfigValueStr).Value
@@ -97,6 +104,81 @@ func NewGetConfigPolicyReply(policy *cpolicy.ConfigPolicy) (*GetConfigPolicyRepl
 					}
 				}
 				ret.FloatPolicy[key].Rules[rule.Name] = r
+			case cpolicy.StringListType:
+				r := &StringListRule{
+					Required: rule.Required,
+					Allowed:  rule.Allowed,
+				}
+				if rule.Default != nil {
+					r.Default = rule.Default.(ctypes.ConfigValueStrList).Value
+					r.HasDefault = true
+				}
+				r.MinLength, r.HasMinLength = fromLength(rule.MinLength)
+				r.MaxLength, r.HasMaxLength = fromLength(rule.MaxLength)
+				if ret.StringListPolicy[key] == nil {
+					ret.StringListPolicy[key] = &StringListPolicy{
+						Rules: map[string]*StringListRule{},
+						Key:   node.Key,
+					}
+				}
+				ret.StringListPolicy[key].Rules[rule.Name] = r
+			case cpolicy.FloatListType:
+				r := &FloatListRule{
+					Required: rule.Required,
+				}
+				if rule.Default != nil {
+					r.Default = rule.Default.(ctypes.ConfigValueFloatList).Value
+					r.HasDefault = true
+				}
+				r.MinLength, r.HasMinLength = fromLength(rule.MinLength)
+				r.MaxLength, r.HasMaxLength = fromLength(rule.MaxLength)
+				if rule.Maximum != nil {
+					r.Maximum = rule.Maximum.(ctypes.ConfigValueFloat).Value
+					r.HasMax = true
+				}
+				if rule.Minimum != nil {
+					r.Minimum = rule.Minimum.(ctypes.ConfigValueFloat).Value
+					r.HasMin = true
+				}
+				if ret.FloatListPolicy[key] == nil {
+					ret.FloatListPolicy[key] = &FloatListPolicy{
+						Rules: map[string]*FloatListRule{},
+						Key:   node.Key,
+					}
+				}
+				ret.FloatListPolicy[key].Rules[rule.Name] = r
+			case cpolicy.MapType:
+				r := &MapRule{
+					Required:     rule.Required,
+					RequiredKeys: rule.RequiredKeys,
+				}
+				if rule.Default != nil {
+					r.Default = rule.Default.(ctypes.ConfigValueMap).Value
+					r.HasDefault = true
+				}
+				r.MinLength, r.HasMinLength = fromLength(rule.MinLength)
+				r.MaxLength, r.HasMaxLength = fromLength(rule.MaxLength)
+				if ret.MapPolicy[key] == nil {
+					ret.MapPolicy[key] = &MapPolicy{
+						Rules: map[string]*MapRule{},
+						Key:   node.Key,
+					}
+				}
+				ret.MapPolicy[key].Rules[rule.Name] = r
+			case cpolicy.DurationType:
+				r := &DurationRule{
+					Required: rule.Required,
+				}
+				r.Default, r.HasDefault = fromDuration(rule.Default)
+				r.Minimum, r.HasMin = fromDuration(rule.Minimum)
+				r.Maximum, r.HasMax = fromDuration(rule.Maximum)
+				if ret.DurationPolicy[key] == nil {
+					ret.DurationPolicy[key] = &DurationPolicy{
+						Rules: map[string]*DurationRule{},
+						Key:   node.Key,
+					}
+				}
+				ret.DurationPolicy[key].Rules[rule.Name] = r
 			}
 
 		}
@@ -120,7 +202,7 @@ func ToConfigPolicy(reply *GetConfigPolicyReply) *cpolicy.ConfigPolicy {
 				br, err = cpolicy.NewBoolRule(key, val.Required)
 			}
 			if err != nil {
-				rpcLogger.Warn("Empty key found with value %v", val)
+				rpcLogger.Warnf("Empty key found with value %v", val)
 				continue
 			}
 			nodes[k].Add(br)
@@ -140,9 +222,17 @@ func ToConfigPolicy(reply *GetConfigPolicyReply) *cpolicy.ConfigPolicy {
 				sr, err = cpolicy.NewStringRule(key, val.Required)
 			}
 			if err != nil {
-				rpcLogger.Warn("Empty key found with value %v", val)
+				rpcLogger.Warnf("Empty key found with value %v", val)
 				continue
 			}
+			if len(val.Allowed) > 0 {
+				sr.SetAllowed(val.Allowed...)
+			}
+			if val.Pattern != "" {
+				if err := sr.SetPattern(val.Pattern); err != nil {
+					rpcLogger.WithField("key", key).Warn("Invalid pattern found: ", err)
+				}
+			}
 
 			nodes[k].Add(sr)
 		}
@@ -161,7 +251,7 @@ func ToConfigPolicy(reply *GetConfigPolicyReply) *cpolicy.ConfigPolicy {
 				ir, err = cpolicy.NewIntegerRule(key, val.Required)
 			}
 			if err != nil {
-				rpcLogger.Warn("Empty key found with value %v", val)
+				rpcLogger.Warnf("Empty key found with value %v", val)
 				continue
 			}
 			if val.HasMin {
@@ -189,7 +279,7 @@ func ToConfigPolicy(reply *GetConfigPolicyReply) *cpolicy.ConfigPolicy {
 				fr, err = cpolicy.NewFloatRule(key, val.Required)
 			}
 			if err != nil {
-				rpcLogger.Warn("Empty key found with value %v", val)
+				rpcLogger.Warnf("Empty key found with value %v", val)
 				continue
 			}
 
@@ -204,6 +294,126 @@ func ToConfigPolicy(reply *GetConfigPolicyReply) *cpolicy.ConfigPolicy {
 		}
 	}
 
+	for k, v := range reply.StringListPolicy {
+		if _, ok := nodes[k]; !ok {
+			nodes[k] = cpolicy.NewPolicyNode()
+		}
+		for key, val := range v.Rules {
+			var sr *cpolicy.StringListRule
+			var err error
+			if val.HasDefault {
+				sr, err = cpolicy.NewStringListRule(key, val.Required, val.Default)
+			} else {
+				sr, err = cpolicy.NewStringListRule(key, val.Required)
+			}
+			if err != nil {
+				rpcLogger.Warnf("Empty key found with value %v", val)
+				continue
+			}
+			if val.HasMinLength {
+				sr.SetMinLength(int(val.MinLength))
+			}
+			if val.HasMaxLength {
+				sr.SetMaxLength(int(val.MaxLength))
+			}
+			if len(val.Allowed) > 0 {
+				sr.SetAllowed(val.Allowed...)
+			}
+
+			nodes[k].Add(sr)
+		}
+	}
+
+	for k, v := range reply.FloatListPolicy {
+		if _, ok := nodes[k]; !ok {
+			nodes[k] = cpolicy.NewPolicyNode()
+		}
+		for key, val := range v.Rules {
+			var fr *cpolicy.FloatListRule
+			var err error
+			if val.HasDefault {
+				fr, err = cpolicy.NewFloatListRule(key, val.Required, val.Default)
+			} else {
+				fr, err = cpolicy.NewFloatListRule(key, val.Required)
+			}
+			if err != nil {
+				rpcLogger.Warnf("Empty key found with value %v", val)
+				continue
+			}
+			if val.HasMinLength {
+				fr.SetMinLength(int(val.MinLength))
+			}
+			if val.HasMaxLength {
+				fr.SetMaxLength(int(val.MaxLength))
+			}
+			if val.HasMin {
+				fr.SetMinimum(val.Minimum)
+			}
+			if val.HasMax {
+				fr.SetMaximum(val.Maximum)
+			}
+
+			nodes[k].Add(fr)
+		}
+	}
+
+	for k, v := range reply.MapPolicy {
+		if _, ok := nodes[k]; !ok {
+			nodes[k] = cpolicy.NewPolicyNode()
+		}
+		for key, val := range v.Rules {
+			var mr *cpolicy.MapRule
+			var err error
+			if val.HasDefault {
+				mr, err = cpolicy.NewMapRule(key, val.Required, val.Default)
+			} else {
+				mr, err = cpolicy.NewMapRule(key, val.Required)
+			}
+			if err != nil {
+				rpcLogger.Warnf("Empty key found with value %v", val)
+				continue
+			}
+			if val.HasMinLength {
+				mr.SetMinLength(int(val.MinLength))
+			}
+			if val.HasMaxLength {
+				mr.SetMaxLength(int(val.MaxLength))
+			}
+			if len(val.RequiredKeys) > 0 {
+				mr.SetRequiredKeys(val.RequiredKeys...)
+			}
+
+			nodes[k].Add(mr)
+		}
+	}
+
+	for k, v := range reply.DurationPolicy {
+		if _, ok := nodes[k]; !ok {
+			nodes[k] = cpolicy.NewPolicyNode()
+		}
+		for key, val := range v.Rules {
+			var dr *cpolicy.DurationRule
+			var err error
+			if val.HasDefault {
+				dr, err = cpolicy.NewDurationRule(key, val.Required, time.Duration(val.Default))
+			} else {
+				dr, err = cpolicy.NewDurationRule(key, val.Required)
+			}
+			if err != nil {
+				rpcLogger.Warnf("Empty key found with value %v", val)
+				continue
+			}
+			if val.HasMin {
+				dr.SetMinimum(time.Duration(val.Minimum))
+			}
+			if val.HasMax {
+				dr.SetMaximum(time.Duration(val.Maximum))
+			}
+
+			nodes[k].Add(dr)
+		}
+	}
+
 	for key, node := range nodes {
 		var keys []string
 		// if the []string is present, use it.
@@ -216,6 +426,14 @@ func ToConfigPolicy(reply *GetConfigPolicyReply) *cpolicy.ConfigPolicy {
 			keys = val.Key
 		} else if val, ok := reply.IntegerPolicy[key]; ok && val != nil && val.Key != nil {
 			keys = val.Key
+		} else if val, ok := reply.StringListPolicy[key]; ok && val != nil && val.Key != nil {
+			keys = val.Key
+		} else if val, ok := reply.FloatListPolicy[key]; ok && val != nil && val.Key != nil {
+			keys = val.Key
+		} else if val, ok := reply.MapPolicy[key]; ok && val != nil && val.Key != nil {
+			keys = val.Key
+		} else if val, ok := reply.DurationPolicy[key]; ok && val != nil && val.Key != nil {
+			keys = val.Key
 		} else {
 			keys = strings.Split(key, ".")
 		}
@@ -224,3 +442,26 @@ func ToConfigPolicy(reply *GetConfigPolicyReply) *cpolicy.ConfigPolicy {
 
 	return result
 }
+
+// fromLength converts an optional length of a cpolicy rule into its
+// protobuf representation
+func fromLength(l *int) (int64, bool) {
+	if l == nil {
+		return 0, false
+	}
+	return int64(*l), true
+}
+
+// fromDuration converts an optional duration of a cpolicy rule into
+// nanoseconds
+func fromDuration(cv interface{}) (int64, bool) {
+	s, ok := cv.(ctypes.ConfigValueStr)
+	if !ok {
+		return 0, false
+	}
+	d, err := time.ParseDuration(s.Value)
+	if err != nil {
+		return 0, false
+	}
+	return int64(d), true
+}
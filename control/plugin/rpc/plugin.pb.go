@@ -35,6 +35,9 @@ It has these top-level messages:
 	PubProcArg
 	Metric
 	ConfigMap
+	StringList
+	FloatList
+	StringMap
 	KillArg
 	GetConfigPolicyReply
 	BoolRule
@@ -45,6 +48,14 @@ It has these top-level messages:
 	IntegerPolicy
 	StringRule
 	StringPolicy
+	StringListRule
+	StringListPolicy
+	FloatListRule
+	FloatListPolicy
+	MapRule
+	MapPolicy
+	DurationRule
+	DurationPolicy
 	MetricsArg
 	MetricsReply
 	GetMetricTypesArg
@@ -428,8 +439,11 @@ type ConfigMap struct {
 	IntMap    map[string]int64  `protobuf:"bytes,1,rep,name=IntMap,json=intMap" json:"IntMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
 	StringMap map[string]string `protobuf:"bytes,2,rep,name=StringMap,json=stringMap" json:"StringMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// double is float64
-	FloatMap map[string]float64 `protobuf:"bytes,3,rep,name=FloatMap,json=floatMap" json:"FloatMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
-	BoolMap  map[string]bool    `protobuf:"bytes,4,rep,name=BoolMap,json=boolMap" json:"BoolMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	FloatMap      map[string]float64     `protobuf:"bytes,3,rep,name=FloatMap,json=floatMap" json:"FloatMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	BoolMap       map[string]bool        `protobuf:"bytes,4,rep,name=BoolMap,json=boolMap" json:"BoolMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	StringListMap map[string]*StringList `protobuf:"bytes,5,rep,name=StringListMap,json=stringListMap" json:"StringListMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	FloatListMap  map[string]*FloatList  `protobuf:"bytes,6,rep,name=FloatListMap,json=floatListMap" json:"FloatListMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StringMapMap  map[string]*StringMap  `protobuf:"bytes,7,rep,name=StringMapMap,json=stringMapMap" json:"StringMapMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *ConfigMap) Reset()                    { *m = ConfigMap{} }
@@ -465,6 +479,61 @@ func (m *ConfigMap) GetBoolMap() map[string]bool {
 	return nil
 }
 
+func (m *ConfigMap) GetStringListMap() map[string]*StringList {
+	if m != nil {
+		return m.StringListMap
+	}
+	return nil
+}
+
+func (m *ConfigMap) GetFloatListMap() map[string]*FloatList {
+	if m != nil {
+		return m.FloatListMap
+	}
+	return nil
+}
+
+func (m *ConfigMap) GetStringMapMap() map[string]*StringMap {
+	if m != nil {
+		return m.StringMapMap
+	}
+	return nil
+}
+
+type StringList struct {
+	Values []string `protobuf:"bytes,1,rep,name=values" json:"values,omitempty"`
+}
+
+func (m *StringList) Reset()                    { *m = StringList{} }
+func (m *StringList) String() string            { return proto.CompactTextString(m) }
+func (*StringList) ProtoMessage()               {}
+func (*StringList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+type FloatList struct {
+	Values []float64 `protobuf:"fixed64,1,rep,packed,name=values" json:"values,omitempty"`
+}
+
+func (m *FloatList) Reset()                    { *m = FloatList{} }
+func (m *FloatList) String() string            { return proto.CompactTextString(m) }
+func (*FloatList) ProtoMessage()               {}
+func (*FloatList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+type StringMap struct {
+	Values map[string]string `protobuf:"bytes,1,rep,name=values" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *StringMap) Reset()                    { *m = StringMap{} }
+func (m *StringMap) String() string            { return proto.CompactTextString(m) }
+func (*StringMap) ProtoMessage()               {}
+func (*StringMap) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *StringMap) GetValues() map[string]string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 type KillArg struct {
 	Reason string `protobuf:"bytes,1,opt,name=Reason,json=reason" json:"Reason,omitempty"`
 }
@@ -472,20 +541,24 @@ type KillArg struct {
 func (m *KillArg) Reset()                    { *m = KillArg{} }
 func (m *KillArg) String() string            { return proto.CompactTextString(m) }
 func (*KillArg) ProtoMessage()               {}
-func (*KillArg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*KillArg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 type GetConfigPolicyReply struct {
-	Error         string                    `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
-	BoolPolicy    map[string]*BoolPolicy    `protobuf:"bytes,2,rep,name=bool_policy,json=boolPolicy" json:"bool_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	FloatPolicy   map[string]*FloatPolicy   `protobuf:"bytes,3,rep,name=float_policy,json=floatPolicy" json:"float_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	IntegerPolicy map[string]*IntegerPolicy `protobuf:"bytes,4,rep,name=integer_policy,json=integerPolicy" json:"integer_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	StringPolicy  map[string]*StringPolicy  `protobuf:"bytes,5,rep,name=string_policy,json=stringPolicy" json:"string_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Error            string                       `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+	BoolPolicy       map[string]*BoolPolicy       `protobuf:"bytes,2,rep,name=bool_policy,json=boolPolicy" json:"bool_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	FloatPolicy      map[string]*FloatPolicy      `protobuf:"bytes,3,rep,name=float_policy,json=floatPolicy" json:"float_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	IntegerPolicy    map[string]*IntegerPolicy    `protobuf:"bytes,4,rep,name=integer_policy,json=integerPolicy" json:"integer_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StringPolicy     map[string]*StringPolicy     `protobuf:"bytes,5,rep,name=string_policy,json=stringPolicy" json:"string_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StringListPolicy map[string]*StringListPolicy `protobuf:"bytes,6,rep,name=string_list_policy,json=stringListPolicy" json:"string_list_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	FloatListPolicy  map[string]*FloatListPolicy  `protobuf:"bytes,7,rep,name=float_list_policy,json=floatListPolicy" json:"float_list_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	MapPolicy        map[string]*MapPolicy        `protobuf:"bytes,8,rep,name=map_policy,json=mapPolicy" json:"map_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DurationPolicy   map[string]*DurationPolicy   `protobuf:"bytes,9,rep,name=duration_policy,json=durationPolicy" json:"duration_policy,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *GetConfigPolicyReply) Reset()                    { *m = GetConfigPolicyReply{} }
 func (m *GetConfigPolicyReply) String() string            { return proto.CompactTextString(m) }
 func (*GetConfigPolicyReply) ProtoMessage()               {}
-func (*GetConfigPolicyReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*GetConfigPolicyReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *GetConfigPolicyReply) GetBoolPolicy() map[string]*BoolPolicy {
 	if m != nil {
@@ -515,6 +588,34 @@ func (m *GetConfigPolicyReply) GetStringPolicy() map[string]*StringPolicy {
 	return nil
 }
 
+func (m *GetConfigPolicyReply) GetStringListPolicy() map[string]*StringListPolicy {
+	if m != nil {
+		return m.StringListPolicy
+	}
+	return nil
+}
+
+func (m *GetConfigPolicyReply) GetFloatListPolicy() map[string]*FloatListPolicy {
+	if m != nil {
+		return m.FloatListPolicy
+	}
+	return nil
+}
+
+func (m *GetConfigPolicyReply) GetMapPolicy() map[string]*MapPolicy {
+	if m != nil {
+		return m.MapPolicy
+	}
+	return nil
+}
+
+func (m *GetConfigPolicyReply) GetDurationPolicy() map[string]*DurationPolicy {
+	if m != nil {
+		return m.DurationPolicy
+	}
+	return nil
+}
+
 type BoolRule struct {
 	Required   bool `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
 	Default    bool `protobuf:"varint,2,opt,name=default" json:"default,omitempty"`
@@ -524,7 +625,7 @@ type BoolRule struct {
 func (m *BoolRule) Reset()                    { *m = BoolRule{} }
 func (m *BoolRule) String() string            { return proto.CompactTextString(m) }
 func (*BoolRule) ProtoMessage()               {}
-func (*BoolRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*BoolRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 type BoolPolicy struct {
 	Rules map[string]*BoolRule `protobuf:"bytes,1,rep,name=rules" json:"rules,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
@@ -534,7 +635,7 @@ type BoolPolicy struct {
 func (m *BoolPolicy) Reset()                    { *m = BoolPolicy{} }
 func (m *BoolPolicy) String() string            { return proto.CompactTextString(m) }
 func (*BoolPolicy) ProtoMessage()               {}
-func (*BoolPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*BoolPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *BoolPolicy) GetRules() map[string]*BoolRule {
 	if m != nil {
@@ -556,7 +657,7 @@ type FloatRule struct {
 func (m *FloatRule) Reset()                    { *m = FloatRule{} }
 func (m *FloatRule) String() string            { return proto.CompactTextString(m) }
 func (*FloatRule) ProtoMessage()               {}
-func (*FloatRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*FloatRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 type FloatPolicy struct {
 	Rules map[string]*FloatRule `protobuf:"bytes,1,rep,name=rules" json:"rules,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
@@ -566,7 +667,7 @@ type FloatPolicy struct {
 func (m *FloatPolicy) Reset()                    { *m = FloatPolicy{} }
 func (m *FloatPolicy) String() string            { return proto.CompactTextString(m) }
 func (*FloatPolicy) ProtoMessage()               {}
-func (*FloatPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*FloatPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *FloatPolicy) GetRules() map[string]*FloatRule {
 	if m != nil {
@@ -588,7 +689,7 @@ type IntegerRule struct {
 func (m *IntegerRule) Reset()                    { *m = IntegerRule{} }
 func (m *IntegerRule) String() string            { return proto.CompactTextString(m) }
 func (*IntegerRule) ProtoMessage()               {}
-func (*IntegerRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*IntegerRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 type IntegerPolicy struct {
 	Rules map[string]*IntegerRule `protobuf:"bytes,1,rep,name=rules" json:"rules,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
@@ -598,7 +699,7 @@ type IntegerPolicy struct {
 func (m *IntegerPolicy) Reset()                    { *m = IntegerPolicy{} }
 func (m *IntegerPolicy) String() string            { return proto.CompactTextString(m) }
 func (*IntegerPolicy) ProtoMessage()               {}
-func (*IntegerPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*IntegerPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *IntegerPolicy) GetRules() map[string]*IntegerRule {
 	if m != nil {
@@ -608,15 +709,17 @@ func (m *IntegerPolicy) GetRules() map[string]*IntegerRule {
 }
 
 type StringRule struct {
-	Required   bool   `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
-	Default    string `protobuf:"bytes,2,opt,name=default" json:"default,omitempty"`
-	HasDefault bool   `protobuf:"varint,3,opt,name=has_default,json=hasDefault" json:"has_default,omitempty"`
+	Required   bool     `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
+	Default    string   `protobuf:"bytes,2,opt,name=default" json:"default,omitempty"`
+	HasDefault bool     `protobuf:"varint,3,opt,name=has_default,json=hasDefault" json:"has_default,omitempty"`
+	Allowed    []string `protobuf:"bytes,4,rep,name=allowed" json:"allowed,omitempty"`
+	Pattern    string   `protobuf:"bytes,5,opt,name=pattern" json:"pattern,omitempty"`
 }
 
 func (m *StringRule) Reset()                    { *m = StringRule{} }
 func (m *StringRule) String() string            { return proto.CompactTextString(m) }
 func (*StringRule) ProtoMessage()               {}
-func (*StringRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*StringRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 type StringPolicy struct {
 	Rules map[string]*StringRule `protobuf:"bytes,1,rep,name=rules" json:"rules,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
@@ -626,7 +729,7 @@ type StringPolicy struct {
 func (m *StringPolicy) Reset()                    { *m = StringPolicy{} }
 func (m *StringPolicy) String() string            { return proto.CompactTextString(m) }
 func (*StringPolicy) ProtoMessage()               {}
-func (*StringPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*StringPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *StringPolicy) GetRules() map[string]*StringRule {
 	if m != nil {
@@ -635,6 +738,147 @@ func (m *StringPolicy) GetRules() map[string]*StringRule {
 	return nil
 }
 
+type StringListRule struct {
+	Required     bool     `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
+	Default      []string `protobuf:"bytes,2,rep,name=default" json:"default,omitempty"`
+	HasDefault   bool     `protobuf:"varint,3,opt,name=has_default,json=hasDefault" json:"has_default,omitempty"`
+	MinLength    int64    `protobuf:"varint,4,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
+	MaxLength    int64    `protobuf:"varint,5,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
+	HasMinLength bool     `protobuf:"varint,6,opt,name=has_min_length,json=hasMinLength" json:"has_min_length,omitempty"`
+	HasMaxLength bool     `protobuf:"varint,7,opt,name=has_max_length,json=hasMaxLength" json:"has_max_length,omitempty"`
+	Allowed      []string `protobuf:"bytes,8,rep,name=allowed" json:"allowed,omitempty"`
+}
+
+func (m *StringListRule) Reset()                    { *m = StringListRule{} }
+func (m *StringListRule) String() string            { return proto.CompactTextString(m) }
+func (*StringListRule) ProtoMessage()               {}
+func (*StringListRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+type StringListPolicy struct {
+	Rules map[string]*StringListRule `protobuf:"bytes,1,rep,name=rules" json:"rules,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Key   []string                   `protobuf:"bytes,2,rep,name=key" json:"key,omitempty"`
+}
+
+func (m *StringListPolicy) Reset()                    { *m = StringListPolicy{} }
+func (m *StringListPolicy) String() string            { return proto.CompactTextString(m) }
+func (*StringListPolicy) ProtoMessage()               {}
+func (*StringListPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *StringListPolicy) GetRules() map[string]*StringListRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+type FloatListRule struct {
+	Required     bool      `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
+	Default      []float64 `protobuf:"fixed64,2,rep,packed,name=default" json:"default,omitempty"`
+	HasDefault   bool      `protobuf:"varint,3,opt,name=has_default,json=hasDefault" json:"has_default,omitempty"`
+	MinLength    int64     `protobuf:"varint,4,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
+	MaxLength    int64     `protobuf:"varint,5,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
+	HasMinLength bool      `protobuf:"varint,6,opt,name=has_min_length,json=hasMinLength" json:"has_min_length,omitempty"`
+	HasMaxLength bool      `protobuf:"varint,7,opt,name=has_max_length,json=hasMaxLength" json:"has_max_length,omitempty"`
+	Minimum      float64   `protobuf:"fixed64,8,opt,name=minimum" json:"minimum,omitempty"`
+	Maximum      float64   `protobuf:"fixed64,9,opt,name=maximum" json:"maximum,omitempty"`
+	HasMin       bool      `protobuf:"varint,10,opt,name=has_min,json=hasMin" json:"has_min,omitempty"`
+	HasMax       bool      `protobuf:"varint,11,opt,name=has_max,json=hasMax" json:"has_max,omitempty"`
+}
+
+func (m *FloatListRule) Reset()                    { *m = FloatListRule{} }
+func (m *FloatListRule) String() string            { return proto.CompactTextString(m) }
+func (*FloatListRule) ProtoMessage()               {}
+func (*FloatListRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+type FloatListPolicy struct {
+	Rules map[string]*FloatListRule `protobuf:"bytes,1,rep,name=rules" json:"rules,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Key   []string                  `protobuf:"bytes,2,rep,name=key" json:"key,omitempty"`
+}
+
+func (m *FloatListPolicy) Reset()                    { *m = FloatListPolicy{} }
+func (m *FloatListPolicy) String() string            { return proto.CompactTextString(m) }
+func (*FloatListPolicy) ProtoMessage()               {}
+func (*FloatListPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *FloatListPolicy) GetRules() map[string]*FloatListRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+type MapRule struct {
+	Required     bool              `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
+	Default      map[string]string `protobuf:"bytes,2,rep,name=default" json:"default,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	HasDefault   bool              `protobuf:"varint,3,opt,name=has_default,json=hasDefault" json:"has_default,omitempty"`
+	MinLength    int64             `protobuf:"varint,4,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
+	MaxLength    int64             `protobuf:"varint,5,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
+	HasMinLength bool              `protobuf:"varint,6,opt,name=has_min_length,json=hasMinLength" json:"has_min_length,omitempty"`
+	HasMaxLength bool              `protobuf:"varint,7,opt,name=has_max_length,json=hasMaxLength" json:"has_max_length,omitempty"`
+	RequiredKeys []string          `protobuf:"bytes,8,rep,name=required_keys,json=requiredKeys" json:"required_keys,omitempty"`
+}
+
+func (m *MapRule) Reset()                    { *m = MapRule{} }
+func (m *MapRule) String() string            { return proto.CompactTextString(m) }
+func (*MapRule) ProtoMessage()               {}
+func (*MapRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *MapRule) GetDefault() map[string]string {
+	if m != nil {
+		return m.Default
+	}
+	return nil
+}
+
+type MapPolicy struct {
+	Rules map[string]*MapRule `protobuf:"bytes,1,rep,name=rules" json:"rules,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Key   []string            `protobuf:"bytes,2,rep,name=key" json:"key,omitempty"`
+}
+
+func (m *MapPolicy) Reset()                    { *m = MapPolicy{} }
+func (m *MapPolicy) String() string            { return proto.CompactTextString(m) }
+func (*MapPolicy) ProtoMessage()               {}
+func (*MapPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *MapPolicy) GetRules() map[string]*MapRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+type DurationRule struct {
+	Required   bool  `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
+	Default    int64 `protobuf:"varint,2,opt,name=default" json:"default,omitempty"`
+	HasDefault bool  `protobuf:"varint,3,opt,name=has_default,json=hasDefault" json:"has_default,omitempty"`
+	Minimum    int64 `protobuf:"varint,4,opt,name=minimum" json:"minimum,omitempty"`
+	Maximum    int64 `protobuf:"varint,5,opt,name=maximum" json:"maximum,omitempty"`
+	HasMin     bool  `protobuf:"varint,6,opt,name=has_min,json=hasMin" json:"has_min,omitempty"`
+	HasMax     bool  `protobuf:"varint,7,opt,name=has_max,json=hasMax" json:"has_max,omitempty"`
+}
+
+func (m *DurationRule) Reset()                    { *m = DurationRule{} }
+func (m *DurationRule) String() string            { return proto.CompactTextString(m) }
+func (*DurationRule) ProtoMessage()               {}
+func (*DurationRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+type DurationPolicy struct {
+	Rules map[string]*DurationRule `protobuf:"bytes,1,rep,name=rules" json:"rules,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Key   []string                 `protobuf:"bytes,2,rep,name=key" json:"key,omitempty"`
+}
+
+func (m *DurationPolicy) Reset()                    { *m = DurationPolicy{} }
+func (m *DurationPolicy) String() string            { return proto.CompactTextString(m) }
+func (*DurationPolicy) ProtoMessage()               {}
+func (*DurationPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *DurationPolicy) GetRules() map[string]*DurationRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
 type MetricsArg struct {
 	Metrics []*Metric `protobuf:"bytes,1,rep,name=metrics" json:"metrics,omitempty"`
 }
@@ -642,7 +886,7 @@ type MetricsArg struct {
 func (m *MetricsArg) Reset()                    { *m = MetricsArg{} }
 func (m *MetricsArg) String() string            { return proto.CompactTextString(m) }
 func (*MetricsArg) ProtoMessage()               {}
-func (*MetricsArg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*MetricsArg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 func (m *MetricsArg) GetMetrics() []*Metric {
 	if m != nil {
@@ -659,7 +903,7 @@ type MetricsReply struct {
 func (m *MetricsReply) Reset()                    { *m = MetricsReply{} }
 func (m *MetricsReply) String() string            { return proto.CompactTextString(m) }
 func (*MetricsReply) ProtoMessage()               {}
-func (*MetricsReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*MetricsReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
 func (m *MetricsReply) GetMetrics() []*Metric {
 	if m != nil {
@@ -675,7 +919,7 @@ type GetMetricTypesArg struct {
 func (m *GetMetricTypesArg) Reset()                    { *m = GetMetricTypesArg{} }
 func (m *GetMetricTypesArg) String() string            { return proto.CompactTextString(m) }
 func (*GetMetricTypesArg) ProtoMessage()               {}
-func (*GetMetricTypesArg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*GetMetricTypesArg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 func (m *GetMetricTypesArg) GetConfig() *ConfigMap {
 	if m != nil {
@@ -692,6 +936,9 @@ func init() {
 	proto.RegisterType((*PubProcArg)(nil), "rpc.PubProcArg")
 	proto.RegisterType((*Metric)(nil), "rpc.Metric")
 	proto.RegisterType((*ConfigMap)(nil), "rpc.ConfigMap")
+	proto.RegisterType((*StringList)(nil), "rpc.StringList")
+	proto.RegisterType((*FloatList)(nil), "rpc.FloatList")
+	proto.RegisterType((*StringMap)(nil), "rpc.StringMap")
 	proto.RegisterType((*KillArg)(nil), "rpc.KillArg")
 	proto.RegisterType((*GetConfigPolicyReply)(nil), "rpc.GetConfigPolicyReply")
 	proto.RegisterType((*BoolRule)(nil), "rpc.BoolRule")
@@ -702,6 +949,14 @@ func init() {
 	proto.RegisterType((*IntegerPolicy)(nil), "rpc.IntegerPolicy")
 	proto.RegisterType((*StringRule)(nil), "rpc.StringRule")
 	proto.RegisterType((*StringPolicy)(nil), "rpc.StringPolicy")
+	proto.RegisterType((*StringListRule)(nil), "rpc.StringListRule")
+	proto.RegisterType((*StringListPolicy)(nil), "rpc.StringListPolicy")
+	proto.RegisterType((*FloatListRule)(nil), "rpc.FloatListRule")
+	proto.RegisterType((*FloatListPolicy)(nil), "rpc.FloatListPolicy")
+	proto.RegisterType((*MapRule)(nil), "rpc.MapRule")
+	proto.RegisterType((*MapPolicy)(nil), "rpc.MapPolicy")
+	proto.RegisterType((*DurationRule)(nil), "rpc.DurationRule")
+	proto.RegisterType((*DurationPolicy)(nil), "rpc.DurationPolicy")
 	proto.RegisterType((*MetricsArg)(nil), "rpc.MetricsArg")
 	proto.RegisterType((*MetricsReply)(nil), "rpc.MetricsReply")
 	proto.RegisterType((*GetMetricTypesArg)(nil), "rpc.GetMetricTypesArg")
@@ -1242,90 +1497,128 @@ func init() {
 }
 
 var fileDescriptor0 = []byte{
-	// 1356 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xcc, 0x58, 0xdb, 0x6e, 0x1b, 0x45,
-	0x18, 0xf6, 0x66, 0x7d, 0xda, 0x7f, 0x1d, 0x27, 0x19, 0x95, 0x62, 0xdc, 0x56, 0x75, 0xb7, 0xb4,
-	0x75, 0x69, 0x71, 0xc0, 0x29, 0xa5, 0x4d, 0xe1, 0xa2, 0xa5, 0xa1, 0x69, 0x4b, 0x2a, 0x6b, 0x1b,
-	0x7a, 0x83, 0x44, 0xb5, 0x5e, 0x4f, 0x9c, 0x11, 0x7b, 0x62, 0x76, 0x5c, 0xc5, 0xaf, 0xc0, 0x1b,
-	0x20, 0x21, 0x21, 0xf1, 0x18, 0x5c, 0x71, 0xc1, 0x05, 0xe2, 0x25, 0x78, 0x09, 0x1e, 0x00, 0xcd,
-	0x61, 0xed, 0x59, 0x1f, 0xea, 0xe4, 0x02, 0xa9, 0x77, 0xfb, 0x1f, 0xbe, 0x6f, 0xe6, 0xff, 0xe6,
-	0x9f, 0x19, 0x8f, 0x61, 0x77, 0x48, 0xd8, 0xf1, 0xa8, 0xdf, 0xf1, 0xe3, 0x70, 0x9b, 0x44, 0x0c,
-	0x07, 0xe9, 0x80, 0x7c, 0x7c, 0xb2, 0x9d, 0x46, 0x5e, 0xb2, 0xed, 0xc7, 0x11, 0xa3, 0x71, 0xb0,
-	0x9d, 0x04, 0xa3, 0x21, 0x89, 0xb6, 0x69, 0xe2, 0xab, 0xcf, 0x4e, 0x42, 0x63, 0x16, 0x23, 0x93,
-	0x26, 0xbe, 0x53, 0x81, 0xd2, 0x5e, 0x98, 0xb0, 0xb1, 0xd3, 0x82, 0xea, 0x1e, 0xa5, 0x2e, 0x4e,
-	0x82, 0x31, 0x3a, 0x07, 0x25, 0x4c, 0x69, 0x4c, 0x1b, 0x46, 0xcb, 0x68, 0x5b, 0xae, 0x34, 0x9c,
-	0xdb, 0x50, 0x3c, 0x24, 0x21, 0x46, 0x9b, 0x60, 0xa6, 0xd8, 0x17, 0x31, 0xd3, 0xe5, 0x9f, 0x08,
-	0x41, 0x31, 0xe2, 0xae, 0x35, 0xe1, 0x12, 0xdf, 0xce, 0xf7, 0xb0, 0xf9, 0xc2, 0x0b, 0x71, 0x9a,
-	0x78, 0x3e, 0xde, 0x0b, 0x70, 0x88, 0x23, 0xc6, 0x79, 0x5f, 0x79, 0xc1, 0x08, 0x67, 0xbc, 0x6f,
-	0xb8, 0x81, 0x5a, 0x60, 0x3f, 0xc6, 0xa9, 0x4f, 0x49, 0xc2, 0x48, 0x1c, 0x09, 0x12, 0xcb, 0xb5,
-	0x07, 0x53, 0x17, 0xe7, 0xe7, 0x5c, 0x0d, 0x53, 0x84, 0x8a, 0x91, 0x17, 0x62, 0xe7, 0x3b, 0x80,
-	0xde, 0xa8, 0xdf, 0xa3, 0xb1, 0xff, 0x90, 0x0e, 0xd1, 0x35, 0xa8, 0x1c, 0x60, 0x46, 0x89, 0x9f,
-	0x36, 0x8c, 0x96, 0xd9, 0xb6, 0xbb, 0x76, 0x87, 0x26, 0x7e, 0x47, 0xfa, 0xdc, 0x4a, 0x28, 0x63,
-	0xe8, 0x3a, 0x94, 0xbf, 0x8a, 0xa3, 0x23, 0x32, 0x14, 0xa3, 0xd8, 0xdd, 0xba, 0xc8, 0x92, 0xae,
-	0x03, 0x2f, 0x71, 0xcb, 0xbe, 0xf8, 0x74, 0xfe, 0x2d, 0x42, 0x59, 0x62, 0xd1, 0x0e, 0x58, 0x93,
-	0x3a, 0x14, 0xf7, 0x7b, 0x02, 0x35, 0x5b, 0x9d, 0x6b, 0x45, 0x99, 0x07, 0x35, 0xa0, 0xf2, 0x0a,
-	0xd3, 0x34, 0x2b, 0xc7, 0x74, 0x2b, 0x6f, 0xa4, 0xa9, 0xcd, 0xc0, 0x7c, 0xdb, 0x0c, 0xd0, 0x7d,
-	0x40, 0xdf, 0x78, 0x29, 0x7b, 0x38, 0x78, 0x83, 0x29, 0x23, 0x29, 0x1e, 0x70, 0xe9, 0x1b, 0x45,
-	0x81, 0xb1, 0x04, 0x86, 0x3b, 0x5c, 0x14, 0xcc, 0x25, 0xa1, 0x9b, 0x50, 0x3c, 0xf4, 0x86, 0x69,
-	0xa3, 0xa4, 0x4d, 0x56, 0x16, 0xd3, 0xe1, 0xfe, 0xbd, 0x88, 0xd1, 0xb1, 0x5b, 0x64, 0xde, 0x30,
-	0x45, 0x37, 0xc0, 0xe2, 0x90, 0x94, 0x79, 0x61, 0xd2, 0x28, 0xcf, 0x92, 0x5b, 0x2c, 0x8b, 0xf1,
-	0x15, 0xf8, 0x36, 0x22, 0xac, 0x51, 0x91, 0x2b, 0x30, 0x8a, 0x08, 0x9b, 0x5d, 0xb7, 0xea, 0xfc,
-	0xba, 0x5d, 0x01, 0x3b, 0x65, 0x94, 0x44, 0xc3, 0xd7, 0x03, 0x8f, 0x79, 0x0d, 0x8b, 0x67, 0xec,
-	0x17, 0x5c, 0x90, 0xce, 0xc7, 0x1e, 0xf3, 0xd0, 0x55, 0xa8, 0x1d, 0x05, 0xb1, 0xc7, 0x76, 0xba,
-	0x32, 0x07, 0x5a, 0x46, 0x7b, 0x6d, 0xbf, 0xe0, 0xda, 0xca, 0x9b, 0x4b, 0xba, 0x7b, 0x47, 0x26,
-	0xd9, 0x2d, 0xa3, 0x6d, 0x4c, 0x92, 0xee, 0xde, 0x11, 0x49, 0x97, 0x01, 0x48, 0x34, 0xe1, 0xa9,
-	0xb5, 0x8c, 0x76, 0x69, 0xbf, 0xe0, 0x5a, 0xc2, 0xa7, 0x25, 0x64, 0x1c, 0xeb, 0x7c, 0x5d, 0x54,
-	0xc2, 0x94, 0xa1, 0x3f, 0x66, 0x38, 0x95, 0x09, 0xf5, 0x96, 0xd1, 0xae, 0xf1, 0x04, 0xe1, 0x13,
-	0x09, 0x97, 0xc0, 0xea, 0xc7, 0x71, 0x20, 0xe3, 0x1b, 0x2d, 0xa3, 0x5d, 0xdd, 0x2f, 0xb8, 0x55,
-	0xee, 0xe2, 0xe1, 0xe6, 0xe7, 0x60, 0x4d, 0x04, 0xe6, 0xbb, 0xe4, 0x07, 0x3c, 0x56, 0x9d, 0xce,
-	0x3f, 0x79, 0xf7, 0x8b, 0x86, 0x57, 0x1d, 0x2e, 0x8d, 0xdd, 0xb5, 0x7b, 0xc6, 0xa3, 0x32, 0x14,
-	0x39, 0xa5, 0xf3, 0x8f, 0x09, 0xd6, 0xa4, 0x15, 0x50, 0x17, 0xca, 0x4f, 0x23, 0x76, 0xe0, 0x25,
-	0xaa, 0xed, 0x9a, 0xf9, 0x56, 0xe9, 0xc8, 0xa0, 0x5c, 0xce, 0x32, 0x11, 0x06, 0x7a, 0x00, 0xd6,
-	0x4b, 0x21, 0x2e, 0x87, 0xad, 0x09, 0xd8, 0xa5, 0x19, 0xd8, 0x24, 0x2e, 0x91, 0x56, 0x9a, 0xd9,
-	0xe8, 0x1e, 0x54, 0xbf, 0xe6, 0x82, 0x72, 0xac, 0x29, 0xb0, 0x17, 0x67, 0xb0, 0x59, 0x58, 0x42,
-	0xab, 0x47, 0xca, 0x44, 0x9f, 0x41, 0xe5, 0x51, 0x1c, 0x07, 0x1c, 0x58, 0x14, 0xc0, 0x0b, 0x33,
-	0x40, 0x15, 0x95, 0xb8, 0x4a, 0x5f, 0x5a, 0xcd, 0xfb, 0x60, 0x6b, 0x45, 0xac, 0x92, 0xcc, 0xd4,
-	0x24, 0x6b, 0x7e, 0x01, 0xf5, 0x7c, 0x21, 0x67, 0x11, 0xbc, 0xf9, 0x00, 0xd6, 0x73, 0xa5, 0xac,
-	0x02, 0x1b, 0x3a, 0x78, 0x17, 0x6a, 0x7a, 0x39, 0xab, 0xb0, 0x55, 0x0d, 0xeb, 0x5c, 0x81, 0xca,
-	0x73, 0x12, 0x04, 0xfc, 0xc8, 0x3a, 0x0f, 0x65, 0x17, 0x7b, 0x69, 0x1c, 0x29, 0x64, 0x99, 0x0a,
-	0xcb, 0xf9, 0xbd, 0x04, 0xe7, 0x9e, 0x60, 0x26, 0xb5, 0xeb, 0xc5, 0x01, 0xf1, 0xc7, 0x6f, 0x39,
-	0x95, 0xd1, 0x33, 0xb0, 0x45, 0x4f, 0x26, 0x22, 0x53, 0xad, 0xf9, 0x4d, 0x21, 0xff, 0x22, 0x16,
-	0xb1, 0x12, 0xd2, 0x96, 0x8b, 0x01, 0xfd, 0x89, 0x03, 0x1d, 0xa8, 0x7d, 0x96, 0x91, 0xc9, 0x26,
-	0xf8, 0x68, 0x39, 0x99, 0x10, 0x51, 0x67, 0x93, 0x3b, 0x52, 0xd1, 0xbd, 0x84, 0x3a, 0xbf, 0x93,
-	0x86, 0x98, 0x66, 0x84, 0xb2, 0x39, 0x6e, 0x2f, 0x27, 0x7c, 0x2a, 0xf3, 0x75, 0xca, 0x75, 0xa2,
-	0xfb, 0x50, 0x0f, 0xd6, 0xd5, 0x99, 0xa2, 0x38, 0xe5, 0x31, 0x77, 0x6b, 0x39, 0xa7, 0xec, 0x13,
-	0x9d, 0xb2, 0x96, 0x6a, 0xae, 0xe6, 0x0b, 0xd8, 0x98, 0x11, 0x65, 0xc1, 0x92, 0x5e, 0xd3, 0x97,
-	0xd4, 0xee, 0x6e, 0x88, 0xe1, 0xa6, 0x30, 0xbd, 0x3f, 0x7a, 0xb0, 0x39, 0xab, 0xcb, 0x02, 0xc2,
-	0xeb, 0x79, 0xc2, 0x4d, 0x41, 0xa8, 0xe1, 0x74, 0xc6, 0x43, 0x40, 0xf3, 0xc2, 0x2c, 0xe0, 0x6c,
-	0xe7, 0x39, 0x91, 0xe0, 0xcc, 0x21, 0x75, 0x56, 0x17, 0xb6, 0xe6, 0xa4, 0x59, 0x40, 0x7a, 0x23,
-	0x4f, 0xba, 0x25, 0x48, 0x75, 0xa0, 0xde, 0xdf, 0x1e, 0x54, 0xb9, 0x28, 0xee, 0x28, 0xc0, 0xa8,
-	0x09, 0x55, 0x8a, 0x7f, 0x1c, 0x11, 0x8a, 0x07, 0x82, 0xaf, 0xea, 0x4e, 0x6c, 0x7e, 0x41, 0x0e,
-	0xf0, 0x91, 0x37, 0x0a, 0x98, 0xda, 0x23, 0x99, 0x89, 0x2e, 0x83, 0x7d, 0xec, 0xa5, 0xaf, 0xb3,
-	0xa8, 0x29, 0xa2, 0x70, 0xec, 0xa5, 0x8f, 0xa5, 0xc7, 0xf9, 0xd9, 0x00, 0x98, 0x0a, 0x8f, 0x3e,
-	0x81, 0x12, 0x1d, 0x05, 0x38, 0xcd, 0x1d, 0x92, 0xd3, 0x78, 0x87, 0x4f, 0x45, 0xdd, 0x79, 0x32,
-	0x31, 0x2b, 0x91, 0xef, 0x14, 0x59, 0x62, 0xf3, 0x09, 0xc0, 0x34, 0x6d, 0x81, 0x04, 0x57, 0xf3,
-	0x12, 0xac, 0x4f, 0xc6, 0xe0, 0x28, 0xbd, 0xfc, 0xbf, 0x0c, 0xb0, 0xc4, 0x1a, 0x9e, 0x46, 0x80,
-	0x90, 0x44, 0x24, 0x1c, 0x85, 0xea, 0x80, 0xc9, 0x4c, 0x11, 0xf1, 0x4e, 0x44, 0xc4, 0x54, 0x11,
-	0x69, 0xea, 0xa2, 0x15, 0x65, 0x64, 0x89, 0x68, 0xa5, 0x59, 0xd1, 0xd0, 0xfb, 0x50, 0xe1, 0x09,
-	0x21, 0x89, 0xc4, 0x35, 0x5f, 0x75, 0xcb, 0xc7, 0x5e, 0x7a, 0x40, 0xa2, 0x49, 0xc0, 0x3b, 0x11,
-	0x77, 0xbb, 0x0a, 0x78, 0x27, 0xce, 0x2f, 0x06, 0xd8, 0x5a, 0x3b, 0xa2, 0x4f, 0xf3, 0x3a, 0x5f,
-	0x98, 0xed, 0xd7, 0x53, 0x09, 0xbd, 0xbf, 0x42, 0xe8, 0x0f, 0xf3, 0x42, 0xd7, 0xa7, 0x83, 0xcc,
-	0x2a, 0xfd, 0xb7, 0x21, 0xee, 0x0e, 0xde, 0xd9, 0x67, 0xd5, 0xda, 0x5c, 0xaa, 0xb5, 0xb9, 0x54,
-	0x6b, 0xf3, 0x7f, 0xd5, 0xfa, 0x37, 0x03, 0xd6, 0x73, 0xdb, 0x14, 0xed, 0xe4, 0xd5, 0xbe, 0x34,
-	0xbf, 0x93, 0x4f, 0xa5, 0xf7, 0xb3, 0x15, 0x7a, 0x2f, 0x3c, 0x84, 0x34, 0x59, 0x75, 0xc5, 0x7d,
-	0x00, 0xb9, 0xeb, 0xcf, 0xba, 0xb9, 0xad, 0x33, 0x6c, 0xee, 0x5f, 0x0d, 0xa8, 0xe9, 0x67, 0x0b,
-	0xea, 0xe6, 0x85, 0xb8, 0x38, 0x77, 0xfa, 0x9c, 0x4a, 0x87, 0xa7, 0x2b, 0x74, 0x58, 0x78, 0xba,
-	0x4f, 0xab, 0xd5, 0x65, 0xd8, 0x01, 0x50, 0x2f, 0x0d, 0xf5, 0xee, 0x08, 0x57, 0xbf, 0x3b, 0x9c,
-	0xe7, 0x50, 0x53, 0x20, 0x79, 0x95, 0x9f, 0x0e, 0x36, 0xbd, 0xf1, 0xd7, 0xf4, 0x77, 0xd8, 0x03,
-	0xd8, 0x7a, 0x82, 0x99, 0xcc, 0x3d, 0x1c, 0x27, 0x58, 0x4c, 0xe4, 0x3a, 0xa8, 0x97, 0x83, 0x28,
-	0x6b, 0xe9, 0xbb, 0xa2, 0xfb, 0xd3, 0x1a, 0xff, 0x89, 0x19, 0x04, 0xd8, 0x67, 0x31, 0x45, 0x77,
-	0xa1, 0xae, 0x0c, 0x35, 0x3d, 0xb4, 0xa1, 0x4d, 0x84, 0x13, 0x37, 0xb7, 0x74, 0x87, 0x98, 0xbd,
-	0x53, 0x40, 0x5f, 0x42, 0x3d, 0x3f, 0x05, 0x74, 0x3e, 0xbb, 0x7f, 0xf3, 0xf3, 0x5a, 0x0c, 0xbf,
-	0x0a, 0xc5, 0x1e, 0x89, 0x86, 0x08, 0x44, 0x50, 0xbc, 0x3f, 0x9b, 0xf2, 0x50, 0xcd, 0x9e, 0xa0,
-	0x4e, 0x01, 0x5d, 0x83, 0x22, 0xff, 0xa9, 0x84, 0x6a, 0x22, 0xa0, 0x7e, 0x35, 0xcd, 0xa7, 0xed,
-	0xc2, 0xc6, 0xcc, 0xad, 0x9f, 0xa3, 0xfd, 0x60, 0xe9, 0xef, 0x02, 0xa7, 0xd0, 0xfd, 0xd3, 0x00,
-	0x8b, 0xbf, 0x20, 0x71, 0x9a, 0xc6, 0x14, 0x6d, 0x43, 0x45, 0x19, 0x4a, 0x85, 0xe9, 0xfb, 0xf2,
-	0xdd, 0x2e, 0xe3, 0x0f, 0x5e, 0xc6, 0xa8, 0x1f, 0x90, 0xf4, 0x18, 0x53, 0x74, 0x0b, 0x2a, 0xca,
-	0x98, 0x2f, 0x63, 0x6e, 0xd8, 0x77, 0xa4, 0x84, 0x7e, 0x59, 0xfc, 0x25, 0xb1, 0xf3, 0x5f, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x51, 0xb5, 0x89, 0x63, 0xd0, 0x10, 0x00, 0x00,
+	// 1968 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x59, 0x5f, 0x6f, 0x1b, 0x59,
+	0x15, 0xcf, 0xd8, 0x8e, 0xed, 0x39, 0xe3, 0x38, 0xc9, 0xdd, 0xb6, 0xb8, 0xde, 0x2d, 0x75, 0x27,
+	0x6d, 0xd7, 0xdd, 0xee, 0x3a, 0xe0, 0x74, 0xcb, 0x6e, 0x0b, 0x0f, 0xbb, 0xa4, 0x9b, 0x74, 0x9b,
+	0x2c, 0x61, 0xb6, 0x54, 0x68, 0x11, 0x54, 0x37, 0xf6, 0x8d, 0x33, 0xda, 0xf9, 0xc7, 0xcc, 0xb8,
+	0xc4, 0x7c, 0x04, 0x78, 0x07, 0x21, 0x21, 0x21, 0x21, 0x21, 0x81, 0xf8, 0x12, 0x3c, 0xf0, 0x80,
+	0xe0, 0x9d, 0x8f, 0xc0, 0x07, 0x40, 0x7c, 0x00, 0x74, 0xff, 0x8d, 0xef, 0x1d, 0x8f, 0x63, 0x1b,
+	0x09, 0x69, 0xfb, 0xe6, 0xf3, 0xef, 0xe7, 0x7b, 0x7f, 0xe7, 0xcc, 0xb9, 0x67, 0xe6, 0xc2, 0xa3,
+	0x91, 0x9b, 0x9e, 0x8f, 0x4f, 0x7b, 0x83, 0xd0, 0xdf, 0x75, 0x83, 0x94, 0x78, 0xc9, 0xd0, 0x7d,
+	0xef, 0x62, 0x37, 0x09, 0x70, 0xb4, 0x3b, 0x08, 0x83, 0x34, 0x0e, 0xbd, 0xdd, 0xc8, 0x1b, 0x8f,
+	0xdc, 0x60, 0x37, 0x8e, 0x06, 0xe2, 0x67, 0x2f, 0x8a, 0xc3, 0x34, 0x44, 0xe5, 0x38, 0x1a, 0xd8,
+	0x35, 0x58, 0x7f, 0xe2, 0x47, 0xe9, 0xc4, 0xee, 0x40, 0xfd, 0x49, 0x1c, 0x3b, 0x24, 0xf2, 0x26,
+	0xe8, 0x0a, 0xac, 0x93, 0x38, 0x0e, 0xe3, 0x96, 0xd1, 0x31, 0xba, 0xa6, 0xc3, 0x05, 0xfb, 0x5d,
+	0xa8, 0x3c, 0x77, 0x7d, 0x82, 0xb6, 0xa0, 0x9c, 0x90, 0x01, 0xb3, 0x95, 0x1d, 0xfa, 0x13, 0x21,
+	0xa8, 0x04, 0x54, 0x55, 0x62, 0x2a, 0xf6, 0xdb, 0xfe, 0x09, 0x6c, 0x7d, 0x86, 0x7d, 0x92, 0x44,
+	0x78, 0x40, 0x9e, 0x78, 0xc4, 0x27, 0x41, 0x4a, 0x71, 0x5f, 0x60, 0x6f, 0x4c, 0x24, 0xee, 0x2b,
+	0x2a, 0xa0, 0x0e, 0x58, 0xfb, 0x24, 0x19, 0xc4, 0x6e, 0x94, 0xba, 0x61, 0xc0, 0x40, 0x4c, 0xc7,
+	0x1a, 0x4e, 0x55, 0x14, 0x9f, 0x62, 0xb5, 0xca, 0xcc, 0x54, 0x09, 0xb0, 0x4f, 0xec, 0x1f, 0x01,
+	0x9c, 0x8c, 0x4f, 0x4f, 0xe2, 0x70, 0xf0, 0x51, 0x3c, 0x42, 0x77, 0xa0, 0x76, 0x4c, 0xd2, 0xd8,
+	0x1d, 0x24, 0x2d, 0xa3, 0x53, 0xee, 0x5a, 0x7d, 0xab, 0x17, 0x47, 0x83, 0x1e, 0xd7, 0x39, 0x35,
+	0x9f, 0xdb, 0xd0, 0x5d, 0xa8, 0x7e, 0x37, 0x0c, 0xce, 0xdc, 0x11, 0xfb, 0x17, 0xab, 0xdf, 0x64,
+	0x5e, 0x5c, 0x75, 0x8c, 0x23, 0xa7, 0x3a, 0x60, 0x3f, 0xed, 0xff, 0x54, 0xa0, 0xca, 0x63, 0xd1,
+	0x1e, 0x98, 0xd9, 0x3e, 0x04, 0xf6, 0x55, 0x16, 0x95, 0xdf, 0x9d, 0x63, 0x06, 0x52, 0x83, 0x5a,
+	0x50, 0x7b, 0x41, 0xe2, 0x44, 0x6e, 0xa7, 0xec, 0xd4, 0x5e, 0x71, 0x51, 0x59, 0x41, 0xf9, 0xb2,
+	0x15, 0xa0, 0x0f, 0x01, 0x1d, 0xe1, 0x24, 0xfd, 0x68, 0xf8, 0x8a, 0xc4, 0xa9, 0x9b, 0x90, 0x21,
+	0xa5, 0xbe, 0x55, 0x61, 0x31, 0x26, 0x8b, 0xa1, 0x0a, 0x07, 0x79, 0x33, 0x4e, 0xe8, 0x1e, 0x54,
+	0x9e, 0xe3, 0x51, 0xd2, 0x5a, 0x57, 0x16, 0xcb, 0x37, 0xd3, 0xa3, 0xfa, 0x27, 0x41, 0x1a, 0x4f,
+	0x9c, 0x4a, 0x8a, 0x47, 0x09, 0x7a, 0x1b, 0x4c, 0x1a, 0x92, 0xa4, 0xd8, 0x8f, 0x5a, 0xd5, 0x3c,
+	0xb8, 0x99, 0x4a, 0x1b, 0xcd, 0xc0, 0x0f, 0x02, 0x37, 0x6d, 0xd5, 0x78, 0x06, 0xc6, 0x81, 0x9b,
+	0xe6, 0xf3, 0x56, 0x9f, 0xcd, 0xdb, 0x2d, 0xb0, 0x92, 0x34, 0x76, 0x83, 0xd1, 0xcb, 0x21, 0x4e,
+	0x71, 0xcb, 0xa4, 0x1e, 0x87, 0x6b, 0x0e, 0x70, 0xe5, 0x3e, 0x4e, 0x31, 0xda, 0x81, 0xc6, 0x99,
+	0x17, 0xe2, 0x74, 0xaf, 0xcf, 0x7d, 0xa0, 0x63, 0x74, 0x4b, 0x87, 0x6b, 0x8e, 0x25, 0xb4, 0x9a,
+	0xd3, 0xc3, 0x07, 0xdc, 0xc9, 0xea, 0x18, 0x5d, 0x23, 0x73, 0x7a, 0xf8, 0x80, 0x39, 0xdd, 0x04,
+	0x70, 0x83, 0x0c, 0xa7, 0xd1, 0x31, 0xba, 0xeb, 0x87, 0x6b, 0x8e, 0xc9, 0x74, 0x8a, 0x83, 0xc4,
+	0xd8, 0xa0, 0x79, 0x11, 0x0e, 0x53, 0x84, 0xd3, 0x49, 0x4a, 0x12, 0xee, 0xd0, 0xec, 0x18, 0xdd,
+	0x06, 0x75, 0x60, 0x3a, 0xe6, 0x70, 0x03, 0xcc, 0xd3, 0x30, 0xf4, 0xb8, 0x7d, 0xb3, 0x63, 0x74,
+	0xeb, 0x87, 0x6b, 0x4e, 0x9d, 0xaa, 0xa8, 0xb9, 0xfd, 0x2d, 0x30, 0x33, 0x82, 0xe9, 0x53, 0xf2,
+	0x25, 0x99, 0x88, 0x4a, 0xa7, 0x3f, 0x69, 0xf5, 0xb3, 0x82, 0x17, 0x15, 0xce, 0x85, 0x47, 0xa5,
+	0x0f, 0x8c, 0x8f, 0xab, 0x50, 0xa1, 0x90, 0xf6, 0x9f, 0x6a, 0x60, 0x66, 0xa5, 0x80, 0xfa, 0x50,
+	0x7d, 0x1a, 0xa4, 0xc7, 0x38, 0x12, 0x65, 0xd7, 0xd6, 0x4b, 0xa5, 0xc7, 0x8d, 0x3c, 0x9d, 0x55,
+	0x97, 0x09, 0xe8, 0x31, 0x98, 0x9f, 0x33, 0x72, 0x69, 0x58, 0x89, 0x85, 0xdd, 0xc8, 0x85, 0x65,
+	0x76, 0x1e, 0x69, 0x26, 0x52, 0x46, 0x1f, 0x40, 0xfd, 0x13, 0x4a, 0x28, 0x8d, 0x2d, 0xb3, 0xd8,
+	0xb7, 0x72, 0xb1, 0xd2, 0xcc, 0x43, 0xeb, 0x67, 0x42, 0x44, 0xef, 0x43, 0xed, 0xe3, 0x30, 0xf4,
+	0x68, 0x60, 0x85, 0x05, 0xbe, 0x99, 0x0b, 0x14, 0x56, 0x1e, 0x57, 0x3b, 0xe5, 0x12, 0x3a, 0x80,
+	0x0d, 0xbe, 0x9a, 0x23, 0x37, 0x61, 0xff, 0xca, 0x4b, 0xf6, 0x56, 0xe1, 0x8a, 0x85, 0x0f, 0x87,
+	0xd8, 0x48, 0x54, 0x1d, 0xda, 0x87, 0x06, 0x5b, 0x9a, 0xc4, 0xa9, 0x32, 0x9c, 0x4e, 0xd1, 0xea,
+	0x35, 0x98, 0xc6, 0x99, 0xa2, 0xa2, 0x28, 0x19, 0x39, 0x14, 0xa5, 0x56, 0x88, 0xa2, 0xba, 0x08,
+	0x94, 0x44, 0x51, 0xb5, 0x3f, 0x04, 0x4b, 0xc9, 0xcc, 0xa2, 0x3a, 0x28, 0x2b, 0x75, 0xd0, 0xfe,
+	0x36, 0x34, 0xf5, 0xec, 0xac, 0x52, 0x45, 0xed, 0xc7, 0xb0, 0xa1, 0xe5, 0x67, 0x51, 0xb0, 0xa1,
+	0x06, 0x3f, 0x82, 0x86, 0x9a, 0xa3, 0x45, 0xb1, 0x75, 0x35, 0xf6, 0xfb, 0x80, 0x66, 0x53, 0x54,
+	0x80, 0x70, 0x47, 0x45, 0xb0, 0xfa, 0x9b, 0x8c, 0xd8, 0x69, 0xa4, 0x0a, 0xf9, 0x3d, 0xd8, 0x9e,
+	0xc9, 0x56, 0x01, 0xe2, 0x6d, 0x1d, 0x91, 0x37, 0xd3, 0x2c, 0x30, 0x07, 0x38, 0x93, 0xb8, 0x65,
+	0x01, 0xb3, 0x40, 0x05, 0xd0, 0xbe, 0x0d, 0x30, 0x5d, 0x3a, 0xba, 0x06, 0x55, 0x66, 0xe2, 0xc7,
+	0x8f, 0xe9, 0x08, 0xc9, 0xde, 0x01, 0x33, 0x5b, 0x4e, 0xce, 0xc9, 0xc8, 0x9c, 0x7e, 0xae, 0x3c,
+	0xb4, 0xf4, 0xa9, 0x57, 0x9c, 0xe4, 0x53, 0x9f, 0xd9, 0x7b, 0xec, 0x00, 0x15, 0x4d, 0x5c, 0x78,
+	0xd2, 0x92, 0x53, 0xd4, 0xab, 0x14, 0x8d, 0x7d, 0x0b, 0x6a, 0xcf, 0x5c, 0xcf, 0xa3, 0x67, 0xe8,
+	0x35, 0xa8, 0x3a, 0x04, 0x27, 0x61, 0x20, 0x22, 0xab, 0x31, 0x93, 0xec, 0x7f, 0x03, 0x5c, 0x39,
+	0x20, 0x29, 0x7f, 0x02, 0x4e, 0x42, 0xcf, 0x1d, 0x4c, 0x2e, 0x19, 0x13, 0xd0, 0xa7, 0x60, 0xb1,
+	0x26, 0x19, 0x31, 0x4f, 0xd1, 0x84, 0xee, 0xb1, 0x5d, 0x14, 0xa1, 0xb0, 0xd6, 0xc0, 0x65, 0xbe,
+	0x29, 0x38, 0xcd, 0x14, 0xe8, 0x58, 0x34, 0x7e, 0x09, 0xc6, 0xbb, 0xd2, 0x3b, 0xf3, 0xc1, 0x18,
+	0xd9, 0x2a, 0x9a, 0x75, 0x36, 0xd5, 0xa0, 0xcf, 0xa1, 0x49, 0x87, 0xa4, 0x11, 0x89, 0x25, 0x20,
+	0xef, 0x56, 0xef, 0xce, 0x07, 0x7c, 0xca, 0xfd, 0x55, 0xc8, 0x0d, 0x57, 0xd5, 0xa1, 0x13, 0x10,
+	0xcd, 0x48, 0x62, 0xf2, 0x26, 0x76, 0x7f, 0x3e, 0x26, 0x4f, 0xa6, 0x0a, 0xd9, 0x48, 0x14, 0x15,
+	0xfa, 0x31, 0x20, 0x81, 0xe8, 0xb9, 0x49, 0xb6, 0x77, 0xde, 0xd3, 0x76, 0x17, 0xc1, 0xd2, 0x4a,
+	0x53, 0xa1, 0xb7, 0x92, 0x9c, 0x1a, 0x7d, 0x01, 0xdb, 0x9c, 0x54, 0x15, 0x9d, 0xf7, 0xba, 0xde,
+	0x02, 0x66, 0xf3, 0xe0, 0x9b, 0x67, 0xba, 0x16, 0x1d, 0x00, 0xf8, 0x38, 0x92, 0xa0, 0x75, 0x06,
+	0xda, 0x9d, 0x0f, 0x7a, 0x8c, 0x23, 0x15, 0xce, 0xf4, 0xa5, 0x8c, 0x5e, 0xc0, 0xe6, 0x70, 0x1c,
+	0x63, 0x3a, 0x46, 0x48, 0x34, 0x93, 0xa1, 0xbd, 0x37, 0x1f, 0x6d, 0x5f, 0x04, 0xa8, 0x90, 0xcd,
+	0xa1, 0xa6, 0x6c, 0x7f, 0x06, 0x9b, 0xb9, 0x82, 0x5b, 0xb6, 0x51, 0x4d, 0xc3, 0xd4, 0xbe, 0x72,
+	0x02, 0x5b, 0xf9, 0x9a, 0x2b, 0x00, 0xbc, 0xab, 0x03, 0x6e, 0x4d, 0xfb, 0xd4, 0x2c, 0xe2, 0x73,
+	0x40, 0xb3, 0x45, 0x57, 0x80, 0xd9, 0xd5, 0x31, 0x11, 0xc3, 0xd4, 0x22, 0x55, 0x54, 0x47, 0xf6,
+	0xbf, 0xcb, 0x41, 0xdf, 0xd6, 0x41, 0xb7, 0x95, 0xe6, 0x33, 0x8b, 0xf9, 0x05, 0x5c, 0x2d, 0xac,
+	0xb9, 0x02, 0xdc, 0xfb, 0x3a, 0xee, 0xd5, 0x5c, 0xeb, 0x9f, 0xc5, 0xfe, 0x21, 0x5c, 0x29, 0xaa,
+	0xb8, 0x02, 0xe8, 0x77, 0x74, 0xe8, 0x2b, 0xfa, 0x19, 0x30, 0x8b, 0x7c, 0x04, 0x4d, 0xbd, 0xec,
+	0x96, 0x3d, 0x06, 0xb2, 0x28, 0x15, 0xed, 0x05, 0xbc, 0x51, 0x50, 0x76, 0x05, 0x90, 0xf7, 0x74,
+	0xc8, 0x37, 0x18, 0xa4, 0x1e, 0xaa, 0xf6, 0x65, 0x0c, 0x75, 0x5a, 0x70, 0xce, 0xd8, 0x23, 0xa8,
+	0x0d, 0xf5, 0x98, 0xfc, 0x74, 0xec, 0xc6, 0x64, 0xc8, 0x10, 0xeb, 0x4e, 0x26, 0xd3, 0x37, 0x8d,
+	0x21, 0x39, 0xc3, 0x63, 0x2f, 0x15, 0xe7, 0xb2, 0x14, 0xd1, 0x4d, 0xb0, 0xce, 0x71, 0xf2, 0x52,
+	0x5a, 0xcb, 0xcc, 0x0a, 0xe7, 0x38, 0xd9, 0xe7, 0x1a, 0xfb, 0x37, 0x06, 0xc0, 0xb4, 0xa8, 0xd1,
+	0x37, 0x60, 0x3d, 0x1e, 0x7b, 0xb9, 0x73, 0x67, 0x6a, 0xef, 0xd1, 0xa5, 0x88, 0x73, 0x87, 0x3b,
+	0xca, 0x4d, 0x96, 0xd8, 0x89, 0x47, 0x7f, 0xb6, 0x0f, 0x00, 0xa6, 0x6e, 0x05, 0x24, 0xec, 0xe8,
+	0x24, 0x6c, 0x64, 0xff, 0x41, 0xa3, 0xd4, 0xed, 0xff, 0xcd, 0x10, 0x07, 0xe7, 0x32, 0x04, 0xf8,
+	0x6e, 0xe0, 0xfa, 0x63, 0x5f, 0x0c, 0x35, 0x52, 0x64, 0x16, 0x7c, 0xc1, 0x2c, 0x65, 0x61, 0xc1,
+	0x17, 0xd2, 0x22, 0x69, 0xa9, 0x70, 0xcb, 0x1c, 0xd2, 0xd6, 0xf3, 0xa4, 0xa1, 0xaf, 0x41, 0x8d,
+	0x3a, 0xf8, 0x6e, 0xc0, 0xde, 0x97, 0xea, 0x4e, 0xf5, 0x1c, 0x27, 0xc7, 0x6e, 0x90, 0x19, 0xf0,
+	0x45, 0xab, 0x36, 0x35, 0xe0, 0x0b, 0xfb, 0xb7, 0x06, 0x58, 0xca, 0xa3, 0x8e, 0xbe, 0xa9, 0xf3,
+	0xfc, 0x66, 0xbe, 0x17, 0x2c, 0x45, 0xf4, 0xe1, 0x02, 0xa2, 0xe7, 0x0f, 0x46, 0x79, 0xa6, 0xff,
+	0x6e, 0x80, 0x25, 0xba, 0xc6, 0xaa, 0x5c, 0x97, 0xe7, 0x72, 0x5d, 0x9e, 0xcb, 0x75, 0xf9, 0xff,
+	0xca, 0xf5, 0xef, 0x0d, 0xd8, 0xd0, 0x5a, 0x20, 0xda, 0xd3, 0xd9, 0xbe, 0x31, 0xdb, 0x25, 0x97,
+	0xe2, 0xfb, 0xd3, 0x05, 0x7c, 0x17, 0x36, 0x78, 0x85, 0x56, 0x95, 0xf1, 0x5f, 0x1b, 0x72, 0x74,
+	0x5c, 0xf5, 0xe9, 0x36, 0x97, 0x7f, 0xba, 0x69, 0x28, 0xf6, 0xbc, 0xf0, 0x67, 0x64, 0xc8, 0x86,
+	0x1c, 0xd3, 0x91, 0x22, 0xb5, 0x44, 0x38, 0x4d, 0x49, 0x1c, 0x30, 0xce, 0x4d, 0x47, 0x8a, 0xf6,
+	0xef, 0x0c, 0xf9, 0x06, 0x24, 0xd8, 0xeb, 0xeb, 0xec, 0xbd, 0x35, 0x73, 0x1c, 0x2c, 0x45, 0xde,
+	0xd3, 0x05, 0xe4, 0x5d, 0xf2, 0x5e, 0x90, 0xe7, 0xee, 0x97, 0x25, 0xf9, 0x8a, 0xc4, 0x06, 0xfc,
+	0x95, 0xf8, 0x2b, 0xaf, 0xc4, 0xdf, 0x0d, 0x00, 0xdf, 0x0d, 0x5e, 0x7a, 0x24, 0x18, 0xa5, 0xe7,
+	0xa2, 0x74, 0x4d, 0xdf, 0x0d, 0x8e, 0x98, 0x82, 0x99, 0xf1, 0x85, 0x34, 0xaf, 0x0b, 0x33, 0xbe,
+	0x10, 0xe6, 0xdb, 0xd0, 0x14, 0xa5, 0x2b, 0x5d, 0x78, 0x05, 0x37, 0x78, 0x05, 0xe7, 0xbc, 0xa6,
+	0x40, 0xb5, 0xa9, 0x57, 0x86, 0xa5, 0x64, 0xb2, 0xae, 0x65, 0xd2, 0xfe, 0xb3, 0x01, 0x5b, 0xf9,
+	0x43, 0x14, 0x3d, 0xd4, 0x73, 0xd6, 0x29, 0x3c, 0x6a, 0x97, 0xca, 0xdb, 0xf1, 0x82, 0xbc, 0x15,
+	0x1e, 0x69, 0x7a, 0x76, 0xd4, 0xdc, 0xfd, 0xb3, 0x24, 0x5e, 0x50, 0xff, 0x97, 0xd4, 0x19, 0xaf,
+	0x6b, 0xea, 0x64, 0xc3, 0xac, 0xcf, 0x3d, 0x9c, 0x4c, 0xfd, 0x70, 0x52, 0xba, 0x1e, 0xcc, 0xeb,
+	0x7a, 0x96, 0xd6, 0xf5, 0xfe, 0x68, 0xc0, 0x66, 0x6e, 0xe0, 0x41, 0xef, 0xeb, 0x55, 0x70, 0xb3,
+	0x68, 0x2a, 0x5a, 0xaa, 0x08, 0x8e, 0x16, 0x14, 0x41, 0xe1, 0x18, 0xaa, 0xa5, 0x59, 0xad, 0x81,
+	0x7f, 0x95, 0xa0, 0x46, 0x5f, 0xa4, 0x17, 0x65, 0x7f, 0x4f, 0xcf, 0xbe, 0xd5, 0xbf, 0x2e, 0x47,
+	0x30, 0x1a, 0xda, 0x13, 0x99, 0x16, 0x9f, 0x93, 0x5e, 0xc3, 0xc2, 0xd8, 0x81, 0x0d, 0xb9, 0xd7,
+	0x97, 0x5f, 0x92, 0x49, 0x22, 0x9e, 0xec, 0x86, 0x54, 0x3e, 0x23, 0x93, 0x84, 0x7e, 0x93, 0x51,
+	0x37, 0xba, 0xd2, 0x7b, 0xfd, 0xaf, 0x0c, 0x30, 0xb3, 0x81, 0x15, 0xed, 0xea, 0xd5, 0x70, 0x5d,
+	0x9f, 0x67, 0x97, 0xaa, 0x83, 0x4f, 0x16, 0xd4, 0x81, 0xad, 0xd7, 0x41, 0x43, 0xcd, 0x97, 0xba,
+	0xb0, 0x7f, 0x18, 0xd0, 0x90, 0x63, 0xef, 0xaa, 0xe7, 0x5f, 0x79, 0xb5, 0xf3, 0x4f, 0x3e, 0x7a,
+	0x95, 0xb9, 0xb3, 0xca, 0xba, 0x3e, 0xab, 0xac, 0x3e, 0x70, 0xfc, 0xc1, 0x80, 0xa6, 0x3e, 0xc4,
+	0xa3, 0x07, 0x3a, 0xd7, 0x5f, 0x2f, 0x18, 0xf4, 0x97, 0x22, 0xfc, 0xd9, 0x02, 0xc2, 0x0b, 0x5f,
+	0xd5, 0x54, 0x66, 0x55, 0xd6, 0xf7, 0x00, 0xc4, 0xfd, 0x88, 0xb8, 0x2d, 0xf1, 0x17, 0xdf, 0x96,
+	0xd8, 0xcf, 0xa0, 0x21, 0x82, 0xf8, 0xf7, 0x9e, 0xe5, 0xc2, 0xa6, 0x9f, 0x85, 0x4a, 0xea, 0xed,
+	0xd1, 0x63, 0xd8, 0x3e, 0x20, 0x29, 0xf7, 0x7d, 0x3e, 0x89, 0x08, 0x5b, 0xc8, 0x5d, 0x10, 0xf7,
+	0x1d, 0x2d, 0x43, 0x99, 0x53, 0x67, 0x6e, 0x43, 0xfa, 0xbf, 0x28, 0xd1, 0x0f, 0xe3, 0x9e, 0x47,
+	0x06, 0x69, 0x18, 0xa3, 0x87, 0xd0, 0x14, 0x82, 0x58, 0x1e, 0xda, 0x54, 0x16, 0x42, 0x81, 0xdb,
+	0xdb, 0xaa, 0x82, 0xad, 0xde, 0x5e, 0x43, 0xdf, 0x81, 0xa6, 0xbe, 0x04, 0x74, 0x4d, 0x7e, 0x4c,
+	0xd0, 0xd7, 0x55, 0x1c, 0xbe, 0x03, 0x95, 0x13, 0x37, 0x18, 0x21, 0x60, 0x46, 0x76, 0x6b, 0xd6,
+	0xe6, 0x6f, 0x30, 0xf2, 0xe2, 0xcc, 0x5e, 0x43, 0x77, 0xa0, 0x42, 0xbf, 0xa7, 0x21, 0x5e, 0xff,
+	0xe2, 0xd3, 0xda, 0xac, 0xdb, 0x23, 0xd8, 0xcc, 0x7d, 0xc2, 0xd0, 0x60, 0xaf, 0xcf, 0xfd, 0xc8,
+	0x61, 0xaf, 0xf5, 0xff, 0x6a, 0x80, 0x49, 0xef, 0xbd, 0x48, 0x92, 0x84, 0x31, 0xda, 0x85, 0x9a,
+	0x10, 0x04, 0x0b, 0xd3, 0x5b, 0xb1, 0xaf, 0xf6, 0x36, 0xfe, 0x42, 0xb7, 0x31, 0x3e, 0xf5, 0xdc,
+	0xe4, 0x9c, 0xc4, 0xe8, 0x3e, 0xd4, 0x84, 0x30, 0xbb, 0x8d, 0x99, 0xbf, 0xfd, 0x8a, 0x6c, 0xe1,
+	0xb4, 0xca, 0x2e, 0x52, 0xf7, 0xfe, 0x3b, 0x00, 0x21, 0x45, 0xfe, 0x78, 0x86, 0x1d, 0x00, 0x00,
 }
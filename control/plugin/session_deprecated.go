@@ -294,6 +294,9 @@ func init() {
 	gob.RegisterName("conf_value_int", *(&ctypes.ConfigValueInt{}))
 	gob.RegisterName("conf_value_float", *(&ctypes.ConfigValueFloat{}))
 	gob.RegisterName("conf_value_bool", *(&ctypes.ConfigValueBool{}))
+	gob.RegisterName("conf_value_string_list", *(&ctypes.ConfigValueStrList{}))
+	gob.RegisterName("conf_value_float_list", *(&ctypes.ConfigValueFloatList{}))
+	gob.RegisterName("conf_value_map", *(&ctypes.ConfigValueMap{}))
 
 	gob.RegisterName("conf_policy_node", cpolicy.NewPolicyNode())
 	gob.RegisterName("conf_data_node", &cdata.ConfigDataNode{})
@@ -301,6 +304,10 @@ func init() {
 	gob.RegisterName("conf_policy_int", &cpolicy.IntRule{})
 	gob.RegisterName("conf_policy_float", &cpolicy.FloatRule{})
 	gob.RegisterName("conf_policy_bool", &cpolicy.BoolRule{})
+	gob.RegisterName("conf_policy_string_list", &cpolicy.StringListRule{})
+	gob.RegisterName("conf_policy_float_list", &cpolicy.FloatListRule{})
+	gob.RegisterName("conf_policy_map", &cpolicy.MapRule{})
+	gob.RegisterName("conf_policy_duration", &cpolicy.DurationRule{})
 }
 
 // simpleFormatter is a logrus formatter that includes only the message.
@@ -0,0 +1,145 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/pkg/pindex"
+)
+
+var (
+	// ErrNoPluginIndex - Error message when no plugin index is configured
+	ErrNoPluginIndex = errors.New("No plugin index configured")
+)
+
+// PluginIndexes returns the locations of the configured plugin indexes
+func (p *pluginControl) PluginIndexes() []string {
+	var locations []string
+	if p.Config == nil {
+		return locations
+	}
+	for _, l := range strings.Split(p.Config.PluginIndex, ",") {
+		if l = strings.TrimSpace(l); l != "" {
+			locations = append(locations, l)
+		}
+	}
+	return locations
+}
+
+// InstallPlugin loads the plugin of the type, name and version from the first
+// configured plugin index listing it.  The latest version listed is installed
+// when the version is less than 1.  The plugin fetched is checked against the
+// checksum listed in the index and its signature is verified according to the
+// plugin trust level before it is loaded.
+func (p *pluginControl) InstallPlugin(pluginType, name string, version int) (core.CatalogedPlugin, serror.SnapError) {
+	f := map[string]interface{}{
+		"_block":         "install-plugin",
+		"plugin-type":    pluginType,
+		"plugin-name":    name,
+		"plugin-version": version,
+	}
+	if _, err := core.ToPluginType(pluginType); err != nil {
+		se := serror.New(err)
+		se.SetFields(f)
+		return nil, se
+	}
+	locations := p.PluginIndexes()
+	if len(locations) == 0 {
+		se := serror.New(ErrNoPluginIndex)
+		se.SetFields(f)
+		return nil, se
+	}
+
+	var idx *pindex.Index
+	var entry *pindex.Entry
+	var lastErr error
+	for _, l := range locations {
+		i, err := pindex.Read(l)
+		if err != nil {
+			controlLogger.WithFields(f).WithFields(log.Fields{
+				"plugin-index": l,
+			}).Warn(err)
+			lastErr = err
+			continue
+		}
+		if entry, err = i.Find(pluginType, name, version); err != nil {
+			lastErr = err
+			continue
+		}
+		idx = i
+		break
+	}
+	if entry == nil {
+		se := serror.New(lastErr)
+		se.SetFields(f)
+		return nil, se
+	}
+	f["plugin-index"] = idx.Location()
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		se := serror.New(err)
+		se.SetFields(f)
+		return nil, se
+	}
+	fail := func(err error) (core.CatalogedPlugin, serror.SnapError) {
+		if rerr := os.RemoveAll(dir); rerr != nil {
+			controlLogger.WithFields(f).Error(rerr)
+		}
+		se, ok := err.(serror.SnapError)
+		if !ok {
+			se = serror.New(err)
+		}
+		se.SetFields(f)
+		return nil, se
+	}
+	path, signature, err := idx.Fetch(entry, dir)
+	if err != nil {
+		return fail(err)
+	}
+	rp, err := core.NewRequestedPlugin(path)
+	if err != nil {
+		return fail(err)
+	}
+	rp.SetAutoLoaded(false)
+	rp.SetSignature(signature)
+
+	controlLogger.WithFields(f).Info(fmt.Sprintf("installing plugin %s/%s@%d", entry.Type, entry.Name, entry.Version))
+	pl, se := p.Load(rp)
+	if se != nil {
+		return fail(se)
+	}
+	// a plugin package is extracted on load and the fetched file is no
+	// longer needed
+	if filepath.Ext(path) == ".aci" {
+		os.RemoveAll(dir)
+	}
+	return pl, nil
+}
@@ -23,6 +23,7 @@ package control
 
 import (
 	"crypto/sha256"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"os"
@@ -44,6 +45,8 @@ import (
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/cdata"
 	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/pkg/aci"
+	"github.com/intelsdi-x/snap/pkg/psigning"
 )
 
 const (
@@ -166,6 +169,18 @@ type pluginDetails struct {
 	Path         string
 	Signed       bool
 	Signature    []byte
+	// SnapManifest is the snap manifest of a plugin package
+	SnapManifest *aci.SnapManifest
+	// ManifestSigned is set for a package signed through the signature
+	// embedded with its snap manifest
+	ManifestSigned bool
+	// Signer is the signer of the plugin when its signature was verified
+	Signer *psigning.Signer
+	// SignerRevoked is set when the key of the signer is revoked and the
+	// plugin is kept loaded
+	SignerRevoked bool
+	// Embedded is the plugin when it runs within snapd
+	Embedded *embeddedPlugin
 }
 
 type loadedPlugin struct {
@@ -218,6 +233,21 @@ func (lp *loadedPlugin) IsSigned() bool {
 	return lp.Details.Signed
 }
 
+// Signer returns the identity of the signer of the plugin
+// implements the CatalogedPlugin interface
+func (lp *loadedPlugin) Signer() string {
+	if lp.Details.Signer == nil {
+		return ""
+	}
+	return lp.Details.Signer.String()
+}
+
+// SignerRevoked returns whether the key of the signer was revoked
+// implements the CatalogedPlugin interface
+func (lp *loadedPlugin) SignerRevoked() bool {
+	return lp.Details.SignerRevoked
+}
+
 // LoadedTimestamp returns a unix timestamp of the LoadTime of a plugin
 // implements the CatalogedPlugin interface
 func (lp *loadedPlugin) LoadedTimestamp() *time.Time {
@@ -236,6 +266,8 @@ type pluginManager struct {
 	loadedPlugins     *loadedPlugins
 	logPath           string
 	pluginConfig      *pluginConfig
+	channel           *secureChannel
+	transport         string
 }
 
 func newPluginManager(opts ...pluginManagerOpt) *pluginManager {
@@ -248,6 +280,7 @@ func newPluginManager(opts ...pluginManagerOpt) *pluginManager {
 		loadedPlugins:     newLoadedPlugins(),
 		logPath:           logPath,
 		pluginConfig:      newPluginConfig(),
+		transport:         PluginTransportTCP,
 	}
 
 	for _, opt := range opts {
@@ -266,6 +299,30 @@ func OptSetPluginConfig(cf *pluginConfig) pluginManagerOpt {
 	}
 }
 
+// OptSetSecureChannel sets the secure channel plugins are started with
+func OptSetSecureChannel(s *secureChannel) pluginManagerOpt {
+	return func(p *pluginManager) {
+		p.channel = s
+	}
+}
+
+// SecureChannel returns the secure channel plugins are started with
+func (p *pluginManager) SecureChannel() *secureChannel {
+	return p.channel
+}
+
+// OptSetPluginTransport sets the transport plugins are requested to listen on
+func OptSetPluginTransport(t string) pluginManagerOpt {
+	return func(p *pluginManager) {
+		p.transport = t
+	}
+}
+
+// PluginTransport returns the transport plugins are requested to listen on
+func (p *pluginManager) PluginTransport() string {
+	return p.transport
+}
+
 // SetPluginLoadTimeout sets plugin load timeout
 func (p *pluginManager) SetPluginLoadTimeout(to int) {
 	p.pluginLoadTimeout = to
@@ -292,13 +349,42 @@ func (p *pluginManager) LoadPlugin(details *pluginDetails, emitter gomit.Emitter
 		"_block": "load-plugin",
 		"path":   filepath.Base(lPlugin.Details.Exec),
 	}).Info("plugin load called")
-	ePlugin, err := plugin.NewExecutablePlugin(p.GenerateArgs(int(log.GetLevel())), path.Join(lPlugin.Details.ExecPath, lPlugin.Details.Exec))
-	if err != nil {
-		pmLogger.WithFields(log.Fields{
-			"_block": "load-plugin",
-			"error":  err.Error(),
-		}).Error("load plugin error while creating executable plugin")
-		return nil, serror.New(err)
+	var (
+		ePlugin executablePlugin
+		cert    *pluginCertificate
+		err     error
+	)
+	if details.Embedded != nil {
+		ePlugin = details.Embedded
+	} else {
+		args := p.GenerateArgs(int(log.GetLevel()))
+		cert, err = p.channel.secure(&args, lPlugin.Details)
+		if err != nil {
+			pmLogger.WithFields(log.Fields{
+				"_block": "load-plugin",
+				"error":  err.Error(),
+			}).Error("load plugin error while issuing plugin certificate")
+			return nil, serror.New(err)
+		}
+		defer cert.release()
+		socketDir, err := newPluginSocketDir(p.transport, &args)
+		if err != nil {
+			pmLogger.WithFields(log.Fields{
+				"_block": "load-plugin",
+				"error":  err.Error(),
+			}).Error("load plugin error while creating plugin socket directory")
+			return nil, serror.New(err)
+		}
+		// the plugin is killed once its info is retrieved
+		defer removePluginSocketDir(socketDir)
+		ePlugin, err = plugin.NewExecutablePlugin(args, path.Join(lPlugin.Details.ExecPath, lPlugin.Details.Exec))
+		if err != nil {
+			pmLogger.WithFields(log.Fields{
+				"_block": "load-plugin",
+				"error":  err.Error(),
+			}).Error("load plugin error while creating executable plugin")
+			return nil, serror.New(err)
+		}
 	}
 
 	pmLogger.WithFields(log.Fields{
@@ -314,7 +400,22 @@ func (p *pluginManager) LoadPlugin(details *pluginDetails, emitter gomit.Emitter
 		return nil, serror.New(err)
 	}
 
-	ap, err := newAvailablePlugin(resp, emitter, ePlugin)
+	var tlsConfig *tls.Config
+	if details.Embedded == nil {
+		tlsConfig, err = p.channel.clientConfig(cert, resp)
+	}
+	if err != nil {
+		pmLogger.WithFields(log.Fields{
+			"_block":         "load-plugin",
+			"plugin-name":    resp.Meta.Name,
+			"plugin-version": resp.Meta.Version,
+			"error":          err.Error(),
+		}).Error("load plugin error while securing the plugin")
+		ePlugin.Kill()
+		return nil, serror.New(err)
+	}
+
+	ap, err := newAvailablePlugin(resp, emitter, ePlugin, tlsConfig)
 	if err != nil {
 		pmLogger.WithFields(log.Fields{
 			"_block": "load-plugin",
@@ -533,7 +634,7 @@ func (p *pluginManager) UnloadPlugin(pl core.Plugin) (*loadedPlugin, serror.Snap
 	// aka, was not auto loaded from auto_discover_path
 	// nor loaded from tests
 	// then do clean up
-	if !plugin.Details.IsAutoLoaded {
+	if !plugin.Details.IsAutoLoaded && plugin.Details.Embedded == nil {
 		pmLogger.WithFields(log.Fields{
 			"plugin-type":    plugin.TypeName(),
 			"plugin-name":    plugin.Name(),
@@ -574,6 +675,7 @@ func (p *pluginManager) GenerateArgs(logLevel int) plugin.Arg {
 }
 
 func (p *pluginManager) teardown() {
+	defer p.channel.close()
 	for _, lp := range p.loadedPlugins.table {
 		_, err := p.UnloadPlugin(lp)
 		if err != nil {
@@ -0,0 +1,60 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+)
+
+const (
+	// PluginTransportTCP - plugins listen on a TCP port on localhost
+	PluginTransportTCP = "tcp"
+	// PluginTransportUnix - plugins supporting it listen on a Unix socket in
+	// a directory created for each plugin, the others on a TCP port
+	PluginTransportUnix = "unix"
+)
+
+// newPluginSocketDir creates the directory a plugin places its Unix socket in
+// and requests the plugin to listen on it through its args. The directory is
+// only accessible by the user running snapd and is to be removed once the
+// plugin stopped. No directory is created, and "" is returned, when plugins
+// listen on TCP.
+func newPluginSocketDir(transport string, args *plugin.Arg) (string, error) {
+	if transport != PluginTransportUnix {
+		return "", nil
+	}
+	// ioutil.TempDir creates the directory with 0700 permissions
+	dir, err := ioutil.TempDir("", "snap-plugin-socket")
+	if err != nil {
+		return "", err
+	}
+	args.SocketDir = dir
+	return dir, nil
+}
+
+// removePluginSocketDir removes the socket directory of a plugin
+func removePluginSocketDir(dir string) {
+	if dir != "" {
+		os.RemoveAll(dir)
+	}
+}
@@ -20,6 +20,7 @@ limitations under the License.
 package control
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"os"
@@ -153,7 +154,7 @@ func (r *runner) Stop() []error {
 	return errs
 }
 
-func (r *runner) startPlugin(p executablePlugin) (*availablePlugin, error) {
+func (r *runner) startPlugin(p executablePlugin, cert *pluginCertificate) (*availablePlugin, error) {
 	resp, err := p.Run(time.Second * 5)
 	if err != nil {
 		e := errors.New("error starting plugin: " + err.Error())
@@ -173,8 +174,25 @@ func (r *runner) startPlugin(p executablePlugin) (*availablePlugin, error) {
 		return nil, e
 	}
 
+	var channel *secureChannel
+	if r.pluginManager != nil {
+		channel = r.pluginManager.SecureChannel()
+	}
+	var tlsConfig *tls.Config
+	if !isEmbedded(p) {
+		tlsConfig, err = channel.clientConfig(cert, resp)
+	}
+	if err != nil {
+		runnerLog.WithFields(log.Fields{
+			"_block": "start-plugin",
+			"error":  err.Error(),
+		}).Error("error securing a plugin")
+		p.Kill()
+		return nil, err
+	}
+
 	// build availablePlugin
-	ap, err := newAvailablePlugin(resp, r.emitter, p)
+	ap, err := newAvailablePlugin(resp, r.emitter, p, tlsConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -299,6 +317,19 @@ func (r *runner) HandleGomitEvent(e gomit.Event) {
 }
 
 func (r *runner) runPlugin(details *pluginDetails) error {
+	if details.Embedded != nil {
+		ap, err := r.startPlugin(details.Embedded, nil)
+		if err != nil {
+			runnerLog.WithFields(log.Fields{
+				"_block": "run-plugin",
+				"plugin": details.Exec,
+				"error":  err,
+			}).Error("error starting new embedded plugin")
+			return err
+		}
+		ap.exec = details.Exec
+		return nil
+	}
 	if details.IsPackage {
 		f, err := os.Open(details.Path)
 		if err != nil {
@@ -310,25 +341,54 @@ func (r *runner) runPlugin(details *pluginDetails) error {
 			return err
 		}
 		details.ExecPath = path.Join(tempPath, "rootfs")
+		if details.SnapManifest != nil {
+			if err := details.SnapManifest.Verify(details.ExecPath); err != nil {
+				os.RemoveAll(tempPath)
+				return err
+			}
+		}
+	}
+	args := r.pluginManager.GenerateArgs(int(log.GetLevel()))
+	cert, err := r.pluginManager.SecureChannel().secure(&args, details)
+	if err != nil {
+		runnerLog.WithFields(log.Fields{
+			"_block": "run-plugin",
+			"path":   path.Join(details.ExecPath, details.Exec),
+			"error":  err,
+		}).Error("error issuing plugin certificate")
+		return err
+	}
+	defer cert.release()
+	socketDir, err := newPluginSocketDir(r.pluginManager.PluginTransport(), &args)
+	if err != nil {
+		runnerLog.WithFields(log.Fields{
+			"_block": "run-plugin",
+			"path":   path.Join(details.ExecPath, details.Exec),
+			"error":  err,
+		}).Error("error creating plugin socket directory")
+		return err
 	}
-	ePlugin, err := plugin.NewExecutablePlugin(r.pluginManager.GenerateArgs(int(log.GetLevel())), path.Join(details.ExecPath, details.Exec))
+	ePlugin, err := plugin.NewExecutablePlugin(args, path.Join(details.ExecPath, details.Exec))
 	if err != nil {
 		runnerLog.WithFields(log.Fields{
 			"_block": "run-plugin",
 			"path":   path.Join(details.ExecPath, details.Exec),
 			"error":  err,
 		}).Error("error creating executable plugin")
+		removePluginSocketDir(socketDir)
 		return err
 	}
-	ap, err := r.startPlugin(ePlugin)
+	ap, err := r.startPlugin(ePlugin, cert)
 	if err != nil {
 		runnerLog.WithFields(log.Fields{
 			"_block": "run-plugin",
 			"path":   path.Join(details.ExecPath, details.Exec),
 			"error":  err,
 		}).Error("error starting new plugin")
+		removePluginSocketDir(socketDir)
 		return err
 	}
+	ap.socketDir = socketDir
 	ap.exec = details.Exec
 	ap.execPath = details.ExecPath
 	if details.IsPackage {
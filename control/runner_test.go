@@ -361,7 +361,7 @@ func TestRunnerPluginRunning(t *testing.T) {
 						So(err, ShouldBeNil)
 
 						// exPlugin := new(MockExecutablePlugin)
-						ap, e := r.startPlugin(exPlugin)
+						ap, e := r.startPlugin(exPlugin, nil)
 
 						So(e, ShouldBeNil)
 						So(ap, ShouldNotBeNil)
@@ -382,7 +382,7 @@ func TestRunnerPluginRunning(t *testing.T) {
 
 						So(err, ShouldBeNil)
 						colCount := len(r.availablePlugins.all())
-						ap, e := r.startPlugin(exPlugin)
+						ap, e := r.startPlugin(exPlugin, nil)
 						So(e, ShouldBeNil)
 						So(ap, ShouldNotBeNil)
 						So(len(r.availablePlugins.all()), ShouldEqual, colCount+1)
@@ -399,7 +399,7 @@ func TestRunnerPluginRunning(t *testing.T) {
 						}
 
 						So(err, ShouldBeNil)
-						ap, e := r.startPlugin(exPlugin)
+						ap, e := r.startPlugin(exPlugin, nil)
 						So(e, ShouldBeNil)
 						ap.client = new(MockHealthyPluginCollectorClient)
 						ap.CheckHealth()
@@ -416,7 +416,7 @@ func TestRunnerPluginRunning(t *testing.T) {
 						}
 
 						So(err, ShouldBeNil)
-						ap, e := r.startPlugin(exPlugin)
+						ap, e := r.startPlugin(exPlugin, nil)
 						So(e, ShouldBeNil)
 						ap.client = new(MockUnhealthyPluginCollectorClient)
 						ap.CheckHealth()
@@ -433,7 +433,7 @@ func TestRunnerPluginRunning(t *testing.T) {
 						}
 
 						So(err, ShouldBeNil)
-						ap, e := r.startPlugin(exPlugin)
+						ap, e := r.startPlugin(exPlugin, nil)
 						So(e, ShouldBeNil)
 						ap.client = new(MockUnhealthyPluginCollectorClient)
 						ap.CheckHealth()
@@ -454,7 +454,7 @@ func TestRunnerPluginRunning(t *testing.T) {
 						}
 
 						So(err, ShouldBeNil)
-						ap, e := r.startPlugin(exPlugin)
+						ap, e := r.startPlugin(exPlugin, nil)
 						So(e, ShouldBeNil)
 						ap.client = new(MockUnhealthyPluginCollectorClient)
 						ap.CheckHealth()
@@ -468,7 +468,7 @@ func TestRunnerPluginRunning(t *testing.T) {
 						r.SetEmitter(new(MockEmitter))
 						exPlugin := new(MockExecutablePlugin)
 						exPlugin.Timeout = true // set to not response
-						ap, e := r.startPlugin(exPlugin)
+						ap, e := r.startPlugin(exPlugin, nil)
 
 						So(ap, ShouldBeNil)
 						So(e, ShouldResemble, errors.New("error starting plugin: timeout"))
@@ -489,7 +489,7 @@ func TestRunnerPluginRunning(t *testing.T) {
 					So(err, ShouldBeNil)
 
 					// exPlugin := new(MockExecutablePlugin)
-					ap, e := r.startPlugin(exPlugin)
+					ap, e := r.startPlugin(exPlugin, nil)
 
 					So(e, ShouldBeNil)
 					So(ap, ShouldNotBeNil)
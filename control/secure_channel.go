@@ -0,0 +1,182 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/pkg/tlsca"
+)
+
+const (
+	// PluginTLSDisabled - plugins are not given certificates and RPC to them
+	// is only secured by the encryption of the native and JSON-RPC types
+	PluginTLSDisabled = "disabled"
+	// PluginTLSEnabled - plugins are given certificates and RPC to plugins
+	// serving TLS uses mutual TLS
+	PluginTLSEnabled = "enabled"
+	// PluginTLSRequired - plugins not serving TLS fail to load
+	PluginTLSRequired = "required"
+
+	// secureChannelValidity is how long the certificates of the local CA are
+	// valid for, they are issued again after a restart of snapd
+	secureChannelValidity = 10 * 365 * 24 * time.Hour
+)
+
+var (
+	// ErrPluginTLSRequired - Error message for a plugin which did not
+	// serve TLS when it is required
+	ErrPluginTLSRequired = errors.New("Plugin does not support TLS which is required")
+
+	secureChannelLog = log.WithField("_module", "control-secure-channel")
+)
+
+// secureChannel secures the RPC to plugins with mutual TLS. An ephemeral CA
+// issues a certificate for every started plugin, naming the plugin binary,
+// its checksum and the key it was signed with. Snapd only accepts the
+// certificate issued for the plugin it started, so another local process
+// can't impersonate the plugin even when it gets its listen address.
+type secureChannel struct {
+	mode   string
+	ca     *tlsca.CA
+	client *tlsca.Certificate
+	// dir holds the certificates and keys given to plugins
+	dir string
+}
+
+// pluginCertificate is the certificate issued for a started plugin
+type pluginCertificate struct {
+	serverName string
+	certPath   string
+	keyPath    string
+}
+
+func newSecureChannel(mode string) (*secureChannel, error) {
+	s := &secureChannel{mode: mode}
+	if mode == PluginTLSDisabled {
+		return s, nil
+	}
+	ca, err := tlsca.New("snapd plugin CA", secureChannelValidity)
+	if err != nil {
+		return nil, err
+	}
+	client, err := ca.Issue(pkix.Name{CommonName: "snapd"}, nil)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := ioutil.TempDir("", "snap-plugin-tls")
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "ca.crt"), ca.CertPEM(), 0644); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	s.ca, s.client, s.dir = ca, client, dir
+	return s, nil
+}
+
+func (s *secureChannel) enabled() bool {
+	return s != nil && s.ca != nil
+}
+
+// secure issues a certificate for the plugin of the details and requests
+// the plugin to serve TLS with it through its args. The certificate files
+// are to be released once the plugin responded.
+func (s *secureChannel) secure(args *plugin.Arg, details *pluginDetails) (*pluginCertificate, error) {
+	if !s.enabled() {
+		return nil, nil
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	subject := pkix.Name{
+		Organization: []string{"snap plugin"},
+		CommonName:   details.Exec,
+		SerialNumber: fmt.Sprintf("%x", details.CheckSum),
+	}
+	if details.Signer != nil && details.Signer.KeyID != "" {
+		subject.OrganizationalUnit = []string{details.Signer.KeyID}
+	}
+	pc := &pluginCertificate{serverName: fmt.Sprintf("%x.plugin.snap", id)}
+	cert, err := s.ca.Issue(subject, []string{pc.serverName})
+	if err != nil {
+		return nil, err
+	}
+	pc.certPath = filepath.Join(s.dir, fmt.Sprintf("%x.crt", id))
+	pc.keyPath = filepath.Join(s.dir, fmt.Sprintf("%x.key", id))
+	if err := ioutil.WriteFile(pc.certPath, cert.CertPEM, 0600); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(pc.keyPath, cert.KeyPEM, 0600); err != nil {
+		pc.release()
+		return nil, err
+	}
+	args.TLSEnabled = true
+	args.CertPath = pc.certPath
+	args.KeyPath = pc.keyPath
+	args.CACertPath = filepath.Join(s.dir, "ca.crt")
+	return pc, nil
+}
+
+// clientConfig returns the TLS configuration for the client of a plugin
+// which responded, nil if the plugin doesn't serve TLS and it isn't required.
+func (s *secureChannel) clientConfig(pc *pluginCertificate, resp plugin.Response) (*tls.Config, error) {
+	if pc == nil || !resp.Meta.TLSEnabled {
+		if s != nil && s.mode == PluginTLSRequired {
+			return nil, ErrPluginTLSRequired
+		}
+		return nil, nil
+	}
+	secureChannelLog.WithFields(log.Fields{
+		"_block":         "client-config",
+		"plugin-name":    resp.Meta.Name,
+		"plugin-version": resp.Meta.Version,
+		"server-name":    pc.serverName,
+	}).Debug("securing plugin RPC with mutual TLS")
+	return s.ca.ClientConfig(s.client, pc.serverName)
+}
+
+// release removes the certificate files, which the plugin read at startup
+func (pc *pluginCertificate) release() {
+	if pc == nil {
+		return
+	}
+	os.Remove(pc.certPath)
+	os.Remove(pc.keyPath)
+}
+
+func (s *secureChannel) close() {
+	if s.enabled() {
+		os.RemoveAll(s.dir)
+	}
+}
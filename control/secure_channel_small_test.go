@@ -0,0 +1,112 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/pkg/psigning"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSecureChannel(t *testing.T) {
+	details := &pluginDetails{
+		Exec:   "snap-plugin-collector-mock",
+		Signer: &psigning.Signer{Scheme: "ed25519", Identity: "release", KeyID: "abcd"},
+	}
+	Convey("Given a secure channel", t, func() {
+		s, err := newSecureChannel(PluginTLSEnabled)
+		So(err, ShouldBeNil)
+		defer s.close()
+		So(s.enabled(), ShouldBeTrue)
+
+		Convey("It issues plugins a certificate through their args", func() {
+			args := plugin.Arg{}
+			pc, err := s.secure(&args, details)
+			So(err, ShouldBeNil)
+			So(args.TLSEnabled, ShouldBeTrue)
+			b, err := ioutil.ReadFile(args.CertPath)
+			So(err, ShouldBeNil)
+			block, _ := pem.Decode(b)
+			So(block, ShouldNotBeNil)
+			cert, err := x509.ParseCertificate(block.Bytes)
+			So(err, ShouldBeNil)
+			So(cert.Subject.CommonName, ShouldEqual, details.Exec)
+			So(cert.Subject.OrganizationalUnit, ShouldResemble, []string{"abcd"})
+			So(cert.DNSNames, ShouldResemble, []string{pc.serverName})
+			_, err = os.Stat(args.CACertPath)
+			So(err, ShouldBeNil)
+
+			Convey("which are released", func() {
+				pc.release()
+				_, err := os.Stat(args.CertPath)
+				So(os.IsNotExist(err), ShouldBeTrue)
+				_, err = os.Stat(args.KeyPath)
+				So(os.IsNotExist(err), ShouldBeTrue)
+			})
+			Convey("It pins the client of a plugin serving TLS to its certificate", func() {
+				resp := plugin.Response{Meta: plugin.PluginMeta{TLSEnabled: true}}
+				config, err := s.clientConfig(pc, resp)
+				So(err, ShouldBeNil)
+				So(config, ShouldNotBeNil)
+				So(config.ServerName, ShouldEqual, pc.serverName)
+				So(config.Certificates, ShouldHaveLength, 1)
+			})
+			Convey("It doesn't secure plugins not serving TLS", func() {
+				config, err := s.clientConfig(pc, plugin.Response{})
+				So(err, ShouldBeNil)
+				So(config, ShouldBeNil)
+			})
+			pc.release()
+		})
+	})
+	Convey("Given a secure channel requiring TLS", t, func() {
+		s, err := newSecureChannel(PluginTLSRequired)
+		So(err, ShouldBeNil)
+		defer s.close()
+		Convey("It rejects plugins not serving TLS", func() {
+			pc, err := s.secure(&plugin.Arg{}, details)
+			So(err, ShouldBeNil)
+			defer pc.release()
+			_, err = s.clientConfig(pc, plugin.Response{})
+			So(err, ShouldEqual, ErrPluginTLSRequired)
+		})
+	})
+	Convey("Given a disabled secure channel", t, func() {
+		s, err := newSecureChannel(PluginTLSDisabled)
+		So(err, ShouldBeNil)
+		So(s.enabled(), ShouldBeFalse)
+		Convey("It doesn't issue certificates", func() {
+			args := plugin.Arg{}
+			pc, err := s.secure(&args, details)
+			So(err, ShouldBeNil)
+			So(pc, ShouldBeNil)
+			So(args.TLSEnabled, ShouldBeFalse)
+		})
+	})
+}
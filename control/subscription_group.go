@@ -53,6 +53,9 @@ type ManagesSubscriptionGroups interface {
 		plugins []core.SubscribedPlugin) []serror.SnapError
 	Get(id string) (map[string]metricTypes, []serror.SnapError, error)
 	Remove(id string) []serror.SnapError
+	Update(id string, requested []core.RequestedMetric,
+		configTree *cdata.ConfigDataTree,
+		plugins []core.SubscribedPlugin) []serror.SnapError
 	ValidateDeps(requested []core.RequestedMetric,
 		plugins []core.SubscribedPlugin,
 		configTree *cdata.ConfigDataTree) (serrs []serror.SnapError)
@@ -152,6 +155,49 @@ func (s subscriptionGroups) remove(id string) []serror.SnapError {
 	return serrs
 }
 
+// Update replaces the requested metrics, config tree and plugins of an
+// existing subscription group.  Only the plugins which are no longer needed
+// are unsubscribed and only the plugins which were not needed before are
+// subscribed.  If the new subscription cannot be processed the previous one is
+// restored and the errors are returned.
+// Returns `ErrSubscriptionGroupDoesNotExist` when the subscription group
+// does not exist.
+func (s subscriptionGroups) Update(id string, requested []core.RequestedMetric,
+	configTree *cdata.ConfigDataTree,
+	plugins []core.SubscribedPlugin) []serror.SnapError {
+	s.Lock()
+	defer s.Unlock()
+	return s.update(id, requested, configTree, plugins)
+}
+
+func (s subscriptionGroups) update(id string, requested []core.RequestedMetric,
+	configTree *cdata.ConfigDataTree,
+	plugins []core.SubscribedPlugin) []serror.SnapError {
+	sg, ok := s.subscriptionMap[id]
+	if !ok {
+		return []serror.SnapError{serror.New(ErrSubscriptionGroupDoesNotExist)}
+	}
+	prevMetrics, prevPlugins, prevConfig := sg.requestedMetrics, sg.requestedPlugins, sg.configTree
+	sg.requestedMetrics = requested
+	sg.requestedPlugins = plugins
+	sg.configTree = configTree
+	errs := sg.process(id)
+	if errs != nil {
+		sg.requestedMetrics = prevMetrics
+		sg.requestedPlugins = prevPlugins
+		sg.configTree = prevConfig
+		if rerrs := sg.process(id); rerrs != nil {
+			controlLogger.WithFields(log.Fields{
+				"_block":          "subscriptionGroups.update",
+				"subscription-id": id,
+			}).Error("unable to restore subscription group after failed update")
+			errs = append(errs, rerrs...)
+		}
+		return errs
+	}
+	return nil
+}
+
 // Get returns the metrics (core.Metric) and an array of serror.SnapError when
 // provided a subscription ID. The array of serror.SnapError returned was
 // produced the last time `process` was run which is important since
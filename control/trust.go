@@ -0,0 +1,159 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/pkg/psigning"
+)
+
+const (
+	// RevokedKeyActionUnload - plugins signed by a revoked key are unloaded
+	RevokedKeyActionUnload = "unload"
+	// RevokedKeyActionFlag - plugins signed by a revoked key are kept loaded
+	// and flagged, and can no longer be started
+	RevokedKeyActionFlag = "flag"
+)
+
+var (
+	// ErrTrustedKeyNotFound - Error message when a trusted key is not found
+	ErrTrustedKeyNotFound = errors.New("Trusted key not found")
+	// ErrUnsupportedKeyScheme - Error message for a key of an unknown scheme
+	ErrUnsupportedKeyScheme = errors.New("Unsupported key scheme")
+)
+
+// TrustedKeys returns the keys of the signature verifiers, revoked ones
+// included
+func (p *pluginControl) TrustedKeys() []psigning.Key {
+	keys := []psigning.Key{}
+	for _, v := range p.signatureVerifiers() {
+		if ks, ok := v.(psigning.KeyStore); ok {
+			keys = append(keys, ks.Keys()...)
+		}
+	}
+	return keys
+}
+
+// AddTrustedKey adds the key of the scheme to the verifier of the scheme,
+// creating the verifier when snapd was started without keys of the scheme.
+// Ed25519 keys are named, the name is the identity of their signers.
+func (p *pluginControl) AddTrustedKey(scheme, name string, key []byte) ([]psigning.Key, serror.SnapError) {
+	f := map[string]interface{}{
+		"_block": "add-trusted-key",
+		"scheme": scheme,
+		"name":   name,
+	}
+	ks, err := p.keyStore(scheme)
+	if err != nil {
+		se := serror.New(err)
+		se.SetFields(f)
+		return nil, se
+	}
+	keys, err := ks.AddKey(name, key)
+	if err != nil {
+		se := serror.New(err)
+		se.SetFields(f)
+		return nil, se
+	}
+	for _, k := range keys {
+		controlLogger.WithFields(f).Info("added trusted key ", k.ID)
+	}
+	return keys, nil
+}
+
+// keyStore returns the verifier of the scheme, creating an empty one when
+// there is none
+func (p *pluginControl) keyStore(scheme string) (psigning.KeyStore, error) {
+	for _, v := range p.signatureVerifiers() {
+		if ks, ok := v.(psigning.KeyStore); ok && v.Scheme() == scheme {
+			return ks, nil
+		}
+	}
+	var ks psigning.KeyStore
+	switch scheme {
+	case psigning.SchemeOpenPGP:
+		ks, _ = psigning.NewOpenPGPVerifier()
+	case psigning.SchemeX509:
+		ks, _ = psigning.NewX509Verifier(nil, nil)
+	case psigning.SchemeEd25519:
+		ks, _ = psigning.NewEd25519Verifier(nil)
+	default:
+		return nil, fmt.Errorf("%v: %v", ErrUnsupportedKeyScheme, scheme)
+	}
+	p.AddSignatureVerifier(ks)
+	return ks, nil
+}
+
+// RevokeTrustedKey revokes the trusted key of the id.  The loaded plugins
+// signed by the key are unloaded or, when the revoked key action is flag or
+// a plugin can not be unloaded, flagged as signed by a revoked key.  Flagged
+// plugins keep running for the tasks using them but are no longer started.
+func (p *pluginControl) RevokeTrustedKey(id string) (unloaded, flagged []core.CatalogedPlugin, serr serror.SnapError) {
+	f := map[string]interface{}{
+		"_block": "revoke-trusted-key",
+		"key-id": id,
+	}
+	var found bool
+	for _, v := range p.signatureVerifiers() {
+		if ks, ok := v.(psigning.KeyStore); ok && ks.RevokeKey(id) {
+			found = true
+		}
+	}
+	if !found {
+		se := serror.New(fmt.Errorf("%v: %v", ErrTrustedKeyNotFound, id))
+		se.SetFields(f)
+		return nil, nil, se
+	}
+	controlLogger.WithFields(f).Warn("revoked trusted key")
+
+	var signed []*loadedPlugin
+	for _, lp := range p.pluginManager.all() {
+		if lp.Details.Signer != nil && lp.Details.Signer.KeyID == id {
+			signed = append(signed, lp)
+		}
+	}
+	action := RevokedKeyActionUnload
+	if p.Config != nil && p.Config.RevokedKeyAction != "" {
+		action = p.Config.RevokedKeyAction
+	}
+	for _, lp := range signed {
+		f["plugin-name"] = lp.Name()
+		f["plugin-version"] = lp.Version()
+		f["plugin-type"] = lp.TypeName()
+		if action == RevokedKeyActionUnload {
+			_, se := p.Unload(lp)
+			if se == nil {
+				controlLogger.WithFields(f).Warn("unloaded plugin signed by revoked key")
+				unloaded = append(unloaded, lp)
+				continue
+			}
+			f["error"] = se.Error()
+		}
+		lp.Details.SignerRevoked = true
+		controlLogger.WithFields(f).Warn("flagged plugin signed by revoked key")
+		delete(f, "error")
+		flagged = append(flagged, lp)
+	}
+	return unloaded, flagged, nil
+}
@@ -0,0 +1,99 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/intelsdi-x/snap/control/plugin"
+	"github.com/intelsdi-x/snap/pkg/psigning"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/crypto/ed25519"
+)
+
+func signedLoadedPlugin(name string, signer *psigning.Signer) *loadedPlugin {
+	return &loadedPlugin{
+		Meta:  plugin.PluginMeta{Name: name, Version: 1},
+		Type:  plugin.CollectorPluginType,
+		State: LoadedState,
+		Details: &pluginDetails{
+			IsAutoLoaded: true,
+			Signed:       true,
+			Signer:       signer,
+		},
+	}
+}
+
+func TestTrustedKeys(t *testing.T) {
+	Convey("Given a plugin control", t, func() {
+		c := New(GetDefaultConfig())
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		So(err, ShouldBeNil)
+		keys, se := c.AddTrustedKey(psigning.SchemeEd25519, "release", []byte(base64.StdEncoding.EncodeToString(pub)))
+		So(se, ShouldBeNil)
+		So(keys, ShouldHaveLength, 1)
+		id := keys[0].ID
+		So(c.TrustedKeys(), ShouldResemble, keys)
+
+		signer := &psigning.Signer{Scheme: psigning.SchemeEd25519, Identity: "release", KeyID: id}
+		lp := signedLoadedPlugin("signed", signer)
+		other := signedLoadedPlugin("other", &psigning.Signer{Scheme: psigning.SchemeEd25519, Identity: "other", KeyID: "other"})
+		So(c.pluginManager.(*pluginManager).loadedPlugins.add(lp), ShouldBeNil)
+		So(c.pluginManager.(*pluginManager).loadedPlugins.add(other), ShouldBeNil)
+
+		Convey("adding a key of an unknown scheme returns an error", func() {
+			_, se := c.AddTrustedKey("unknown", "", []byte("key"))
+			So(se, ShouldNotBeNil)
+		})
+		Convey("revoking a key unloads the plugins it signed", func() {
+			unloaded, flagged, se := c.RevokeTrustedKey(id)
+			So(se, ShouldBeNil)
+			So(unloaded, ShouldHaveLength, 1)
+			So(unloaded[0].Name(), ShouldEqual, "signed")
+			So(flagged, ShouldBeEmpty)
+			So(c.TrustedKeys()[0].Revoked, ShouldBeTrue)
+			_, err := c.pluginManager.get(lp.Key())
+			So(err, ShouldNotBeNil)
+			_, err = c.pluginManager.get(other.Key())
+			So(err, ShouldBeNil)
+		})
+		Convey("revoking a key flags the plugins it signed with the flag action", func() {
+			c.Config.RevokedKeyAction = RevokedKeyActionFlag
+			unloaded, flagged, se := c.RevokeTrustedKey(id)
+			So(se, ShouldBeNil)
+			So(unloaded, ShouldBeEmpty)
+			So(flagged, ShouldHaveLength, 1)
+			So(lp.SignerRevoked(), ShouldBeTrue)
+			So(lp.Signer(), ShouldEqual, "ed25519:release")
+			err := c.verifyPlugin(lp)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "revoked")
+		})
+		Convey("revoking an unknown key returns an error", func() {
+			_, _, se := c.RevokeTrustedKey("unknown")
+			So(se, ShouldNotBeNil)
+		})
+	})
+}
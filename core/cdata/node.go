@@ -70,26 +70,109 @@ func (c *ConfigDataNode) UnmarshalJSON(data []byte) error {
 	}
 
 	for k, i := range t {
-		switch t := i.(type) {
+		v, err := ParseConfigValue(i)
+		if err != nil {
+			return fmt.Errorf("Error Unmarshalling JSON ConfigDataNode. Key: %v Type: %v is unsupported.", k, i)
+		}
+		c.table[k] = v
+	}
+	c.mutex = new(sync.Mutex)
+	return nil
+}
+
+// ParseConfigValue converts a value decoded from the JSON or YAML of a task
+// or a config file into a ctypes.ConfigValue.  Lists of strings and lists of
+// numbers become ctypes.ConfigValueStrList and ctypes.ConfigValueFloatList and
+// objects become a ctypes.ConfigValueMap of their values as strings.
+func ParseConfigValue(i interface{}) (ctypes.ConfigValue, error) {
+	switch t := i.(type) {
+	case string:
+		return ctypes.ConfigValueStr{Value: t}, nil
+	case bool:
+		return ctypes.ConfigValueBool{Value: t}, nil
+	case int:
+		return ctypes.ConfigValueInt{Value: t}, nil
+	case json.Number:
+		if v, err := t.Int64(); err == nil {
+			return ctypes.ConfigValueInt{Value: int(v)}, nil
+		}
+		if v, err := t.Float64(); err == nil {
+			return ctypes.ConfigValueFloat{Value: v}, nil
+		}
+	case float64:
+		//working around the fact that json decodes numbers to floats
+		//if we can convert the number to an int without loss it will be an int
+		if t == float64(int(t)) {
+			return ctypes.ConfigValueInt{Value: int(t)}, nil
+		}
+		return ctypes.ConfigValueFloat{Value: t}, nil
+	case []interface{}:
+		return parseList(t)
+	case map[string]interface{}:
+		m := make(map[string]string, len(t))
+		for k, v := range t {
+			s, err := scalarToString(v)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = s
+		}
+		return ctypes.ConfigValueMap{Value: m}, nil
+	case map[interface{}]interface{}:
+		m := make(map[string]string, len(t))
+		for k, v := range t {
+			s, err := scalarToString(v)
+			if err != nil {
+				return nil, err
+			}
+			m[fmt.Sprint(k)] = s
+		}
+		return ctypes.ConfigValueMap{Value: m}, nil
+	}
+	return nil, fmt.Errorf("unsupported config value %v (%T)", i, i)
+}
+
+// parseList returns a string list when all the elements are strings and a
+// float list when all the elements are numbers.  An empty list is returned
+// as an empty string list.
+func parseList(l []interface{}) (ctypes.ConfigValue, error) {
+	strs := make([]string, 0, len(l))
+	nums := make([]float64, 0, len(l))
+	for _, e := range l {
+		switch v := e.(type) {
 		case string:
-			c.table[k] = ctypes.ConfigValueStr{Value: t}
-		case bool:
-			c.table[k] = ctypes.ConfigValueBool{Value: t}
+			strs = append(strs, v)
+		case float64:
+			nums = append(nums, v)
+		case int:
+			nums = append(nums, float64(v))
 		case json.Number:
-			if v, err := t.Int64(); err == nil {
-				c.table[k] = ctypes.ConfigValueInt{Value: int(v)}
-				continue
-			}
-			if v, err := t.Float64(); err == nil {
-				c.table[k] = ctypes.ConfigValueFloat{Value: v}
-				continue
+			f, err := v.Float64()
+			if err != nil {
+				return nil, err
 			}
+			nums = append(nums, f)
 		default:
-			return fmt.Errorf("Error Unmarshalling JSON ConfigDataNode. Key: %v Type: %v is unsupported.", k, t)
+			return nil, fmt.Errorf("unsupported list element %v (%T)", e, e)
 		}
 	}
-	c.mutex = new(sync.Mutex)
-	return nil
+	switch {
+	case len(nums) == 0:
+		return ctypes.ConfigValueStrList{Value: strs}, nil
+	case len(strs) == 0:
+		return ctypes.ConfigValueFloatList{Value: nums}, nil
+	}
+	return nil, fmt.Errorf("list mixes strings and numbers %v", l)
+}
+
+func scalarToString(i interface{}) (string, error) {
+	switch v := i.(type) {
+	case string:
+		return v, nil
+	case bool, int, float64, json.Number:
+		return fmt.Sprint(v), nil
+	}
+	return "", fmt.Errorf("unsupported map value %v (%T)", i, i)
 }
 
 // Returns a new and empty node.
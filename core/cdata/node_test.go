@@ -22,6 +22,7 @@ limitations under the License.
 package cdata
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/intelsdi-x/snap/core/ctypes"
@@ -84,5 +85,20 @@ func TestConfigDataNode(t *testing.T) {
 			So(t["f"].(ctypes.ConfigValueFloat).Value, ShouldEqual, 2.3)
 			So(len(t), ShouldEqual, 3)
 		})
+		Convey("lists and maps are unmarshalled into typed values", func() {
+			cd := NewNode()
+			err := json.Unmarshal([]byte(`{"hosts":["a","b"],"ports":[80,443],"labels":{"env":"prod"}}`), cd)
+			So(err, ShouldBeNil)
+			t := cd.Table()
+			So(t["hosts"].(ctypes.ConfigValueStrList).Value, ShouldResemble, []string{"a", "b"})
+			So(t["ports"].(ctypes.ConfigValueFloatList).Value, ShouldResemble, []float64{80, 443})
+			So(t["labels"].(ctypes.ConfigValueMap).Value, ShouldResemble, map[string]string{"env": "prod"})
+		})
+
+		Convey("a list of mixed types is rejected", func() {
+			cd := NewNode()
+			err := json.Unmarshal([]byte(`{"mixed":["a",1]}`), cd)
+			So(err, ShouldNotBeNil)
+		})
 	})
 }
@@ -75,6 +75,45 @@ func (c ConfigValueBool) MarshalJSON() ([]byte, error) {
 	return json.Marshal(c.Value)
 }
 
+type ConfigValueStrList struct {
+	Value []string
+}
+
+func (c ConfigValueStrList) Type() string {
+	return "string_list"
+}
+
+func (c ConfigValueStrList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Value)
+}
+
+// ConfigValueFloatList is a list of numbers.  Numbers are held as float64
+// the same way JSON decodes them.
+type ConfigValueFloatList struct {
+	Value []float64
+}
+
+func (c ConfigValueFloatList) Type() string {
+	return "float_list"
+}
+
+func (c ConfigValueFloatList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Value)
+}
+
+// ConfigValueMap is a map of string keys to string values (e.g. labels).
+type ConfigValueMap struct {
+	Value map[string]string
+}
+
+func (c ConfigValueMap) Type() string {
+	return "map"
+}
+
+func (c ConfigValueMap) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Value)
+}
+
 // Returns a slice of string keywords for the types supported by ConfigValue.
 func SupportedTypes() []string {
 	// This is kind of a hack but keeps the definition of types here in
@@ -90,6 +129,12 @@ func SupportedTypes() []string {
 		ConfigValueFloat{}.Type(),
 		// Bool
 		ConfigValueBool{}.Type(),
+		// String list
+		ConfigValueStrList{}.Type(),
+		// Float list
+		ConfigValueFloatList{}.Type(),
+		// Map
+		ConfigValueMap{}.Type(),
 	}
 	return t
 }
@@ -0,0 +1,79 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import "time"
+
+// FireDurationBuckets are the upper bounds of the buckets of the histogram of
+// the durations of the fires of a task
+var FireDurationBuckets = []time.Duration{
+	time.Millisecond * 10,
+	time.Millisecond * 50,
+	time.Millisecond * 100,
+	time.Millisecond * 500,
+	time.Second,
+	time.Second * 5,
+	time.Second * 10,
+	time.Second * 30,
+	time.Minute,
+}
+
+// FireDurations is a histogram of the durations of the fires of a task
+type FireDurations struct {
+	Count uint64
+	Sum   time.Duration
+	Max   time.Duration
+	// Buckets holds the number of fires per bucket of FireDurationBuckets
+	// (the fires which took longer than the bound of the previous bucket and
+	// up to the bound of the bucket) and then the number of fires which took
+	// longer than the last bound
+	Buckets []uint64
+}
+
+// NewFireDurations returns an empty histogram of fire durations
+func NewFireDurations() FireDurations {
+	return FireDurations{Buckets: make([]uint64, len(FireDurationBuckets)+1)}
+}
+
+// Observe records the duration of a fire
+func (f *FireDurations) Observe(d time.Duration) {
+	if len(f.Buckets) != len(FireDurationBuckets)+1 {
+		f.Buckets = make([]uint64, len(FireDurationBuckets)+1)
+	}
+	f.Count++
+	f.Sum += d
+	if d > f.Max {
+		f.Max = d
+	}
+	for i, bound := range FireDurationBuckets {
+		if d <= bound {
+			f.Buckets[i]++
+			return
+		}
+	}
+	f.Buckets[len(FireDurationBuckets)]++
+}
+
+// Copy returns a copy of the histogram
+func (f FireDurations) Copy() FireDurations {
+	c := f
+	c.Buckets = append([]uint64(nil), f.Buckets...)
+	return c
+}
@@ -0,0 +1,51 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFireDurations(t *testing.T) {
+	Convey("Fire durations", t, func() {
+		f := NewFireDurations()
+		f.Observe(time.Millisecond * 5)
+		f.Observe(time.Millisecond * 10)
+		f.Observe(time.Millisecond * 70)
+		f.Observe(time.Hour)
+		So(f.Count, ShouldEqual, 4)
+		So(f.Sum, ShouldEqual, time.Hour+time.Millisecond*85)
+		So(f.Max, ShouldEqual, time.Hour)
+		So(f.Buckets[0], ShouldEqual, 2)
+		So(f.Buckets[2], ShouldEqual, 1)
+		So(f.Buckets[len(FireDurationBuckets)], ShouldEqual, 1)
+
+		Convey("copies do not share their buckets", func() {
+			c := f.Copy()
+			f.Observe(time.Millisecond)
+			So(c.Buckets[0], ShouldEqual, 2)
+		})
+	})
+}
@@ -32,6 +32,9 @@ var (
 	// Standard Tags are in added to the metric by the framework on plugin load.
 	// STD_TAG_PLUGIN_RUNNING_ON describes where the plugin is running (hostname).
 	STD_TAG_PLUGIN_RUNNING_ON = "plugin_running_on"
+	// STD_TAG_LOGICAL_TIMESTAMP is added to the metrics of a run backfilling a
+	// missed interval and holds the time of that interval (RFC 3339).
+	STD_TAG_LOGICAL_TIMESTAMP = "logical_timestamp"
 	nsPriorityList            = []string{"/", "|", "%", ":", "-", ";", "_", "^", ">", "<", "+", "=", "&", "㊽", "Ä", "大", "小", "ᵹ", "☍", "ヒ"}
 )
 
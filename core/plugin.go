@@ -78,6 +78,12 @@ type AvailablePlugin interface {
 type CatalogedPlugin interface {
 	Plugin
 	IsSigned() bool
+	// Signer returns the identity of the signer of the plugin, empty when
+	// the signature of the plugin was not verified
+	Signer() string
+	// SignerRevoked returns whether the key of the signer was revoked
+	// after the plugin was loaded
+	SignerRevoked() bool
 	Status() string
 	PluginPath() string
 	LoadedTimestamp() *time.Time
@@ -93,6 +99,15 @@ type SubscribedPlugin interface {
 	Config() *cdata.ConfigDataNode
 }
 
+// PluginInstallRequest is the request to install a plugin from a plugin index.
+// The latest version listed in the index is installed when the version is
+// less than 1.
+type PluginInstallRequest struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version int    `json:"version,omitempty"`
+}
+
 type RequestedPlugin struct {
 	path       string
 	checkSum   [sha256.Size]byte
@@ -21,6 +21,8 @@ package core
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/intelsdi-x/snap/pkg/schedule"
@@ -31,26 +33,145 @@ type Schedule struct {
 	Interval       string `json:"interval,omitempty"`
 	StartTimestamp *int64 `json:"start_timestamp,omitempty"`
 	StopTimestamp  *int64 `json:"stop_timestamp,omitempty"`
+	// Triggers of a triggered schedule
+	Triggers []schedule.Trigger `json:"triggers,omitempty"`
+	// CatchUp is the policy for the intervals missed by a simple, windowed
+	// or cron schedule
+	CatchUp string `json:"catch-up,omitempty"`
+	// Splay is the bound of a random delay added to each interval of a
+	// simple or cron schedule
+	Splay string `json:"splay,omitempty"`
+	// Exclusions are periods of time during which a simple or cron schedule
+	// does not fire
+	Exclusions []ScheduleExclusion `json:"exclusions,omitempty"`
+	// Windows of a recurring schedule
+	Windows []ScheduleWindow `json:"windows,omitempty"`
 }
 
+// ScheduleWindow is a window of time of a recurring schedule which opens
+// every day or on the given days of the week (monday or mon), optionally in
+// the given weeks of the month only (1 to 5 or -1 for the last one).  Start
+// and stop are times of day as 15:04 or 15:04:05 in the given timezone or in
+// local time.
+type ScheduleWindow struct {
+	Days     []string `json:"days,omitempty"`
+	Weeks    []int    `json:"weeks,omitempty"`
+	Start    string   `json:"start"`
+	Stop     string   `json:"stop"`
+	Timezone string   `json:"timezone,omitempty"`
+}
+
+// ScheduleExclusion is a period of time during which a schedule does not fire
+type ScheduleExclusion struct {
+	StartTimestamp int64 `json:"start_timestamp"`
+	StopTimestamp  int64 `json:"stop_timestamp"`
+}
+
+func (s Schedule) isEmpty() bool {
+	return s.Type == "" && s.Interval == "" && s.StartTimestamp == nil && s.StopTimestamp == nil && len(s.Triggers) == 0 && s.CatchUp == "" && s.Splay == "" && len(s.Exclusions) == 0 && len(s.Windows) == 0
+}
+
+// scheduleError is an error of a schedule with the JSON path of the field of
+// the task manifest it relates to
+type scheduleError struct {
+	path string
+	err  error
+}
+
+func (e *scheduleError) Error() string {
+	return e.err.Error()
+}
+
+// fieldError returns err tagged with the path of the schedule field
+func fieldError(field string, err error) error {
+	return &scheduleError{path: "schedule." + field, err: err}
+}
+
+// schedulePath returns the path of the schedule field an error from
+// makeSchedule relates to
+func schedulePath(err error) string {
+	if se, ok := err.(*scheduleError); ok {
+		return se.path
+	}
+	return "schedule"
+}
+
+// validatedSchedule validates sch unless making it failed with err and tags
+// the errors with the path of the field they relate to
+func validatedSchedule(sch schedule.Schedule, err error) (schedule.Schedule, error) {
+	if err != nil {
+		return nil, err
+	}
+	if err := sch.Validate(); err != nil {
+		switch err {
+		case schedule.ErrInvalidStopTime, schedule.ErrStopBeforeStart:
+			return nil, fieldError("stop_timestamp", err)
+		case schedule.ErrInvalidSplay:
+			return nil, fieldError("splay", err)
+		case schedule.ErrMissingWindows, schedule.ErrWindowNeverOpens:
+			return nil, fieldError("windows", err)
+		case schedule.ErrMissingTriggers:
+			return nil, fieldError("triggers", err)
+		}
+		// the other errors are those of the interval or cron entry
+		return nil, fieldError("interval", err)
+	}
+	return sch, nil
+}
+
+// splayAndExclusions returns the splay and the exclusions of the schedule
+func (s Schedule) splayAndExclusions() (time.Duration, []schedule.Exclusion, error) {
+	var splay time.Duration
+	if s.Splay != "" {
+		d, err := time.ParseDuration(s.Splay)
+		if err != nil {
+			return 0, nil, fieldError("splay", err)
+		}
+		splay = d
+	}
+	var excl []schedule.Exclusion
+	for i, e := range s.Exclusions {
+		ex := schedule.Exclusion{
+			Start: time.Unix(e.StartTimestamp, 0),
+			Stop:  time.Unix(e.StopTimestamp, 0),
+		}
+		if err := ex.Validate(); err != nil {
+			return 0, nil, fieldError(fmt.Sprintf("exclusions[%d]", i), err)
+		}
+		excl = append(excl, ex)
+	}
+	return splay, excl, nil
+}
+
+// makeSchedule returns the schedule described by s.  The errors returned
+// are tagged with the path of the schedule field they relate to.
 func makeSchedule(s Schedule) (schedule.Schedule, error) {
+	if err := schedule.ValidateCatchUp(s.CatchUp); err != nil {
+		return nil, fieldError("catch-up", err)
+	}
+	switch s.Type {
+	case "windowed", "recurring", "triggered":
+		if s.Splay != "" {
+			return nil, fieldError("splay", errors.New("Only simple and cron schedules can have a splay or exclusions"))
+		}
+		if len(s.Exclusions) > 0 {
+			return nil, fieldError("exclusions", errors.New("Only simple and cron schedules can have a splay or exclusions"))
+		}
+	}
 	switch s.Type {
 	case "simple":
 		d, err := time.ParseDuration(s.Interval)
 		if err != nil {
-			return nil, err
+			return nil, fieldError("interval", err)
 		}
 		sch := schedule.NewSimpleSchedule(d)
-
-		err = sch.Validate()
-		if err != nil {
-			return nil, err
-		}
-		return sch, nil
+		sch.CatchUp = s.CatchUp
+		sch.Splay, sch.Exclusions, err = s.splayAndExclusions()
+		return validatedSchedule(sch, err)
 	case "windowed":
 		d, err := time.ParseDuration(s.Interval)
 		if err != nil {
-			return nil, err
+			return nil, fieldError("interval", err)
 		}
 
 		var start, stop *time.Time
@@ -67,24 +188,115 @@ func makeSchedule(s Schedule) (schedule.Schedule, error) {
 			start,
 			stop,
 		)
-
-		err = sch.Validate()
-		if err != nil {
-			return nil, err
-		}
-		return sch, nil
+		sch.CatchUp = s.CatchUp
+		return validatedSchedule(sch, nil)
 	case "cron":
 		if s.Interval == "" {
-			return nil, errors.New("missing cron entry")
+			return nil, fieldError("interval", errors.New("missing cron entry"))
 		}
 		sch := schedule.NewCronSchedule(s.Interval)
-
-		err := sch.Validate()
+		sch.CatchUp = s.CatchUp
+		var err error
+		sch.Splay, sch.Exclusions, err = s.splayAndExclusions()
+		return validatedSchedule(sch, err)
+	case "recurring":
+		d, err := time.ParseDuration(s.Interval)
 		if err != nil {
-			return nil, err
+			return nil, fieldError("interval", err)
+		}
+		var windows []schedule.Window
+		for i, sw := range s.Windows {
+			w, err := sw.window()
+			if err == nil {
+				err = w.Validate()
+			}
+			if err != nil {
+				return nil, fieldError(fmt.Sprintf("windows[%d]", i), err)
+			}
+			windows = append(windows, w)
+		}
+		sch := schedule.NewRecurringSchedule(d, windows...)
+		sch.CatchUp = s.CatchUp
+		return validatedSchedule(sch, nil)
+	case "triggered":
+		if s.CatchUp != "" {
+			return nil, fieldError("catch-up", errors.New("A triggered schedule cannot have a catch-up policy"))
+		}
+		for i, tr := range s.Triggers {
+			if err := schedule.NewTriggeredSchedule(tr).Validate(); err != nil {
+				return nil, fieldError(fmt.Sprintf("triggers[%d]", i), err)
+			}
 		}
-		return sch, nil
+		return validatedSchedule(schedule.NewTriggeredSchedule(s.Triggers...), nil)
 	default:
-		return nil, errors.New("unknown schedule type " + s.Type)
+		return nil, fieldError("type", errors.New("unknown schedule type "+s.Type))
+	}
+}
+
+// NewScheduleWindow returns the ScheduleWindow of a window of a recurring
+// schedule
+func NewScheduleWindow(w schedule.Window) ScheduleWindow {
+	sw := ScheduleWindow{
+		Weeks: w.Weeks,
+		Start: formatTimeOfDay(w.Start),
+		Stop:  formatTimeOfDay(w.Stop),
+	}
+	for _, d := range w.Days {
+		sw.Days = append(sw.Days, strings.ToLower(d.String()))
+	}
+	if w.Location != nil {
+		sw.Timezone = w.Location.String()
+	}
+	return sw
+}
+
+func (sw ScheduleWindow) window() (schedule.Window, error) {
+	w := schedule.Window{Weeks: sw.Weeks}
+	for _, name := range sw.Days {
+		d, err := parseWeekday(name)
+		if err != nil {
+			return schedule.Window{}, err
+		}
+		w.Days = append(w.Days, d)
+	}
+	var err error
+	if w.Start, err = parseTimeOfDay(sw.Start); err != nil {
+		return schedule.Window{}, err
+	}
+	if w.Stop, err = parseTimeOfDay(sw.Stop); err != nil {
+		return schedule.Window{}, err
+	}
+	if sw.Timezone != "" {
+		if w.Location, err = time.LoadLocation(sw.Timezone); err != nil {
+			return schedule.Window{}, fmt.Errorf("Invalid window timezone: %v", err)
+		}
+	}
+	return w, nil
+}
+
+func parseWeekday(name string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		full := strings.ToLower(d.String())
+		if n := strings.ToLower(name); n == full || n == full[:3] {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("Invalid window day '%s'", name)
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second, nil
+		}
+	}
+	return 0, fmt.Errorf("Invalid window time of day '%s', expected 15:04 or 15:04:05", s)
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	t := time.Date(0, 1, 1, 0, 0, 0, int(d), time.UTC)
+	if t.Second() != 0 {
+		return t.Format("15:04:05")
 	}
+	return t.Format("15:04")
 }
@@ -26,6 +26,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/intelsdi-x/snap/pkg/schedule"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -165,4 +166,131 @@ func TestMakeSchedule(t *testing.T) {
 		So(err, ShouldNotBeNil)
 		So(err.Error(), ShouldStartWith, "Expected 5 or 6 fields, found ")
 	})
+
+	Convey("Simple schedule with a catch-up policy", t, func() {
+		sched1 := &Schedule{Type: "simple", Interval: "1s", CatchUp: schedule.CatchUpBackfill}
+		rsched, err := makeSchedule(*sched1)
+		So(err, ShouldBeNil)
+		So(rsched.(schedule.CatchUpSchedule).CatchUpPolicy(), ShouldEqual, schedule.CatchUpBackfill)
+	})
+
+	Convey("Cron schedule with an invalid catch-up policy", t, func() {
+		sched1 := &Schedule{Type: "cron", Interval: "0 * * * * *", CatchUp: "replay"}
+		rsched, err := makeSchedule(*sched1)
+		So(rsched, ShouldBeNil)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Triggered schedule with a catch-up policy", t, func() {
+		sched1 := &Schedule{Type: "triggered", Triggers: []schedule.Trigger{{Task: "inventory", On: schedule.TriggerOnSuccess}}, CatchUp: schedule.CatchUpOnce}
+		rsched, err := makeSchedule(*sched1)
+		So(rsched, ShouldBeNil)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Cron schedule with a timezone, a splay and an exclusion", t, func() {
+		sched1 := &Schedule{
+			Type:       "cron",
+			Interval:   "TZ=UTC 0 0 * * * *",
+			Splay:      "30s",
+			Exclusions: []ScheduleExclusion{{StartTimestamp: 1451613600, StopTimestamp: 1451620800}},
+		}
+		rsched, err := makeSchedule(*sched1)
+		So(err, ShouldBeNil)
+		csched := rsched.(*schedule.CronSchedule)
+		So(csched.Splay, ShouldEqual, 30*time.Second)
+		So(csched.Exclusions, ShouldHaveLength, 1)
+		So(csched.Exclusions[0].Stop.Unix(), ShouldEqual, 1451620800)
+	})
+
+	Convey("Simple schedule with a splay not smaller than its interval", t, func() {
+		sched1 := &Schedule{Type: "simple", Interval: "1s", Splay: "1s"}
+		rsched, err := makeSchedule(*sched1)
+		So(rsched, ShouldBeNil)
+		So(err.Error(), ShouldEqual, schedule.ErrInvalidSplay.Error())
+		So(schedulePath(err), ShouldEqual, "schedule.splay")
+	})
+
+	Convey("Simple schedule with an exclusion stopping before it starts", t, func() {
+		sched1 := &Schedule{Type: "simple", Interval: "1s", Exclusions: []ScheduleExclusion{{StartTimestamp: 2, StopTimestamp: 1}}}
+		rsched, err := makeSchedule(*sched1)
+		So(rsched, ShouldBeNil)
+		So(err.Error(), ShouldEqual, schedule.ErrInvalidExclusion.Error())
+		So(schedulePath(err), ShouldEqual, "schedule.exclusions[0]")
+	})
+
+	Convey("Windowed schedule with a splay", t, func() {
+		sched1 := &Schedule{Type: "windowed", Interval: "1s", Splay: "100ms"}
+		rsched, err := makeSchedule(*sched1)
+		So(rsched, ShouldBeNil)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Recurring schedule with weekday windows", t, func() {
+		sw := ScheduleWindow{Days: []string{"mon", "Tuesday", "wed", "thu", "fri"}, Start: "09:00", Stop: "17:30", Timezone: "UTC"}
+		sched1 := &Schedule{Type: "recurring", Interval: "10m", Windows: []ScheduleWindow{sw}}
+		rsched, err := makeSchedule(*sched1)
+		So(err, ShouldBeNil)
+		rs := rsched.(*schedule.RecurringSchedule)
+		So(rs.Windows, ShouldHaveLength, 1)
+		So(rs.Windows[0].Days, ShouldResemble, []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday})
+		So(rs.Windows[0].Stop, ShouldEqual, 17*time.Hour+30*time.Minute)
+		So(NewScheduleWindow(rs.Windows[0]), ShouldResemble, ScheduleWindow{
+			Days:     []string{"monday", "tuesday", "wednesday", "thursday", "friday"},
+			Start:    "09:00",
+			Stop:     "17:30",
+			Timezone: "UTC",
+		})
+	})
+
+	Convey("Recurring schedule without windows", t, func() {
+		sched1 := &Schedule{Type: "recurring", Interval: "10m"}
+		rsched, err := makeSchedule(*sched1)
+		So(rsched, ShouldBeNil)
+		So(err.Error(), ShouldEqual, schedule.ErrMissingWindows.Error())
+		So(schedulePath(err), ShouldEqual, "schedule.windows")
+	})
+
+	Convey("Recurring schedule with an invalid window", t, func() {
+		sched1 := &Schedule{Type: "recurring", Interval: "10m", Windows: []ScheduleWindow{{Days: []string{"someday"}, Start: "09:00", Stop: "17:00"}}}
+		_, err := makeSchedule(*sched1)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "Invalid window day 'someday'")
+		So(schedulePath(err), ShouldEqual, "schedule.windows[0]")
+		sched1.Windows = []ScheduleWindow{{Start: "9am", Stop: "17:00"}}
+		_, err = makeSchedule(*sched1)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Triggered schedule without triggers", t, func() {
+		sched1 := &Schedule{Type: "triggered"}
+		rsched, err := makeSchedule(*sched1)
+		So(rsched, ShouldBeNil)
+		So(err.Error(), ShouldEqual, schedule.ErrMissingTriggers.Error())
+		So(schedulePath(err), ShouldEqual, "schedule.triggers")
+	})
+
+	Convey("Schedule errors are tagged with the path of their field", t, func() {
+		_, err := makeSchedule(Schedule{Type: "cron", Interval: "* * *"})
+		So(schedulePath(err), ShouldEqual, "schedule.interval")
+		_, err = makeSchedule(Schedule{Type: "cron", Interval: "* * * * *", Splay: "soon"})
+		So(schedulePath(err), ShouldEqual, "schedule.splay")
+		_, err = makeSchedule(Schedule{Type: "simple", Interval: "1s", CatchUp: "sometimes"})
+		So(schedulePath(err), ShouldEqual, "schedule.catch-up")
+		_, err = makeSchedule(Schedule{Type: "windowed", Interval: "1s", Exclusions: []ScheduleExclusion{{StartTimestamp: 1, StopTimestamp: 2}}})
+		So(schedulePath(err), ShouldEqual, "schedule.exclusions")
+		_, err = makeSchedule(Schedule{Type: "recurring", Interval: "10m", Windows: []ScheduleWindow{{Start: "09:00", Stop: "17:00", Timezone: "Nowhere/Nothing"}}})
+		So(schedulePath(err), ShouldEqual, "schedule.windows[0]")
+		_, err = makeSchedule(Schedule{Type: "triggered", Triggers: []schedule.Trigger{{Task: "inventory", On: schedule.TriggerOnSuccess}, {Task: "inventory", On: "never"}}})
+		So(schedulePath(err), ShouldEqual, "schedule.triggers[1]")
+		_, err = makeSchedule(Schedule{Type: "hourly"})
+		So(schedulePath(err), ShouldEqual, "schedule.type")
+	})
+
+	Convey("Triggered schedule with a trigger", t, func() {
+		sched1 := &Schedule{Type: "triggered", Triggers: []schedule.Trigger{{Task: "inventory", On: schedule.TriggerOnSuccess}}}
+		rsched, err := makeSchedule(*sched1)
+		So(err, ShouldBeNil)
+		So(rsched, ShouldNotBeNil)
+	})
 }
@@ -29,6 +29,8 @@ const (
 	TaskStarted            = "Scheduler.TaskStarted"
 	TaskStopped            = "Scheduler.TaskStopped"
 	TaskDisabled           = "Scheduler.TaskDisabled"
+	TaskEnded              = "Scheduler.TaskEnded"
+	TaskRunSucceeded       = "Scheduler.TaskRunSucceeded"
 	MetricCollected        = "Scheduler.MetricsCollected"
 	MetricCollectionFailed = "Scheduler.MetricCollectionFailed"
 )
@@ -79,6 +81,22 @@ func (e TaskDisabledEvent) Namespace() string {
 	return TaskDisabled
 }
 
+type TaskEndedEvent struct {
+	TaskID string
+}
+
+func (e TaskEndedEvent) Namespace() string {
+	return TaskEnded
+}
+
+type TaskRunSucceededEvent struct {
+	TaskID string
+}
+
+func (e TaskRunSucceededEvent) Namespace() string {
+	return TaskRunSucceeded
+}
+
 type MetricCollectedEvent struct {
 	TaskID  string
 	Metrics []core.Metric
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"strings"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
@@ -56,6 +57,47 @@ var (
 	}
 )
 
+const (
+	// TaskOverlapSkip - a task due to fire while it is still firing does not
+	// fire and the interval is missed (the default)
+	TaskOverlapSkip = "skip"
+	// TaskOverlapConcurrent - a task fires up to its max-concurrent times at
+	// once, an interval due while as many fires are running is missed
+	TaskOverlapConcurrent = "concurrent"
+	// TaskOverlapCancel - a task due to fire while it is still firing cancels
+	// the running fire and fires again.  The canceled fire submits no more
+	// jobs but the job it is running is not interrupted.
+	TaskOverlapCancel = "cancel"
+)
+
+const (
+	// TaskPriorityHigh - the jobs of a task are favored over the jobs of the
+	// tasks of lower priority
+	TaskPriorityHigh = "high"
+	// TaskPriorityNormal - the priority of a task (the default)
+	TaskPriorityNormal = "normal"
+	// TaskPriorityLow - the jobs of a task give way to the jobs of the tasks
+	// of higher priority
+	TaskPriorityLow = "low"
+)
+
+// TaskPriorities are the priority classes of tasks from the highest
+var TaskPriorities = []string{TaskPriorityHigh, TaskPriorityNormal, TaskPriorityLow}
+
+// ValidatePriority returns an error if the priority class is not known.  An
+// empty priority is the normal one.
+func ValidatePriority(priority string) error {
+	if priority == "" {
+		return nil
+	}
+	for _, p := range TaskPriorities {
+		if p == priority {
+			return nil
+		}
+	}
+	return fmt.Errorf("Priority '%s' is not valid, must be one of %s", priority, strings.Join(TaskPriorities, ", "))
+}
+
 type TaskWatcherCloser interface {
 	Close() error
 }
@@ -89,6 +131,14 @@ type Task interface {
 	SetTaskID(id string)
 	SetStopOnFailure(int)
 	GetStopOnFailure() int
+	SetOverlap(string, int)
+	GetOverlap() (string, int)
+	FireDurations() FireDurations
+	// History returns the records of the last fires of the task from the
+	// most recent
+	History() []TaskRun
+	SetPriority(string)
+	GetPriority() string
 	Option(...TaskOption) TaskOption
 	WMap() *wmap.WorkflowMap
 	Schedule() schedule.Schedule
@@ -133,6 +183,69 @@ func OptionStopOnFailure(v int) TaskOption {
 	}
 }
 
+// OptionOverlap sets what a task due to fire while it is still firing does
+// (see TaskOverlapSkip) and how many times the task can fire at once with
+// TaskOverlapConcurrent.
+func OptionOverlap(policy string, maxConcurrent int) TaskOption {
+	return func(t Task) TaskOption {
+		previous, previousMax := t.GetOverlap()
+		t.SetOverlap(policy, maxConcurrent)
+		log.WithFields(log.Fields{
+			"_module":        "core",
+			"_block":         "OptionOverlap",
+			"task-id":        t.ID(),
+			"task-name":      t.GetName(),
+			"overlap":        policy,
+			"max-concurrent": maxConcurrent,
+		}).Debug("Setting overlap policy for task")
+		return OptionOverlap(previous, previousMax)
+	}
+}
+
+// OptionPriority sets the priority class of a task (see TaskPriorityNormal)
+func OptionPriority(priority string) TaskOption {
+	return func(t Task) TaskOption {
+		previous := t.GetPriority()
+		t.SetPriority(priority)
+		log.WithFields(log.Fields{
+			"_module":   "core",
+			"_block":    "OptionPriority",
+			"task-id":   t.ID(),
+			"task-name": t.GetName(),
+			"priority":  priority,
+		}).Debug("Setting priority for task")
+		return OptionPriority(previous)
+	}
+}
+
+func validateOverlap(policy string, maxConcurrent int) error {
+	switch policy {
+	case TaskOverlapConcurrent:
+		if maxConcurrent < 1 {
+			return fmt.Errorf("The %s overlap policy requires max-concurrent to be at least 1", TaskOverlapConcurrent)
+		}
+		return nil
+	case "", TaskOverlapSkip, TaskOverlapCancel:
+		if maxConcurrent != 0 {
+			return fmt.Errorf("max-concurrent requires the %s overlap policy", TaskOverlapConcurrent)
+		}
+		return nil
+	}
+	return fmt.Errorf("Overlap policy '%s' is not valid, must be one of %s, %s or %s", policy, TaskOverlapSkip, TaskOverlapConcurrent, TaskOverlapCancel)
+}
+
+// overlapOption returns the option setting the overlap policy of the request
+// if the request has one
+func overlapOption(tr *TaskCreationRequest) (TaskOption, error) {
+	if tr.Overlap == "" && tr.MaxConcurrent == 0 {
+		return nil, nil
+	}
+	if err := validateOverlap(tr.Overlap, tr.MaxConcurrent); err != nil {
+		return nil, err
+	}
+	return OptionOverlap(tr.Overlap, tr.MaxConcurrent), nil
+}
+
 // SetTaskName sets the name of the task.
 // This is optional.
 // If task name is not set, the task name is then defaulted to "Task-<task-id>"
@@ -163,7 +276,13 @@ type TaskCreationRequest struct {
 	Workflow    *wmap.WorkflowMap `json:"workflow"`
 	Schedule    *Schedule         `json:"schedule"`
 	Start       bool              `json:"start"`
-	MaxFailures int               `json:"max-failures"`
+	MaxFailures *int              `json:"max-failures,omitempty"`
+	// Overlap is what the task does when it is due to fire while it is still
+	// firing (see TaskOverlapSkip)
+	Overlap       string `json:"overlap,omitempty"`
+	MaxConcurrent int    `json:"max-concurrent,omitempty"`
+	// Priority is the priority class of the task (see TaskPriorityNormal)
+	Priority string `json:"priority,omitempty"`
 }
 
 func (tr *TaskCreationRequest) UnmarshalJSON(data []byte) error {
@@ -197,6 +316,18 @@ func (tr *TaskCreationRequest) UnmarshalJSON(data []byte) error {
 			if err := json.Unmarshal(v, &(tr.MaxFailures)); err != nil {
 				return fmt.Errorf("%v (while parsing 'max-failures')", err)
 			}
+		case "overlap":
+			if err := json.Unmarshal(v, &(tr.Overlap)); err != nil {
+				return fmt.Errorf("%v (while parsing 'overlap')", err)
+			}
+		case "max-concurrent":
+			if err := json.Unmarshal(v, &(tr.MaxConcurrent)); err != nil {
+				return fmt.Errorf("%v (while parsing 'max-concurrent')", err)
+			}
+		case "priority":
+			if err := json.Unmarshal(v, &(tr.Priority)); err != nil {
+				return fmt.Errorf("%v (while parsing 'priority')", err)
+			}
 		case "version":
 			if err := json.Unmarshal(v, &(tr.Version)); err != nil {
 				return fmt.Errorf("%v (while parsing 'version')", err)
@@ -212,14 +343,28 @@ func (tr *TaskCreationRequest) UnmarshalJSON(data []byte) error {
 // . Content can be retrieved from a configuration file or a HTTP REST request body
 // . Mode is used to specify if the created task should start right away or not
 // . function pointer is responsible for effectively creating and returning the created task
+// Parameters declared by the content are expanded with their defaults.
 func CreateTaskFromContent(body io.ReadCloser,
 	mode *bool,
 	fp func(sch schedule.Schedule,
 		wfMap *wmap.WorkflowMap,
 		startOnCreate bool,
 		opts ...TaskOption) (Task, TaskErrors)) (Task, error) {
+	return CreateTaskFromTemplate(body, nil, mode, fp)
+}
 
-	tr, err := createTaskRequest(body)
+// CreateTaskFromTemplate creates a task according to content like
+// CreateTaskFromContent after expanding the parameters declared by the
+// content with the given values (see ExpandTaskTemplate).
+func CreateTaskFromTemplate(body io.ReadCloser,
+	params map[string]interface{},
+	mode *bool,
+	fp func(sch schedule.Schedule,
+		wfMap *wmap.WorkflowMap,
+		startOnCreate bool,
+		opts ...TaskOption) (Task, TaskErrors)) (Task, error) {
+
+	tr, err := createTaskRequestWithParams(body, params)
 	if err != nil {
 		return nil, err
 	}
@@ -247,9 +392,24 @@ func CreateTaskFromContent(body io.ReadCloser,
 	}
 
 	// if a MaxFailures value is included as part of the task creation request
-	if tr.MaxFailures != 0 {
+	if tr.MaxFailures != nil && *tr.MaxFailures != 0 {
 		// then set the appropriate value in the opts
-		opts = append(opts, OptionStopOnFailure(tr.MaxFailures))
+		opts = append(opts, OptionStopOnFailure(*tr.MaxFailures))
+	}
+
+	overlap, err := overlapOption(tr)
+	if err != nil {
+		return nil, err
+	}
+	if overlap != nil {
+		opts = append(opts, overlap)
+	}
+
+	if tr.Priority != "" {
+		if err := ValidatePriority(tr.Priority); err != nil {
+			return nil, err
+		}
+		opts = append(opts, OptionPriority(tr.Priority))
 	}
 
 	if mode == nil {
@@ -269,10 +429,169 @@ func CreateTaskFromContent(body io.ReadCloser,
 	return task, nil
 }
 
+// UpdateTaskFromContent updates the task with the given id according to
+// content (2nd parameter) which uses the format of a task creation request.
+// Only the schedule, workflow, name, deadline, max-failures, overlap and
+// priority of a task can be changed and only the fields present in the content are changed.
+// A max-failures of 0 sets the default limit, as it does on task creation.
+// Parameters declared by the content are expanded with their defaults (see
+// ExpandTaskTemplate).
+// The function pointer is responsible for effectively updating the task.
+func UpdateTaskFromContent(id string,
+	body io.ReadCloser,
+	fp func(id string,
+		sch schedule.Schedule,
+		wfMap *wmap.WorkflowMap,
+		opts ...TaskOption) (Task, TaskErrors)) (Task, error) {
+
+	tr, err := createTaskRequestWithParams(body, nil)
+	if err != nil {
+		return nil, err
+	}
+	if tr.Start {
+		return nil, errors.New("A task update cannot start a task")
+	}
+
+	var sch schedule.Schedule
+	if tr.Schedule != nil {
+		if tr.Schedule.isEmpty() {
+			return nil, errors.New("The schedule of a task update must not be empty")
+		}
+		sch, err = makeSchedule(*tr.Schedule)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if tr.Workflow != nil && *tr.Workflow == (wmap.WorkflowMap{}) {
+		return nil, errors.New("The workflow of a task update must not be empty")
+	}
+
+	var opts []TaskOption
+	if tr.Deadline != "" {
+		dl, err := time.ParseDuration(tr.Deadline)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, TaskDeadlineDuration(dl))
+	}
+	if tr.Name != "" {
+		opts = append(opts, SetTaskName(tr.Name))
+	}
+	if tr.MaxFailures != nil {
+		opts = append(opts, OptionStopOnFailure(*tr.MaxFailures))
+	}
+	overlap, err := overlapOption(tr)
+	if err != nil {
+		return nil, err
+	}
+	if overlap != nil {
+		opts = append(opts, overlap)
+	}
+	if tr.Priority != "" {
+		if err := ValidatePriority(tr.Priority); err != nil {
+			return nil, err
+		}
+		opts = append(opts, OptionPriority(tr.Priority))
+	}
+
+	if sch == nil && tr.Workflow == nil && len(opts) == 0 {
+		return nil, errors.New("A task update must change at least one of schedule, workflow, name, deadline, max-failures, overlap or priority")
+	}
+	if fp == nil {
+		return nil, errors.New("Missing task update routine")
+	}
+	task, errs := fp(id, sch, tr.Workflow, opts...)
+	if errs != nil && len(errs.Errors()) != 0 {
+		var errMsg string
+		for _, e := range errs.Errors() {
+			errMsg = errMsg + e.Error() + " -- "
+		}
+		return nil, errors.New(errMsg[:len(errMsg)-4])
+	}
+	return task, nil
+}
+
+// ValidateTaskFromContent validates the task in content (1st parameter)
+// without creating it. Every error found is returned with a "path" field
+// holding the JSON path of the manifest field the error relates to.
+// Parameters declared by the content are expanded with their defaults first.
+// . function pointer is responsible for validating the schedule and the
+// workflow against the plugins that are loaded
+func ValidateTaskFromContent(body io.ReadCloser,
+	fp func(sch schedule.Schedule,
+		wfMap *wmap.WorkflowMap) TaskErrors) ([]serror.SnapError, error) {
+
+	tr, err := createTaskRequestWithParams(body, nil)
+	if err != nil {
+		return nil, err
+	}
+	if fp == nil {
+		return nil, errors.New("Missing task validation routine")
+	}
+
+	serrs := []serror.SnapError{}
+	var sch schedule.Schedule
+	if tr.Schedule == nil || tr.Schedule.isEmpty() {
+		serrs = append(serrs, withPath(errors.New("Task must include a schedule, and the schedule must not be empty"), "schedule"))
+	} else {
+		sch, err = makeSchedule(*tr.Schedule)
+		if err != nil {
+			serrs = append(serrs, withPath(err, schedulePath(err)))
+		}
+	}
+	if tr.Deadline != "" {
+		if _, err := time.ParseDuration(tr.Deadline); err != nil {
+			serrs = append(serrs, withPath(err, "deadline"))
+		}
+	}
+	if _, err := overlapOption(tr); err != nil {
+		serrs = append(serrs, withPath(err, "overlap"))
+	}
+	if err := ValidatePriority(tr.Priority); err != nil {
+		serrs = append(serrs, withPath(err, "priority"))
+	}
+	if tr.Workflow == nil || *tr.Workflow == (wmap.WorkflowMap{}) {
+		serrs = append(serrs, withPath(errors.New("Task must include a workflow, and the workflow must not be empty"), "workflow"))
+		return serrs, nil
+	}
+	if errs := fp(sch, tr.Workflow); errs != nil {
+		for _, e := range errs.Errors() {
+			if _, ok := e.Fields()["path"]; !ok {
+				fields := map[string]interface{}{"path": "workflow"}
+				for k, v := range e.Fields() {
+					fields[k] = v
+				}
+				e.SetFields(fields)
+			}
+			serrs = append(serrs, e)
+		}
+	}
+	return serrs, nil
+}
+
+func withPath(err error, path string) serror.SnapError {
+	return serror.New(err, map[string]interface{}{"path": path})
+}
+
 func createTaskRequest(body io.ReadCloser) (*TaskCreationRequest, error) {
 	var tr TaskCreationRequest
-	errCode, err := UnmarshalBody(&tr, body)
-	if errCode != 0 && err != nil {
+	if _, err := UnmarshalBody(&tr, body); err != nil {
+		return nil, err
+	}
+	return &tr, nil
+}
+
+func createTaskRequestWithParams(body io.ReadCloser, params map[string]interface{}) (*TaskCreationRequest, error) {
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	b, err = ExpandTaskTemplate(b, params)
+	if err != nil {
+		return nil, err
+	}
+	var tr TaskCreationRequest
+	if err := json.Unmarshal(b, &tr); err != nil {
 		return nil, err
 	}
 	return &tr, nil
@@ -291,7 +610,7 @@ func UnmarshalBody(in interface{}, body io.ReadCloser) (int, error) {
 }
 
 func validateTaskRequest(tr *TaskCreationRequest) error {
-	if tr.Schedule == nil || *tr.Schedule == (Schedule{}) {
+	if tr.Schedule == nil || tr.Schedule.isEmpty() {
 		return fmt.Errorf("Task must include a schedule, and the schedule must not be empty")
 	}
 
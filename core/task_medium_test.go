@@ -24,8 +24,11 @@ package core
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/intelsdi-x/snap/core/serror"
 	"github.com/intelsdi-x/snap/pkg/schedule"
@@ -335,3 +338,126 @@ func TestCreateTaskFromContent(t *testing.T) {
 		So(err, ShouldBeNil)
 	})
 }
+
+func TestUpdateTaskFromContent(t *testing.T) {
+	var opts []TaskOption
+	updateRoutine := func(id string, sch schedule.Schedule, wfMap *wmap.WorkflowMap, o ...TaskOption) (Task, TaskErrors) {
+		opts = o
+		return nil, nil
+	}
+
+	Convey("An update setting max-failures to 0 changes it", t, func() {
+		opts = nil
+		body := ioutil.NopCloser(strings.NewReader(`{"max-failures": 0}`))
+		_, err := UpdateTaskFromContent("id", body, updateRoutine)
+		So(err, ShouldBeNil)
+		So(opts, ShouldHaveLength, 1)
+	})
+
+	Convey("An update without max-failures does not change it", t, func() {
+		opts = nil
+		body := ioutil.NopCloser(strings.NewReader(`{"name": "task"}`))
+		_, err := UpdateTaskFromContent("id", body, updateRoutine)
+		So(err, ShouldBeNil)
+		So(opts, ShouldHaveLength, 1)
+	})
+
+	Convey("An update expands parameters with their defaults", t, func() {
+		opts = nil
+		body := ioutil.NopCloser(strings.NewReader(`{"parameters": {"name": {"default": "task"}}, "name": "${name}"}`))
+		_, err := UpdateTaskFromContent("id", body, updateRoutine)
+		So(err, ShouldBeNil)
+		So(opts, ShouldHaveLength, 1)
+	})
+
+	Convey("An update with a parameter without default fails", t, func() {
+		body := ioutil.NopCloser(strings.NewReader(`{"parameters": {"name": {}}, "name": "${name}"}`))
+		_, err := UpdateTaskFromContent("id", body, updateRoutine)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "no default")
+	})
+}
+
+func TestValidateTaskFromContent(t *testing.T) {
+	validateRoutine := func(sch schedule.Schedule, wfMap *wmap.WorkflowMap) TaskErrors {
+		return &taskErrors{
+			errs: []serror.SnapError{
+				serror.New(errors.New("Metric not found"), map[string]interface{}{"path": `workflow.collect.metrics["/intel/mock/foo"]`}),
+				serror.New(errors.New("Dummy error"), map[string]interface{}{"name": "mock"}),
+			},
+		}
+	}
+
+	Convey("Bad JSON file", t, func() {
+		err := createTaskFile(YAML_FILE, YAML_FILE_CONTENT)
+		So(err, ShouldBeNil)
+
+		file, err := os.Open(YAML_FILE)
+		So(err, ShouldBeNil)
+		errs, err := ValidateTaskFromContent(file, validateRoutine)
+		So(errs, ShouldBeNil)
+		So(err, ShouldNotBeNil)
+
+		err = deleteTaskFile(YAML_FILE)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Proper JSON file no validation routine", t, func() {
+		err := createTaskFile(JSON_FILE, JSON_FILE_CONTENT)
+		So(err, ShouldBeNil)
+
+		file, err := os.Open(JSON_FILE)
+		So(err, ShouldBeNil)
+		_, err = ValidateTaskFromContent(file, nil)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "Missing task validation routine")
+
+		err = deleteTaskFile(JSON_FILE)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Proper JSON file erroring routine", t, func() {
+		err := createTaskFile(JSON_FILE, JSON_FILE_CONTENT)
+		So(err, ShouldBeNil)
+
+		file, err := os.Open(JSON_FILE)
+		So(err, ShouldBeNil)
+		errs, err := ValidateTaskFromContent(file, validateRoutine)
+		So(err, ShouldBeNil)
+		So(errs, ShouldHaveLength, 2)
+		So(errs[0].Fields()["path"], ShouldEqual, `workflow.collect.metrics["/intel/mock/foo"]`)
+		So(errs[1].Fields()["path"], ShouldEqual, "workflow")
+		So(errs[1].Fields()["name"], ShouldEqual, "mock")
+
+		err = deleteTaskFile(JSON_FILE)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("A template with defaults for all its parameters is expanded", t, func() {
+		var interval time.Duration
+		routine := func(sch schedule.Schedule, wfMap *wmap.WorkflowMap) TaskErrors {
+			interval = sch.(*schedule.SimpleSchedule).Interval
+			return nil
+		}
+		body := ioutil.NopCloser(strings.NewReader(`{
+			"parameters": {"interval": {"default": "5s"}, "metric": {"default": "/intel/mock/foo"}},
+			"schedule": {"type": "simple", "interval": "${interval}"},
+			"workflow": {"collect": {"metrics": {"${metric}": {}}}}
+		}`))
+		errs, err := ValidateTaskFromContent(body, routine)
+		So(err, ShouldBeNil)
+		So(errs, ShouldBeEmpty)
+		So(interval, ShouldEqual, 5*time.Second)
+	})
+
+	Convey("Every invalid field is reported with its path", t, func() {
+		body := ioutil.NopCloser(strings.NewReader(`{"schedule": {"type": "simple", "interval": "0s"}, "deadline": "soon"}`))
+		errs, err := ValidateTaskFromContent(body, validateRoutine)
+		So(err, ShouldBeNil)
+		So(errs, ShouldHaveLength, 3)
+		So(errs[0].Error(), ShouldEqual, schedule.ErrInvalidInterval.Error())
+		So(errs[0].Fields()["path"], ShouldEqual, "schedule.interval")
+		So(errs[1].Fields()["path"], ShouldEqual, "deadline")
+		So(errs[2].Fields()["path"], ShouldEqual, "workflow")
+	})
+}
@@ -0,0 +1,60 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import "time"
+
+// TaskRun is the record of a fire of a task
+type TaskRun struct {
+	// FireTime is when the task fired
+	FireTime time.Time
+	// LogicalTime is the time of the missed interval a backfilling fire ran
+	// for (zero for the other fires)
+	LogicalTime time.Time
+	Duration    time.Duration
+	Failed      bool
+	Canceled    bool
+	// Nodes are the stages of the workflow the fire ran in the order they
+	// ended
+	Nodes []TaskRunNode
+}
+
+// TaskRunNode is the record of a stage of the workflow of a fire of a task:
+// the collection of the metrics or a process or publish node
+type TaskRunNode struct {
+	// Type is "collector", "processor" or "publisher"
+	Type string
+	// Name and Version are the plugin of a process or publish node
+	Name     string
+	Version  int
+	Duration time.Duration
+	// MetricCount is the number of metrics collected, returned by a processor
+	// or published
+	MetricCount int
+	Errors      []string
+}
+
+// Copy returns a copy of the record which does not share its nodes
+func (r TaskRun) Copy() TaskRun {
+	nodes := make([]TaskRunNode, len(r.Nodes))
+	copy(nodes, r.Nodes)
+	r.Nodes = nodes
+	return r
+}
@@ -0,0 +1,54 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOverlapPolicy(t *testing.T) {
+	Convey("Overlap policy", t, func() {
+		So(validateOverlap("", 0), ShouldBeNil)
+		So(validateOverlap(TaskOverlapSkip, 0), ShouldBeNil)
+		So(validateOverlap(TaskOverlapCancel, 0), ShouldBeNil)
+		So(validateOverlap(TaskOverlapConcurrent, 3), ShouldBeNil)
+		So(validateOverlap(TaskOverlapConcurrent, 0), ShouldNotBeNil)
+		So(validateOverlap(TaskOverlapSkip, 2), ShouldNotBeNil)
+		So(validateOverlap("queue", 0), ShouldNotBeNil)
+
+		Convey("of a task creation request is validated", func() {
+			body := ioutil.NopCloser(bytes.NewBufferString(`{
+				"version": 1,
+				"schedule": {"type": "simple", "interval": "1s"},
+				"workflow": {"collect": {"metrics": {"/foo": {}}}},
+				"overlap": "queue"
+			}`))
+			_, err := CreateTaskFromContent(body, nil, nil)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "Overlap policy 'queue' is not valid")
+		})
+	})
+}
@@ -0,0 +1,223 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// The sections of a task manifest in which parameter placeholders are expanded
+var templatedSections = []string{"name", "deadline", "schedule", "workflow"}
+
+var (
+	// A placeholder is written ${name} where name is a declared parameter.
+	// $${ is an escaped ${ which is not expanded.
+	placeholderRegexp = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_.-]*)\}`)
+	paramNameRegexp   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+)
+
+// TaskParameter declares a parameter of a task manifest.  A parameter without
+// a default must be given a value when the task is created.
+type TaskParameter struct {
+	Default     interface{} `json:"default,omitempty"`
+	Description string      `json:"description,omitempty"`
+}
+
+// ExpandTaskTemplate expands the parameter placeholders of a task manifest
+// given as JSON.  The parameters are declared in the "parameters" section of
+// the manifest and take their value from values or else from their default.
+// A placeholder that is the whole of a string is replaced by the value with
+// its type (so "${port}" can become the number 8080) while a placeholder
+// inside a string is replaced by the value formatted as a string.  Map keys
+// are expanded too.  A literal ${ is written $${.  The "parameters" section is removed from the returned
+// manifest.  A manifest without parameters is returned unchanged.
+func ExpandTaskTemplate(b []byte, values map[string]interface{}) ([]byte, error) {
+	manifest := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		// leave reporting the error to the parsing of the task itself
+		return b, nil
+	}
+	rawParams, ok := manifest["parameters"]
+	if !ok {
+		if len(values) > 0 {
+			return nil, fmt.Errorf("Task manifest does not declare any parameters, unknown parameters %v", sortedKeys(values))
+		}
+		return b, nil
+	}
+	var params map[string]TaskParameter
+	if err := unmarshalNumbers(rawParams, &params); err != nil {
+		return nil, fmt.Errorf("%v (while parsing 'parameters')", err)
+	}
+
+	resolved := make(map[string]interface{}, len(params))
+	for name, p := range params {
+		if !paramNameRegexp.MatchString(name) {
+			return nil, fmt.Errorf("Invalid task parameter name '%s'", name)
+		}
+		if v, ok := values[name]; ok {
+			resolved[name] = v
+			continue
+		}
+		if p.Default == nil {
+			return nil, fmt.Errorf("Task parameter '%s' has no default and no value was given", name)
+		}
+		resolved[name] = p.Default
+	}
+	for name := range values {
+		if _, ok := params[name]; !ok {
+			return nil, fmt.Errorf("Unknown task parameter '%s'", name)
+		}
+	}
+
+	delete(manifest, "parameters")
+	for _, section := range templatedSections {
+		raw, ok := manifest[section]
+		if !ok {
+			continue
+		}
+		var v interface{}
+		if err := unmarshalNumbers(raw, &v); err != nil {
+			return nil, err
+		}
+		v, err := expandValue(v, resolved)
+		if err != nil {
+			return nil, fmt.Errorf("%v (while expanding '%s')", err, section)
+		}
+		if manifest[section], err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(manifest)
+}
+
+// ParseTaskParams parses parameter values given as key=value pairs.  A value
+// that is a JSON number, boolean or null keeps that type, any other value is a
+// string.
+func ParseTaskParams(pairs []string) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(pairs))
+	for _, pair := range pairs {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("Invalid task parameter '%s', expected key=value", pair)
+		}
+		var v interface{}
+		if err := unmarshalNumbers([]byte(kv[1]), &v); err != nil {
+			v = kv[1]
+		}
+		switch v.(type) {
+		case json.Number, bool, nil:
+		default:
+			v = kv[1]
+		}
+		values[kv[0]] = v
+	}
+	return values, nil
+}
+
+func expandValue(v interface{}, values map[string]interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case string:
+		return expandString(t, values)
+	case []interface{}:
+		for i := range t {
+			e, err := expandValue(t[i], values)
+			if err != nil {
+				return nil, err
+			}
+			t[i] = e
+		}
+		return t, nil
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			ek, err := expandString(k, values)
+			if err != nil {
+				return nil, err
+			}
+			key, ok := ek.(string)
+			if !ok {
+				key = fmt.Sprint(ek)
+			}
+			if m[key], err = expandValue(e, values); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	}
+	return v, nil
+}
+
+func expandString(s string, values map[string]interface{}) (interface{}, error) {
+	// a string that is only a placeholder takes the value with its type
+	if m := placeholderRegexp.FindStringSubmatch(s); m != nil && m[0] == s && m[1] != "" {
+		v, ok := values[m[1]]
+		if !ok {
+			return nil, fmt.Errorf("Undeclared task parameter '%s'", m[1])
+		}
+		return v, nil
+	}
+	var err error
+	out := placeholderRegexp.ReplaceAllStringFunc(s, func(p string) string {
+		if p == "$${" {
+			return "${"
+		}
+		name := p[2 : len(p)-1]
+		v, ok := values[name]
+		if !ok {
+			err = fmt.Errorf("Undeclared task parameter '%s'", name)
+			return p
+		}
+		if v == nil {
+			return ""
+		}
+		return fmt.Sprint(v)
+	})
+	return out, err
+}
+
+// unmarshalNumbers unmarshals JSON keeping numbers as json.Number so integers
+// are not turned into floats.
+func unmarshalNumbers(b []byte, v interface{}) error {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(v); err != nil {
+		return err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return errors.New("invalid data after top-level value")
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,136 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const templatedTask = `{
+    "version": 1,
+    "parameters": {
+        "interval": {"default": "10s"},
+        "db": {"description": "database to collect from"},
+        "port": {"default": 5432}
+    },
+    "schedule": {"type": "simple", "interval": "${interval}"},
+    "workflow": {
+        "collect": {
+            "metrics": {"/intel/psql/${db}/*": {}},
+            "config": {"/intel/psql": {"port": "${port}", "url": "psql://localhost:${port}/${db}"}}
+        }
+    }
+}`
+
+func TestExpandTaskTemplate(t *testing.T) {
+	Convey("Expanding a task manifest", t, func() {
+		Convey("without parameters returns it unchanged", func() {
+			b := []byte(`{"schedule": {"type": "simple", "interval": "${interval}"}}`)
+			out, err := ExpandTaskTemplate(b, nil)
+			So(err, ShouldBeNil)
+			So(out, ShouldResemble, b)
+		})
+
+		Convey("without parameters but with values returns an error", func() {
+			_, err := ExpandTaskTemplate([]byte(`{"schedule": {}}`), map[string]interface{}{"db": "x"})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("that is not JSON returns it unchanged", func() {
+			b := []byte(`not json`)
+			out, err := ExpandTaskTemplate(b, nil)
+			So(err, ShouldBeNil)
+			So(out, ShouldResemble, b)
+		})
+
+		Convey("uses the given values and the defaults", func() {
+			out, err := ExpandTaskTemplate([]byte(templatedTask), map[string]interface{}{"db": "snap"})
+			So(err, ShouldBeNil)
+			var tr TaskCreationRequest
+			So(json.Unmarshal(out, &tr), ShouldBeNil)
+			So(tr.Schedule.Interval, ShouldEqual, "10s")
+			So(tr.Workflow.CollectNode.Metrics, ShouldContainKey, "/intel/psql/snap/*")
+			cfg := tr.Workflow.CollectNode.Config["/intel/psql"]
+			So(cfg["port"], ShouldEqual, 5432)
+			So(cfg["url"], ShouldEqual, "psql://localhost:5432/snap")
+		})
+
+		Convey("uses a value over the default", func() {
+			out, err := ExpandTaskTemplate([]byte(templatedTask), map[string]interface{}{"db": "snap", "interval": "1m"})
+			So(err, ShouldBeNil)
+			var tr TaskCreationRequest
+			So(json.Unmarshal(out, &tr), ShouldBeNil)
+			So(tr.Schedule.Interval, ShouldEqual, "1m")
+		})
+
+		Convey("returns an error when a parameter has no value", func() {
+			_, err := ExpandTaskTemplate([]byte(templatedTask), nil)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "'db'")
+		})
+
+		Convey("returns an error for an unknown value", func() {
+			_, err := ExpandTaskTemplate([]byte(templatedTask), map[string]interface{}{"db": "snap", "host": "x"})
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "Unknown task parameter 'host'")
+		})
+
+		Convey("keeps an escaped placeholder", func() {
+			b := []byte(`{"parameters": {"db": {"default": "snap"}}, "name": "$${db}", "workflow": {"collect": {"config": {"/intel/sh": {"cmd": "echo $${HOME} ${db} $$"}}}}}`)
+			out, err := ExpandTaskTemplate(b, nil)
+			So(err, ShouldBeNil)
+			var tr TaskCreationRequest
+			So(json.Unmarshal(out, &tr), ShouldBeNil)
+			So(tr.Name, ShouldEqual, "${db}")
+			So(tr.Workflow.CollectNode.Config["/intel/sh"]["cmd"], ShouldEqual, "echo ${HOME} snap $$")
+		})
+
+		Convey("returns an error for an undeclared placeholder", func() {
+			b := []byte(`{"parameters": {}, "schedule": {"type": "simple", "interval": "${interval}"}}`)
+			_, err := ExpandTaskTemplate(b, nil)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "Undeclared task parameter 'interval' (while expanding 'schedule')")
+		})
+	})
+}
+
+func TestParseTaskParams(t *testing.T) {
+	Convey("Parsing task parameter values", t, func() {
+		Convey("keeps numbers and booleans typed", func() {
+			values, err := ParseTaskParams([]string{"port=5432", "debug=true", "db=snap", "url=a=b", "list=[1]"})
+			So(err, ShouldBeNil)
+			So(values["port"], ShouldEqual, json.Number("5432"))
+			So(values["debug"], ShouldEqual, true)
+			So(values["db"], ShouldEqual, "snap")
+			So(values["url"], ShouldEqual, "a=b")
+			So(values["list"], ShouldEqual, "[1]")
+		})
+
+		Convey("returns an error for a value without key", func() {
+			_, err := ParseTaskParams([]string{"snap"})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
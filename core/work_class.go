@@ -0,0 +1,52 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import "fmt"
+
+// WorkClass holds the settings the jobs of the tasks of a priority class are
+// queued and worked with.  They apply to each of the collect, process and
+// publish queues.
+type WorkClass struct {
+	// Name is the priority class (see TaskPriorities)
+	Name string `json:"name"`
+	// Weight is the share of the workers the jobs of a task of the class get
+	// relative to the jobs of the other tasks when workers are contended
+	Weight uint `json:"weight"`
+	// QueueSize is the number of jobs of the class which can wait for a
+	// worker
+	QueueSize uint `json:"queue_size"`
+	// PoolSize is the number of workers the jobs of the class can use at once
+	PoolSize uint `json:"pool_size"`
+}
+
+// Validate returns an error if the work class is not valid
+func (w WorkClass) Validate() error {
+	if w.Name == "" {
+		return fmt.Errorf("Work class must have a name")
+	}
+	if err := ValidatePriority(w.Name); err != nil {
+		return err
+	}
+	if w.Weight == 0 || w.QueueSize == 0 || w.PoolSize == 0 {
+		return fmt.Errorf("Work class '%s' weight, queue size and pool size must be at least 1", w.Name)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWorkClass(t *testing.T) {
+	Convey("Task priority", t, func() {
+		So(ValidatePriority(""), ShouldBeNil)
+		So(ValidatePriority(TaskPriorityHigh), ShouldBeNil)
+		So(ValidatePriority(TaskPriorityLow), ShouldBeNil)
+		So(ValidatePriority("urgent"), ShouldNotBeNil)
+
+		Convey("of a task creation request is validated", func() {
+			body := ioutil.NopCloser(bytes.NewBufferString(`{
+				"version": 1,
+				"schedule": {"type": "simple", "interval": "1s"},
+				"workflow": {"collect": {"metrics": {"/foo": {}}}},
+				"priority": "urgent"
+			}`))
+			_, err := CreateTaskFromContent(body, nil, nil)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "Priority 'urgent' is not valid")
+		})
+	})
+
+	Convey("Work class", t, func() {
+		So(WorkClass{Name: TaskPriorityNormal, Weight: 2, QueueSize: 25, PoolSize: 4}.Validate(), ShouldBeNil)
+		So(WorkClass{Weight: 2, QueueSize: 25, PoolSize: 4}.Validate(), ShouldNotBeNil)
+		So(WorkClass{Name: "urgent", Weight: 2, QueueSize: 25, PoolSize: 4}.Validate(), ShouldNotBeNil)
+		So(WorkClass{Name: TaskPriorityLow, QueueSize: 25, PoolSize: 4}.Validate(), ShouldNotBeNil)
+		So(WorkClass{Name: TaskPriorityLow, Weight: 1, PoolSize: 4}.Validate(), ShouldNotBeNil)
+		So(WorkClass{Name: TaskPriorityLow, Weight: 1, QueueSize: 25}.Validate(), ShouldNotBeNil)
+	})
+}
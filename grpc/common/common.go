@@ -374,6 +374,21 @@ func ParseConfig(config *ConfigMap) map[string]ctypes.ConfigValue {
 		bval := ctypes.ConfigValueBool{Value: v}
 		c[k] = bval
 	}
+	for k, v := range config.StringListMap {
+		if v != nil {
+			c[k] = ctypes.ConfigValueStrList{Value: v.Values}
+		}
+	}
+	for k, v := range config.FloatListMap {
+		if v != nil {
+			c[k] = ctypes.ConfigValueFloatList{Value: v.Values}
+		}
+	}
+	for k, v := range config.StringMapMap {
+		if v != nil {
+			c[k] = ctypes.ConfigValueMap{Value: v.Values}
+		}
+	}
 	return c
 }
 
@@ -388,10 +403,13 @@ func ConfigToConfigMap(cd *cdata.ConfigDataNode) *ConfigMap {
 // Converts ConfigDataNode to ConfigMap protobuf message
 func ToConfigMap(cv map[string]ctypes.ConfigValue) *ConfigMap {
 	newConfig := &ConfigMap{
-		IntMap:    make(map[string]int64),
-		FloatMap:  make(map[string]float64),
-		StringMap: make(map[string]string),
-		BoolMap:   make(map[string]bool),
+		IntMap:        make(map[string]int64),
+		FloatMap:      make(map[string]float64),
+		StringMap:     make(map[string]string),
+		BoolMap:       make(map[string]bool),
+		StringListMap: make(map[string]*StringList),
+		FloatListMap:  make(map[string]*FloatList),
+		StringMapMap:  make(map[string]*StringMap),
 	}
 	for k, v := range cv {
 		switch v.Type() {
@@ -403,6 +421,12 @@ func ToConfigMap(cv map[string]ctypes.ConfigValue) *ConfigMap {
 			newConfig.StringMap[k] = v.(ctypes.ConfigValueStr).Value
 		case "bool":
 			newConfig.BoolMap[k] = v.(ctypes.ConfigValueBool).Value
+		case "string_list":
+			newConfig.StringListMap[k] = &StringList{Values: v.(ctypes.ConfigValueStrList).Value}
+		case "float_list":
+			newConfig.FloatListMap[k] = &FloatList{Values: v.(ctypes.ConfigValueFloatList).Value}
+		case "map":
+			newConfig.StringMapMap[k] = &StringMap{Values: v.(ctypes.ConfigValueMap).Value}
 		}
 	}
 	return newConfig
@@ -36,6 +36,9 @@ It has these top-level messages:
 	NamespaceElement
 	SubscribedPlugin
 	ConfigMap
+	StringList
+	FloatList
+	StringMap
 	Plugin
 */
 package common
@@ -426,8 +429,11 @@ type ConfigMap struct {
 	IntMap    map[string]int64  `protobuf:"bytes,1,rep,name=IntMap,json=intMap" json:"IntMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
 	StringMap map[string]string `protobuf:"bytes,2,rep,name=StringMap,json=stringMap" json:"StringMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// double is float64
-	FloatMap map[string]float64 `protobuf:"bytes,3,rep,name=FloatMap,json=floatMap" json:"FloatMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
-	BoolMap  map[string]bool    `protobuf:"bytes,4,rep,name=BoolMap,json=boolMap" json:"BoolMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	FloatMap      map[string]float64     `protobuf:"bytes,3,rep,name=FloatMap,json=floatMap" json:"FloatMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	BoolMap       map[string]bool        `protobuf:"bytes,4,rep,name=BoolMap,json=boolMap" json:"BoolMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	StringListMap map[string]*StringList `protobuf:"bytes,5,rep,name=StringListMap,json=stringListMap" json:"StringListMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	FloatListMap  map[string]*FloatList  `protobuf:"bytes,6,rep,name=FloatListMap,json=floatListMap" json:"FloatListMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StringMapMap  map[string]*StringMap  `protobuf:"bytes,7,rep,name=StringMapMap,json=stringMapMap" json:"StringMapMap,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *ConfigMap) Reset()                    { *m = ConfigMap{} }
@@ -463,6 +469,61 @@ func (m *ConfigMap) GetBoolMap() map[string]bool {
 	return nil
 }
 
+func (m *ConfigMap) GetStringListMap() map[string]*StringList {
+	if m != nil {
+		return m.StringListMap
+	}
+	return nil
+}
+
+func (m *ConfigMap) GetFloatListMap() map[string]*FloatList {
+	if m != nil {
+		return m.FloatListMap
+	}
+	return nil
+}
+
+func (m *ConfigMap) GetStringMapMap() map[string]*StringMap {
+	if m != nil {
+		return m.StringMapMap
+	}
+	return nil
+}
+
+type StringList struct {
+	Values []string `protobuf:"bytes,1,rep,name=values" json:"values,omitempty"`
+}
+
+func (m *StringList) Reset()                    { *m = StringList{} }
+func (m *StringList) String() string            { return proto.CompactTextString(m) }
+func (*StringList) ProtoMessage()               {}
+func (*StringList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+type FloatList struct {
+	Values []float64 `protobuf:"fixed64,1,rep,packed,name=values" json:"values,omitempty"`
+}
+
+func (m *FloatList) Reset()                    { *m = FloatList{} }
+func (m *FloatList) String() string            { return proto.CompactTextString(m) }
+func (*FloatList) ProtoMessage()               {}
+func (*FloatList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+type StringMap struct {
+	Values map[string]string `protobuf:"bytes,1,rep,name=values" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *StringMap) Reset()                    { *m = StringMap{} }
+func (m *StringMap) String() string            { return proto.CompactTextString(m) }
+func (*StringMap) ProtoMessage()               {}
+func (*StringMap) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *StringMap) GetValues() map[string]string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 // core.Plugin
 type Plugin struct {
 	TypeName string `protobuf:"bytes,1,opt,name=TypeName,json=typeName" json:"TypeName,omitempty"`
@@ -473,7 +534,7 @@ type Plugin struct {
 func (m *Plugin) Reset()                    { *m = Plugin{} }
 func (m *Plugin) String() string            { return proto.CompactTextString(m) }
 func (*Plugin) ProtoMessage()               {}
-func (*Plugin) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*Plugin) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func init() {
 	proto.RegisterType((*Time)(nil), "common.Time")
@@ -484,6 +545,9 @@ func init() {
 	proto.RegisterType((*NamespaceElement)(nil), "common.NamespaceElement")
 	proto.RegisterType((*SubscribedPlugin)(nil), "common.SubscribedPlugin")
 	proto.RegisterType((*ConfigMap)(nil), "common.ConfigMap")
+	proto.RegisterType((*StringList)(nil), "common.StringList")
+	proto.RegisterType((*FloatList)(nil), "common.FloatList")
+	proto.RegisterType((*StringMap)(nil), "common.StringMap")
 	proto.RegisterType((*Plugin)(nil), "common.Plugin")
 }
 
@@ -492,53 +556,63 @@ func init() {
 }
 
 var fileDescriptor0 = []byte{
-	// 764 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x55, 0x51, 0x6f, 0xe3, 0x44,
-	0x10, 0x8e, 0x63, 0xc7, 0xb1, 0xc7, 0xb9, 0x23, 0xac, 0x78, 0xb0, 0x22, 0x1d, 0xe7, 0x33, 0x2f,
-	0x06, 0x41, 0x22, 0xda, 0xa3, 0x1c, 0x6d, 0x55, 0x89, 0xd2, 0x54, 0x41, 0x6a, 0x11, 0x72, 0x4b,
-	0x1f, 0xa9, 0xd6, 0xf6, 0x26, 0xac, 0xb0, 0xd7, 0x96, 0xbd, 0xa9, 0x9a, 0x67, 0x1e, 0xf8, 0x45,
-	0xfc, 0x2a, 0xfe, 0x04, 0xda, 0x5d, 0xdb, 0x75, 0x1a, 0x50, 0x54, 0x89, 0x97, 0x76, 0xe6, 0x9b,
-	0xef, 0x1b, 0xef, 0x8c, 0x3f, 0x6f, 0xe0, 0x70, 0x45, 0xf9, 0x6f, 0xeb, 0x68, 0x1a, 0xe7, 0xd9,
-	0x8c, 0x32, 0x4e, 0xd2, 0x2a, 0xa1, 0x5f, 0x3d, 0xce, 0x2a, 0x86, 0x8b, 0xd9, 0xaa, 0x2c, 0xe2,
-	0x59, 0x9c, 0x67, 0x59, 0xce, 0xea, 0x7f, 0xd3, 0xa2, 0xcc, 0x79, 0x8e, 0x4c, 0x95, 0xf9, 0x5f,
-	0x82, 0x71, 0x4b, 0x33, 0x82, 0xc6, 0xa0, 0x57, 0x24, 0x76, 0x35, 0x4f, 0x0b, 0xf4, 0x50, 0x84,
-	0x08, 0x81, 0xc1, 0x04, 0xd4, 0x97, 0x90, 0x8c, 0xfd, 0x21, 0x0c, 0xe6, 0x59, 0xc1, 0x37, 0xfe,
-	0x5f, 0x1a, 0xd8, 0x37, 0x0c, 0x17, 0xf3, 0xb2, 0xcc, 0x4b, 0xf4, 0x0e, 0x46, 0x44, 0x04, 0xf7,
-	0x15, 0x2f, 0x29, 0x5b, 0xc9, 0x2e, 0x76, 0xe8, 0x48, 0xec, 0x46, 0x42, 0x68, 0xde, 0x50, 0x96,
-	0x94, 0xa4, 0x49, 0xe5, 0xf6, 0x3d, 0x3d, 0x70, 0x0e, 0xfc, 0x69, 0x7d, 0xa8, 0xb6, 0xd7, 0x54,
-	0xfe, 0xbd, 0x94, 0xa4, 0x39, 0xe3, 0xe5, 0xa6, 0x6e, 0xa3, 0x90, 0xc9, 0x19, 0x8c, 0x9f, 0x13,
-	0xc4, 0xd1, 0x7f, 0x27, 0x9b, 0xfa, 0xa1, 0x22, 0x44, 0x9f, 0xc0, 0xe0, 0x01, 0xa7, 0x6b, 0x22,
-	0xcf, 0x6e, 0x87, 0x2a, 0x39, 0xee, 0x7f, 0xd0, 0xfc, 0xaf, 0x61, 0x70, 0x85, 0x23, 0x92, 0x0a,
-	0x0a, 0x65, 0x09, 0x79, 0x94, 0x32, 0x23, 0x54, 0x89, 0x9c, 0x19, 0x67, 0x8d, 0x4e, 0xc6, 0xfe,
-	0x1f, 0x03, 0x30, 0xaf, 0x09, 0x2f, 0x69, 0x8c, 0x8e, 0xc0, 0xfe, 0x09, 0x67, 0xa4, 0x2a, 0x70,
-	0x4c, 0x5c, 0x4d, 0x4e, 0xe0, 0x36, 0x13, 0xb4, 0x85, 0x79, 0x4a, 0x32, 0xc2, 0x78, 0x68, 0xb3,
-	0x06, 0x41, 0x2e, 0x0c, 0xef, 0x48, 0x59, 0xd1, 0x9c, 0xd5, 0xdb, 0x1c, 0x3e, 0xa8, 0x14, 0x7d,
-	0x0e, 0xe6, 0x0f, 0x39, 0x5b, 0xd2, 0x95, 0xab, 0x7b, 0x5a, 0xe0, 0x1c, 0x7c, 0xdc, 0xb4, 0x53,
-	0xe8, 0x35, 0x2e, 0x42, 0x33, 0x96, 0x21, 0x3a, 0x05, 0x74, 0x85, 0x2b, 0xfe, 0x7d, 0xf2, 0x40,
-	0x4a, 0x4e, 0x2b, 0x92, 0x88, 0xf7, 0xe6, 0x1a, 0x52, 0x36, 0x6a, 0x64, 0x02, 0x0b, 0x51, 0xba,
-	0xc3, 0x43, 0xe2, 0x3d, 0xe3, 0x55, 0xe5, 0x0e, 0xb6, 0x4f, 0xad, 0x06, 0x9b, 0x8a, 0x92, 0xda,
-	0xb6, 0xc1, 0xf1, 0xaa, 0x42, 0x5f, 0x80, 0x2d, 0x54, 0x15, 0xc7, 0x59, 0xe1, 0x9a, 0xff, 0xf2,
-	0x08, 0x9b, 0x37, 0x65, 0xb1, 0xb3, 0x5f, 0x18, 0xe5, 0xee, 0x50, 0xed, 0x6c, 0xcd, 0x28, 0x47,
-	0x1e, 0x38, 0x17, 0xa4, 0x8a, 0x4b, 0x5a, 0x70, 0x31, 0xb4, 0xa5, 0xfc, 0x90, 0x3c, 0x41, 0xe8,
-	0x1d, 0x38, 0xca, 0x2c, 0xf7, 0x09, 0xe6, 0xd8, 0xb5, 0x05, 0x63, 0xd1, 0x0b, 0x41, 0x81, 0x17,
-	0x98, 0x63, 0xf4, 0x19, 0x8c, 0x96, 0x69, 0x8e, 0xf9, 0xe1, 0x81, 0xe2, 0x80, 0xa7, 0x05, 0xfd,
-	0x45, 0x2f, 0x74, 0x6a, 0x74, 0x8b, 0x74, 0xf4, 0x5e, 0x91, 0x1c, 0x4f, 0x0b, 0xb4, 0x96, 0x74,
-	0xf4, 0x5e, 0x92, 0xde, 0x02, 0x50, 0xd6, 0xf6, 0x19, 0x79, 0x5a, 0x30, 0x58, 0xf4, 0x42, 0x5b,
-	0x62, 0x1d, 0x42, 0xd3, 0xe3, 0x95, 0x78, 0x47, 0x35, 0xe1, 0xa9, 0x43, 0xb4, 0xe1, 0xa4, 0x52,
-	0x84, 0xd7, 0x9e, 0x16, 0x8c, 0x04, 0x41, 0x62, 0x92, 0xf0, 0x06, 0xec, 0x28, 0xcf, 0x53, 0x55,
-	0xff, 0xc8, 0xd3, 0x02, 0x6b, 0xd1, 0x0b, 0x2d, 0x01, 0x89, 0xf2, 0xe4, 0x5b, 0xb0, 0xdb, 0x1d,
-	0xbf, 0xc4, 0xb0, 0xe7, 0x26, 0x18, 0xa2, 0xa5, 0xff, 0x2b, 0x8c, 0x9f, 0x3b, 0x4c, 0xa8, 0xee,
-	0xa4, 0x4a, 0xeb, 0xa8, 0x9e, 0xef, 0xbe, 0xbf, 0xbb, 0x7b, 0x04, 0x86, 0xe8, 0x25, 0x2d, 0xd7,
-	0xb8, 0xfc, 0x4f, 0x0d, 0xc6, 0x37, 0xeb, 0x48, 0x90, 0x22, 0x92, 0xfc, 0x9c, 0xae, 0x57, 0x94,
-	0xa1, 0x09, 0x58, 0xb7, 0x9b, 0x82, 0x48, 0xb2, 0x7a, 0x86, 0xc5, 0xeb, 0xbc, 0x6d, 0xd2, 0xf9,
-	0x54, 0xba, 0x3e, 0xd7, 0xff, 0xcb, 0xe7, 0xc6, 0x1e, 0x9f, 0xfb, 0x7f, 0xeb, 0x60, 0xb7, 0x28,
-	0xfa, 0x06, 0xcc, 0x1f, 0x19, 0xbf, 0xc6, 0x45, 0xfd, 0xbd, 0xbd, 0xd9, 0x11, 0x4e, 0x55, 0x5d,
-	0xd9, 0xd7, 0xa4, 0x32, 0x41, 0x67, 0x60, 0xab, 0x8b, 0x47, 0x28, 0xd5, 0x5d, 0xe3, 0xed, 0x2a,
-	0x5b, 0x8a, 0x12, 0xdb, 0x55, 0x93, 0xa3, 0x13, 0xb0, 0x2e, 0x85, 0x81, 0x84, 0x5c, 0x97, 0xf2,
-	0xb7, 0xbb, 0xf2, 0x86, 0xa1, 0xd4, 0xd6, 0xb2, 0x4e, 0xd1, 0x07, 0x18, 0x9e, 0xe7, 0x79, 0x2a,
-	0xb4, 0x86, 0xd4, 0x7e, 0xba, 0xab, 0xad, 0x09, 0x4a, 0x3a, 0x8c, 0x54, 0x36, 0xf9, 0x0e, 0x9c,
-	0xce, 0x34, 0xfb, 0x8c, 0xa2, 0x77, 0x8c, 0x32, 0x39, 0x85, 0xd7, 0xdb, 0xe3, 0xbc, 0xc4, 0x66,
-	0x93, 0x13, 0x78, 0xb5, 0x35, 0xcd, 0x3e, 0xb1, 0xd6, 0x15, 0x1f, 0xc3, 0xa8, 0x3b, 0xce, 0x3e,
-	0xad, 0xd5, 0xbd, 0x90, 0x43, 0x30, 0xff, 0x6f, 0xb3, 0x45, 0xa6, 0xfc, 0x89, 0x3b, 0xfc, 0x27,
-	0x00, 0x00, 0xff, 0xff, 0xef, 0x9e, 0x96, 0x24, 0x19, 0x07, 0x00, 0x00,
+	// 916 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x5d, 0x6f, 0xdb, 0x36,
+	0x14, 0x8d, 0x6c, 0x59, 0xb6, 0xae, 0x9c, 0x2e, 0x25, 0x86, 0x41, 0x30, 0xd0, 0x55, 0x55, 0x0a,
+	0x4c, 0x1b, 0x36, 0x07, 0x4b, 0xba, 0xac, 0x6b, 0x8b, 0x02, 0xeb, 0xea, 0x2e, 0x1b, 0x92, 0x61,
+	0x50, 0xb2, 0x3e, 0xae, 0xa0, 0x6d, 0xda, 0x23, 0x26, 0x51, 0x82, 0x48, 0x07, 0xf5, 0xc3, 0x9e,
+	0xf6, 0xb0, 0x5f, 0xb4, 0xdf, 0xb0, 0xbf, 0x35, 0x90, 0x94, 0x18, 0xda, 0x4a, 0x10, 0x04, 0xe8,
+	0x4b, 0xc2, 0x7b, 0x78, 0xce, 0xe1, 0xc7, 0xbd, 0x97, 0x32, 0x1c, 0x2d, 0xa9, 0xf8, 0x63, 0x35,
+	0x1d, 0xcf, 0x8a, 0xfc, 0x80, 0x32, 0x41, 0x32, 0x3e, 0xa7, 0x5f, 0xbd, 0x3f, 0xe0, 0x0c, 0x97,
+	0x07, 0xcb, 0xaa, 0x9c, 0x1d, 0xcc, 0x8a, 0x3c, 0x2f, 0x58, 0xfd, 0x6f, 0x5c, 0x56, 0x85, 0x28,
+	0x90, 0xa7, 0xa3, 0xf8, 0x4b, 0x70, 0x2f, 0x68, 0x4e, 0xd0, 0x1e, 0x74, 0x39, 0x99, 0x85, 0x4e,
+	0xe4, 0x24, 0xdd, 0x54, 0x0e, 0x11, 0x02, 0x97, 0x49, 0xa8, 0xa3, 0x20, 0x35, 0x8e, 0xfb, 0xd0,
+	0x9b, 0xe4, 0xa5, 0x58, 0xc7, 0xff, 0x3a, 0xe0, 0x9f, 0x33, 0x5c, 0x4e, 0xaa, 0xaa, 0xa8, 0xd0,
+	0x23, 0x18, 0x12, 0x39, 0x78, 0xc7, 0x45, 0x45, 0xd9, 0x52, 0xb9, 0xf8, 0x69, 0xa0, 0xb0, 0x73,
+	0x05, 0xa1, 0x49, 0x43, 0x59, 0x50, 0x92, 0xcd, 0x79, 0xd8, 0x89, 0xba, 0x49, 0x70, 0x18, 0x8f,
+	0xeb, 0x4d, 0x19, 0xaf, 0xb1, 0xfa, 0xfb, 0x46, 0x91, 0x26, 0x4c, 0x54, 0xeb, 0xda, 0x46, 0x23,
+	0xa3, 0x97, 0xb0, 0xb7, 0x4d, 0x90, 0x5b, 0xff, 0x93, 0xac, 0xeb, 0x45, 0xe5, 0x10, 0x7d, 0x0c,
+	0xbd, 0x4b, 0x9c, 0xad, 0x88, 0xda, 0xbb, 0x9f, 0xea, 0xe0, 0x59, 0xe7, 0xa9, 0x13, 0x7f, 0x0d,
+	0xbd, 0x53, 0x3c, 0x25, 0x99, 0xa4, 0x50, 0x36, 0x27, 0xef, 0x95, 0xcc, 0x4d, 0x75, 0xa0, 0xce,
+	0x8c, 0xf3, 0x46, 0xa7, 0xc6, 0xf1, 0xdf, 0x3d, 0xf0, 0xce, 0x88, 0xa8, 0xe8, 0x0c, 0x1d, 0x83,
+	0xff, 0x0b, 0xce, 0x09, 0x2f, 0xf1, 0x8c, 0x84, 0x8e, 0x3a, 0x41, 0xd8, 0x9c, 0xc0, 0x4c, 0x4c,
+	0x32, 0x92, 0x13, 0x26, 0x52, 0x9f, 0x35, 0x08, 0x0a, 0xa1, 0xff, 0x96, 0x54, 0x9c, 0x16, 0xac,
+	0xbe, 0xcd, 0xfe, 0xa5, 0x0e, 0xd1, 0xe7, 0xe0, 0xfd, 0x50, 0xb0, 0x05, 0x5d, 0x86, 0xdd, 0xc8,
+	0x49, 0x82, 0xc3, 0xfb, 0x8d, 0x9d, 0x46, 0xcf, 0x70, 0x99, 0x7a, 0x33, 0x35, 0x44, 0x2f, 0x00,
+	0x9d, 0x62, 0x2e, 0xbe, 0x9f, 0x5f, 0x92, 0x4a, 0x50, 0x4e, 0xe6, 0x32, 0x6f, 0xa1, 0xab, 0x64,
+	0xc3, 0x46, 0x26, 0xb1, 0x14, 0x65, 0x2d, 0x1e, 0x92, 0x79, 0xc6, 0x4b, 0x1e, 0xf6, 0x36, 0x77,
+	0xad, 0x0f, 0x36, 0x96, 0x53, 0xfa, 0xb6, 0x5d, 0x81, 0x97, 0x1c, 0x7d, 0x01, 0xbe, 0x54, 0x71,
+	0x81, 0xf3, 0x32, 0xf4, 0xae, 0x59, 0xc2, 0x17, 0xcd, 0xb4, 0xbc, 0xb3, 0xdf, 0x18, 0x15, 0x61,
+	0x5f, 0xdf, 0xd9, 0x8a, 0x51, 0x81, 0x22, 0x08, 0x5e, 0x13, 0x3e, 0xab, 0x68, 0x29, 0xe4, 0xa1,
+	0x07, 0xba, 0x1e, 0xe6, 0x57, 0x10, 0x7a, 0x04, 0x81, 0x2e, 0x96, 0x77, 0x73, 0x2c, 0x70, 0xe8,
+	0x4b, 0xc6, 0xc9, 0x4e, 0x0a, 0x1a, 0x7c, 0x8d, 0x05, 0x46, 0xfb, 0x30, 0x5c, 0x64, 0x05, 0x16,
+	0x47, 0x87, 0x9a, 0x03, 0x91, 0x93, 0x74, 0x4e, 0x76, 0xd2, 0xa0, 0x46, 0x37, 0x48, 0xc7, 0x4f,
+	0x34, 0x29, 0x88, 0x9c, 0xc4, 0x31, 0xa4, 0xe3, 0x27, 0x8a, 0xf4, 0x10, 0x80, 0x32, 0xe3, 0x33,
+	0x8c, 0x9c, 0xa4, 0x77, 0xb2, 0x93, 0xfa, 0x0a, 0xb3, 0x08, 0x8d, 0xc7, 0xae, 0xcc, 0x51, 0x4d,
+	0xb8, 0x72, 0x98, 0xae, 0x05, 0xe1, 0x9a, 0x70, 0x2f, 0x72, 0x92, 0xa1, 0x24, 0x28, 0x4c, 0x11,
+	0x1e, 0x80, 0x3f, 0x2d, 0x8a, 0x4c, 0xcf, 0x7f, 0x14, 0x39, 0xc9, 0xe0, 0x64, 0x27, 0x1d, 0x48,
+	0x48, 0x4e, 0x8f, 0xbe, 0x05, 0xdf, 0xdc, 0xf1, 0x5d, 0x0a, 0xf6, 0x95, 0x07, 0xae, 0xb4, 0x8c,
+	0x7f, 0x87, 0xbd, 0xed, 0x0a, 0x93, 0xaa, 0xb7, 0x4a, 0xe5, 0x58, 0xaa, 0xed, 0xbb, 0xef, 0xb4,
+	0xef, 0x1e, 0x81, 0x2b, 0xbd, 0xc2, 0xae, 0x55, 0xe5, 0xff, 0x38, 0xb0, 0x77, 0xbe, 0x9a, 0x4a,
+	0xd2, 0x94, 0xcc, 0x7f, 0xcd, 0x56, 0x4b, 0xca, 0xd0, 0x08, 0x06, 0x17, 0xeb, 0x92, 0x28, 0xb2,
+	0x5e, 0x63, 0x20, 0xea, 0xd8, 0x98, 0x58, 0xad, 0x62, 0xd7, 0x79, 0xf7, 0xa6, 0x3a, 0x77, 0x6f,
+	0xa9, 0xf3, 0xf8, 0xbf, 0x3e, 0xf8, 0x06, 0x45, 0xdf, 0x80, 0xf7, 0x13, 0x13, 0x67, 0xb8, 0xac,
+	0xfb, 0xed, 0x41, 0x4b, 0x38, 0xd6, 0xf3, 0xba, 0x7c, 0x3d, 0xaa, 0x02, 0xf4, 0x12, 0x7c, 0xfd,
+	0xf0, 0x48, 0xa5, 0x7e, 0x6b, 0xa2, 0xb6, 0xd2, 0x50, 0xb4, 0xd8, 0xe7, 0x4d, 0x8c, 0x9e, 0xc3,
+	0xe0, 0x8d, 0x2c, 0x20, 0x29, 0xef, 0x2a, 0xf9, 0xc3, 0xb6, 0xbc, 0x61, 0x68, 0xf5, 0x60, 0x51,
+	0x87, 0xe8, 0x29, 0xf4, 0x5f, 0x15, 0x45, 0x26, 0xb5, 0xae, 0xd2, 0x7e, 0xda, 0xd6, 0xd6, 0x04,
+	0x2d, 0xed, 0x4f, 0x75, 0x84, 0x7e, 0x86, 0x5d, 0xbd, 0xa7, 0x53, 0xca, 0xd5, 0xda, 0xba, 0x5d,
+	0x1f, 0xdf, 0xb4, 0xf5, 0x9a, 0xa6, 0x5d, 0x76, 0xb9, 0x8d, 0xa1, 0x1f, 0x61, 0xa8, 0x36, 0xd8,
+	0x58, 0x79, 0xca, 0x6a, 0xff, 0x86, 0x63, 0x6c, 0x38, 0x0d, 0x17, 0x16, 0x24, 0x8d, 0xcc, 0x45,
+	0x49, 0xa3, 0xfe, 0x4d, 0x46, 0x36, 0xab, 0x36, 0xe2, 0x16, 0x34, 0xfa, 0x0e, 0x02, 0x2b, 0x57,
+	0xb7, 0xb5, 0x41, 0xd7, 0x6a, 0x83, 0xd1, 0x0b, 0xb8, 0xb7, 0x99, 0xac, 0xbb, 0x34, 0xd1, 0xe8,
+	0x39, 0xec, 0x6e, 0xe4, 0xea, 0x36, 0xb1, 0x63, 0x8b, 0x9f, 0xc1, 0xd0, 0x4e, 0xd6, 0x6d, 0xda,
+	0x81, 0xad, 0xbd, 0x00, 0xd4, 0x4e, 0xd4, 0x35, 0x0e, 0x89, 0xed, 0x10, 0x1c, 0x22, 0xf3, 0x59,
+	0x34, 0x62, 0xdb, 0x35, 0x85, 0xfb, 0xad, 0x9c, 0x5d, 0x63, 0xfa, 0xd9, 0xa6, 0xa9, 0x69, 0x39,
+	0xa3, 0xdd, 0xf2, 0x6c, 0xa5, 0xef, 0x0e, 0x9e, 0x46, 0x6b, 0x7f, 0x6c, 0x1f, 0x03, 0x5c, 0x1d,
+	0x00, 0x7d, 0x02, 0x9e, 0x9a, 0xe2, 0xaa, 0x93, 0xfd, 0xb4, 0x8e, 0xe2, 0x7d, 0xf0, 0xcd, 0x8e,
+	0xb6, 0x48, 0x8e, 0x21, 0xfd, 0x65, 0xf5, 0xb3, 0x7c, 0x13, 0x2c, 0x92, 0xf5, 0x26, 0x18, 0xca,
+	0x58, 0xbd, 0x8b, 0xf5, 0x27, 0xad, 0x26, 0xcb, 0xf2, 0xb3, 0xe0, 0x3b, 0xfd, 0x6c, 0x48, 0xc1,
+	0xfb, 0xd0, 0x4f, 0xe2, 0xd4, 0x53, 0x3f, 0xc4, 0x8e, 0xfe, 0x1f, 0x00, 0x18, 0xc7, 0x81, 0xfa,
+	0xbf, 0x09, 0x00, 0x00,
 }
@@ -162,6 +162,16 @@ func (c ControlProxy) SubscribeDeps(taskID string, requested []core.RequestedMet
 	return serrs
 }
 
+func (c ControlProxy) UpdateDeps(taskID string, requested []core.RequestedMetric, plugins []core.SubscribedPlugin, configTree *cdata.ConfigDataTree) []serror.SnapError {
+	req := depsRequest(taskID, requested, plugins, configTree)
+	reply, err := c.Client.UpdateDeps(getContext(), req)
+	if err != nil {
+		return []serror.SnapError{serror.New(err)}
+	}
+	serrs := common.ConvertSnapErrors(reply.Errors)
+	return serrs
+}
+
 func (c ControlProxy) UnsubscribeDeps(taskID string) []serror.SnapError {
 	req := &rpc.UnsubscribeDepsRequest{TaskId: taskID}
 	reply, err := c.Client.UnsubscribeDeps(getContext(), req)
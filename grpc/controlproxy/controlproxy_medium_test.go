@@ -89,6 +89,12 @@ func (mc mockClient) SubscribeDeps(ctx context.Context, in *rpc.SubscribeDepsReq
 	}
 	return mc.SubscribeReply, nil
 }
+func (mc mockClient) UpdateDeps(ctx context.Context, in *rpc.SubscribeDepsRequest, opts ...grpc.CallOption) (*rpc.SubscribeDepsReply, error) {
+	if mc.RpcErr {
+		return nil, rpcErr
+	}
+	return mc.SubscribeReply, nil
+}
 func (mc mockClient) UnsubscribeDeps(ctx context.Context, in *rpc.UnsubscribeDepsRequest, opts ...grpc.CallOption) (*rpc.UnsubscribeDepsReply, error) {
 	if mc.RpcErr {
 		return nil, rpcErr
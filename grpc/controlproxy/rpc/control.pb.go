@@ -357,6 +357,7 @@ type MetricManagerClient interface {
 	ValidateDeps(ctx context.Context, in *ValidateDepsRequest, opts ...grpc.CallOption) (*ValidateDepsReply, error)
 	SubscribeDeps(ctx context.Context, in *SubscribeDepsRequest, opts ...grpc.CallOption) (*SubscribeDepsReply, error)
 	UnsubscribeDeps(ctx context.Context, in *UnsubscribeDepsRequest, opts ...grpc.CallOption) (*UnsubscribeDepsReply, error)
+	UpdateDeps(ctx context.Context, in *SubscribeDepsRequest, opts ...grpc.CallOption) (*SubscribeDepsReply, error)
 	GetAutodiscoverPaths(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*GetAutodiscoverPathsReply, error)
 }
 
@@ -422,6 +423,15 @@ func (c *metricManagerClient) UnsubscribeDeps(ctx context.Context, in *Unsubscri
 	return out, nil
 }
 
+func (c *metricManagerClient) UpdateDeps(ctx context.Context, in *SubscribeDepsRequest, opts ...grpc.CallOption) (*SubscribeDepsReply, error) {
+	out := new(SubscribeDepsReply)
+	err := grpc.Invoke(ctx, "/rpc.MetricManager/UpdateDeps", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *metricManagerClient) GetAutodiscoverPaths(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*GetAutodiscoverPathsReply, error) {
 	out := new(GetAutodiscoverPathsReply)
 	err := grpc.Invoke(ctx, "/rpc.MetricManager/GetAutodiscoverPaths", in, out, c.cc, opts...)
@@ -441,6 +451,7 @@ type MetricManagerServer interface {
 	ValidateDeps(context.Context, *ValidateDepsRequest) (*ValidateDepsReply, error)
 	SubscribeDeps(context.Context, *SubscribeDepsRequest) (*SubscribeDepsReply, error)
 	UnsubscribeDeps(context.Context, *UnsubscribeDepsRequest) (*UnsubscribeDepsReply, error)
+	UpdateDeps(context.Context, *SubscribeDepsRequest) (*SubscribeDepsReply, error)
 	GetAutodiscoverPaths(context.Context, *common.Empty) (*GetAutodiscoverPathsReply, error)
 }
 
@@ -556,6 +567,24 @@ func _MetricManager_UnsubscribeDeps_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MetricManager_UpdateDeps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscribeDepsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricManagerServer).UpdateDeps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.MetricManager/UpdateDeps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricManagerServer).UpdateDeps(ctx, req.(*SubscribeDepsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MetricManager_GetAutodiscoverPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(common.Empty)
 	if err := dec(in); err != nil {
@@ -602,6 +631,10 @@ var _MetricManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UnsubscribeDeps",
 			Handler:    _MetricManager_UnsubscribeDeps_Handler,
 		},
+		{
+			MethodName: "UpdateDeps",
+			Handler:    _MetricManager_UpdateDeps_Handler,
+		},
 		{
 			MethodName: "GetAutodiscoverPaths",
 			Handler:    _MetricManager_GetAutodiscoverPaths_Handler,
@@ -616,52 +649,53 @@ func init() {
 }
 
 var fileDescriptor0 = []byte{
-	// 748 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x55, 0xdd, 0x4e, 0x1a, 0x41,
-	0x14, 0x66, 0x5d, 0x01, 0x39, 0x08, 0xd6, 0x11, 0x29, 0x6e, 0x13, 0x43, 0x36, 0xa6, 0x62, 0xd2,
-	0x42, 0x8b, 0x37, 0x4d, 0x2f, 0x34, 0x54, 0x88, 0x6d, 0x0c, 0x0d, 0x59, 0xac, 0x4d, 0x7a, 0x37,
-	0x2c, 0x53, 0xdc, 0xb8, 0xec, 0x4c, 0x67, 0x66, 0x8d, 0xbc, 0x41, 0x2f, 0xfb, 0x38, 0x7d, 0x86,
-	0x3e, 0x55, 0xb3, 0x3b, 0xbb, 0xc8, 0xe2, 0xda, 0x56, 0xd3, 0x2b, 0xf6, 0xfc, 0xcc, 0x77, 0xbe,
-	0xef, 0xcc, 0x9c, 0x03, 0x1c, 0x4d, 0x1c, 0x79, 0xe9, 0x8f, 0x9a, 0x36, 0x9d, 0xb6, 0x1c, 0x4f,
-	0x12, 0x57, 0x8c, 0x9d, 0x97, 0x37, 0x2d, 0xe1, 0x61, 0xd6, 0x9a, 0x70, 0x66, 0xb7, 0x6c, 0xea,
-	0x49, 0x4e, 0x5d, 0xc6, 0xe9, 0xcd, 0xac, 0xb5, 0xe0, 0x68, 0x32, 0x4e, 0x25, 0x45, 0x3a, 0x67,
-	0xb6, 0x71, 0xf8, 0x77, 0x90, 0xe9, 0x94, 0x7a, 0xd1, 0x8f, 0x3a, 0x69, 0x96, 0xa0, 0x38, 0x24,
-	0x9c, 0x53, 0x6e, 0x11, 0xe6, 0xce, 0xcc, 0x5f, 0x1a, 0x6c, 0x0f, 0xfc, 0xd1, 0x80, 0x53, 0xbb,
-	0x4f, 0x24, 0x77, 0x6c, 0x61, 0x91, 0x6f, 0x3e, 0x11, 0x12, 0x35, 0x20, 0x1f, 0x79, 0x6a, 0x5a,
-	0x5d, 0x6f, 0x14, 0xdb, 0xe5, 0x66, 0x04, 0xa4, 0xdc, 0x56, 0x7e, 0xaa, 0xc2, 0x68, 0x17, 0x60,
-	0xe0, 0xfa, 0x13, 0xc7, 0xfb, 0x88, 0xa7, 0xa4, 0xb6, 0x52, 0xd7, 0x1a, 0x05, 0x0b, 0xd8, 0xdc,
-	0x83, 0xf6, 0xa0, 0xa4, 0xe2, 0x17, 0x84, 0x0b, 0x87, 0x7a, 0x35, 0xbd, 0xae, 0x35, 0x74, 0xab,
-	0xc4, 0x16, 0x9d, 0xe8, 0x00, 0x72, 0x27, 0xd4, 0xfb, 0xea, 0x4c, 0x6a, 0xab, 0x75, 0xad, 0x51,
-	0x6c, 0x6f, 0xc6, 0xe5, 0x94, 0xb7, 0x8f, 0x99, 0x95, 0xb3, 0xc3, 0x4f, 0x54, 0x85, 0xdc, 0x39,
-	0x16, 0x57, 0x1f, 0xc6, 0xb5, 0x6c, 0x58, 0x2c, 0x27, 0x43, 0xcb, 0xdc, 0x03, 0xe8, 0xcd, 0xa5,
-	0x05, 0x59, 0xa1, 0xa5, 0xf8, 0x17, 0xac, 0x5c, 0x28, 0x5b, 0x98, 0x9f, 0x61, 0x2b, 0x90, 0x4b,
-	0x84, 0x98, 0x2b, 0x0e, 0xd2, 0xff, 0x5d, 0xef, 0x2d, 0xf0, 0x4a, 0x02, 0x58, 0xc0, 0xd6, 0x05,
-	0x76, 0x9d, 0x31, 0x96, 0xa4, 0x4b, 0xd8, 0x23, 0x1a, 0xd9, 0x86, 0xbc, 0x6a, 0x94, 0x42, 0x2e,
-	0xb6, 0x6b, 0x71, 0xe6, 0xd0, 0x1f, 0x09, 0x9b, 0x3b, 0x23, 0x32, 0x56, 0x09, 0x56, 0x5e, 0x35,
-	0x4f, 0x98, 0x47, 0xb0, 0x99, 0x2c, 0x1a, 0x68, 0x39, 0x48, 0x48, 0x5f, 0xe8, 0xe5, 0xd0, 0xc3,
-	0x4c, 0xb5, 0x28, 0x26, 0xfd, 0x43, 0x83, 0xca, 0x1c, 0x7d, 0x91, 0xf6, 0x0b, 0x28, 0x44, 0x9f,
-	0x64, 0x7c, 0x0f, 0xf1, 0x02, 0x8f, 0x13, 0x1e, 0x43, 0x7d, 0xe1, 0x1a, 0xf5, 0xc4, 0x35, 0x1e,
-	0x03, 0x5a, 0x62, 0xf4, 0x40, 0x4d, 0xaf, 0xa0, 0xfa, 0xc9, 0x13, 0x69, 0xa2, 0x6e, 0x4b, 0x6a,
-	0x89, 0x92, 0x1d, 0xa8, 0xdc, 0x39, 0xf1, 0xc0, 0xa2, 0x4d, 0xd0, 0xfb, 0x98, 0xa1, 0x7d, 0xc8,
-	0xf7, 0x3c, 0xc9, 0x1d, 0x12, 0x1f, 0x29, 0x35, 0x39, 0xb3, 0x9b, 0x7d, 0xcc, 0x02, 0xf7, 0xcc,
-	0xca, 0x13, 0x15, 0x35, 0xdb, 0xb0, 0x16, 0x3b, 0xd1, 0x13, 0xd0, 0xcf, 0xc8, 0x2c, 0xe2, 0xa4,
-	0x5f, 0x91, 0x19, 0xaa, 0x40, 0xf6, 0x02, 0xbb, 0x7e, 0x3c, 0x4e, 0xd9, 0xeb, 0xc0, 0x30, 0x7f,
-	0x6a, 0xb0, 0x7d, 0x42, 0x5d, 0x97, 0xd8, 0x72, 0x69, 0x5a, 0x63, 0x61, 0xdd, 0x84, 0xb0, 0x2e,
-	0xea, 0x40, 0xbe, 0xe3, 0xba, 0xe7, 0x78, 0x12, 0xdf, 0xcb, 0x7e, 0x48, 0x27, 0x15, 0xa4, 0x19,
-	0x65, 0x46, 0x44, 0xb1, 0xb2, 0x8c, 0x2e, 0xac, 0x2f, 0x06, 0x02, 0xb2, 0x57, 0x49, 0xb2, 0xbb,
-	0xa0, 0xf8, 0x85, 0x64, 0x8b, 0xed, 0xb5, 0x58, 0x71, 0x44, 0xfb, 0xed, 0xca, 0x1b, 0xcd, 0xfc,
-	0x02, 0xd5, 0xe5, 0xa2, 0x82, 0x51, 0x4f, 0x90, 0xff, 0x30, 0x78, 0x87, 0x50, 0xe8, 0x70, 0x3e,
-	0x94, 0xdc, 0xf1, 0x26, 0xe8, 0x39, 0x68, 0xc3, 0x08, 0x68, 0xfe, 0x06, 0x83, 0x35, 0x24, 0x18,
-	0xb6, 0x49, 0xcf, 0x25, 0x53, 0xe2, 0x49, 0x4b, 0x13, 0xe6, 0x6b, 0xd8, 0x39, 0x25, 0xb2, 0xe3,
-	0x4b, 0x3a, 0x76, 0x84, 0x4d, 0xaf, 0x09, 0x1f, 0x60, 0x79, 0x19, 0xdd, 0x7b, 0x05, 0xb2, 0xa1,
-	0x15, 0xad, 0x8e, 0x2c, 0x0b, 0x8c, 0xf6, 0xf7, 0x55, 0x28, 0x29, 0x4e, 0x7d, 0xec, 0xe1, 0x09,
-	0xe1, 0xe8, 0x0c, 0xca, 0x49, 0x55, 0xc8, 0xb8, 0xbf, 0xbf, 0xc6, 0xb3, 0xd4, 0x98, 0x6a, 0x83,
-	0x99, 0x41, 0xc7, 0x50, 0x1e, 0xf8, 0x23, 0xd7, 0x11, 0x97, 0x49, 0xb0, 0xd4, 0xfd, 0x6c, 0x6c,
-	0x84, 0xb1, 0xdb, 0x7d, 0x67, 0x66, 0xd0, 0x7b, 0x28, 0x27, 0x37, 0xdb, 0x1f, 0x01, 0x6a, 0x2a,
-	0x76, 0x77, 0x15, 0x9a, 0x19, 0xf4, 0x0e, 0xd6, 0x17, 0xb7, 0x0a, 0x52, 0xb9, 0x29, 0xdb, 0xcd,
-	0xa8, 0xa6, 0x44, 0x14, 0x46, 0x0f, 0x4a, 0x89, 0x31, 0x46, 0x3b, 0x61, 0x6a, 0xda, 0xb2, 0x31,
-	0x9e, 0xa6, 0x85, 0x14, 0xcc, 0x19, 0x6c, 0x2c, 0x8d, 0x26, 0x52, 0x7d, 0x4c, 0x1f, 0x71, 0x63,
-	0x27, 0x3d, 0xa8, 0xc0, 0x4e, 0xa1, 0x92, 0x76, 0xe9, 0xa8, 0x14, 0xbf, 0x94, 0xde, 0x94, 0xc9,
-	0x99, 0xb1, 0x1b, 0x62, 0xdc, 0xfb, 0x3c, 0xcc, 0xcc, 0x28, 0x17, 0xfe, 0x9b, 0x1e, 0xfe, 0x0e,
-	0x00, 0x00, 0xff, 0xff, 0x1b, 0x5c, 0xfe, 0x59, 0xc9, 0x07, 0x00, 0x00,
+	// 755 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xdd, 0x4e, 0xdb, 0x48,
+	0x14, 0x8e, 0x31, 0x49, 0xc8, 0x09, 0x09, 0xcb, 0x10, 0xb2, 0xc6, 0x2b, 0xa1, 0xc8, 0x42, 0x4b,
+	0x90, 0x76, 0x93, 0xdd, 0x70, 0xb3, 0xda, 0x0b, 0x50, 0x4a, 0x22, 0x5a, 0xa1, 0x54, 0x91, 0x03,
+	0x54, 0xea, 0xdd, 0xc4, 0x99, 0x06, 0x0b, 0xc7, 0x33, 0x9d, 0x19, 0x23, 0xf2, 0x16, 0x7d, 0x9c,
+	0x3e, 0x43, 0xd5, 0x87, 0xaa, 0xec, 0xb1, 0x43, 0x1c, 0x4c, 0x5b, 0x50, 0xaf, 0xe2, 0xf3, 0x33,
+	0xdf, 0xf9, 0xbe, 0x33, 0x73, 0x4e, 0xe0, 0x64, 0xea, 0xca, 0x9b, 0x60, 0xdc, 0x72, 0xe8, 0xac,
+	0xed, 0xfa, 0x92, 0x78, 0x62, 0xe2, 0xfe, 0x7d, 0xdf, 0x16, 0x3e, 0x66, 0xed, 0x29, 0x67, 0x4e,
+	0xdb, 0xa1, 0xbe, 0xe4, 0xd4, 0x63, 0x9c, 0xde, 0xcf, 0xdb, 0x4b, 0x8e, 0x16, 0xe3, 0x54, 0x52,
+	0xa4, 0x73, 0xe6, 0x98, 0xc7, 0x3f, 0x06, 0x99, 0xcd, 0xa8, 0x1f, 0xff, 0xa8, 0x93, 0x56, 0x05,
+	0xca, 0x23, 0xc2, 0x39, 0xe5, 0x36, 0x61, 0xde, 0xdc, 0xfa, 0xa2, 0xc1, 0xee, 0x30, 0x18, 0x0f,
+	0x39, 0x75, 0x06, 0x44, 0x72, 0xd7, 0x11, 0x36, 0xf9, 0x18, 0x10, 0x21, 0x51, 0x13, 0x8a, 0xb1,
+	0xc7, 0xd0, 0x1a, 0x7a, 0xb3, 0xdc, 0xa9, 0xb6, 0x62, 0x20, 0xe5, 0xb6, 0x8b, 0x33, 0x15, 0x46,
+	0xfb, 0x00, 0x43, 0x2f, 0x98, 0xba, 0xfe, 0x5b, 0x3c, 0x23, 0xc6, 0x5a, 0x43, 0x6b, 0x96, 0x6c,
+	0x60, 0x0b, 0x0f, 0x3a, 0x80, 0x8a, 0x8a, 0x5f, 0x13, 0x2e, 0x5c, 0xea, 0x1b, 0x7a, 0x43, 0x6b,
+	0xea, 0x76, 0x85, 0x2d, 0x3b, 0xd1, 0x11, 0x14, 0xce, 0xa8, 0xff, 0xc1, 0x9d, 0x1a, 0xeb, 0x0d,
+	0xad, 0x59, 0xee, 0x6c, 0x27, 0xe5, 0x94, 0x77, 0x80, 0x99, 0x5d, 0x70, 0xa2, 0x4f, 0x54, 0x87,
+	0xc2, 0x25, 0x16, 0xb7, 0x6f, 0x26, 0x46, 0x3e, 0x2a, 0x56, 0x90, 0x91, 0x65, 0x1d, 0x00, 0xf4,
+	0x17, 0xd2, 0xc2, 0xac, 0xc8, 0x52, 0xfc, 0x4b, 0x76, 0x21, 0x92, 0x2d, 0xac, 0x77, 0xb0, 0x13,
+	0xca, 0x25, 0x42, 0x2c, 0x14, 0x87, 0xe9, 0x3f, 0xaf, 0xf7, 0x01, 0x78, 0x2d, 0x05, 0x2c, 0x60,
+	0xe7, 0x1a, 0x7b, 0xee, 0x04, 0x4b, 0xd2, 0x23, 0xec, 0x05, 0x8d, 0xec, 0x40, 0x51, 0x35, 0x4a,
+	0x21, 0x97, 0x3b, 0x46, 0x92, 0x39, 0x0a, 0xc6, 0xc2, 0xe1, 0xee, 0x98, 0x4c, 0x54, 0x82, 0x5d,
+	0x54, 0xcd, 0x13, 0xd6, 0x09, 0x6c, 0xa7, 0x8b, 0x86, 0x5a, 0x8e, 0x52, 0xd2, 0x97, 0x7a, 0x39,
+	0xf2, 0x31, 0x53, 0x2d, 0x4a, 0x48, 0x7f, 0xd2, 0xa0, 0xb6, 0x40, 0x5f, 0xa6, 0xfd, 0x17, 0x94,
+	0xe2, 0x4f, 0x32, 0x79, 0x82, 0x78, 0x89, 0x27, 0x09, 0x2f, 0xa1, 0xbe, 0x74, 0x8d, 0x7a, 0xea,
+	0x1a, 0x4f, 0x01, 0xad, 0x30, 0x7a, 0xa6, 0xa6, 0x7f, 0xa0, 0x7e, 0xe5, 0x8b, 0x2c, 0x51, 0x0f,
+	0x25, 0xb5, 0x54, 0xc9, 0x2e, 0xd4, 0x1e, 0x9d, 0x78, 0x66, 0xd1, 0x16, 0xe8, 0x03, 0xcc, 0xd0,
+	0x21, 0x14, 0xfb, 0xbe, 0xe4, 0x2e, 0x49, 0x8e, 0x54, 0x5a, 0x9c, 0x39, 0xad, 0x01, 0x66, 0xa1,
+	0x7b, 0x6e, 0x17, 0x89, 0x8a, 0x5a, 0x1d, 0xd8, 0x48, 0x9c, 0xe8, 0x37, 0xd0, 0x2f, 0xc8, 0x3c,
+	0xe6, 0xa4, 0xdf, 0x92, 0x39, 0xaa, 0x41, 0xfe, 0x1a, 0x7b, 0x41, 0x32, 0x4e, 0xf9, 0xbb, 0xd0,
+	0xb0, 0x3e, 0x6b, 0xb0, 0x7b, 0x46, 0x3d, 0x8f, 0x38, 0x72, 0x65, 0x5a, 0x13, 0x61, 0xbd, 0x94,
+	0xb0, 0x1e, 0xea, 0x42, 0xb1, 0xeb, 0x79, 0x97, 0x78, 0x9a, 0xdc, 0xcb, 0x61, 0x44, 0x27, 0x13,
+	0xa4, 0x15, 0x67, 0xc6, 0x44, 0xb1, 0xb2, 0xcc, 0x1e, 0x6c, 0x2e, 0x07, 0x42, 0xb2, 0xb7, 0x69,
+	0xb2, 0xfb, 0x90, 0xbf, 0x5b, 0x90, 0x2d, 0x77, 0x36, 0x12, 0xc5, 0x31, 0xed, 0xff, 0xd7, 0xfe,
+	0xd3, 0xac, 0xf7, 0x50, 0x5f, 0x2d, 0x2a, 0x18, 0xf5, 0x05, 0xf9, 0x05, 0x83, 0x77, 0x0c, 0xa5,
+	0x2e, 0xe7, 0x23, 0xc9, 0x5d, 0x7f, 0x8a, 0xfe, 0x04, 0x6d, 0x64, 0x68, 0xe9, 0x37, 0x18, 0xae,
+	0x21, 0xc1, 0xb0, 0x43, 0xfa, 0x1e, 0x99, 0x11, 0x5f, 0xda, 0x9a, 0xb0, 0xfe, 0x85, 0xbd, 0x73,
+	0x22, 0xbb, 0x81, 0xa4, 0x13, 0x57, 0x38, 0xf4, 0x8e, 0xf0, 0x21, 0x96, 0x37, 0xf1, 0xbd, 0xd7,
+	0x20, 0x1f, 0x59, 0xf1, 0xea, 0xc8, 0xb3, 0xd0, 0xe8, 0x7c, 0x5d, 0x87, 0x8a, 0xe2, 0x34, 0xc0,
+	0x3e, 0x9e, 0x12, 0x8e, 0x2e, 0xa0, 0x9a, 0x56, 0x85, 0xcc, 0xa7, 0xfb, 0x6b, 0xfe, 0x91, 0x19,
+	0x53, 0x6d, 0xb0, 0x72, 0xe8, 0x14, 0xaa, 0xc3, 0x60, 0xec, 0xb9, 0xe2, 0x26, 0x0d, 0x96, 0xb9,
+	0x9f, 0xcd, 0xad, 0x28, 0xf6, 0xb0, 0xef, 0xac, 0x1c, 0x7a, 0x0d, 0xd5, 0xf4, 0x66, 0xfb, 0x2e,
+	0x80, 0xa1, 0x62, 0x8f, 0x57, 0xa1, 0x95, 0x43, 0xaf, 0x60, 0x73, 0x79, 0xab, 0x20, 0x95, 0x9b,
+	0xb1, 0xdd, 0xcc, 0x7a, 0x46, 0x44, 0x61, 0xf4, 0xa1, 0x92, 0x1a, 0x63, 0xb4, 0x17, 0xa5, 0x66,
+	0x2d, 0x1b, 0xf3, 0xf7, 0xac, 0x90, 0x82, 0xb9, 0x80, 0xad, 0x95, 0xd1, 0x44, 0xaa, 0x8f, 0xd9,
+	0x23, 0x6e, 0xee, 0x65, 0x07, 0x13, 0x5d, 0x70, 0xc5, 0x16, 0xaa, 0x5e, 0x46, 0xe8, 0x1c, 0x6a,
+	0x59, 0x0f, 0x07, 0x55, 0x92, 0xd7, 0xd6, 0x9f, 0x31, 0x39, 0x37, 0xf7, 0x23, 0x84, 0x27, 0x9f,
+	0x98, 0x95, 0x1b, 0x17, 0xa2, 0x7f, 0xe4, 0xe3, 0x6f, 0x03, 0x00, 0x4b, 0xda, 0xf2, 0xf2, 0x0d,
+	0x08, 0x00, 0x00,
 }
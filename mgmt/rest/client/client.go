@@ -34,6 +34,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/asaskevich/govalidator"
 
@@ -101,6 +102,14 @@ func Username(u string) metaOp {
 	}
 }
 
+//Timeout is an option that can be provided to the func client.New.  It
+//limits the time a request, including reading its response, may take.
+func Timeout(t time.Duration) metaOp {
+	return func(c *Client) {
+		c.http.Timeout = t
+	}
+}
+
 // New returns a pointer to a snap api client
 // if ver is an empty string, v1 is used by default
 func New(url, ver string, insecure bool, opts ...metaOp) (*Client, error) {
@@ -172,7 +181,7 @@ func (c *Client) do(method, path string, ct contentType, body ...[]byte) (*rbody
 			}
 			return nil, fmt.Errorf("URL target is not available. %v", err)
 		}
-	case "PUT":
+	case "PUT", "PATCH":
 		var b *bytes.Reader
 		if len(body) == 0 {
 			b = bytes.NewReader([]byte{})
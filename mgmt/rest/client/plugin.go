@@ -20,12 +20,14 @@ limitations under the License.
 package client
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
 	"time"
 
+	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/serror"
 	"github.com/intelsdi-x/snap/mgmt/rest/rbody"
 )
@@ -57,6 +59,40 @@ func (c *Client) LoadPlugin(p []string) *LoadPluginResult {
 	return r
 }
 
+// InstallPlugin loads the plugin of the given type, name and version from the
+// plugin indexes configured on snapd.  The latest version listed in the
+// indexes is installed when the version is less than 1.
+// A slice holding the loaded plugin returns if succeeded. Otherwise, an error is returned.
+func (c *Client) InstallPlugin(pluginType, name string, version int) *LoadPluginResult {
+	r := new(LoadPluginResult)
+	b, err := json.Marshal(core.PluginInstallRequest{Type: pluginType, Name: name, Version: version})
+	if err != nil {
+		r.Err = serror.New(err)
+		return r
+	}
+	resp, err := c.do("POST", "/plugins", ContentTypeJSON, b)
+	if err != nil {
+		r.Err = serror.New(err)
+		return r
+	}
+
+	switch resp.Meta.Type {
+	case rbody.PluginsLoadedType:
+		pl := resp.Body.(*rbody.PluginsLoaded)
+		r.LoadedPlugins = convertLoadedPlugins(pl.LoadedPlugins)
+	case rbody.ErrorType:
+		f := resp.Body.(*rbody.Error).Fields
+		fields := make(map[string]interface{})
+		for k, v := range f {
+			fields[k] = v
+		}
+		r.Err = serror.New(resp.Body.(*rbody.Error), fields)
+	default:
+		r.Err = serror.New(ErrAPIResponseMetaType)
+	}
+	return r
+}
+
 // UnloadPlugin unloads a plugin given plugin type, name, and version through an HTTP DELETE request.
 // The unloaded plugin returns if succeeded. Otherwise, an error is returned.
 func (c *Client) UnloadPlugin(pluginType, name string, version int) *UnloadPluginResult {
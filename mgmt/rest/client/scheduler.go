@@ -0,0 +1,98 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/intelsdi-x/snap/mgmt/rest/rbody"
+)
+
+// GetWorkClasses retrieves the weight, queue size and pool size the jobs of
+// the tasks of each priority class are queued and worked with.
+func (c *Client) GetWorkClasses() *GetWorkClassesResult {
+	r := &GetWorkClassesResult{}
+	resp, err := c.do("GET", "/scheduler/classes", ContentTypeJSON)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+
+	switch resp.Meta.Type {
+	case rbody.WorkClassListReturnedType:
+		// Success
+		r.WorkClassListReturned = resp.Body.(*rbody.WorkClassListReturned)
+	case rbody.ErrorType:
+		r.Err = resp.Body.(*rbody.Error)
+	default:
+		r.Err = ErrAPIResponseMetaType
+	}
+	return r
+}
+
+// SetWorkClass changes the weight, queue size and pool size of a priority
+// class.  The settings given as zero keep their current value.
+func (c *Client) SetWorkClass(name string, weight, queueSize, poolSize uint) *SetWorkClassResult {
+	r := &SetWorkClassResult{}
+	wc := map[string]uint{}
+	if weight != 0 {
+		wc["weight"] = weight
+	}
+	if queueSize != 0 {
+		wc["queue_size"] = queueSize
+	}
+	if poolSize != 0 {
+		wc["pool_size"] = poolSize
+	}
+	b, err := json.Marshal(wc)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	resp, err := c.do("PUT", fmt.Sprintf("/scheduler/classes/%s", name), ContentTypeJSON, b)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+
+	switch resp.Meta.Type {
+	case rbody.WorkClassSetType:
+		// Success
+		r.WorkClassSet = resp.Body.(*rbody.WorkClassSet)
+	case rbody.ErrorType:
+		r.Err = resp.Body.(*rbody.Error)
+	default:
+		r.Err = ErrAPIResponseMetaType
+	}
+	return r
+}
+
+// GetWorkClassesResult is the response from snap/client on a GetWorkClasses call.
+type GetWorkClassesResult struct {
+	*rbody.WorkClassListReturned
+	Err error
+}
+
+// SetWorkClassResult is the response from snap/client on a SetWorkClass call.
+type SetWorkClassResult struct {
+	*rbody.WorkClassSet
+	Err error
+}
@@ -30,11 +30,12 @@ import (
 
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/mgmt/rest/rbody"
+	"github.com/intelsdi-x/snap/pkg/schedule"
 	"github.com/intelsdi-x/snap/scheduler/wmap"
 )
 
 type Schedule struct {
-	// Type specifies the type of the schedule. Currently, the type of "simple", "windowed" and "cron" are supported.
+	// Type specifies the type of the schedule. Currently, the type of "simple", "windowed", "cron", "recurring" and "triggered" are supported.
 	Type string
 	// Interval specifies the time duration.
 	Interval string
@@ -42,21 +43,49 @@ type Schedule struct {
 	StartTime *time.Time
 	// StopTime specifies the end time.
 	StopTime *time.Time
+	// Triggers specifies the tasks events that fire a "triggered" schedule.
+	Triggers []schedule.Trigger
+	// CatchUp specifies the policy for the missed intervals: "skip", "once" or "backfill".
+	CatchUp string `json:"catch-up"`
+	// Splay specifies the bound of a random delay added to each interval of a "simple" or "cron" schedule.
+	Splay string
+	// Exclusions specifies periods of time during which a "simple" or "cron" schedule does not fire.
+	Exclusions []core.ScheduleExclusion
+	// Windows specifies the windows of time a "recurring" schedule fires within.
+	Windows []core.ScheduleWindow
+}
+
+// Overlap specifies what a task due to fire while it is still firing does.
+type Overlap struct {
+	// Policy is "skip", "concurrent" or "cancel".
+	Policy string
+	// MaxConcurrent is how many times a task with the "concurrent" policy can fire at once.
+	MaxConcurrent int
 }
 
 // CreateTask creates a task given the schedule, workflow, task name, and task state.
 // If the startTask flag is true, the newly created task is started after the creation.
-// Otherwise, it's in the Stopped state. CreateTask is accomplished through a POST HTTP JSON request.
+// Otherwise, it's in the Stopped state. A nil overlap keeps the default overlap policy
+// and an empty priority the default priority class.
+// CreateTask is accomplished through a POST HTTP JSON request.
 // A ScheduledTask is returned if it succeeds, otherwise an error is returned.
-func (c *Client) CreateTask(s *Schedule, wf *wmap.WorkflowMap, name string, deadline string, startTask bool, maxFailures int) *CreateTaskResult {
+func (c *Client) CreateTask(s *Schedule, wf *wmap.WorkflowMap, name string, deadline string, startTask bool, maxFailures int, overlap *Overlap, priority string) *CreateTaskResult {
 	t := core.TaskCreationRequest{
 		Schedule: &core.Schedule{
-			Type:     s.Type,
-			Interval: s.Interval,
+			Type:       s.Type,
+			Interval:   s.Interval,
+			Triggers:   s.Triggers,
+			CatchUp:    s.CatchUp,
+			Splay:      s.Splay,
+			Exclusions: s.Exclusions,
+			Windows:    s.Windows,
 		},
-		Workflow:    wf,
-		Start:       startTask,
-		MaxFailures: maxFailures,
+		Workflow: wf,
+		Start:    startTask,
+		Priority: priority,
+	}
+	if maxFailures != 0 {
+		t.MaxFailures = &maxFailures
 	}
 	// Add start and/or stop timestamps if they exist
 	if s.StartTime != nil {
@@ -74,6 +103,10 @@ func (c *Client) CreateTask(s *Schedule, wf *wmap.WorkflowMap, name string, dead
 	if deadline != "" {
 		t.Deadline = deadline
 	}
+	if overlap != nil {
+		t.Overlap = overlap.Policy
+		t.MaxConcurrent = overlap.MaxConcurrent
+	}
 	// Marshal to JSON for request body
 	j, err := json.Marshal(t)
 	if err != nil {
@@ -96,6 +129,27 @@ func (c *Client) CreateTask(s *Schedule, wf *wmap.WorkflowMap, name string, dead
 	}
 }
 
+// ValidateTask validates a task manifest given as JSON without creating the
+// task. ValidateTask is accomplished through a POST HTTP JSON request.
+// The validation result lists every error found in the manifest; an error
+// is returned if the manifest could not be validated at all.
+func (c *Client) ValidateTask(manifest []byte) *ValidateTaskResult {
+	resp, err := c.do("POST", "/tasks/validate", ContentTypeJSON, manifest)
+	if err != nil {
+		return &ValidateTaskResult{Err: err}
+	}
+
+	switch resp.Meta.Type {
+	case rbody.TaskValidatedType:
+		// Success
+		return &ValidateTaskResult{resp.Body.(*rbody.TaskValidated), nil}
+	case rbody.ErrorType:
+		return &ValidateTaskResult{Err: resp.Body.(*rbody.Error)}
+	default:
+		return &ValidateTaskResult{Err: ErrAPIResponseMetaType}
+	}
+}
+
 // WatchTask retrieves running tasks by running a goroutine to
 // interactive with Event and Done channels. An HTTP GET request retrieves tasks.
 // StreamedTaskEvent returns if it succeeds. Otherwise, an error is returned.
@@ -211,6 +265,24 @@ func (c *Client) GetTask(id string) *GetTaskResult {
 	}
 }
 
+// GetTaskHistory retrieves the records of the last fires of a task given its
+// id, from the most recent.
+func (c *Client) GetTaskHistory(id string) *GetTaskHistoryResult {
+	resp, err := c.do("GET", fmt.Sprintf("/tasks/%v/history", id), ContentTypeJSON, nil)
+	if err != nil {
+		return &GetTaskHistoryResult{Err: err}
+	}
+	switch resp.Meta.Type {
+	case rbody.TaskHistoryReturnedType:
+		// Success
+		return &GetTaskHistoryResult{resp.Body.(*rbody.TaskHistoryReturned), nil}
+	case rbody.ErrorType:
+		return &GetTaskHistoryResult{Err: resp.Body.(*rbody.Error)}
+	default:
+		return &GetTaskHistoryResult{Err: ErrAPIResponseMetaType}
+	}
+}
+
 // StartTask starts a task given a task id. The scheduled task will be in
 // the started state if it succeeds. Otherwise, an error is returned.
 func (c *Client) StartTask(id string) *StartTasksResult {
@@ -290,6 +362,78 @@ func (c *Client) EnableTask(id string) *EnableTaskResult {
 	}
 }
 
+// UpdateTask changes an existing task given its id without changing the id.
+// Only the non-empty arguments are changed: a nil schedule or workflow keeps
+// the current one, as do a nil max-failures, a nil overlap and an empty
+// priority. UpdateTask is
+// accomplished through a PATCH HTTP JSON request.
+// The updated task is returned if it succeeds, otherwise an error is returned.
+func (c *Client) UpdateTask(id string, s *Schedule, wf *wmap.WorkflowMap, name string, deadline string, maxFailures *int, overlap *Overlap, priority string) *UpdateTaskResult {
+	t := map[string]interface{}{}
+	if s != nil {
+		sch := &core.Schedule{
+			Type:       s.Type,
+			Interval:   s.Interval,
+			Triggers:   s.Triggers,
+			CatchUp:    s.CatchUp,
+			Splay:      s.Splay,
+			Exclusions: s.Exclusions,
+			Windows:    s.Windows,
+		}
+		// Add start and/or stop timestamps if they exist
+		if s.StartTime != nil {
+			u := s.StartTime.Unix()
+			sch.StartTimestamp = &u
+		}
+		if s.StopTime != nil {
+			u := s.StopTime.Unix()
+			sch.StopTimestamp = &u
+		}
+		t["schedule"] = sch
+	}
+	if wf != nil {
+		t["workflow"] = wf
+	}
+	if name != "" {
+		t["name"] = name
+	}
+	if deadline != "" {
+		t["deadline"] = deadline
+	}
+	if maxFailures != nil {
+		t["max-failures"] = *maxFailures
+	}
+	if overlap != nil {
+		t["overlap"] = overlap.Policy
+		if overlap.MaxConcurrent != 0 {
+			t["max-concurrent"] = overlap.MaxConcurrent
+		}
+	}
+	if priority != "" {
+		t["priority"] = priority
+	}
+	// Marshal to JSON for request body
+	j, err := json.Marshal(t)
+	if err != nil {
+		return &UpdateTaskResult{Err: err}
+	}
+
+	resp, err := c.do("PATCH", fmt.Sprintf("/tasks/%v", id), ContentTypeJSON, j)
+	if err != nil {
+		return &UpdateTaskResult{Err: err}
+	}
+
+	switch resp.Meta.Type {
+	case rbody.ScheduledTaskUpdatedType:
+		// Success
+		return &UpdateTaskResult{resp.Body.(*rbody.ScheduledTaskUpdated), nil}
+	case rbody.ErrorType:
+		return &UpdateTaskResult{Err: resp.Body.(*rbody.Error)}
+	default:
+		return &UpdateTaskResult{Err: ErrAPIResponseMetaType}
+	}
+}
+
 // CreateTaskResult is the response from snap/client on a CreateTask call.
 type CreateTaskResult struct {
 	*rbody.AddScheduledTask
@@ -320,6 +464,12 @@ type GetTaskResult struct {
 	Err error
 }
 
+// GetTaskHistoryResult is the response from snap/client on a GetTaskHistory call.
+type GetTaskHistoryResult struct {
+	*rbody.TaskHistoryReturned
+	Err error
+}
+
 // StartTasksResult is the response from snap/client on a StartTask call.
 type StartTasksResult struct {
 	*rbody.ScheduledTaskStarted
@@ -343,3 +493,15 @@ type EnableTaskResult struct {
 	*rbody.ScheduledTaskEnabled
 	Err error
 }
+
+// UpdateTaskResult is the response from snap/client on a UpdateTask call.
+type UpdateTaskResult struct {
+	*rbody.ScheduledTaskUpdated
+	Err error
+}
+
+// ValidateTaskResult is the response from snap/client on a ValidateTask call.
+type ValidateTaskResult struct {
+	*rbody.TaskValidated
+	Err error
+}
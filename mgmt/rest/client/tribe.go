@@ -63,6 +63,78 @@ func (c *Client) GetMember(name string) *GetMemberResult {
 	}
 }
 
+// GetAgreementDrift retrieves the differences between an agreement and the
+// plugins and tasks running on the member serving the request.
+// The request is an HTTP GET call. An error is returned if it fails.
+func (c *Client) GetAgreementDrift(name string) *GetAgreementDriftResult {
+	resp, err := c.do("GET", fmt.Sprintf("/tribe/agreements/%s/drift", name), ContentTypeJSON, nil)
+	if err != nil {
+		return &GetAgreementDriftResult{Err: err}
+	}
+	switch resp.Meta.Type {
+	case rbody.TribeAgreementDriftType:
+		return &GetAgreementDriftResult{resp.Body.(*rbody.TribeAgreementDrift), nil}
+	case rbody.ErrorType:
+		return &GetAgreementDriftResult{Err: resp.Body.(*rbody.Error)}
+	default:
+		return &GetAgreementDriftResult{Err: ErrAPIResponseMetaType}
+	}
+}
+
+// GetAgreementTasks retrieves the state of the tasks of an agreement on every
+// member of the agreement.
+// The request is an HTTP GET call. An error is returned if it fails.
+func (c *Client) GetAgreementTasks(name string) *GetAgreementTasksResult {
+	resp, err := c.do("GET", fmt.Sprintf("/tribe/agreements/%s/tasks", name), ContentTypeJSON, nil)
+	if err != nil {
+		return &GetAgreementTasksResult{Err: err}
+	}
+	switch resp.Meta.Type {
+	case rbody.TribeAgreementTasksType:
+		return &GetAgreementTasksResult{resp.Body.(*rbody.TribeAgreementTasks), nil}
+	case rbody.ErrorType:
+		return &GetAgreementTasksResult{Err: resp.Body.(*rbody.Error)}
+	default:
+		return &GetAgreementTasksResult{Err: ErrAPIResponseMetaType}
+	}
+}
+
+// GetAgreementPlugins retrieves the plugins of an agreement loaded on every
+// member of the agreement.
+// The request is an HTTP GET call. An error is returned if it fails.
+func (c *Client) GetAgreementPlugins(name string) *GetAgreementPluginsResult {
+	resp, err := c.do("GET", fmt.Sprintf("/tribe/agreements/%s/plugins", name), ContentTypeJSON, nil)
+	if err != nil {
+		return &GetAgreementPluginsResult{Err: err}
+	}
+	switch resp.Meta.Type {
+	case rbody.TribeAgreementPluginsType:
+		return &GetAgreementPluginsResult{resp.Body.(*rbody.TribeAgreementPlugins), nil}
+	case rbody.ErrorType:
+		return &GetAgreementPluginsResult{Err: resp.Body.(*rbody.Error)}
+	default:
+		return &GetAgreementPluginsResult{Err: ErrAPIResponseMetaType}
+	}
+}
+
+// GetTribeStatus retrieves how agreement changes are coordinated by the
+// tribe, including the leader and replication lag when running in leader mode.
+// The request is an HTTP GET call. An error is returned if it fails.
+func (c *Client) GetTribeStatus() *GetTribeStatusResult {
+	resp, err := c.do("GET", "/tribe/status", ContentTypeJSON, nil)
+	if err != nil {
+		return &GetTribeStatusResult{Err: err}
+	}
+	switch resp.Meta.Type {
+	case rbody.TribeStatusType:
+		return &GetTribeStatusResult{resp.Body.(*rbody.TribeStatus), nil}
+	case rbody.ErrorType:
+		return &GetTribeStatusResult{Err: resp.Body.(*rbody.Error)}
+	default:
+		return &GetTribeStatusResult{Err: ErrAPIResponseMetaType}
+	}
+}
+
 // ListAgreements retrieves a list of a tribe agreements through an HTTP GET call.
 // A list of tribe agreement map returns if it succeeds. Otherwise, an error is returned.
 func (c *Client) ListAgreements() *ListAgreementResult {
@@ -237,3 +309,27 @@ type LeaveAgreementResult struct {
 	*rbody.TribeLeaveAgreement
 	Err error
 }
+
+// GetAgreementDriftResult is the response from snap/client on a GetAgreementDrift call.
+type GetAgreementDriftResult struct {
+	*rbody.TribeAgreementDrift
+	Err error
+}
+
+// GetAgreementTasksResult is the response from snap/client on a GetAgreementTasks call.
+type GetAgreementTasksResult struct {
+	*rbody.TribeAgreementTasks
+	Err error
+}
+
+// GetAgreementPluginsResult is the response from snap/client on a GetAgreementPlugins call.
+type GetAgreementPluginsResult struct {
+	*rbody.TribeAgreementPlugins
+	Err error
+}
+
+// GetTribeStatusResult is the response from snap/client on a GetTribeStatus call.
+type GetTribeStatusResult struct {
+	*rbody.TribeStatus
+	Err error
+}
@@ -0,0 +1,121 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/intelsdi-x/snap/mgmt/rest/rbody"
+)
+
+// GetTrustedKeys retrieves the keys the signatures of plugins are verified
+// against, revoked keys included.
+func (c *Client) GetTrustedKeys() *GetTrustedKeysResult {
+	r := &GetTrustedKeysResult{}
+	resp, err := c.do("GET", "/trust/keys", ContentTypeJSON)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+
+	switch resp.Meta.Type {
+	case rbody.TrustKeyListReturnedType:
+		// Success
+		r.TrustKeyListReturned = resp.Body.(*rbody.TrustKeyListReturned)
+	case rbody.ErrorType:
+		r.Err = resp.Body.(*rbody.Error)
+	default:
+		r.Err = ErrAPIResponseMetaType
+	}
+	return r
+}
+
+// AddTrustedKey adds the keys of the scheme (openpgp, x509 or ed25519): an
+// armored OpenPGP keyring, PEM X.509 CA certificates or an Ed25519 public
+// key, which is named after its signer.
+func (c *Client) AddTrustedKey(scheme, name string, key []byte) *AddTrustedKeyResult {
+	r := &AddTrustedKeyResult{}
+	b, err := json.Marshal(struct {
+		Scheme string `json:"scheme"`
+		Name   string `json:"name"`
+		Key    string `json:"key"`
+	}{Scheme: scheme, Name: name, Key: string(key)})
+	if err != nil {
+		r.Err = err
+		return r
+	}
+	resp, err := c.do("POST", "/trust/keys", ContentTypeJSON, b)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+
+	switch resp.Meta.Type {
+	case rbody.TrustKeysAddedType:
+		// Success
+		r.TrustKeysAdded = resp.Body.(*rbody.TrustKeysAdded)
+	case rbody.ErrorType:
+		r.Err = resp.Body.(*rbody.Error)
+	default:
+		r.Err = ErrAPIResponseMetaType
+	}
+	return r
+}
+
+// RevokeTrustedKey revokes the trusted key of the id.  The plugins signed by
+// the key are unloaded, or flagged depending on the configuration of snapd.
+func (c *Client) RevokeTrustedKey(id string) *RevokeTrustedKeyResult {
+	r := &RevokeTrustedKeyResult{}
+	resp, err := c.do("DELETE", fmt.Sprintf("/trust/keys/%s", id), ContentTypeJSON)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+
+	switch resp.Meta.Type {
+	case rbody.TrustKeyRevokedType:
+		// Success
+		r.TrustKeyRevoked = resp.Body.(*rbody.TrustKeyRevoked)
+	case rbody.ErrorType:
+		r.Err = resp.Body.(*rbody.Error)
+	default:
+		r.Err = ErrAPIResponseMetaType
+	}
+	return r
+}
+
+// GetTrustedKeysResult is the response from snap/client on a GetTrustedKeys call.
+type GetTrustedKeysResult struct {
+	*rbody.TrustKeyListReturned
+	Err error
+}
+
+// AddTrustedKeyResult is the response from snap/client on an AddTrustedKey call.
+type AddTrustedKeyResult struct {
+	*rbody.TrustKeysAdded
+	Err error
+}
+
+// RevokeTrustedKeyResult is the response from snap/client on a RevokeTrustedKey call.
+type RevokeTrustedKeyResult struct {
+	*rbody.TrustKeyRevoked
+	Err error
+}
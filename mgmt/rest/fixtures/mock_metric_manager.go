@@ -27,6 +27,7 @@ import (
 	"github.com/intelsdi-x/snap/control/plugin/cpolicy"
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/pkg/pindex"
 )
 
 var pluginCatalog []core.CatalogedPlugin = []core.CatalogedPlugin{
@@ -50,13 +51,15 @@ type MockLoadedPlugin struct {
 	MyVersion int
 }
 
-func (m MockLoadedPlugin) Name() string       { return m.MyName }
-func (m MockLoadedPlugin) TypeName() string   { return m.MyType }
-func (m MockLoadedPlugin) Version() int       { return m.MyVersion }
-func (m MockLoadedPlugin) Plugin() string     { return "" }
-func (m MockLoadedPlugin) IsSigned() bool     { return false }
-func (m MockLoadedPlugin) Status() string     { return "" }
-func (m MockLoadedPlugin) PluginPath() string { return "" }
+func (m MockLoadedPlugin) Name() string        { return m.MyName }
+func (m MockLoadedPlugin) TypeName() string    { return m.MyType }
+func (m MockLoadedPlugin) Version() int        { return m.MyVersion }
+func (m MockLoadedPlugin) Plugin() string      { return "" }
+func (m MockLoadedPlugin) IsSigned() bool      { return false }
+func (m MockLoadedPlugin) Signer() string      { return "" }
+func (m MockLoadedPlugin) SignerRevoked() bool { return false }
+func (m MockLoadedPlugin) Status() string      { return "" }
+func (m MockLoadedPlugin) PluginPath() string  { return "" }
 func (m MockLoadedPlugin) LoadedTimestamp() *time.Time {
 	t := time.Date(2016, time.September, 6, 0, 0, 0, 0, time.UTC)
 	return &t
@@ -108,6 +111,14 @@ func (m MockManagesMetrics) Unload(plugin core.Plugin) (core.CatalogedPlugin, se
 	}
 	return nil, serror.New(errors.New("plugin not found"))
 }
+func (m MockManagesMetrics) InstallPlugin(pluginType, name string, version int) (core.CatalogedPlugin, serror.SnapError) {
+	for _, pl := range pluginCatalog {
+		if pluginType == pl.TypeName() && name == pl.Name() && (version < 1 || version == pl.Version()) {
+			return pl, nil
+		}
+	}
+	return nil, serror.New(pindex.ErrPluginNotFound)
+}
 
 func (m MockManagesMetrics) PluginCatalog() core.PluginCatalog {
 	return pluginCatalog
@@ -286,6 +297,28 @@ const (
   ]
 }`
 
+	INSTALL_PLUGIN_RESPONSE = `{
+  "meta": {
+    "code": 201,
+    "message": "Plugins loaded: bar(publisher v3)",
+    "type": "plugins_loaded",
+    "version": 1
+  },
+  "body": {
+    "loaded_plugins": [
+      {
+        "name": "bar",
+        "version": 3,
+        "type": "publisher",
+        "signed": false,
+        "status": "",
+        "loaded_timestamp": 1473120000,
+        "href": "http://localhost:%d/v1/plugins/publisher/bar/3"
+      }
+    ]
+  }
+}`
+
 	UNLOAD_PLUGIN_RESPONSE = `{
   "meta": {
     "code": 200,
@@ -51,6 +51,19 @@ var taskCatalog map[string]core.Task = map[string]core.Task{
 		MyState:             "passed",
 		MyHref:              "http://localhost:8181/v2/tasks/asdfghjkl"}}
 
+// mockTaskHistory is the history of the mock tasks
+var mockTaskHistory = []core.TaskRun{
+	{
+		FireTime: time.Unix(1470000000, 0),
+		Duration: time.Millisecond * 20,
+		Failed:   true,
+		Nodes: []core.TaskRunNode{
+			{Type: "collector", Duration: time.Millisecond * 12, MetricCount: 3},
+			{Type: "publisher", Name: "file", Version: 3, Duration: time.Millisecond * 5, MetricCount: 3, Errors: []string{"file not writable"}},
+		},
+	},
+}
+
 type mockTask struct {
 	MyID                 string            `json:"id"`
 	MyName               string            `json:"name"`
@@ -83,6 +96,12 @@ func (t *mockTask) SetDeadlineDuration(time.Duration) { return }
 func (t *mockTask) SetTaskID(id string)               { return }
 func (t *mockTask) SetStopOnFailure(int)              { return }
 func (t *mockTask) GetStopOnFailure() int             { return 0 }
+func (t *mockTask) SetOverlap(string, int)            { return }
+func (t *mockTask) GetOverlap() (string, int)         { return core.TaskOverlapSkip, 0 }
+func (t *mockTask) FireDurations() core.FireDurations { return core.NewFireDurations() }
+func (t *mockTask) SetPriority(string)                { return }
+func (t *mockTask) GetPriority() string               { return core.TaskPriorityNormal }
+func (t *mockTask) History() []core.TaskRun           { return mockTaskHistory }
 func (t *mockTask) Option(...core.TaskOption) core.TaskOption {
 	return core.TaskDeadlineDuration(0)
 }
@@ -144,6 +163,39 @@ func (m *MockTaskManager) EnableTask(id string) (core.Task, error) {
 		MyState:             "failed",
 		MyHref:              "http://localhost:8181/v2/tasks/alskdjf"}, nil
 }
+func (m *MockTaskManager) UpdateTask(
+	id string,
+	sch schedule.Schedule,
+	wmap *wmap.WorkflowMap,
+	opts ...core.TaskOption) (core.Task, core.TaskErrors) {
+	return &mockTask{
+		MyID:                id,
+		MyName:              "TaskUpdated",
+		MyCreationTimestamp: time.Now().Unix(),
+		MyLastRunTimestamp:  time.Now().Unix(),
+		MyHitCount:          44,
+		MyMissCount:         8,
+		MyState:             "failed",
+		MyHref:              "http://localhost:8181/v2/tasks/" + id}, nil
+}
+
+func (m *MockTaskManager) ValidateTask(
+	sch schedule.Schedule,
+	wmap *wmap.WorkflowMap) core.TaskErrors {
+	return nil
+}
+
+func (m *MockTaskManager) GetWorkClasses() []core.WorkClass {
+	return []core.WorkClass{
+		{Name: core.TaskPriorityHigh, Weight: 4, QueueSize: 25, PoolSize: 4},
+		{Name: core.TaskPriorityNormal, Weight: 2, QueueSize: 25, PoolSize: 4},
+		{Name: core.TaskPriorityLow, Weight: 1, QueueSize: 25, PoolSize: 4},
+	}
+}
+
+func (m *MockTaskManager) SetWorkClass(wc core.WorkClass) error {
+	return wc.Validate()
+}
 
 // Mock task used in the 'Add tasks' test in rest_v1_test.go
 const TASK = `{
@@ -251,6 +303,55 @@ const (
     "creation_timestamp": -62135596800,
     "last_run_timestamp": -1,
     "task_state": "Running",
+    "overlap": "skip",
+    "priority": "normal",
+    "fire_durations": {
+      "count": 0,
+      "sum_ms": 0,
+      "max_ms": 0,
+      "buckets": [
+        {
+          "up_to_ms": 10,
+          "count": 0
+        },
+        {
+          "up_to_ms": 50,
+          "count": 0
+        },
+        {
+          "up_to_ms": 100,
+          "count": 0
+        },
+        {
+          "up_to_ms": 500,
+          "count": 0
+        },
+        {
+          "up_to_ms": 1000,
+          "count": 0
+        },
+        {
+          "up_to_ms": 5000,
+          "count": 0
+        },
+        {
+          "up_to_ms": 10000,
+          "count": 0
+        },
+        {
+          "up_to_ms": 30000,
+          "count": 0
+        },
+        {
+          "up_to_ms": 60000,
+          "count": 0
+        },
+        {
+          "up_to_ms": -1,
+          "count": 0
+        }
+      ]
+    },
     "href": "http://localhost:%d/v1/tasks/:1234"
   }
 }`
@@ -278,6 +379,8 @@ const (
     "creation_timestamp": -62135596800,
     "last_run_timestamp": -1,
     "task_state": "Running",
+    "overlap": "skip",
+    "priority": "normal",
     "href": "http://localhost:%d/v1/tasks/MyTaskID"
   }
 }`
@@ -329,10 +432,75 @@ const (
     "creation_timestamp": -62135596800,
     "last_run_timestamp": -1,
     "task_state": "Running",
+    "overlap": "skip",
+    "priority": "normal",
     "href": ""
   }
 }`
 
+	UPDATE_TASK_RESPONSE_ID_UPDATE = `{
+  "meta": {
+    "code": 200,
+    "message": "Scheduled task (MockTask1234) updated",
+    "type": "scheduled_task_updated",
+    "version": 1
+  },
+  "body": {
+    "id": "MockTask1234",
+    "name": "TaskUpdated",
+    "deadline": "4ns",
+    "workflow": {
+      "collect": {
+        "metrics": {}
+      }
+    },
+    "schedule": {
+      "type": "simple",
+      "interval": "1s"
+    },
+    "creation_timestamp": -62135596800,
+    "last_run_timestamp": -1,
+    "task_state": "Running",
+    "overlap": "skip",
+    "priority": "normal",
+    "href": "http://localhost:%d/v1/tasks/MockTask1234"
+  }
+}`
+
+	VALIDATE_TASK_RESPONSE_VALID = `{
+  "meta": {
+    "code": 200,
+    "message": "Task is valid",
+    "type": "task_validated",
+    "version": 1
+  },
+  "body": {
+    "valid": true
+  }
+}`
+
+	VALIDATE_TASK_RESPONSE_INVALID = `{
+  "meta": {
+    "code": 200,
+    "message": "Task is not valid (2 errors)",
+    "type": "task_validated",
+    "version": 1
+  },
+  "body": {
+    "valid": false,
+    "errors": [
+      {
+        "message": "unknown schedule type daily",
+        "path": "schedule.type"
+      },
+      {
+        "message": "Task must include a workflow, and the workflow must not be empty",
+        "path": "workflow"
+      }
+    ]
+  }
+}`
+
 	REMOVE_TASK_RESPONSE_ID = `{
   "meta": {
     "code": 200,
@@ -344,4 +512,86 @@ const (
     "id": "MockTask1234"
   }
 }`
+
+	GET_WORK_CLASSES_RESPONSE = `{
+  "meta": {
+    "code": 200,
+    "message": "Work classes retrieved",
+    "type": "work_class_list_returned",
+    "version": 1
+  },
+  "body": {
+    "work_classes": [
+      {
+        "name": "high",
+        "weight": 4,
+        "queue_size": 25,
+        "pool_size": 4
+      },
+      {
+        "name": "normal",
+        "weight": 2,
+        "queue_size": 25,
+        "pool_size": 4
+      },
+      {
+        "name": "low",
+        "weight": 1,
+        "queue_size": 25,
+        "pool_size": 4
+      }
+    ]
+  }
+}`
+
+	SET_WORK_CLASS_RESPONSE = `{
+  "meta": {
+    "code": 200,
+    "message": "Work class (low) set",
+    "type": "work_class_set",
+    "version": 1
+  },
+  "body": {
+    "name": "low",
+    "weight": 1,
+    "queue_size": 25,
+    "pool_size": 1
+  }
+}`
+
+	GET_TASK_HISTORY_RESPONSE = `{
+  "meta": {
+    "code": 200,
+    "message": "Task history (MockTask1234) returned",
+    "type": "task_history_returned",
+    "version": 1
+  },
+  "body": {
+    "id": "MockTask1234",
+    "runs": [
+      {
+        "fire_timestamp": 1470000000,
+        "duration_ms": 20,
+        "failed": true,
+        "nodes": [
+          {
+            "type": "collector",
+            "duration_ms": 12,
+            "metric_count": 3
+          },
+          {
+            "type": "publisher",
+            "name": "file",
+            "version": 3,
+            "duration_ms": 5,
+            "metric_count": 3,
+            "errors": [
+              "file not writable"
+            ]
+          }
+        ]
+      }
+    ]
+  }
+}`
 )
@@ -22,6 +22,7 @@ package fixtures
 
 import (
 	"net"
+	"time"
 
 	"github.com/hashicorp/memberlist"
 	"github.com/intelsdi-x/snap/core"
@@ -82,6 +83,100 @@ func (m *MockTribeManager) GetMembers() []string {
 func (m *MockTribeManager) GetMember(name string) *agreement.Member {
 	return &agreement.Member{}
 }
+func (m *MockTribeManager) GetDrift(name string) (*agreement.Drift, serror.SnapError) {
+	return &agreement.Drift{
+		Agreement: name,
+		Member:    "one",
+		Policy:    "report",
+		CheckedAt: time.Date(2016, time.November, 1, 12, 0, 0, 0, time.UTC),
+		Plugins: []agreement.PluginDrift{
+			{
+				Name:    "mockVersion",
+				Version: 1,
+				Type:    "collector",
+				Reason:  agreement.DriftMissing,
+			},
+		},
+		Tasks: []agreement.TaskDrift{
+			{
+				ID:            "mockTask",
+				Reason:        agreement.DriftState,
+				ExpectedState: "Running",
+				ActualState:   "Stopped",
+			},
+		},
+	}, nil
+}
+func (m *MockTribeManager) GetAgreementTasks(name string) ([]agreement.MemberTasks, serror.SnapError) {
+	return []agreement.MemberTasks{
+		{
+			Member: "one",
+			Tasks: []agreement.TaskSummary{
+				{
+					ID:               "mockTask",
+					Name:             "Task-mockTask",
+					State:            "Running",
+					HitCount:         44,
+					FailedCount:      1,
+					LastRunTimestamp: 1477998000,
+				},
+			},
+		},
+		{
+			Member: "two",
+			Tasks: []agreement.TaskSummary{
+				{
+					ID:      "mockTask",
+					Missing: true,
+				},
+			},
+		},
+	}, nil
+}
+func (m *MockTribeManager) GetAgreementPlugins(name string) ([]agreement.MemberPlugins, serror.SnapError) {
+	return []agreement.MemberPlugins{
+		{
+			Member: "one",
+			Plugins: []agreement.PluginSummary{
+				{
+					Name:            "mockVersion",
+					Version:         1,
+					Type:            "collector",
+					Loaded:          true,
+					Status:          "loaded",
+					LoadedTimestamp: 1477998000,
+				},
+			},
+		},
+		{
+			Member:  "two",
+			Error:   "Member is not available",
+			Plugins: []agreement.PluginSummary{},
+		},
+	}, nil
+}
+func (m *MockTribeManager) GetStatus() *agreement.Status {
+	return &agreement.Status{
+		Mode:         agreement.LeaderMode,
+		Name:         "one",
+		State:        "leader",
+		Leader:       "one",
+		Term:         2,
+		LastIndex:    5,
+		CommitIndex:  5,
+		AppliedIndex: 5,
+		Voters:       []string{"one", "two", "three"},
+		Replication: []agreement.Replication{
+			{
+				Name:        "three",
+				Voter:       true,
+				MatchIndex:  3,
+				Lag:         2,
+				LastContact: time.Date(2016, time.November, 1, 12, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+}
 
 // These constants are the expected tribe responses from running
 // rest_v1_test.go on the tribe routes found in mgmt/rest/server.go
@@ -201,6 +296,149 @@ const (
   }
 }`
 
+	GET_TRIBE_AGREEMENT_DRIFT_RESPONSE = `{
+  "meta": {
+    "code": 200,
+    "message": "Tribe agreement drift retrieved",
+    "type": "tribe_agreement_drift_returned",
+    "version": 1
+  },
+  "body": {
+    "drift": {
+      "agreement": "Agree1",
+      "member": "one",
+      "policy": "report",
+      "checked_at": "2016-11-01T12:00:00Z",
+      "plugins": [
+        {
+          "name": "mockVersion",
+          "version": 1,
+          "type": "collector",
+          "reason": "missing",
+          "repaired": false
+        }
+      ],
+      "tasks": [
+        {
+          "id": "mockTask",
+          "reason": "state",
+          "expected_state": "Running",
+          "actual_state": "Stopped",
+          "repaired": false
+        }
+      ]
+    }
+  }
+}`
+
+	GET_TRIBE_AGREEMENT_TASKS_RESPONSE = `{
+  "meta": {
+    "code": 200,
+    "message": "Tribe agreement tasks retrieved",
+    "type": "tribe_agreement_tasks_returned",
+    "version": 1
+  },
+  "body": {
+    "agreement": "Agree1",
+    "members": [
+      {
+        "member": "one",
+        "tasks": [
+          {
+            "id": "mockTask",
+            "name": "Task-mockTask",
+            "state": "Running",
+            "hit_count": 44,
+            "miss_count": 0,
+            "failed_count": 1,
+            "last_run_timestamp": 1477998000,
+            "missing": false
+          }
+        ]
+      },
+      {
+        "member": "two",
+        "tasks": [
+          {
+            "id": "mockTask",
+            "hit_count": 0,
+            "miss_count": 0,
+            "failed_count": 0,
+            "missing": true
+          }
+        ]
+      }
+    ]
+  }
+}`
+
+	GET_TRIBE_AGREEMENT_PLUGINS_RESPONSE = `{
+  "meta": {
+    "code": 200,
+    "message": "Tribe agreement plugins retrieved",
+    "type": "tribe_agreement_plugins_returned",
+    "version": 1
+  },
+  "body": {
+    "agreement": "Agree1",
+    "members": [
+      {
+        "member": "one",
+        "plugins": [
+          {
+            "name": "mockVersion",
+            "version": 1,
+            "type": "collector",
+            "loaded": true,
+            "status": "loaded",
+            "loaded_timestamp": 1477998000
+          }
+        ]
+      },
+      {
+        "member": "two",
+        "error": "Member is not available",
+        "plugins": []
+      }
+    ]
+  }
+}`
+
+	GET_TRIBE_STATUS_RESPONSE = `{
+  "meta": {
+    "code": 200,
+    "message": "Tribe status retrieved",
+    "type": "tribe_status_returned",
+    "version": 1
+  },
+  "body": {
+    "status": {
+      "mode": "leader",
+      "name": "one",
+      "state": "leader",
+      "leader": "one",
+      "term": 2,
+      "last_index": 5,
+      "commit_index": 5,
+      "applied_index": 5,
+      "voters": [
+        "one",
+        "two",
+        "three"
+      ],
+      "replication": [
+        {
+          "name": "three",
+          "voter": true,
+          "match_index": 3,
+          "lag": 2,
+          "last_contact": "2016-11-01T12:00:00Z"
+        }
+      ]
+    }
+  }
+}`
+
 	GET_TRIBE_MEMBER_NAME = `{
   "meta": {
     "code": 200,
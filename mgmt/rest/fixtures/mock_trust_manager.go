@@ -0,0 +1,126 @@
+// +build legacy small medium large
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fixtures
+
+import (
+	"errors"
+
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/pkg/psigning"
+)
+
+var mockTrustedKeys = []psigning.Key{
+	{ID: "8E6C8F6BF6AB2B0A", Scheme: "openpgp", Identity: "Snap Release Key", Source: "/etc/snap/keyrings/snap.gpg"},
+	{ID: "25F1E0A2F15C0D4B", Scheme: "ed25519", Identity: "release", Source: "release", Revoked: true},
+}
+
+type MockTrustManager struct{}
+
+func (MockTrustManager) TrustedKeys() []psigning.Key {
+	return mockTrustedKeys
+}
+
+func (MockTrustManager) AddTrustedKey(scheme, name string, key []byte) ([]psigning.Key, serror.SnapError) {
+	if scheme != psigning.SchemeEd25519 {
+		return nil, serror.New(errors.New("Unsupported key scheme"))
+	}
+	return []psigning.Key{{ID: "C1D2E3F405A6B7C8", Scheme: scheme, Identity: name, Source: name}}, nil
+}
+
+func (MockTrustManager) RevokeTrustedKey(id string) ([]core.CatalogedPlugin, []core.CatalogedPlugin, serror.SnapError) {
+	if id != mockTrustedKeys[0].ID {
+		return nil, nil, serror.New(errors.New("Trusted key not found"))
+	}
+	return []core.CatalogedPlugin{MockLoadedPlugin{MyName: "foo", MyType: "collector", MyVersion: 2}}, nil, nil
+}
+
+// These constants are the expected responses from running the trust tests in
+// rest_v1_test.go on the trust routes found in mgmt/rest/server.go
+const (
+	GET_TRUSTED_KEYS_RESPONSE = `{
+  "meta": {
+    "code": 200,
+    "message": "Trusted keys retrieved",
+    "type": "trust_key_list_returned",
+    "version": 1
+  },
+  "body": {
+    "keys": [
+      {
+        "id": "8E6C8F6BF6AB2B0A",
+        "scheme": "openpgp",
+        "identity": "Snap Release Key",
+        "source": "/etc/snap/keyrings/snap.gpg",
+        "revoked": false
+      },
+      {
+        "id": "25F1E0A2F15C0D4B",
+        "scheme": "ed25519",
+        "identity": "release",
+        "source": "release",
+        "revoked": true
+      }
+    ]
+  }
+}`
+
+	ADD_TRUSTED_KEY_RESPONSE = `{
+  "meta": {
+    "code": 201,
+    "message": "Trusted keys added (1)",
+    "type": "trust_keys_added",
+    "version": 1
+  },
+  "body": {
+    "keys": [
+      {
+        "id": "C1D2E3F405A6B7C8",
+        "scheme": "ed25519",
+        "identity": "nightly",
+        "source": "nightly",
+        "revoked": false
+      }
+    ]
+  }
+}`
+
+	REVOKE_TRUSTED_KEY_RESPONSE = `{
+  "meta": {
+    "code": 200,
+    "message": "Trusted key revoked (8E6C8F6BF6AB2B0A)",
+    "type": "trust_key_revoked",
+    "version": 1
+  },
+  "body": {
+    "id": "8E6C8F6BF6AB2B0A",
+    "unloaded_plugins": [
+      {
+        "name": "foo",
+        "version": 2,
+        "type": "collector"
+      }
+    ],
+    "flagged_plugins": []
+  }
+}`
+)
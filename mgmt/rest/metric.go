@@ -29,6 +29,7 @@ import (
 
 	"github.com/julienschmidt/httprouter"
 
+	"github.com/intelsdi-x/snap/control/plugin/cpolicy"
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/mgmt/rest/rbody"
 	"github.com/intelsdi-x/snap/pkg/stringutils"
@@ -156,19 +157,7 @@ func (s *Server) getMetricsFromTree(w http.ResponseWriter, r *http.Request, para
 		LastAdvertisedTimestamp: mt.LastAdvertisedTime().Unix(),
 		Href: catalogedMetricURI(r.Host, mt),
 	}
-	rt := mt.Policy().RulesAsTable()
-	policies := make([]rbody.PolicyTable, 0, len(rt))
-	for _, r := range rt {
-		policies = append(policies, rbody.PolicyTable{
-			Name:     r.Name,
-			Type:     r.Type,
-			Default:  r.Default,
-			Required: r.Required,
-			Minimum:  r.Minimum,
-			Maximum:  r.Maximum,
-		})
-	}
-	mb.Policy = policies
+	mb.Policy = policyTable(mt.Policy().RulesAsTable())
 	b.Metric = mb
 	respond(200, b, w)
 }
@@ -177,18 +166,7 @@ func respondWithMetrics(host string, mets []core.CatalogedMetric, w http.Respons
 	b := rbody.NewMetricsReturned()
 
 	for _, met := range mets {
-		rt := met.Policy().RulesAsTable()
-		policies := make([]rbody.PolicyTable, 0, len(rt))
-		for _, r := range rt {
-			policies = append(policies, rbody.PolicyTable{
-				Name:     r.Name,
-				Type:     r.Type,
-				Default:  r.Default,
-				Required: r.Required,
-				Minimum:  r.Minimum,
-				Maximum:  r.Maximum,
-			})
-		}
+		policies := policyTable(met.Policy().RulesAsTable())
 		dyn, indexes := met.Namespace().IsDynamic()
 		var dynamicElements []rbody.DynamicElement
 		if dyn {
@@ -226,3 +204,25 @@ func getDynamicElements(ns core.Namespace, indexes []int) []rbody.DynamicElement
 	}
 	return elements
 }
+
+// policyTable converts the rules of a config policy node into their response
+// body representation
+func policyTable(rt []cpolicy.RuleTable) []rbody.PolicyTable {
+	policies := make([]rbody.PolicyTable, 0, len(rt))
+	for _, r := range rt {
+		policies = append(policies, rbody.PolicyTable{
+			Name:         r.Name,
+			Type:         r.Type,
+			Default:      r.Default,
+			Required:     r.Required,
+			Minimum:      r.Minimum,
+			Maximum:      r.Maximum,
+			MinLength:    r.MinLength,
+			MaxLength:    r.MaxLength,
+			Allowed:      r.Allowed,
+			Pattern:      r.Pattern,
+			RequiredKeys: r.RequiredKeys,
+		})
+	}
+	return policies
+}
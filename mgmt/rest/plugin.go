@@ -42,6 +42,7 @@ import (
 	"github.com/intelsdi-x/snap/core"
 	"github.com/intelsdi-x/snap/core/serror"
 	"github.com/intelsdi-x/snap/mgmt/rest/rbody"
+	"github.com/intelsdi-x/snap/pkg/pindex"
 )
 
 const PluginAlreadyLoaded = "plugin is already loaded"
@@ -75,6 +76,10 @@ func (s *Server) loadPlugin(w http.ResponseWriter, r *http.Request, _ httprouter
 		respond(500, rbody.FromError(err), w)
 		return
 	}
+	if mediaType == "application/json" {
+		s.installPlugin(w, r)
+		return
+	}
 	if strings.HasPrefix(mediaType, "multipart/") {
 		var pluginPath string
 		var signature []byte
@@ -187,6 +192,42 @@ func (s *Server) loadPlugin(w http.ResponseWriter, r *http.Request, _ httprouter
 	}
 }
 
+// installPlugin loads the plugin requested by type, name and version from the
+// configured plugin indexes
+func (s *Server) installPlugin(w http.ResponseWriter, r *http.Request) {
+	ir := &core.PluginInstallRequest{}
+	errCode, err := core.UnmarshalBody(ir, r.Body)
+	if errCode != 0 && err != nil {
+		respond(errCode, rbody.FromError(err), w)
+		return
+	}
+	if ir.Type == "" || ir.Name == "" {
+		respond(400, rbody.FromError(errors.New("missing plugin type or name")), w)
+		return
+	}
+	restLogger.Infof("Installing plugin: %s/%s@%d", ir.Type, ir.Name, ir.Version)
+	pl, se := s.mm.InstallPlugin(ir.Type, ir.Name, ir.Version)
+	if se != nil {
+		restLogger.Error(se)
+		rb := rbody.FromSnapError(se)
+		var ec int
+		switch {
+		case rb.ResponseBodyMessage() == PluginAlreadyLoaded:
+			ec = 409
+		case strings.HasPrefix(rb.ResponseBodyMessage(), pindex.ErrPluginNotFound.Error()):
+			ec = 404
+		default:
+			ec = 500
+		}
+		respond(ec, rb, w)
+		return
+	}
+	lp := &rbody.PluginsLoaded{
+		LoadedPlugins: []rbody.LoadedPlugin{*catalogedPluginToLoaded(r.Host, pl)},
+	}
+	respond(201, lp, w)
+}
+
 func writeFile(filename string, b []byte) (string, error) {
 	// Create temporary directory
 	dir, err := ioutil.TempDir("", "")
@@ -342,6 +383,8 @@ func catalogedPluginToLoaded(host string, c core.CatalogedPlugin) *rbody.LoadedP
 		Version:         c.Version(),
 		Type:            c.TypeName(),
 		Signed:          c.IsSigned(),
+		Signer:          c.Signer(),
+		SignerRevoked:   c.SignerRevoked(),
 		Status:          c.Status(),
 		LoadedTimestamp: c.LoadedTimestamp().Unix(),
 		Href:            pluginURI(host, c),
@@ -398,19 +441,7 @@ func (s *Server) getPlugin(w http.ResponseWriter, r *http.Request, p httprouter.
 	d, _ := strconv.ParseBool(rd)
 	var configPolicy []rbody.PolicyTable
 	if plugin.TypeName() == "processor" || plugin.TypeName() == "publisher" {
-		rules := plugin.Policy().Get([]string{""}).RulesAsTable()
-		configPolicy = make([]rbody.PolicyTable, 0, len(rules))
-		for _, r := range rules {
-			configPolicy = append(configPolicy, rbody.PolicyTable{
-				Name:     r.Name,
-				Type:     r.Type,
-				Default:  r.Default,
-				Required: r.Required,
-				Minimum:  r.Minimum,
-				Maximum:  r.Maximum,
-			})
-		}
-
+		configPolicy = policyTable(plugin.Policy().Get([]string{""}).RulesAsTable())
 	} else {
 		configPolicy = nil
 	}
@@ -441,6 +472,8 @@ func (s *Server) getPlugin(w http.ResponseWriter, r *http.Request, p httprouter.
 			Version:         plugin.Version(),
 			Type:            plugin.TypeName(),
 			Signed:          plugin.IsSigned(),
+			Signer:          plugin.Signer(),
+			SignerRevoked:   plugin.SignerRevoked(),
 			Status:          plugin.Status(),
 			LoadedTimestamp: plugin.LoadedTimestamp().Unix(),
 			Href:            pluginURI(r.Host, plugin),
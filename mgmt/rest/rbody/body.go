@@ -100,6 +100,22 @@ func UnmarshalBody(t string, b []byte) (Body, error) {
 		return unmarshalAndHandleError(b, &ScheduledTaskRemoved{})
 	case ScheduledTaskEnabledType:
 		return unmarshalAndHandleError(b, &ScheduledTaskEnabled{})
+	case ScheduledTaskUpdatedType:
+		return unmarshalAndHandleError(b, &ScheduledTaskUpdated{})
+	case TaskValidatedType:
+		return unmarshalAndHandleError(b, &TaskValidated{})
+	case TaskHistoryReturnedType:
+		return unmarshalAndHandleError(b, &TaskHistoryReturned{})
+	case WorkClassListReturnedType:
+		return unmarshalAndHandleError(b, &WorkClassListReturned{})
+	case WorkClassSetType:
+		return unmarshalAndHandleError(b, &WorkClassSet{})
+	case TrustKeyListReturnedType:
+		return unmarshalAndHandleError(b, &TrustKeyListReturned{})
+	case TrustKeysAddedType:
+		return unmarshalAndHandleError(b, &TrustKeysAdded{})
+	case TrustKeyRevokedType:
+		return unmarshalAndHandleError(b, &TrustKeyRevoked{})
 	case MetricReturnedType:
 		return unmarshalAndHandleError(b, &MetricReturned{})
 	case MetricsReturnedType:
@@ -116,6 +132,14 @@ func UnmarshalBody(t string, b []byte) (Body, error) {
 		return unmarshalAndHandleError(b, &TribeDeleteAgreement{})
 	case TribeMemberShowType:
 		return unmarshalAndHandleError(b, &TribeMemberShow{})
+	case TribeStatusType:
+		return unmarshalAndHandleError(b, &TribeStatus{})
+	case TribeAgreementDriftType:
+		return unmarshalAndHandleError(b, &TribeAgreementDrift{})
+	case TribeAgreementTasksType:
+		return unmarshalAndHandleError(b, &TribeAgreementTasks{})
+	case TribeAgreementPluginsType:
+		return unmarshalAndHandleError(b, &TribeAgreementPlugins{})
 	case TribeJoinAgreementType:
 		return unmarshalAndHandleError(b, &TribeJoinAgreement{})
 	case TribeLeaveAgreementType:
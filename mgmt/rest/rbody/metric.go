@@ -27,12 +27,17 @@ const (
 )
 
 type PolicyTable struct {
-	Name     string      `json:"name"`
-	Type     string      `json:"type"`
-	Default  interface{} `json:"default,omitempty"`
-	Required bool        `json:"required"`
-	Minimum  interface{} `json:"minimum,omitempty"`
-	Maximum  interface{} `json:"maximum,omitempty"`
+	Name         string      `json:"name"`
+	Type         string      `json:"type"`
+	Default      interface{} `json:"default,omitempty"`
+	Required     bool        `json:"required"`
+	Minimum      interface{} `json:"minimum,omitempty"`
+	Maximum      interface{} `json:"maximum,omitempty"`
+	MinLength    *int        `json:"min_length,omitempty"`
+	MaxLength    *int        `json:"max_length,omitempty"`
+	Allowed      []string    `json:"allowed,omitempty"`
+	Pattern      string      `json:"pattern,omitempty"`
+	RequiredKeys []string    `json:"required_keys,omitempty"`
 }
 
 type Metric struct {
@@ -93,6 +93,8 @@ type LoadedPlugin struct {
 	Version         int           `json:"version"`
 	Type            string        `json:"type"`
 	Signed          bool          `json:"signed"`
+	Signer          string        `json:"signer,omitempty"`
+	SignerRevoked   bool          `json:"signer_revoked,omitempty"`
 	Status          string        `json:"status"`
 	LoadedTimestamp int64         `json:"loaded_timestamp"`
 	Href            string        `json:"href"`
@@ -0,0 +1,55 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbody
+
+import (
+	"fmt"
+
+	"github.com/intelsdi-x/snap/core"
+)
+
+const (
+	WorkClassListReturnedType = "work_class_list_returned"
+	WorkClassSetType          = "work_class_set"
+)
+
+// WorkClassListReturned holds the settings of the priority classes of the
+// tasks from the highest
+type WorkClassListReturned struct {
+	WorkClasses []core.WorkClass `json:"work_classes"`
+}
+
+func (w *WorkClassListReturned) ResponseBodyMessage() string {
+	return "Work classes retrieved"
+}
+
+func (w *WorkClassListReturned) ResponseBodyType() string {
+	return WorkClassListReturnedType
+}
+
+type WorkClassSet core.WorkClass
+
+func (w *WorkClassSet) ResponseBodyMessage() string {
+	return fmt.Sprintf("Work class (%s) set", w.Name)
+}
+
+func (w *WorkClassSet) ResponseBodyType() string {
+	return WorkClassSetType
+}
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/serror"
 	"github.com/intelsdi-x/snap/pkg/schedule"
 	"github.com/intelsdi-x/snap/scheduler/wmap"
 )
@@ -39,6 +40,9 @@ const (
 	ScheduledTaskRemovedType       = "scheduled_task_removed"
 	ScheduledTaskWatchingEndedType = "schedule_task_watch_ended"
 	ScheduledTaskEnabledType       = "scheduled_task_enabled"
+	ScheduledTaskUpdatedType       = "scheduled_task_updated"
+	TaskValidatedType              = "task_validated"
+	TaskHistoryReturnedType        = "task_history_returned"
 
 	// Event types for task watcher streaming
 	TaskWatchStreamOpen   = "stream-open"
@@ -105,9 +109,12 @@ func AddSchedulerTaskFromTask(t core.Task) *AddScheduledTask {
 		MissCount:          int(t.MissedCount()),
 		FailedCount:        int(t.FailedCount()),
 		LastFailureMessage: t.LastFailureMessage(),
-		State:              t.State().String(),
 		Workflow:           t.WMap(),
 	}
+	st.State = t.State().String()
+	st.Dormant, st.DormantUntilTimestamp = dormancy(t)
+	st.Overlap, st.MaxConcurrent = t.GetOverlap()
+	st.Priority = t.GetPriority()
 	assertSchedule(t.Schedule(), st)
 	if st.LastRunTimestamp < 0 {
 		st.LastRunTimestamp = -1
@@ -128,7 +135,57 @@ type ScheduledTask struct {
 	FailedCount        int               `json:"failed_count,omitempty"`
 	LastFailureMessage string            `json:"last_failure_message,omitempty"`
 	State              string            `json:"task_state"`
-	Href               string            `json:"href"`
+	// Dormant is whether the task is running but its schedule is outside of
+	// its windows, DormantUntilTimestamp is when the next window opens
+	Dormant               bool   `json:"dormant,omitempty"`
+	DormantUntilTimestamp int64  `json:"dormant_until_timestamp,omitempty"`
+	Overlap               string `json:"overlap,omitempty"`
+	MaxConcurrent         int    `json:"max_concurrent,omitempty"`
+	Priority              string `json:"priority,omitempty"`
+	// FireDurations is the histogram of the durations of the fires of the task
+	FireDurations *FireDurations `json:"fire_durations,omitempty"`
+	Href          string         `json:"href"`
+}
+
+// FireDurations is a histogram of the durations of the fires of a task.  The
+// durations are in milliseconds.
+type FireDurations struct {
+	Count   uint64               `json:"count"`
+	SumMs   float64              `json:"sum_ms"`
+	MaxMs   float64              `json:"max_ms"`
+	Buckets []FireDurationBucket `json:"buckets"`
+}
+
+// FireDurationBucket holds the number of fires which took longer than the
+// bound of the previous bucket and up to the bound of the bucket.  The bound
+// of the last bucket is -1 (no bound).
+type FireDurationBucket struct {
+	UpToMs float64 `json:"up_to_ms"`
+	Count  uint64  `json:"count"`
+}
+
+// FireDurationsFromTask returns the histogram of the durations of the fires of
+// the task
+func FireDurationsFromTask(t core.Task) *FireDurations {
+	f := t.FireDurations()
+	fd := &FireDurations{
+		Count:   f.Count,
+		SumMs:   milliseconds(f.Sum),
+		MaxMs:   milliseconds(f.Max),
+		Buckets: make([]FireDurationBucket, 0, len(f.Buckets)),
+	}
+	for i, count := range f.Buckets {
+		bound := float64(-1)
+		if i < len(core.FireDurationBuckets) {
+			bound = milliseconds(core.FireDurationBuckets[i])
+		}
+		fd.Buckets = append(fd.Buckets, FireDurationBucket{UpToMs: bound, Count: count})
+	}
+	return fd
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
 }
 
 func (s *ScheduledTask) CreationTime() time.Time {
@@ -143,6 +200,20 @@ func (s *ScheduledTask) ResponseBodyType() string {
 	return ScheduledTaskType
 }
 
+// dormancy returns whether the task is running while its schedule is outside
+// of its windows and when the next window opens
+func dormancy(t core.Task) (bool, int64) {
+	state := t.State()
+	if state == core.TaskSpinning || state == core.TaskFiring {
+		if ds, ok := t.Schedule().(schedule.DormantSchedule); ok {
+			if until, dormant := ds.DormantUntil(time.Now()); dormant {
+				return true, until.Unix()
+			}
+		}
+	}
+	return false, 0
+}
+
 func SchedulerTaskFromTask(t core.Task) *ScheduledTask {
 	st := &ScheduledTask{
 		ID:                 t.ID(),
@@ -154,8 +225,9 @@ func SchedulerTaskFromTask(t core.Task) *ScheduledTask {
 		MissCount:          int(t.MissedCount()),
 		FailedCount:        int(t.FailedCount()),
 		LastFailureMessage: t.LastFailureMessage(),
-		State:              t.State().String(),
 	}
+	st.State = t.State().String()
+	st.Dormant, st.DormantUntilTimestamp = dormancy(t)
 	if st.LastRunTimestamp < 0 {
 		st.LastRunTimestamp = -1
 	}
@@ -213,12 +285,138 @@ func (s *ScheduledTaskEnabled) ResponseBodyType() string {
 	return ScheduledTaskEnabledType
 }
 
+type ScheduledTaskUpdated struct {
+	AddScheduledTask
+}
+
+func (s *ScheduledTaskUpdated) ResponseBodyMessage() string {
+	return fmt.Sprintf("Scheduled task (%s) updated", s.AddScheduledTask.ID)
+}
+
+func (s *ScheduledTaskUpdated) ResponseBodyType() string {
+	return ScheduledTaskUpdatedType
+}
+
+// TaskValidated is the result of validating a task without creating it.
+type TaskValidated struct {
+	Valid  bool                  `json:"valid"`
+	Errors []TaskValidationError `json:"errors,omitempty"`
+}
+
+// TaskValidationError is an error found while validating a task.  Path is the
+// JSON path of the task manifest field the error relates to.
+type TaskValidationError struct {
+	Message string            `json:"message"`
+	Path    string            `json:"path"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+func TaskValidatedFromErrors(errs []serror.SnapError) *TaskValidated {
+	tv := &TaskValidated{Valid: len(errs) == 0}
+	for _, e := range errs {
+		ve := TaskValidationError{Message: e.Error()}
+		for k, v := range e.Fields() {
+			if k == "path" {
+				ve.Path = fmt.Sprint(v)
+				continue
+			}
+			if ve.Fields == nil {
+				ve.Fields = make(map[string]string)
+			}
+			ve.Fields[k] = fmt.Sprint(v)
+		}
+		tv.Errors = append(tv.Errors, ve)
+	}
+	return tv
+}
+
+func (t *TaskValidated) ResponseBodyMessage() string {
+	if t.Valid {
+		return "Task is valid"
+	}
+	return fmt.Sprintf("Task is not valid (%d errors)", len(t.Errors))
+}
+
+func (t *TaskValidated) ResponseBodyType() string {
+	return TaskValidatedType
+}
+
+// TaskHistoryReturned holds the records of the last fires of a task from the
+// most recent.  The durations are in milliseconds.
+type TaskHistoryReturned struct {
+	ID   string    `json:"id"`
+	Runs []TaskRun `json:"runs"`
+}
+
+// TaskRun is the record of a fire of a task
+type TaskRun struct {
+	FireTimestamp int64 `json:"fire_timestamp"`
+	// LogicalTimestamp is the time of the missed interval a backfilling fire
+	// ran for
+	LogicalTimestamp int64         `json:"logical_timestamp,omitempty"`
+	DurationMs       float64       `json:"duration_ms"`
+	Failed           bool          `json:"failed,omitempty"`
+	Canceled         bool          `json:"canceled,omitempty"`
+	Nodes            []TaskRunNode `json:"nodes"`
+}
+
+// TaskRunNode is the record of the collection of the metrics or of a process
+// or publish node in a fire of a task
+type TaskRunNode struct {
+	Type        string   `json:"type"`
+	Name        string   `json:"name,omitempty"`
+	Version     int      `json:"version,omitempty"`
+	DurationMs  float64  `json:"duration_ms"`
+	MetricCount int      `json:"metric_count"`
+	Errors      []string `json:"errors,omitempty"`
+}
+
+// TaskHistoryFromTask returns the records of the last fires of the task
+func TaskHistoryFromTask(t core.Task) *TaskHistoryReturned {
+	h := &TaskHistoryReturned{ID: t.ID(), Runs: []TaskRun{}}
+	for _, r := range t.History() {
+		run := TaskRun{
+			FireTimestamp: r.FireTime.Unix(),
+			DurationMs:    milliseconds(r.Duration),
+			Failed:        r.Failed,
+			Canceled:      r.Canceled,
+			Nodes:         make([]TaskRunNode, 0, len(r.Nodes)),
+		}
+		if !r.LogicalTime.IsZero() {
+			run.LogicalTimestamp = r.LogicalTime.Unix()
+		}
+		for _, n := range r.Nodes {
+			run.Nodes = append(run.Nodes, TaskRunNode{
+				Type:        n.Type,
+				Name:        n.Name,
+				Version:     n.Version,
+				DurationMs:  milliseconds(n.Duration),
+				MetricCount: n.MetricCount,
+				Errors:      n.Errors,
+			})
+		}
+		h.Runs = append(h.Runs, run)
+	}
+	return h
+}
+
+func (t *TaskHistoryReturned) ResponseBodyMessage() string {
+	return fmt.Sprintf("Task history (%s) returned", t.ID)
+}
+
+func (t *TaskHistoryReturned) ResponseBodyType() string {
+	return TaskHistoryReturnedType
+}
+
 func assertSchedule(s schedule.Schedule, t *AddScheduledTask) {
 	switch v := s.(type) {
 	case *schedule.SimpleSchedule:
 		t.Schedule = &core.Schedule{
-			Type:     "simple",
-			Interval: v.Interval.String(),
+			Type:       "simple",
+			Interval:   v.Interval.String(),
+			CatchUp:    v.CatchUp,
+			Splay:      splayString(v.Splay),
+			Exclusions: scheduleExclusions(v.Exclusions),
 		}
 		return
 	case *schedule.WindowedSchedule:
@@ -229,15 +427,53 @@ func assertSchedule(s schedule.Schedule, t *AddScheduledTask) {
 			Interval:       v.Interval.String(),
 			StartTimestamp: &startTime,
 			StopTimestamp:  &stopTime,
+			CatchUp:        v.CatchUp,
 		}
 		return
 	case *schedule.CronSchedule:
 		t.Schedule = &core.Schedule{
-			Type:     "cron",
-			Interval: v.Entry(),
+			Type:       "cron",
+			Interval:   v.Entry(),
+			CatchUp:    v.CatchUp,
+			Splay:      splayString(v.Splay),
+			Exclusions: scheduleExclusions(v.Exclusions),
 		}
 		return
+	case *schedule.RecurringSchedule:
+		t.Schedule = &core.Schedule{
+			Type:     "recurring",
+			Interval: v.Interval.String(),
+			CatchUp:  v.CatchUp,
+		}
+		for _, w := range v.Windows {
+			t.Schedule.Windows = append(t.Schedule.Windows, core.NewScheduleWindow(w))
+		}
+		return
+	case *schedule.TriggeredSchedule:
+		t.Schedule = &core.Schedule{
+			Type:     "triggered",
+			Triggers: v.GetTriggers(),
+		}
+		return
+	}
+}
+
+func splayString(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+func scheduleExclusions(excl []schedule.Exclusion) []core.ScheduleExclusion {
+	var out []core.ScheduleExclusion
+	for _, e := range excl {
+		out = append(out, core.ScheduleExclusion{
+			StartTimestamp: e.Start.Unix(),
+			StopTimestamp:  e.Stop.Unix(),
+		})
 	}
+	return out
 }
 
 type ScheduledTaskWatchingEnded struct {
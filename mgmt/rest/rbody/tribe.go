@@ -22,15 +22,19 @@ package rbody
 import "github.com/intelsdi-x/snap/mgmt/tribe/agreement"
 
 const (
-	TribeListAgreementType   = "tribe_agreement_list_returned"
-	TribeGetAgreementType    = "tribe_agreement_returned"
-	TribeAddAgreementType    = "tribe_agreement_created"
-	TribeDeleteAgreementType = "tribe_agreement_deleted"
-	TribeAddMemberType       = "tribe_member_added"
-	TribeJoinAgreementType   = "tribe_agreement_joined"
-	TribeLeaveAgreementType  = "tribe_agreement_left"
-	TribeMemberListType      = "tribe_member_list_returned"
-	TribeMemberShowType      = "tribe_member_details_returned"
+	TribeListAgreementType    = "tribe_agreement_list_returned"
+	TribeGetAgreementType     = "tribe_agreement_returned"
+	TribeAddAgreementType     = "tribe_agreement_created"
+	TribeDeleteAgreementType  = "tribe_agreement_deleted"
+	TribeAddMemberType        = "tribe_member_added"
+	TribeJoinAgreementType    = "tribe_agreement_joined"
+	TribeLeaveAgreementType   = "tribe_agreement_left"
+	TribeMemberListType       = "tribe_member_list_returned"
+	TribeMemberShowType       = "tribe_member_details_returned"
+	TribeStatusType           = "tribe_status_returned"
+	TribeAgreementDriftType   = "tribe_agreement_drift_returned"
+	TribeAgreementTasksType   = "tribe_agreement_tasks_returned"
+	TribeAgreementPluginsType = "tribe_agreement_plugins_returned"
 )
 
 type TribeAddAgreement struct {
@@ -131,3 +135,53 @@ func (t *TribeMemberShow) ResponseBodyMessage() string {
 func (t *TribeMemberShow) ResponseBodyType() string {
 	return TribeMemberShowType
 }
+
+type TribeAgreementDrift struct {
+	Drift *agreement.Drift `json:"drift"`
+}
+
+func (t *TribeAgreementDrift) ResponseBodyMessage() string {
+	return "Tribe agreement drift retrieved"
+}
+
+func (t *TribeAgreementDrift) ResponseBodyType() string {
+	return TribeAgreementDriftType
+}
+
+type TribeAgreementTasks struct {
+	Agreement string                  `json:"agreement"`
+	Members   []agreement.MemberTasks `json:"members"`
+}
+
+func (t *TribeAgreementTasks) ResponseBodyMessage() string {
+	return "Tribe agreement tasks retrieved"
+}
+
+func (t *TribeAgreementTasks) ResponseBodyType() string {
+	return TribeAgreementTasksType
+}
+
+type TribeAgreementPlugins struct {
+	Agreement string                    `json:"agreement"`
+	Members   []agreement.MemberPlugins `json:"members"`
+}
+
+func (t *TribeAgreementPlugins) ResponseBodyMessage() string {
+	return "Tribe agreement plugins retrieved"
+}
+
+func (t *TribeAgreementPlugins) ResponseBodyType() string {
+	return TribeAgreementPluginsType
+}
+
+type TribeStatus struct {
+	Status *agreement.Status `json:"status"`
+}
+
+func (t *TribeStatus) ResponseBodyMessage() string {
+	return "Tribe status retrieved"
+}
+
+func (t *TribeStatus) ResponseBodyType() string {
+	return TribeStatusType
+}
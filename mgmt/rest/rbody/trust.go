@@ -0,0 +1,74 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbody
+
+import (
+	"fmt"
+
+	"github.com/intelsdi-x/snap/pkg/psigning"
+)
+
+const (
+	TrustKeyListReturnedType = "trust_key_list_returned"
+	TrustKeysAddedType       = "trust_keys_added"
+	TrustKeyRevokedType      = "trust_key_revoked"
+)
+
+// TrustKeyListReturned holds the keys plugin signatures are verified
+// against, revoked keys included
+type TrustKeyListReturned struct {
+	Keys []psigning.Key `json:"keys"`
+}
+
+func (t *TrustKeyListReturned) ResponseBodyMessage() string {
+	return "Trusted keys retrieved"
+}
+
+func (t *TrustKeyListReturned) ResponseBodyType() string {
+	return TrustKeyListReturnedType
+}
+
+type TrustKeysAdded struct {
+	Keys []psigning.Key `json:"keys"`
+}
+
+func (t *TrustKeysAdded) ResponseBodyMessage() string {
+	return fmt.Sprintf("Trusted keys added (%d)", len(t.Keys))
+}
+
+func (t *TrustKeysAdded) ResponseBodyType() string {
+	return TrustKeysAddedType
+}
+
+// TrustKeyRevoked holds the plugins signed by the revoked key which were
+// unloaded and the ones which were kept loaded and flagged
+type TrustKeyRevoked struct {
+	ID              string           `json:"id"`
+	UnloadedPlugins []PluginUnloaded `json:"unloaded_plugins"`
+	FlaggedPlugins  []PluginUnloaded `json:"flagged_plugins"`
+}
+
+func (t *TrustKeyRevoked) ResponseBodyMessage() string {
+	return fmt.Sprintf("Trusted key revoked (%s)", t.ID)
+}
+
+func (t *TrustKeyRevoked) ResponseBodyType() string {
+	return TrustKeyRevokedType
+}
@@ -72,6 +72,9 @@ func startV1API(cfg *mockConfig, testType string) *restAPIInstance {
 	case "task":
 		mockTaskManager := &fixtures.MockTaskManager{}
 		r.BindTaskManager(mockTaskManager)
+	case "trust":
+		mockTrustManager := &fixtures.MockTrustManager{}
+		r.BindTrustManager(mockTrustManager)
 	}
 
 	go func(ch <-chan error) {
@@ -172,6 +175,28 @@ func TestV1Plugin(t *testing.T) {
 			So(resp1.StatusCode, ShouldEqual, 201)
 		})
 
+		Convey("Install plugin - v1/plugins", func() {
+			resp, err := http.Post(
+				fmt.Sprintf("http://localhost:%d/v1/plugins", r.port),
+				"application/json",
+				bytes.NewReader([]byte(`{"type": "publisher", "name": "bar"}`)))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 201)
+			body, err := ioutil.ReadAll(resp.Body)
+			So(err, ShouldBeNil)
+			So(
+				fmt.Sprintf(fixtures.INSTALL_PLUGIN_RESPONSE, r.port),
+				ShouldResemble,
+				string(body))
+
+			resp, err = http.Post(
+				fmt.Sprintf("http://localhost:%d/v1/plugins", r.port),
+				"application/json",
+				bytes.NewReader([]byte(`{"type": "publisher", "name": "bar", "version": 4}`)))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 404)
+		})
+
 		Convey("Delete plugins - v1/plugins/:type:name:version", func() {
 			c := &http.Client{}
 			pluginName := "foo"
@@ -344,6 +369,19 @@ func TestV1Task(t *testing.T) {
 				string(body))
 		})
 
+		Convey("Get task history - v1/tasks/:id/history", func() {
+			resp, err := http.Get(
+				fmt.Sprintf("http://localhost:%d/v1/tasks/MockTask1234/history", r.port))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			body, err := ioutil.ReadAll(resp.Body)
+			So(err, ShouldBeNil)
+			So(
+				fixtures.GET_TASK_HISTORY_RESPONSE,
+				ShouldResemble,
+				string(body))
+		})
+
 		Convey("Watch tasks - v1/tasks/:id/watch", func() {
 			taskID := "1234"
 			resp, err := http.Get(
@@ -441,6 +479,68 @@ func TestV1Task(t *testing.T) {
 				string(body))
 		})
 
+		Convey("Update tasks - v1/tasks/:id", func() {
+			c := &http.Client{}
+			taskID := "MockTask1234"
+			req, err := http.NewRequest(
+				"PATCH",
+				fmt.Sprintf("http://localhost:%d/v1/tasks/%s", r.port, taskID),
+				strings.NewReader(`{"schedule": {"type": "simple", "interval": "5s"}}`))
+			So(err, ShouldBeNil)
+			resp, err := c.Do(req)
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			body, err := ioutil.ReadAll(resp.Body)
+			So(err, ShouldBeNil)
+			So(
+				fmt.Sprintf(fixtures.UPDATE_TASK_RESPONSE_ID_UPDATE, r.port),
+				ShouldResemble,
+				string(body))
+		})
+
+		Convey("Update tasks without changes - v1/tasks/:id", func() {
+			c := &http.Client{}
+			req, err := http.NewRequest(
+				"PATCH",
+				fmt.Sprintf("http://localhost:%d/v1/tasks/MockTask1234", r.port),
+				strings.NewReader(`{}`))
+			So(err, ShouldBeNil)
+			resp, err := c.Do(req)
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("Validate tasks - v1/tasks/validate", func() {
+			resp, err := http.Post(
+				fmt.Sprintf("http://localhost:%d/v1/tasks/validate", r.port),
+				http.DetectContentType([]byte(fixtures.TASK)),
+				bytes.NewReader([]byte(fixtures.TASK)))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			body, err := ioutil.ReadAll(resp.Body)
+			So(err, ShouldBeNil)
+			So(
+				fixtures.VALIDATE_TASK_RESPONSE_VALID,
+				ShouldResemble,
+				string(body))
+		})
+
+		Convey("Validate invalid tasks - v1/tasks/validate", func() {
+			task := `{"schedule": {"type": "daily", "interval": "1s"}}`
+			resp, err := http.Post(
+				fmt.Sprintf("http://localhost:%d/v1/tasks/validate", r.port),
+				http.DetectContentType([]byte(task)),
+				strings.NewReader(task))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			body, err := ioutil.ReadAll(resp.Body)
+			So(err, ShouldBeNil)
+			So(
+				fixtures.VALIDATE_TASK_RESPONSE_INVALID,
+				ShouldResemble,
+				string(body))
+		})
+
 		Convey("Remove tasks - V1/tasks/:id", func() {
 			c := &http.Client{}
 			taskID := "MockTask1234"
@@ -464,6 +564,116 @@ func TestV1Task(t *testing.T) {
 				ShouldResemble,
 				string(body))
 		})
+
+		Convey("Get work classes - v1/scheduler/classes", func() {
+			resp, err := http.Get(
+				fmt.Sprintf("http://localhost:%d/v1/scheduler/classes", r.port))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			body, err := ioutil.ReadAll(resp.Body)
+			So(err, ShouldBeNil)
+			So(
+				fixtures.GET_WORK_CLASSES_RESPONSE,
+				ShouldResemble,
+				string(body))
+		})
+
+		Convey("Set work class - v1/scheduler/classes/:class", func() {
+			c := &http.Client{}
+			req, err := http.NewRequest(
+				"PUT",
+				fmt.Sprintf("http://localhost:%d/v1/scheduler/classes/low", r.port),
+				strings.NewReader(`{"pool_size": 1}`))
+			So(err, ShouldBeNil)
+			resp, err := c.Do(req)
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			body, err := ioutil.ReadAll(resp.Body)
+			So(err, ShouldBeNil)
+			So(
+				fixtures.SET_WORK_CLASS_RESPONSE,
+				ShouldResemble,
+				string(body))
+		})
+
+		Convey("Set unknown work class - v1/scheduler/classes/:class", func() {
+			c := &http.Client{}
+			req, err := http.NewRequest(
+				"PUT",
+				fmt.Sprintf("http://localhost:%d/v1/scheduler/classes/urgent", r.port),
+				strings.NewReader(`{"weight": 8}`))
+			So(err, ShouldBeNil)
+			resp, err := c.Do(req)
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
+func TestV1Trust(t *testing.T) {
+	r := startV1API(getDefaultMockConfig(), "trust")
+	Convey("Test Trust REST API V1", t, func() {
+		Convey("Get trusted keys - v1/trust/keys", func() {
+			resp, err := http.Get(
+				fmt.Sprintf("http://localhost:%d/v1/trust/keys", r.port))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			body, err := ioutil.ReadAll(resp.Body)
+			So(err, ShouldBeNil)
+			So(
+				fixtures.GET_TRUSTED_KEYS_RESPONSE,
+				ShouldResemble,
+				string(body))
+		})
+
+		Convey("Add trusted key - v1/trust/keys", func() {
+			resp, err := http.Post(
+				fmt.Sprintf("http://localhost:%d/v1/trust/keys", r.port),
+				"application/json",
+				strings.NewReader(`{"scheme": "ed25519", "name": "nightly", "key": "key"}`))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+			body, err := ioutil.ReadAll(resp.Body)
+			So(err, ShouldBeNil)
+			So(
+				fixtures.ADD_TRUSTED_KEY_RESPONSE,
+				ShouldResemble,
+				string(body))
+
+			resp, err = http.Post(
+				fmt.Sprintf("http://localhost:%d/v1/trust/keys", r.port),
+				"application/json",
+				strings.NewReader(`{"scheme": "unknown", "key": "key"}`))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("Revoke trusted key - v1/trust/keys/:id", func() {
+			c := &http.Client{}
+			req, err := http.NewRequest(
+				"DELETE",
+				fmt.Sprintf("http://localhost:%d/v1/trust/keys/8E6C8F6BF6AB2B0A", r.port),
+				nil)
+			So(err, ShouldBeNil)
+			resp, err := c.Do(req)
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			body, err := ioutil.ReadAll(resp.Body)
+			So(err, ShouldBeNil)
+			So(
+				fixtures.REVOKE_TRUSTED_KEY_RESPONSE,
+				ShouldResemble,
+				string(body))
+
+			req, err = http.NewRequest(
+				"DELETE",
+				fmt.Sprintf("http://localhost:%d/v1/trust/keys/unknown", r.port),
+				nil)
+			So(err, ShouldBeNil)
+			resp, err = c.Do(req)
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+		})
 	})
 }
 
@@ -526,6 +736,61 @@ func TestV1Tribe(t *testing.T) {
 				string(body))
 		})
 
+		Convey("Get tribe agreement drift - v1/tribe/agreements/:name/drift", func() {
+			tribeName := "Agree1"
+			resp, err := http.Get(
+				fmt.Sprintf("http://localhost:%d/v1/tribe/agreements/%s/drift", r.port, tribeName))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 200)
+			body, err := ioutil.ReadAll(resp.Body)
+			So(err, ShouldBeNil)
+			So(
+				fmt.Sprintf(fixtures.GET_TRIBE_AGREEMENT_DRIFT_RESPONSE),
+				ShouldResemble,
+				string(body))
+		})
+
+		Convey("Get tribe agreement tasks - v1/tribe/agreements/:name/tasks", func() {
+			tribeName := "Agree1"
+			resp, err := http.Get(
+				fmt.Sprintf("http://localhost:%d/v1/tribe/agreements/%s/tasks", r.port, tribeName))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 200)
+			body, err := ioutil.ReadAll(resp.Body)
+			So(err, ShouldBeNil)
+			So(
+				fmt.Sprintf(fixtures.GET_TRIBE_AGREEMENT_TASKS_RESPONSE),
+				ShouldResemble,
+				string(body))
+		})
+
+		Convey("Get tribe agreement plugins - v1/tribe/agreements/:name/plugins", func() {
+			tribeName := "Agree1"
+			resp, err := http.Get(
+				fmt.Sprintf("http://localhost:%d/v1/tribe/agreements/%s/plugins", r.port, tribeName))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 200)
+			body, err := ioutil.ReadAll(resp.Body)
+			So(err, ShouldBeNil)
+			So(
+				fmt.Sprintf(fixtures.GET_TRIBE_AGREEMENT_PLUGINS_RESPONSE),
+				ShouldResemble,
+				string(body))
+		})
+
+		Convey("Get tribe status - v1/tribe/status", func() {
+			resp, err := http.Get(
+				fmt.Sprintf("http://localhost:%d/v1/tribe/status", r.port))
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 200)
+			body, err := ioutil.ReadAll(resp.Body)
+			So(err, ShouldBeNil)
+			So(
+				fmt.Sprintf(fixtures.GET_TRIBE_STATUS_RESPONSE),
+				ShouldResemble,
+				string(body))
+		})
+
 		Convey("Get tribe member - v1/tribe/member/:name", func() {
 			tribeName := "Imma_Mock"
 			resp, err := http.Get(
@@ -0,0 +1,61 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/mgmt/rest/rbody"
+)
+
+func (s *Server) getWorkClasses(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	respond(200, &rbody.WorkClassListReturned{WorkClasses: s.mt.GetWorkClasses()}, w)
+}
+
+// setWorkClass changes the settings of a priority class.  The settings left
+// out of the body keep their current value.
+func (s *Server) setWorkClass(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	name := p.ByName("class")
+	if err := core.ValidatePriority(name); err != nil {
+		respond(404, rbody.FromError(err), w)
+		return
+	}
+	var wc core.WorkClass
+	for _, c := range s.mt.GetWorkClasses() {
+		if c.Name == name {
+			wc = c
+		}
+	}
+	errCode, err := core.UnmarshalBody(&wc, r.Body)
+	if errCode != 0 && err != nil {
+		respond(400, rbody.FromError(err), w)
+		return
+	}
+	wc.Name = name
+	if err := s.mt.SetWorkClass(wc); err != nil {
+		respond(400, rbody.FromError(err), w)
+		return
+	}
+	c := rbody.WorkClassSet(wc)
+	respond(200, &c, w)
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/intelsdi-x/snap/core/serror"
 	"github.com/intelsdi-x/snap/mgmt/rest/rbody"
 	"github.com/intelsdi-x/snap/mgmt/tribe/agreement"
+	"github.com/intelsdi-x/snap/pkg/psigning"
 	cschedule "github.com/intelsdi-x/snap/pkg/schedule"
 	"github.com/intelsdi-x/snap/pkg/stringutils"
 	"github.com/intelsdi-x/snap/scheduler/wmap"
@@ -128,6 +129,7 @@ type managesMetrics interface {
 	GetMetricVersions(core.Namespace) ([]core.CatalogedMetric, error)
 	GetMetric(core.Namespace, int) (core.CatalogedMetric, error)
 	Load(*core.RequestedPlugin) (core.CatalogedPlugin, serror.SnapError)
+	InstallPlugin(string, string, int) (core.CatalogedPlugin, serror.SnapError)
 	Unload(core.Plugin) (core.CatalogedPlugin, serror.SnapError)
 	PluginCatalog() core.PluginCatalog
 	AvailablePlugins() []core.AvailablePlugin
@@ -143,6 +145,10 @@ type managesTasks interface {
 	RemoveTask(string) error
 	WatchTask(string, core.TaskWatcherHandler) (core.TaskWatcherCloser, error)
 	EnableTask(string) (core.Task, error)
+	UpdateTask(string, cschedule.Schedule, *wmap.WorkflowMap, ...core.TaskOption) (core.Task, core.TaskErrors)
+	ValidateTask(cschedule.Schedule, *wmap.WorkflowMap) core.TaskErrors
+	GetWorkClasses() []core.WorkClass
+	SetWorkClass(core.WorkClass) error
 }
 
 type managesTribe interface {
@@ -154,6 +160,10 @@ type managesTribe interface {
 	LeaveAgreement(agreementName, memberName string) serror.SnapError
 	GetMembers() []string
 	GetMember(name string) *agreement.Member
+	GetStatus() *agreement.Status
+	GetDrift(name string) (*agreement.Drift, serror.SnapError)
+	GetAgreementTasks(name string) ([]agreement.MemberTasks, serror.SnapError)
+	GetAgreementPlugins(name string) ([]agreement.MemberPlugins, serror.SnapError)
 }
 
 type managesConfig interface {
@@ -165,11 +175,18 @@ type managesConfig interface {
 	DeletePluginConfigDataNodeFieldAll(fields ...string) cdata.ConfigDataNode
 }
 
+type managesTrust interface {
+	TrustedKeys() []psigning.Key
+	AddTrustedKey(scheme, name string, key []byte) ([]psigning.Key, serror.SnapError)
+	RevokeTrustedKey(id string) ([]core.CatalogedPlugin, []core.CatalogedPlugin, serror.SnapError)
+}
+
 type Server struct {
 	mm         managesMetrics
 	mt         managesTasks
 	tr         managesTribe
 	mc         managesConfig
+	ts         managesTrust
 	n          *negroni.Negroni
 	r          *httprouter.Router
 	snapTLS    *snapTLS
@@ -455,6 +472,10 @@ func (s *Server) BindConfigManager(c managesConfig) {
 	s.mc = c
 }
 
+func (s *Server) BindTrustManager(t managesTrust) {
+	s.ts = t
+}
+
 func (s *Server) addRoutes() {
 	// plugin routes
 	s.r.GET("/v1/plugins", s.getPlugins)
@@ -475,11 +496,25 @@ func (s *Server) addRoutes() {
 	s.r.GET("/v1/tasks", s.getTasks)
 	s.r.GET("/v1/tasks/:id", s.getTask)
 	s.r.GET("/v1/tasks/:id/watch", s.watchTask)
+	s.r.GET("/v1/tasks/:id/history", s.getTaskHistory)
 	s.r.POST("/v1/tasks", s.addTask)
+	s.r.POST("/v1/tasks/validate", s.validateTask)
 	s.r.PUT("/v1/tasks/:id/start", s.startTask)
 	s.r.PUT("/v1/tasks/:id/stop", s.stopTask)
 	s.r.DELETE("/v1/tasks/:id", s.removeTask)
 	s.r.PUT("/v1/tasks/:id/enable", s.enableTask)
+	s.r.PATCH("/v1/tasks/:id", s.updateTask)
+
+	// scheduler routes
+	s.r.GET("/v1/scheduler/classes", s.getWorkClasses)
+	s.r.PUT("/v1/scheduler/classes/:class", s.setWorkClass)
+
+	// trust routes
+	if s.ts != nil {
+		s.r.GET("/v1/trust/keys", s.getTrustedKeys)
+		s.r.POST("/v1/trust/keys", s.addTrustedKey)
+		s.r.DELETE("/v1/trust/keys/:id", s.revokeTrustedKey)
+	}
 
 	// tribe routes
 	if s.tr != nil {
@@ -489,8 +524,12 @@ func (s *Server) addRoutes() {
 		s.r.DELETE("/v1/tribe/agreements/:name", s.deleteAgreement)
 		s.r.PUT("/v1/tribe/agreements/:name/join", s.joinAgreement)
 		s.r.DELETE("/v1/tribe/agreements/:name/leave", s.leaveAgreement)
+		s.r.GET("/v1/tribe/agreements/:name/drift", s.getAgreementDrift)
+		s.r.GET("/v1/tribe/agreements/:name/tasks", s.getAgreementTasks)
+		s.r.GET("/v1/tribe/agreements/:name/plugins", s.getAgreementPlugins)
 		s.r.GET("/v1/tribe/members", s.getMembers)
 		s.r.GET("/v1/tribe/member/:name", s.getMember)
+		s.r.GET("/v1/tribe/status", s.getTribeStatus)
 	}
 }
 
@@ -79,10 +79,21 @@ func (s *Server) getTask(w http.ResponseWriter, r *http.Request, p httprouter.Pa
 	}
 	task := &rbody.ScheduledTaskReturned{}
 	task.AddScheduledTask = *rbody.AddSchedulerTaskFromTask(t)
+	task.FireDurations = rbody.FireDurationsFromTask(t)
 	task.Href = taskURI(r.Host, t)
 	respond(200, task, w)
 }
 
+func (s *Server) getTaskHistory(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id := p.ByName("id")
+	t, err := s.mt.GetTask(id)
+	if err != nil {
+		respond(404, rbody.FromError(err), w)
+		return
+	}
+	respond(200, rbody.TaskHistoryFromTask(t), w)
+}
+
 func (s *Server) watchTask(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 	s.wg.Add(1)
 	defer s.wg.Done()
@@ -253,6 +264,34 @@ func (s *Server) enableTask(w http.ResponseWriter, r *http.Request, p httprouter
 	respond(200, task, w)
 }
 
+// updateTask changes the schedule, workflow, name, deadline, max-failures or
+// overlap of an existing task without changing its ID
+func (s *Server) updateTask(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id := p.ByName("id")
+	tsk, err := core.UpdateTaskFromContent(id, r.Body, s.mt.UpdateTask)
+	if err != nil {
+		if strings.Contains(err.Error(), ErrTaskNotFound.Error()) {
+			respond(404, rbody.FromError(err), w)
+			return
+		}
+		respond(400, rbody.FromError(err), w)
+		return
+	}
+	task := &rbody.ScheduledTaskUpdated{}
+	task.AddScheduledTask = *rbody.AddSchedulerTaskFromTask(tsk)
+	task.Href = taskURI(r.Host, tsk)
+	respond(200, task, w)
+}
+
+func (s *Server) validateTask(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	errs, err := core.ValidateTaskFromContent(r.Body, s.mt.ValidateTask)
+	if err != nil {
+		respond(400, rbody.FromError(err), w)
+		return
+	}
+	respond(200, rbody.TaskValidatedFromErrors(errs), w)
+}
+
 type TaskWatchHandler struct {
 	streamCount int
 	alive       bool
@@ -189,6 +189,78 @@ func (s *Server) getMembers(w http.ResponseWriter, r *http.Request, p httprouter
 	respond(200, &rbody.TribeMemberList{Members: members}, w)
 }
 
+func (s *Server) getAgreementDrift(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	tribeLogger = tribeLogger.WithField("_block", "getAgreementDrift")
+	name := p.ByName("name")
+	if _, ok := s.tr.GetAgreements()[name]; !ok {
+		fields := map[string]interface{}{
+			"agreement_name": name,
+		}
+		tribeLogger.WithFields(fields).Error(ErrAgreementDoesNotExist)
+		respond(400, rbody.FromSnapError(serror.New(ErrAgreementDoesNotExist, fields)), w)
+		return
+	}
+	res := &rbody.TribeAgreementDrift{}
+	var serr serror.SnapError
+	res.Drift, serr = s.tr.GetDrift(name)
+	if serr != nil {
+		tribeLogger.Error(serr)
+		respond(400, rbody.FromSnapError(serr), w)
+		return
+	}
+	respond(200, res, w)
+}
+
+func (s *Server) getAgreementTasks(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	tribeLogger = tribeLogger.WithField("_block", "getAgreementTasks")
+	name := p.ByName("name")
+	if _, ok := s.tr.GetAgreements()[name]; !ok {
+		fields := map[string]interface{}{
+			"agreement_name": name,
+		}
+		tribeLogger.WithFields(fields).Error(ErrAgreementDoesNotExist)
+		respond(400, rbody.FromSnapError(serror.New(ErrAgreementDoesNotExist, fields)), w)
+		return
+	}
+	res := &rbody.TribeAgreementTasks{Agreement: name}
+	var serr serror.SnapError
+	res.Members, serr = s.tr.GetAgreementTasks(name)
+	if serr != nil {
+		tribeLogger.Error(serr)
+		respond(400, rbody.FromSnapError(serr), w)
+		return
+	}
+	respond(200, res, w)
+}
+
+func (s *Server) getAgreementPlugins(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	tribeLogger = tribeLogger.WithField("_block", "getAgreementPlugins")
+	name := p.ByName("name")
+	if _, ok := s.tr.GetAgreements()[name]; !ok {
+		fields := map[string]interface{}{
+			"agreement_name": name,
+		}
+		tribeLogger.WithFields(fields).Error(ErrAgreementDoesNotExist)
+		respond(400, rbody.FromSnapError(serror.New(ErrAgreementDoesNotExist, fields)), w)
+		return
+	}
+	res := &rbody.TribeAgreementPlugins{Agreement: name}
+	var serr serror.SnapError
+	res.Members, serr = s.tr.GetAgreementPlugins(name)
+	if serr != nil {
+		tribeLogger.Error(serr)
+		respond(400, rbody.FromSnapError(serr), w)
+		return
+	}
+	respond(200, res, w)
+}
+
+func (s *Server) getTribeStatus(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	res := &rbody.TribeStatus{}
+	res.Status = s.tr.GetStatus()
+	respond(200, res, w)
+}
+
 func (s *Server) getMember(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
 	tribeLogger = tribeLogger.WithField("_block", "getMember")
 	name := p.ByName("name")
@@ -0,0 +1,77 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/mgmt/rest/rbody"
+)
+
+func (s *Server) getTrustedKeys(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	respond(200, &rbody.TrustKeyListReturned{Keys: s.ts.TrustedKeys()}, w)
+}
+
+// addTrustedKey adds the keys of the body, an armored OpenPGP keyring, PEM
+// X.509 CA certificates or a named Ed25519 public key
+func (s *Server) addTrustedKey(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	k := struct {
+		Scheme string `json:"scheme"`
+		Name   string `json:"name"`
+		Key    string `json:"key"`
+	}{}
+	errCode, err := core.UnmarshalBody(&k, r.Body)
+	if errCode != 0 && err != nil {
+		respond(400, rbody.FromError(err), w)
+		return
+	}
+	keys, serr := s.ts.AddTrustedKey(k.Scheme, k.Name, []byte(k.Key))
+	if serr != nil {
+		respond(400, rbody.FromSnapError(serr), w)
+		return
+	}
+	respond(201, &rbody.TrustKeysAdded{Keys: keys}, w)
+}
+
+// revokeTrustedKey revokes a trusted key, the plugins signed by the key are
+// unloaded or flagged
+func (s *Server) revokeTrustedKey(w http.ResponseWriter, r *http.Request, p httprouter.Params) {
+	id := p.ByName("id")
+	unloaded, flagged, serr := s.ts.RevokeTrustedKey(id)
+	if serr != nil {
+		respond(404, rbody.FromSnapError(serr), w)
+		return
+	}
+	body := &rbody.TrustKeyRevoked{
+		ID:              id,
+		UnloadedPlugins: []rbody.PluginUnloaded{},
+		FlaggedPlugins:  []rbody.PluginUnloaded{},
+	}
+	for _, pl := range unloaded {
+		body.UnloadedPlugins = append(body.UnloadedPlugins, rbody.PluginUnloaded{Name: pl.Name(), Version: pl.Version(), Type: pl.TypeName()})
+	}
+	for _, pl := range flagged {
+		body.FlaggedPlugins = append(body.FlaggedPlugins, rbody.PluginUnloaded{Name: pl.Name(), Version: pl.Version(), Type: pl.TypeName()})
+	}
+	respond(200, body, w)
+}
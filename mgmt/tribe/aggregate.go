@@ -0,0 +1,187 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tribe
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/mgmt/rest/client"
+	"github.com/intelsdi-x/snap/mgmt/tribe/agreement"
+)
+
+// defaultAggregateTimeout limits the time the request to a member may take
+// when aggregating the state of an agreement
+const defaultAggregateTimeout = 10 * time.Second
+
+var errMemberUnavailable = errors.New("Member is not available")
+
+// GetAgreementTasks returns the state of the tasks of the given agreement on
+// every member of the agreement
+func (t *tribe) GetAgreementTasks(name string) ([]agreement.MemberTasks, serror.SnapError) {
+	_, tasks, members, serr := t.agreementMembers(name)
+	if serr != nil {
+		return nil, serr
+	}
+	res := make([]agreement.MemberTasks, len(members))
+	t.fanOut(members, func(idx int, m *agreement.Member, c *client.Client, err error) {
+		res[idx] = agreement.MemberTasks{Member: m.Name, Tasks: []agreement.TaskSummary{}}
+		if err != nil {
+			res[idx].Error = err.Error()
+			return
+		}
+		r := c.GetTasks()
+		if r.Err != nil {
+			res[idx].Error = r.Err.Error()
+			return
+		}
+		for _, at := range tasks {
+			summary := agreement.TaskSummary{ID: at.ID, Missing: true}
+			for _, task := range r.ScheduledTasks {
+				if task.ID != at.ID {
+					continue
+				}
+				summary = agreement.TaskSummary{
+					ID:                 task.ID,
+					Name:               task.Name,
+					State:              task.State,
+					HitCount:           task.HitCount,
+					MissCount:          task.MissCount,
+					FailedCount:        task.FailedCount,
+					LastFailureMessage: task.LastFailureMessage,
+					LastRunTimestamp:   task.LastRunTimestamp,
+				}
+				break
+			}
+			res[idx].Tasks = append(res[idx].Tasks, summary)
+		}
+	})
+	return res, nil
+}
+
+// GetAgreementPlugins returns the plugins of the given agreement loaded on
+// every member of the agreement
+func (t *tribe) GetAgreementPlugins(name string) ([]agreement.MemberPlugins, serror.SnapError) {
+	plugins, _, members, serr := t.agreementMembers(name)
+	if serr != nil {
+		return nil, serr
+	}
+	res := make([]agreement.MemberPlugins, len(members))
+	t.fanOut(members, func(idx int, m *agreement.Member, c *client.Client, err error) {
+		res[idx] = agreement.MemberPlugins{Member: m.Name, Plugins: []agreement.PluginSummary{}}
+		if err != nil {
+			res[idx].Error = err.Error()
+			return
+		}
+		r := c.GetPlugins(false)
+		if r.Err != nil {
+			res[idx].Error = r.Err.Error()
+			return
+		}
+		for _, p := range plugins {
+			summary := agreement.PluginSummary{
+				Name:    p.Name(),
+				Version: p.Version(),
+				Type:    p.TypeName(),
+			}
+			for _, lp := range r.LoadedPlugins {
+				if lp.Name == p.Name() && lp.Version == p.Version() && lp.Type == p.TypeName() {
+					summary.Loaded = true
+					summary.Status = lp.Status
+					summary.LoadedTimestamp = lp.LoadedTimestamp
+					break
+				}
+			}
+			res[idx].Plugins = append(res[idx].Plugins, summary)
+		}
+	})
+	return res, nil
+}
+
+// agreementMembers returns the plugins and tasks of the agreement together
+// with its members sorted by name
+func (t *tribe) agreementMembers(name string) ([]agreement.Plugin, []agreement.Task, []*agreement.Member, serror.SnapError) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	a, ok := t.agreements[name]
+	if !ok {
+		return nil, nil, nil, serror.New(errAgreementDoesNotExist, map[string]interface{}{"agreement": name})
+	}
+	plugins := []agreement.Plugin{}
+	if a.PluginAgreement != nil {
+		plugins = append(plugins, a.PluginAgreement.Plugins...)
+	}
+	tasks := []agreement.Task{}
+	if a.TaskAgreement != nil {
+		tasks = append(tasks, a.TaskAgreement.Tasks...)
+	}
+	names := []string{}
+	for n := range a.Members {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	members := []*agreement.Member{}
+	for _, n := range names {
+		// the members of the agreement are resolved through the tribe
+		// members since only those carry the address of the node
+		if m, ok := t.members[n]; ok {
+			members = append(members, m)
+		} else {
+			members = append(members, &agreement.Member{Name: n})
+		}
+	}
+	return plugins, tasks, members, nil
+}
+
+// fanOut calls f concurrently with a REST client for each of the members and
+// waits for all the calls to return.  The error passed to f is set when no
+// client could be created for the member.  The requests of the clients time
+// out after the aggregate timeout so a member that does not answer is
+// reported with the timeout error instead of holding up the response.
+func (t *tribe) fanOut(members []*agreement.Member, f func(idx int, m *agreement.Member, c *client.Client, err error)) {
+	wg := sync.WaitGroup{}
+	for idx, m := range members {
+		wg.Add(1)
+		go func(idx int, m *agreement.Member) {
+			defer wg.Done()
+			if m.Node == nil {
+				f(idx, m, nil, errMemberUnavailable)
+				return
+			}
+			uri := fmt.Sprintf("%s://%s:%s", m.GetRestProto(), m.GetAddr(), m.GetRestPort())
+			c, err := client.New(uri, "v1", m.GetRestInsecureSkipVerify(), client.Password(t.GetRequestPassword()),
+				client.Timeout(t.aggregateTimeout))
+			if err != nil {
+				t.logger.WithFields(log.Fields{
+					"_block": "fanOut",
+					"member": m.Name,
+					"url":    uri,
+				}).Warn(err)
+			}
+			f(idx, m, c, err)
+		}(idx, m)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,140 @@
+// +build legacy
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tribe
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/hashicorp/memberlist"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/mgmt/tribe/agreement"
+	"github.com/pborman/uuid"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAgreementAggregates(t *testing.T) {
+	log.SetLevel(log.WarnLevel)
+	conf := getTestConfig()
+	conf.Name = "aggregate-member"
+	tr, err := New(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr.SetTaskManager(&mockTaskManager{})
+	defer tr.Stop()
+	agreement1 := "agreement1"
+	taskID := uuid.New()
+	if serr := tr.AddAgreement(agreement1); serr != nil {
+		t.Fatal(serr)
+	}
+	if serr := tr.AddPlugin(agreement1, agreement.Plugin{Name_: "plugin1", Version_: 1, Type_: core.CollectorPluginType}); serr != nil {
+		t.Fatal(serr)
+	}
+	if serr := tr.AddTask(agreement1, agreement.Task{ID: taskID}); serr != nil {
+		t.Fatal(serr)
+	}
+	if serr := tr.JoinAgreement(agreement1, conf.Name); serr != nil {
+		t.Fatal(serr)
+	}
+	// a member known only by name can not be queried
+	tr.mutex.Lock()
+	tr.agreements[agreement1].Members["ghost"] = &agreement.Member{Name: "ghost"}
+	tr.mutex.Unlock()
+
+	Convey("Given an agreement with an unreachable member", t, func() {
+		Convey("the tasks of every member are returned sorted by member", func() {
+			members, serr := tr.GetAgreementTasks(agreement1)
+			So(serr, ShouldBeNil)
+			So(members, ShouldHaveLength, 2)
+			So(members[0].Member, ShouldEqual, conf.Name)
+			So(members[1].Member, ShouldEqual, "ghost")
+			So(members[1].Error, ShouldEqual, errMemberUnavailable.Error())
+			So(members[1].Tasks, ShouldBeEmpty)
+		})
+
+		Convey("the plugins of every member are returned", func() {
+			members, serr := tr.GetAgreementPlugins(agreement1)
+			So(serr, ShouldBeNil)
+			So(members, ShouldHaveLength, 2)
+			So(members[1].Error, ShouldEqual, errMemberUnavailable.Error())
+		})
+
+		Convey("a member that does not answer in time is reported with an error", func() {
+			unblock := make(chan struct{})
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				<-unblock
+			}))
+			defer ts.Close()
+			defer close(unblock)
+			u, err := url.Parse(ts.URL)
+			So(err, ShouldBeNil)
+			host, port, err := net.SplitHostPort(u.Host)
+			So(err, ShouldBeNil)
+			// the member is resolved through the tribe members which carry
+			// its address
+			slow := &agreement.Member{
+				Name: "slow",
+				Node: &memberlist.Node{Name: "slow", Addr: net.ParseIP(host)},
+				Tags: map[string]string{
+					agreement.RestPort:     port,
+					agreement.RestProtocol: "http",
+				},
+			}
+			tr.mutex.Lock()
+			tr.members["slow"] = slow
+			tr.agreements[agreement1].Members["slow"] = slow
+			tr.mutex.Unlock()
+			defer func() {
+				tr.mutex.Lock()
+				delete(tr.members, "slow")
+				delete(tr.agreements[agreement1].Members, "slow")
+				tr.mutex.Unlock()
+			}()
+			tr.aggregateTimeout = 200 * time.Millisecond
+			defer func() { tr.aggregateTimeout = defaultAggregateTimeout }()
+
+			start := time.Now()
+			members, serr := tr.GetAgreementTasks(agreement1)
+			So(serr, ShouldBeNil)
+			So(time.Since(start), ShouldBeLessThan, 5*time.Second)
+			So(members, ShouldHaveLength, 3)
+			So(members[2].Member, ShouldEqual, "slow")
+			So(members[2].Error, ShouldNotBeEmpty)
+			So(members[2].Tasks, ShouldBeEmpty)
+		})
+
+		Convey("an unknown agreement returns an error", func() {
+			_, serr := tr.GetAgreementTasks("agreement2")
+			So(serr, ShouldNotBeNil)
+			So(serr.Error(), ShouldEqual, errAgreementDoesNotExist.Error())
+			_, serr = tr.GetAgreementPlugins("agreement2")
+			So(serr, ShouldNotBeNil)
+		})
+	})
+}
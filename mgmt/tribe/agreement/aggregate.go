@@ -0,0 +1,61 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agreement
+
+// MemberTasks lists the state of the tasks of an agreement on one member.
+// Error is set when the member could not be queried.
+type MemberTasks struct {
+	Member string        `json:"member"`
+	Error  string        `json:"error,omitempty"`
+	Tasks  []TaskSummary `json:"tasks"`
+}
+
+// TaskSummary is the state of a task of an agreement on a member.  Missing
+// is true when the member does not have the task.
+type TaskSummary struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name,omitempty"`
+	State              string `json:"state,omitempty"`
+	HitCount           int    `json:"hit_count"`
+	MissCount          int    `json:"miss_count"`
+	FailedCount        int    `json:"failed_count"`
+	LastFailureMessage string `json:"last_failure_message,omitempty"`
+	LastRunTimestamp   int64  `json:"last_run_timestamp,omitempty"`
+	Missing            bool   `json:"missing"`
+}
+
+// MemberPlugins lists the plugins of an agreement loaded on one member.
+// Error is set when the member could not be queried.
+type MemberPlugins struct {
+	Member  string          `json:"member"`
+	Error   string          `json:"error,omitempty"`
+	Plugins []PluginSummary `json:"plugins"`
+}
+
+// PluginSummary is a plugin of an agreement as loaded on a member.  Loaded
+// is false when the member does not have the plugin.
+type PluginSummary struct {
+	Name            string `json:"name"`
+	Version         int    `json:"version"`
+	Type            string `json:"type"`
+	Loaded          bool   `json:"loaded"`
+	Status          string `json:"status,omitempty"`
+	LoadedTimestamp int64  `json:"loaded_timestamp,omitempty"`
+}
@@ -0,0 +1,83 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agreement
+
+import "time"
+
+const (
+	// DriftMissing is reported for a plugin or task of the agreement that
+	// the member does not have
+	DriftMissing = "missing"
+	// DriftState is reported for a task whose local state differs from the
+	// state agreed on by the members of the agreement
+	DriftState = "state"
+)
+
+// Drift lists the differences between an agreement and what a member is
+// actually running.
+type Drift struct {
+	Agreement string        `json:"agreement"`
+	Member    string        `json:"member"`
+	Policy    string        `json:"policy"`
+	CheckedAt time.Time     `json:"checked_at"`
+	Plugins   []PluginDrift `json:"plugins"`
+	Tasks     []TaskDrift   `json:"tasks"`
+}
+
+// InSync returns true when no drift was found.  Items reported as repaired
+// do not drift.
+func (d *Drift) InSync() bool {
+	for _, p := range d.Plugins {
+		if !p.Repaired {
+			return false
+		}
+	}
+	for _, t := range d.Tasks {
+		if !t.Repaired {
+			return false
+		}
+	}
+	return true
+}
+
+// PluginDrift describes a plugin of the agreement that is not loaded.
+// Pending is set while work repairing the drift is queued or running.  A
+// plugin whose repair succeeded is reported with Repaired set for one
+// reconcile interval.
+type PluginDrift struct {
+	Name     string `json:"name"`
+	Version  int    `json:"version"`
+	Type     string `json:"type"`
+	Reason   string `json:"reason"`
+	Pending  bool   `json:"pending"`
+	Repaired bool   `json:"repaired"`
+}
+
+// TaskDrift describes a task of the agreement that is missing or whose
+// state differs from the agreed state.  Pending and Repaired are set as for
+// a PluginDrift.
+type TaskDrift struct {
+	ID            string `json:"id"`
+	Reason        string `json:"reason"`
+	ExpectedState string `json:"expected_state"`
+	ActualState   string `json:"actual_state,omitempty"`
+	Pending       bool   `json:"pending"`
+	Repaired      bool   `json:"repaired"`
+}
@@ -0,0 +1,55 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agreement
+
+import "time"
+
+const (
+	// GossipMode is reported when agreement changes are only gossiped
+	GossipMode = "gossip"
+	// LeaderMode is reported when agreement changes are ordered by an
+	// elected leader
+	LeaderMode = "leader"
+)
+
+// Status describes how agreement changes are coordinated as seen by a
+// member of the tribe.
+type Status struct {
+	Mode         string        `json:"mode"`
+	Name         string        `json:"name"`
+	State        string        `json:"state,omitempty"`
+	Leader       string        `json:"leader,omitempty"`
+	Term         uint64        `json:"term"`
+	LastIndex    uint64        `json:"last_index"`
+	CommitIndex  uint64        `json:"commit_index"`
+	AppliedIndex uint64        `json:"applied_index"`
+	Voters       []string      `json:"voters,omitempty"`
+	Replication  []Replication `json:"replication,omitempty"`
+}
+
+// Replication describes how far a member's log trails the leader's.  It is
+// only reported by the leader.
+type Replication struct {
+	Name        string    `json:"name"`
+	Voter       bool      `json:"voter"`
+	MatchIndex  uint64    `json:"match_index"`
+	Lag         uint64    `json:"lag"`
+	LastContact time.Time `json:"last_contact,omitempty"`
+}
@@ -26,8 +26,10 @@ import (
 	"time"
 
 	"github.com/hashicorp/memberlist"
+	"github.com/intelsdi-x/snap/mgmt/tribe/worker"
 	"github.com/intelsdi-x/snap/pkg/netutil"
 	"github.com/pborman/uuid"
+	"github.com/vrischmann/jsonutil"
 )
 
 // default configuration values
@@ -40,6 +42,11 @@ const (
 	defaultRestAPIPassword           string        = ""
 	defaultRestAPIPort               int           = 8181
 	defaultRestAPIInsecureSkipVerify string        = "true"
+	defaultRaftDir                   string        = ""
+	defaultLeaderHeartbeatInterval   time.Duration = 500 * time.Millisecond
+	defaultLeaderElectionTimeout     time.Duration = 2 * time.Second
+	defaultReconcilePolicy           string        = worker.ReconcileReportOnly
+	defaultReconcileInterval         time.Duration = 60 * time.Second
 )
 
 // holds the configuration passed in through the SNAP config file
@@ -52,11 +59,17 @@ type Config struct {
 	BindAddr                  string             `json:"bind_addr"yaml:"bind_addr"`
 	BindPort                  int                `json:"bind_port"yaml:"bind_port"`
 	Seed                      string             `json:"seed"yaml:"seed"`
+	Voters                    []string           `json:"voters"yaml:"voters"`
+	RaftDir                   string             `json:"raft_dir"yaml:"raft_dir"`
+	ReconcilePolicy           string             `json:"reconcile_policy"yaml:"reconcile_policy"`
+	ReconcileInterval         jsonutil.Duration  `json:"reconcile_interval"yaml:"reconcile_interval"`
 	MemberlistConfig          *memberlist.Config `json:"-"yaml:"-"`
 	RestAPIProto              string             `json:"-"yaml:"-"`
 	RestAPIPassword           string             `json:"-"yaml:"-"`
 	RestAPIPort               int                `json:"-"yaml:"-"`
 	RestAPIInsecureSkipVerify string             `json:"-"yaml:"-"`
+	LeaderHeartbeatInterval   time.Duration      `json:"-"yaml:"-"`
+	LeaderElectionTimeout     time.Duration      `json:"-"yaml:"-"`
 }
 
 const (
@@ -80,6 +93,22 @@ const (
 					},
 					"seed": {
 						"type" : "string"
+					},
+					"voters": {
+						"type": "array",
+						"items": {
+							"type": "string"
+						}
+					},
+					"raft_dir": {
+						"type": "string"
+					},
+					"reconcile_policy": {
+						"type": "string",
+						"enum": ["report", "enforce"]
+					},
+					"reconcile_interval": {
+						"type": "string"
 					}
 				},
 				"additionalProperties": false
@@ -103,6 +132,11 @@ func GetDefaultConfig() *Config {
 		RestAPIPassword:           defaultRestAPIPassword,
 		RestAPIPort:               defaultRestAPIPort,
 		RestAPIInsecureSkipVerify: defaultRestAPIInsecureSkipVerify,
+		RaftDir:                   defaultRaftDir,
+		LeaderHeartbeatInterval:   defaultLeaderHeartbeatInterval,
+		LeaderElectionTimeout:     defaultLeaderElectionTimeout,
+		ReconcilePolicy:           defaultReconcilePolicy,
+		ReconcileInterval:         jsonutil.Duration{defaultReconcileInterval},
 	}
 }
 
@@ -140,6 +174,25 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 			if err := json.Unmarshal(v, &(c.Seed)); err != nil {
 				return fmt.Errorf("%v (while parsing 'tribe::seed')", err)
 			}
+		case "voters":
+			if err := json.Unmarshal(v, &(c.Voters)); err != nil {
+				return fmt.Errorf("%v (while parsing 'tribe::voters')", err)
+			}
+		case "raft_dir":
+			if err := json.Unmarshal(v, &(c.RaftDir)); err != nil {
+				return fmt.Errorf("%v (while parsing 'tribe::raft_dir')", err)
+			}
+		case "reconcile_policy":
+			if err := json.Unmarshal(v, &(c.ReconcilePolicy)); err != nil {
+				return fmt.Errorf("%v (while parsing 'tribe::reconcile_policy')", err)
+			}
+		case "reconcile_interval":
+			if err := json.Unmarshal(v, &(c.ReconcileInterval)); err != nil {
+				return fmt.Errorf("%v (while parsing 'tribe::reconcile_interval')", err)
+			}
+			if c.ReconcileInterval.Duration <= 0 {
+				return fmt.Errorf("reconcile interval must be greater than zero, got %v (while parsing 'tribe::reconcile_interval')", c.ReconcileInterval.Duration)
+			}
 		default:
 			return fmt.Errorf("Unrecognized key '%v' in global config file while parsing 'tribe'", k)
 		}
@@ -147,6 +200,12 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// LeaderMode returns true when agreement changes are ordered by a leader
+// elected among the configured voters instead of by gossip alone.
+func (c *Config) LeaderMode() bool {
+	return len(c.Voters) > 0
+}
+
 func getHostname() string {
 	hostname, err := os.Hostname()
 	if err != nil {
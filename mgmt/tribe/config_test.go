@@ -22,6 +22,7 @@ limitations under the License.
 package tribe
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -81,6 +82,12 @@ func TestTribeConfigJSON(t *testing.T) {
 		Convey("Seed should be 1.1.1.1:16000", func() {
 			So(cfg.Seed, ShouldEqual, "1.1.1.1:16000")
 		})
+		Convey("ReconcilePolicy should be enforce", func() {
+			So(cfg.ReconcilePolicy, ShouldEqual, "enforce")
+		})
+		Convey("ReconcileInterval should be 30s", func() {
+			So(cfg.ReconcileInterval.Duration, ShouldEqual, 30*time.Second)
+		})
 	})
 
 }
@@ -114,10 +121,34 @@ func TestTribeConfigYaml(t *testing.T) {
 		Convey("Seed should be 1.1.1.1:16000", func() {
 			So(cfg.Seed, ShouldEqual, "1.1.1.1:16000")
 		})
+		Convey("ReconcilePolicy should be enforce", func() {
+			So(cfg.ReconcilePolicy, ShouldEqual, "enforce")
+		})
+		Convey("ReconcileInterval should be 30s", func() {
+			So(cfg.ReconcileInterval.Duration, ShouldEqual, 30*time.Second)
+		})
 	})
 
 }
 
+func TestTribeConfigReconcileInterval(t *testing.T) {
+	Convey("Provided a tribe config with a reconcile interval", t, func() {
+		Convey("A positive interval should be accepted", func() {
+			cfg := GetDefaultConfig()
+			So(json.Unmarshal([]byte(`{"reconcile_interval": "10s"}`), cfg), ShouldBeNil)
+			So(cfg.ReconcileInterval.Duration, ShouldEqual, 10*time.Second)
+		})
+		Convey("A zero interval should be rejected", func() {
+			cfg := GetDefaultConfig()
+			So(json.Unmarshal([]byte(`{"reconcile_interval": "0s"}`), cfg), ShouldNotBeNil)
+		})
+		Convey("A negative interval should be rejected", func() {
+			cfg := GetDefaultConfig()
+			So(json.Unmarshal([]byte(`{"reconcile_interval": "-1m"}`), cfg), ShouldNotBeNil)
+		})
+	})
+}
+
 func TestTribeDefaultConfig(t *testing.T) {
 	cfg := GetDefaultConfig()
 	Convey("Provided a default tribe config", t, func() {
@@ -154,5 +185,11 @@ func TestTribeDefaultConfig(t *testing.T) {
 		Convey("RestAPIInsecureSkipVerify should be true", func() {
 			So(cfg.RestAPIInsecureSkipVerify, ShouldEqual, "true")
 		})
+		Convey("ReconcilePolicy should be report", func() {
+			So(cfg.ReconcilePolicy, ShouldEqual, "report")
+		})
+		Convey("ReconcileInterval should be 60s", func() {
+			So(cfg.ReconcileInterval.Duration, ShouldEqual, 60*time.Second)
+		})
 	})
 }
@@ -45,6 +45,15 @@ func (t *delegate) NotifyMsg(buf []byte) {
 
 	var rebroadcast = true
 
+	// in leader mode agreement changes are only applied from the log
+	if t.tribe.raft != nil && msgType(buf[0]).isAgreementChange() {
+		logger.WithFields(log.Fields{
+			"_block": "delegate-notify-msg",
+			"type":   msgType(buf[0]).String(),
+		}).Debugln("ignoring gossiped agreement change in leader mode")
+		return
+	}
+
 	switch msgType(buf[0]) {
 	case addPluginMsgType:
 		msg := &pluginMsg{}
@@ -137,6 +146,12 @@ func (t *delegate) NotifyMsg(buf []byte) {
 			}
 		}
 		queryResp.lock.Unlock()
+	case requestVoteMsgType, requestVoteResponseMsgType,
+		appendEntriesMsgType, appendEntriesResponseMsgType,
+		proposeMsgType, proposeResponseMsgType,
+		installSnapshotMsgType:
+		t.notifyRaftMsg(buf)
+		return
 
 	default:
 		logger.WithFields(log.Fields{
@@ -156,6 +171,65 @@ func (t *delegate) NotifyMsg(buf []byte) {
 	}
 }
 
+// notifyRaftMsg dispatches the messages exchanged between members in leader
+// mode.  They are sent directly to a member and never rebroadcast.
+func (t *delegate) notifyRaftMsg(buf []byte) {
+	r := t.tribe.raft
+	if r == nil {
+		logger.WithFields(log.Fields{
+			"_block": "delegate-notify-raft-msg",
+			"type":   msgType(buf[0]).String(),
+		}).Debugln("ignoring message since leader mode is not enabled")
+		return
+	}
+	var err error
+	switch msgType(buf[0]) {
+	case requestVoteMsgType:
+		msg := &requestVoteMsg{}
+		if err = decodeMessage(buf[1:], msg); err == nil {
+			r.handleRequestVote(msg)
+		}
+	case requestVoteResponseMsgType:
+		msg := &requestVoteResponseMsg{}
+		if err = decodeMessage(buf[1:], msg); err == nil {
+			r.handleRequestVoteResponse(msg)
+		}
+	case appendEntriesMsgType:
+		msg := &appendEntriesMsg{}
+		if err = decodeMessage(buf[1:], msg); err == nil {
+			r.handleAppendEntries(msg)
+		}
+	case appendEntriesResponseMsgType:
+		msg := &appendEntriesResponseMsg{}
+		if err = decodeMessage(buf[1:], msg); err == nil {
+			r.handleAppendEntriesResponse(msg)
+		}
+	case installSnapshotMsgType:
+		msg := &installSnapshotMsg{}
+		if err = decodeMessage(buf[1:], msg); err == nil {
+			r.handleInstallSnapshot(msg)
+		}
+	case proposeMsgType:
+		msg := &proposeMsg{}
+		if err = decodeMessage(buf[1:], msg); err == nil {
+			// proposals wait for the change to be committed
+			go r.handlePropose(msg)
+		}
+	case proposeResponseMsgType:
+		msg := &proposeResponseMsg{}
+		if err = decodeMessage(buf[1:], msg); err == nil {
+			r.handleProposeResponse(msg)
+		}
+	}
+	if err != nil {
+		logger.WithFields(log.Fields{
+			"_block": "delegate-notify-raft-msg",
+			"type":   msgType(buf[0]).String(),
+			"error":  err,
+		}).Errorln("failed to decode message")
+	}
+}
+
 func (t *delegate) GetBroadcasts(overhead, limit int) [][]byte {
 	return t.tribe.broadcasts.GetBroadcasts(overhead, limit)
 }
@@ -307,7 +381,8 @@ func (t *delegate) MergeRemoteState(buf []byte, join bool) {
 			}
 			t.tribe.intentBuffer[idx] = taskMsg
 		}
-	} else {
+	} else if t.tribe.raft == nil {
+		// in leader mode agreement changes are replayed from the log instead
 		for _, m := range fs.PluginMsgs {
 			if m == nil {
 				continue
@@ -56,6 +56,18 @@ var (
 		EnvVar: "SNAP_TRIBE_ADDR",
 	}
 
+	flTribeVoters = cli.StringFlag{
+		Name:   "tribe-voters",
+		Usage:  "Comma separated names of the members that elect a leader to order agreement changes (enables leader mode)",
+		EnvVar: "SNAP_TRIBE_VOTERS",
+	}
+
+	flTribeRaftDir = cli.StringFlag{
+		Name:   "tribe-raft-dir",
+		Usage:  "Directory where a member in leader mode stores its term, vote and log (required for voters)",
+		EnvVar: "SNAP_TRIBE_RAFT_DIR",
+	}
+
 	// Flags consumed by snapd
-	Flags = []cli.Flag{flTribeNodeName, flTribe, flTribeSeed, flTribeAdvertiseAddr, flTribeAdvertisePort}
+	Flags = []cli.Flag{flTribeNodeName, flTribe, flTribeSeed, flTribeAdvertiseAddr, flTribeAdvertisePort, flTribeVoters, flTribeRaftDir}
 )
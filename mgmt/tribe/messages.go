@@ -45,6 +45,13 @@ const (
 	startTaskMsgType
 	getTaskStateMsgType
 	taskStateQueryResponseMsgType
+	requestVoteMsgType
+	requestVoteResponseMsgType
+	appendEntriesMsgType
+	appendEntriesResponseMsgType
+	proposeMsgType
+	proposeResponseMsgType
+	installSnapshotMsgType
 )
 
 var msgTypes = []string{
@@ -61,12 +68,33 @@ var msgTypes = []string{
 	"Start task",
 	"Get task state",
 	"Get task state response",
+	"Request vote",
+	"Request vote response",
+	"Append entries",
+	"Append entries response",
+	"Propose",
+	"Propose response",
+	"Install snapshot",
 }
 
 func (m msgType) String() string {
 	return msgTypes[int(m)]
 }
 
+// isAgreementChange returns true for the message types that mutate
+// agreements.  In leader mode these are only applied from the replicated log.
+func (m msgType) isAgreementChange() bool {
+	switch m {
+	case addPluginMsgType, removePluginMsgType,
+		addAgreementMsgType, removeAgreementMsgType,
+		joinAgreementMsgType, leaveAgreementMsgType,
+		addTaskMsgType, removeTaskMsgType,
+		stopTaskMsgType, startTaskMsgType:
+		return true
+	}
+	return false
+}
+
 type msg interface {
 	ID() string
 	Time() LTime
@@ -216,6 +244,107 @@ type fullStateMsg struct {
 	Members    map[string]*agreement.Member
 }
 
+// logEntry is an entry of the replicated log kept in leader mode.  Msg holds
+// an encoded agreement change (including its type byte); entries without a
+// Msg are appended by a newly elected leader to commit its term.
+type logEntry struct {
+	Term  uint64
+	Index uint64
+	ID    string
+	Msg   []byte
+}
+
+// raftSnapshot replaces the start of the log up to and including the entry
+// LastIndex with the agreements resulting from applying it.
+type raftSnapshot struct {
+	LastIndex  uint64
+	LastTerm   uint64
+	LTime      LTime
+	Agreements []snapshotAgreement
+}
+
+// snapshotAgreement is an agreement as stored in a snapshot.  Members are
+// referred to by name.
+type snapshotAgreement struct {
+	Name    string
+	Plugins []agreement.Plugin
+	Tasks   []agreement.Task
+	Members []string
+}
+
+type requestVoteMsg struct {
+	Term         uint64
+	Candidate    string
+	LastLogIndex uint64
+	LastLogTerm  uint64
+}
+
+type requestVoteResponseMsg struct {
+	Term    uint64
+	From    string
+	Granted bool
+}
+
+type appendEntriesMsg struct {
+	Term         uint64
+	Leader       string
+	PrevLogIndex uint64
+	PrevLogTerm  uint64
+	Entries      []*logEntry
+	LeaderCommit uint64
+}
+
+type appendEntriesResponseMsg struct {
+	Term         uint64
+	From         string
+	Success      bool
+	MatchIndex   uint64
+	LastLogIndex uint64
+}
+
+// installSnapshotMsg is sent by the leader in place of append entries when
+// the entries a member is missing were replaced by the snapshot.  It is
+// answered with an append entries response.
+type installSnapshotMsg struct {
+	Term     uint64
+	Leader   string
+	Snapshot *raftSnapshot
+}
+
+type proposeMsg struct {
+	UUID string
+	From string
+	Msg  []byte
+}
+
+type proposeResponseMsg struct {
+	UUID  string
+	Error string
+}
+
+// decodeAgreementChange decodes an encoded agreement change (including its
+// type byte) into the matching msg.
+func decodeAgreementChange(buf []byte) (msg, error) {
+	if len(buf) == 0 {
+		return nil, fmt.Errorf("empty message")
+	}
+	var m msg
+	switch msgType(buf[0]) {
+	case addPluginMsgType, removePluginMsgType:
+		m = &pluginMsg{}
+	case addAgreementMsgType, removeAgreementMsgType, joinAgreementMsgType, leaveAgreementMsgType:
+		m = &agreementMsg{}
+	case addTaskMsgType, removeTaskMsgType, stopTaskMsgType, startTaskMsgType:
+		m = &taskMsg{}
+	default:
+		return nil, fmt.Errorf("unexpected message type %d", buf[0])
+	}
+	if err := decodeMessage(buf[1:], m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func decodeMessage(buf []byte, out interface{}) error {
 	var handle codec.MsgpackHandle
 	return codec.NewDecoder(bytes.NewReader(buf), &handle).Decode(out)
@@ -0,0 +1,1039 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tribe
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/hashicorp/memberlist"
+
+	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/mgmt/tribe/agreement"
+)
+
+const (
+	// maxAppendEntries limits the number of log entries sent in a single
+	// append entries message.
+	maxAppendEntries = 64
+	// defaultSnapshotThreshold is the number of entries applied since the
+	// last snapshot after which the applied part of the log is replaced by a
+	// snapshot of the agreements.
+	defaultSnapshotThreshold = 1024
+)
+
+var (
+	errNoLeader        = errors.New("No tribe leader elected")
+	errNotLeader       = errors.New("Not the tribe leader")
+	errProposalTimeout = errors.New("Timed out waiting for the agreement change to be committed")
+	errRaftDirNotSet   = errors.New("A raft directory must be set for the voters of a tribe in leader mode")
+)
+
+type raftState int
+
+const (
+	raftFollower raftState = iota
+	raftCandidate
+	raftLeader
+)
+
+var raftStates = []string{
+	"follower",
+	"candidate",
+	"leader",
+}
+
+func (s raftState) String() string {
+	return raftStates[int(s)]
+}
+
+// raft orders agreement changes when the tribe runs in leader mode.  The
+// configured voters elect a leader which appends every agreement change to a
+// log that it replicates to all the members of the tribe.  A change is
+// committed once a majority of the voters have stored it and is then applied
+// by every member in log order.  Members that are not voters receive the log
+// but never vote or count toward the quorum.  Membership itself is still
+// handled by gossip.
+//
+// The term, the vote and the log are stored in the raft directory before a
+// member answers a vote or append entries request, so a voter that restarts
+// neither votes twice in a term nor forgets entries it acknowledged.  Once
+// snapshotThreshold entries were applied, every member replaces them with a
+// snapshot of the agreements so the log does not grow without bound and a
+// restart only replays the entries following the snapshot.  Members missing
+// entries the leader already compacted are sent its snapshot instead.
+type raft struct {
+	sync.Mutex
+	tribe           *tribe
+	name            string
+	voters          map[string]struct{}
+	heartbeat       time.Duration
+	electionTimeout time.Duration
+	store           *raftStore
+	// snapshotThreshold is the number of applied entries that are replaced
+	// by a snapshot
+	snapshotThreshold uint64
+
+	state    raftState
+	term     uint64
+	votedFor string
+	leader   string
+	votes    map[string]struct{}
+	deadline time.Time
+
+	// snapshot replaces the entries up to its last index and log[i] holds
+	// the entry with index snapshotIndex()+i+1
+	snapshot    *raftSnapshot
+	log         []*logEntry
+	commitIndex uint64
+	lastApplied uint64
+
+	nextIndex   map[string]uint64
+	matchIndex  map[string]uint64
+	lastContact map[string]time.Time
+
+	// applied holds channels closed once the entry with the given ID is
+	// applied locally; responses holds channels waiting on the leader's
+	// answer to a forwarded proposal
+	applied   map[string]chan struct{}
+	responses map[string]chan string
+
+	proposeMutex sync.Mutex
+	applyChan    chan struct{}
+	quitChan     chan struct{}
+	waitGroup    sync.WaitGroup
+}
+
+func newRaft(t *tribe) (*raft, error) {
+	voters := map[string]struct{}{}
+	for _, v := range t.config.Voters {
+		voters[v] = struct{}{}
+	}
+	if _, ok := voters[t.config.Name]; ok && t.config.RaftDir == "" {
+		return nil, errRaftDirNotSet
+	}
+	r := &raft{
+		tribe:             t,
+		name:              t.config.Name,
+		voters:            voters,
+		heartbeat:         t.config.LeaderHeartbeatInterval,
+		electionTimeout:   t.config.LeaderElectionTimeout,
+		snapshotThreshold: defaultSnapshotThreshold,
+		state:             raftFollower,
+		log:               []*logEntry{},
+		nextIndex:         map[string]uint64{},
+		matchIndex:        map[string]uint64{},
+		lastContact:       map[string]time.Time{},
+		applied:           map[string]chan struct{}{},
+		responses:         map[string]chan string{},
+		applyChan:         make(chan struct{}, 1),
+		quitChan:          make(chan struct{}),
+	}
+	if t.config.RaftDir != "" {
+		r.store = &raftStore{dir: t.config.RaftDir}
+		state, err := r.store.loadState()
+		if err != nil {
+			return nil, err
+		}
+		r.term = state.Term
+		r.votedFor = state.VotedFor
+		if r.snapshot, err = r.store.loadSnapshot(); err != nil {
+			return nil, err
+		}
+		if r.log, err = r.store.loadLog(r.snapshotIndex()); err != nil {
+			return nil, err
+		}
+		// the snapshot only holds committed entries and is restored once
+		// the raft is started
+		r.commit(r.snapshotIndex())
+	}
+	r.resetDeadline()
+	return r, nil
+}
+
+// persist stores the term and the vote.  The caller must hold the raft lock.
+func (r *raft) persist() error {
+	if r.store == nil {
+		return nil
+	}
+	err := r.store.saveState(&raftPersistentState{
+		Term:     r.term,
+		VotedFor: r.votedFor,
+	})
+	if err != nil {
+		r.tribe.logger.WithFields(log.Fields{
+			"_block": "raft-persist",
+			"term":   r.term,
+			"error":  err,
+		}).Error("failed to store the raft state")
+	}
+	return err
+}
+
+// persistEntries appends the entries to the stored log.  The caller must hold
+// the raft lock.
+func (r *raft) persistEntries(entries []*logEntry) error {
+	if r.store == nil {
+		return nil
+	}
+	err := r.store.appendLog(entries)
+	if err != nil {
+		r.tribe.logger.WithFields(log.Fields{
+			"_block": "raft-persist-entries",
+			"term":   r.term,
+			"error":  err,
+		}).Error("failed to store the raft log entries")
+	}
+	return err
+}
+
+// persistLog replaces the stored log with the entries following the
+// snapshot.  The caller must hold the raft lock.
+func (r *raft) persistLog() error {
+	if r.store == nil {
+		return nil
+	}
+	err := r.store.rewriteLog(r.log)
+	if err != nil {
+		r.tribe.logger.WithFields(log.Fields{
+			"_block": "raft-persist-log",
+			"term":   r.term,
+			"error":  err,
+		}).Error("failed to store the raft log")
+	}
+	return err
+}
+
+func (r *raft) start() {
+	r.waitGroup.Add(2)
+	go r.run()
+	go r.runApply()
+}
+
+func (r *raft) stop() {
+	close(r.quitChan)
+	r.waitGroup.Wait()
+}
+
+func (r *raft) run() {
+	defer r.waitGroup.Done()
+	ticker := time.NewTicker(r.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.quitChan:
+			return
+		case <-ticker.C:
+			r.Lock()
+			if r.state == raftLeader {
+				r.replicate()
+			} else if r.isVoter(r.name) && time.Now().After(r.deadline) {
+				r.startElection()
+			}
+			r.Unlock()
+		}
+	}
+}
+
+// runApply applies committed entries in log order, restoring the snapshot
+// first when it covers entries that were not applied yet.  Entries are
+// applied without holding the raft lock since applying them takes the tribe
+// lock.
+func (r *raft) runApply() {
+	defer r.waitGroup.Done()
+	for {
+		select {
+		case <-r.quitChan:
+			return
+		case <-r.applyChan:
+		}
+		for {
+			r.Lock()
+			if r.lastApplied >= r.commitIndex {
+				r.Unlock()
+				break
+			}
+			if r.lastApplied < r.snapshotIndex() {
+				s := r.snapshot
+				r.Unlock()
+
+				r.tribe.restoreSnapshot(s)
+
+				r.Lock()
+				if r.lastApplied < s.LastIndex {
+					r.lastApplied = s.LastIndex
+				}
+				r.Unlock()
+				continue
+			}
+			e := r.entry(r.lastApplied + 1)
+			r.Unlock()
+
+			r.apply(e)
+
+			r.Lock()
+			r.lastApplied = e.Index
+			if ch, ok := r.applied[e.ID]; ok {
+				close(ch)
+				delete(r.applied, e.ID)
+			}
+			compact := r.lastApplied-r.snapshotIndex() >= r.snapshotThreshold
+			r.Unlock()
+
+			if compact {
+				r.takeSnapshot(e)
+			}
+		}
+	}
+}
+
+// takeSnapshot replaces the log up to and including the entry with a
+// snapshot of the agreements.  It is called by runApply right after the entry
+// was applied so the agreements are the result of applying the log up to it.
+func (r *raft) takeSnapshot(e *logEntry) {
+	s := &raftSnapshot{
+		LastIndex:  e.Index,
+		LastTerm:   e.Term,
+		LTime:      r.tribe.clock.Time(),
+		Agreements: r.tribe.snapshotAgreements(),
+	}
+	r.Lock()
+	defer r.Unlock()
+	// a newer snapshot may have been installed by the leader meanwhile
+	if s.LastIndex <= r.snapshotIndex() {
+		return
+	}
+	if r.store != nil {
+		if err := r.store.saveSnapshot(s); err != nil {
+			r.tribe.logger.WithFields(log.Fields{
+				"_block": "raft-take-snapshot",
+				"index":  s.LastIndex,
+				"error":  err,
+			}).Error("failed to store the raft snapshot")
+			return
+		}
+	}
+	r.log = append([]*logEntry{}, r.log[s.LastIndex-r.snapshotIndex():]...)
+	r.snapshot = s
+	// entries left in the stored log are skipped when it is loaded
+	r.persistLog()
+}
+
+func (r *raft) apply(e *logEntry) {
+	if len(e.Msg) == 0 {
+		return
+	}
+	m, err := decodeAgreementChange(e.Msg)
+	if err != nil {
+		r.tribe.logger.WithFields(log.Fields{
+			"_block": "raft-apply",
+			"index":  e.Index,
+			"error":  err,
+		}).Error("failed to decode log entry")
+		return
+	}
+	switch m.GetType() {
+	case addPluginMsgType:
+		r.tribe.handleAddPlugin(m.(*pluginMsg))
+	case removePluginMsgType:
+		r.tribe.handleRemovePlugin(m.(*pluginMsg))
+	case addAgreementMsgType:
+		r.tribe.handleAddAgreement(m.(*agreementMsg))
+	case removeAgreementMsgType:
+		r.tribe.handleRemoveAgreement(m.(*agreementMsg))
+	case joinAgreementMsgType:
+		r.tribe.handleJoinAgreement(m.(*agreementMsg))
+	case leaveAgreementMsgType:
+		r.tribe.handleLeaveAgreement(m.(*agreementMsg))
+	case addTaskMsgType:
+		r.tribe.handleAddTask(m.(*taskMsg))
+	case removeTaskMsgType:
+		r.tribe.handleRemoveTask(m.(*taskMsg))
+	case stopTaskMsgType:
+		r.tribe.handleStopTask(m.(*taskMsg))
+	case startTaskMsgType:
+		r.tribe.handleStartTask(m.(*taskMsg))
+	}
+}
+
+// propose submits an agreement change and waits until it has been committed
+// and applied locally.  Members that are not the leader forward the change
+// to the leader.
+func (r *raft) propose(m msg) serror.SnapError {
+	fields := log.Fields{
+		"agreement": m.Agreement(),
+		"type":      m.GetType().String(),
+	}
+	r.Lock()
+	isLeader := r.state == raftLeader
+	leader := r.leader
+	r.Unlock()
+
+	if isLeader {
+		if err := r.proposeAsLeader(m); err != nil {
+			return err
+		}
+		return nil
+	}
+	if leader == "" {
+		return serror.New(errNoLeader, fields)
+	}
+
+	raw, err := encodeMessage(m.GetType(), m)
+	if err != nil {
+		return serror.New(err, fields)
+	}
+	buf, err := encodeMessage(proposeMsgType, &proposeMsg{
+		UUID: m.ID(),
+		From: r.name,
+		Msg:  raw,
+	})
+	if err != nil {
+		return serror.New(err, fields)
+	}
+
+	applied := make(chan struct{})
+	response := make(chan string, 1)
+	r.Lock()
+	r.applied[m.ID()] = applied
+	r.responses[m.ID()] = response
+	r.Unlock()
+	defer func() {
+		r.Lock()
+		delete(r.applied, m.ID())
+		delete(r.responses, m.ID())
+		r.Unlock()
+	}()
+
+	if err := r.send(leader, buf); err != nil {
+		return serror.New(err, fields)
+	}
+
+	timeout := time.After(r.proposeTimeout())
+	select {
+	case e := <-response:
+		if e != "" {
+			return serror.New(errors.New(e), fields)
+		}
+	case <-timeout:
+		return serror.New(errProposalTimeout, fields)
+	}
+	select {
+	case <-applied:
+	case <-timeout:
+		return serror.New(errProposalTimeout, fields)
+	}
+	return nil
+}
+
+// proposeAsLeader validates the change against the applied state, appends it
+// to the log and waits for it to be applied.  Proposals are handled one at a
+// time so each one is validated against the result of the previous one.
+func (r *raft) proposeAsLeader(m msg) serror.SnapError {
+	r.proposeMutex.Lock()
+	defer r.proposeMutex.Unlock()
+
+	fields := log.Fields{
+		"agreement": m.Agreement(),
+		"type":      m.GetType().String(),
+	}
+	if err := r.tribe.canApply(m); err != nil {
+		return err
+	}
+
+	// stamp the change with the leader's clock so the applied order and the
+	// Lamport order agree
+	lt := r.tribe.clock.Increment()
+	switch v := m.(type) {
+	case *pluginMsg:
+		v.LTime = lt
+	case *agreementMsg:
+		v.LTime = lt
+	case *taskMsg:
+		v.LTime = lt
+	}
+	raw, err := encodeMessage(m.GetType(), m)
+	if err != nil {
+		return serror.New(err, fields)
+	}
+
+	applied := make(chan struct{})
+	r.Lock()
+	if r.state != raftLeader {
+		r.Unlock()
+		return serror.New(errNotLeader, fields)
+	}
+	if err := r.appendEntry(m.ID(), raw); err != nil {
+		r.Unlock()
+		return serror.New(err, fields)
+	}
+	r.applied[m.ID()] = applied
+	r.replicate()
+	r.Unlock()
+
+	select {
+	case <-applied:
+		return nil
+	case <-time.After(r.proposeTimeout()):
+		r.Lock()
+		delete(r.applied, m.ID())
+		r.Unlock()
+		return serror.New(errProposalTimeout, fields)
+	}
+}
+
+func (r *raft) proposeTimeout() time.Duration {
+	return 2 * r.electionTimeout
+}
+
+// appendEntry appends an entry for the current term to the local log, stores
+// it and, when this member is the only voter, commits it.  The entry is
+// dropped again if it could not be stored.  The caller must hold the raft
+// lock.
+func (r *raft) appendEntry(id string, raw []byte) error {
+	e := &logEntry{
+		Term:  r.term,
+		Index: r.lastIndex() + 1,
+		ID:    id,
+		Msg:   raw,
+	}
+	if err := r.persistEntries([]*logEntry{e}); err != nil {
+		return err
+	}
+	r.log = append(r.log, e)
+	r.advanceCommit()
+	return nil
+}
+
+func (r *raft) snapshotIndex() uint64 {
+	if r.snapshot == nil {
+		return 0
+	}
+	return r.snapshot.LastIndex
+}
+
+func (r *raft) lastIndex() uint64 {
+	return r.snapshotIndex() + uint64(len(r.log))
+}
+
+// entry returns the entry with the given index, which must follow the
+// snapshot
+func (r *raft) entry(index uint64) *logEntry {
+	return r.log[index-r.snapshotIndex()-1]
+}
+
+// termAt returns the term of the entry with the given index or 0 when the
+// entry is unknown or was replaced by the snapshot
+func (r *raft) termAt(index uint64) uint64 {
+	if r.snapshot != nil && index == r.snapshot.LastIndex {
+		return r.snapshot.LastTerm
+	}
+	if index <= r.snapshotIndex() || index > r.lastIndex() {
+		return 0
+	}
+	return r.entry(index).Term
+}
+
+func (r *raft) isVoter(name string) bool {
+	_, ok := r.voters[name]
+	return ok
+}
+
+func (r *raft) quorum() int {
+	return len(r.voters)/2 + 1
+}
+
+func (r *raft) resetDeadline() {
+	timeout := r.electionTimeout + time.Duration(rand.Int63n(int64(r.electionTimeout)))
+	r.deadline = time.Now().Add(timeout)
+}
+
+// stepDown moves to a newer term as a follower.  The caller must hold the
+// raft lock.
+func (r *raft) stepDown(term uint64) {
+	if term > r.term {
+		r.term = term
+		r.votedFor = ""
+	}
+	if r.state != raftFollower {
+		r.tribe.logger.WithFields(log.Fields{
+			"_block": "raft-step-down",
+			"term":   r.term,
+		}).Infoln("stepping down to follower")
+	}
+	r.state = raftFollower
+	if r.leader == r.name {
+		r.leader = ""
+	}
+	r.resetDeadline()
+}
+
+func (r *raft) startElection() {
+	r.term++
+	r.state = raftCandidate
+	r.votedFor = r.name
+	r.leader = ""
+	r.votes = map[string]struct{}{r.name: struct{}{}}
+	r.resetDeadline()
+	if r.persist() != nil {
+		// the vote for ourselves is not stored so no votes are asked for
+		r.state = raftFollower
+		return
+	}
+	r.tribe.logger.WithFields(log.Fields{
+		"_block": "raft-start-election",
+		"term":   r.term,
+	}).Debugln("starting leader election")
+
+	if len(r.votes) >= r.quorum() {
+		r.becomeLeader()
+		return
+	}
+	buf, err := encodeMessage(requestVoteMsgType, &requestVoteMsg{
+		Term:         r.term,
+		Candidate:    r.name,
+		LastLogIndex: r.lastIndex(),
+		LastLogTerm:  r.termAt(r.lastIndex()),
+	})
+	if err != nil {
+		r.tribe.logger.WithField("_block", "raft-start-election").Error(err)
+		return
+	}
+	for name := range r.voters {
+		if name != r.name {
+			go r.send(name, buf)
+		}
+	}
+}
+
+func (r *raft) becomeLeader() {
+	r.state = raftLeader
+	r.leader = r.name
+	r.tribe.logger.WithFields(log.Fields{
+		"_block": "raft-become-leader",
+		"term":   r.term,
+	}).Infoln("elected tribe leader")
+	r.nextIndex = map[string]uint64{}
+	r.matchIndex = map[string]uint64{}
+	r.lastContact = map[string]time.Time{}
+	// entries from earlier terms are only committed once an entry from the
+	// current term is
+	if r.appendEntry("", nil) != nil {
+		r.stepDown(r.term)
+		return
+	}
+	r.replicate()
+}
+
+// replicate sends append entries messages to every other member of the
+// tribe.  The caller must hold the raft lock.
+func (r *raft) replicate() {
+	for _, n := range r.tribe.memberlist.Members() {
+		if n.Name == r.name {
+			continue
+		}
+		next, ok := r.nextIndex[n.Name]
+		if !ok {
+			next = r.lastIndex() + 1
+			r.nextIndex[n.Name] = next
+		}
+		if next <= r.snapshotIndex() {
+			buf, err := encodeMessage(installSnapshotMsgType, &installSnapshotMsg{
+				Term:     r.term,
+				Leader:   r.name,
+				Snapshot: r.snapshot,
+			})
+			if err != nil {
+				r.tribe.logger.WithField("_block", "raft-replicate").Error(err)
+				continue
+			}
+			go r.sendTo(n, buf)
+			continue
+		}
+		prev := next - 1
+		end := r.lastIndex()
+		if end-prev > maxAppendEntries {
+			end = prev + maxAppendEntries
+		}
+		buf, err := encodeMessage(appendEntriesMsgType, &appendEntriesMsg{
+			Term:         r.term,
+			Leader:       r.name,
+			PrevLogIndex: prev,
+			PrevLogTerm:  r.termAt(prev),
+			Entries:      r.log[prev-r.snapshotIndex() : end-r.snapshotIndex()],
+			LeaderCommit: r.commitIndex,
+		})
+		if err != nil {
+			r.tribe.logger.WithField("_block", "raft-replicate").Error(err)
+			continue
+		}
+		go r.sendTo(n, buf)
+	}
+}
+
+// advanceCommit commits the newest entry of the current term stored by a
+// majority of the voters.  The caller must hold the raft lock.
+func (r *raft) advanceCommit() {
+	for idx := r.lastIndex(); idx > r.commitIndex; idx-- {
+		if r.termAt(idx) != r.term {
+			break
+		}
+		count := 0
+		for name := range r.voters {
+			if name == r.name || r.matchIndex[name] >= idx {
+				count++
+			}
+		}
+		if count >= r.quorum() {
+			r.commit(idx)
+			return
+		}
+	}
+}
+
+func (r *raft) commit(index uint64) {
+	if index > r.lastIndex() {
+		index = r.lastIndex()
+	}
+	if index <= r.commitIndex {
+		return
+	}
+	r.commitIndex = index
+	select {
+	case r.applyChan <- struct{}{}:
+	default:
+	}
+}
+
+func (r *raft) handleRequestVote(m *requestVoteMsg) {
+	r.Lock()
+	changed := m.Term > r.term
+	if changed {
+		r.stepDown(m.Term)
+	}
+	granted := false
+	upToDate := m.LastLogTerm > r.termAt(r.lastIndex()) ||
+		(m.LastLogTerm == r.termAt(r.lastIndex()) && m.LastLogIndex >= r.lastIndex())
+	if m.Term == r.term && r.isVoter(m.Candidate) && upToDate &&
+		(r.votedFor == "" || r.votedFor == m.Candidate) {
+		granted = true
+		r.votedFor = m.Candidate
+		r.resetDeadline()
+	}
+	// the term and the vote are stored before they are answered
+	if (changed || granted) && r.persist() != nil {
+		r.Unlock()
+		return
+	}
+	resp := &requestVoteResponseMsg{
+		Term:    r.term,
+		From:    r.name,
+		Granted: granted,
+	}
+	r.Unlock()
+
+	r.reply(m.Candidate, requestVoteResponseMsgType, resp)
+}
+
+func (r *raft) handleRequestVoteResponse(m *requestVoteResponseMsg) {
+	r.Lock()
+	defer r.Unlock()
+	if m.Term > r.term {
+		r.stepDown(m.Term)
+		return
+	}
+	if r.state != raftCandidate || m.Term != r.term || !m.Granted || !r.isVoter(m.From) {
+		return
+	}
+	r.votes[m.From] = struct{}{}
+	if len(r.votes) >= r.quorum() {
+		r.becomeLeader()
+	}
+}
+
+func (r *raft) handleAppendEntries(m *appendEntriesMsg) {
+	r.Lock()
+	resp := &appendEntriesResponseMsg{
+		From: r.name,
+	}
+	if m.Term < r.term {
+		resp.Term = r.term
+		resp.LastLogIndex = r.lastIndex()
+		r.Unlock()
+		r.reply(m.Leader, appendEntriesResponseMsgType, resp)
+		return
+	}
+	if m.Term > r.term || r.state != raftFollower {
+		r.stepDown(m.Term)
+		if r.persist() != nil {
+			r.Unlock()
+			return
+		}
+	}
+	r.leader = m.Leader
+	r.resetDeadline()
+	resp.Term = r.term
+
+	// the entries up to the snapshot are committed so they match the leader's
+	if m.PrevLogIndex > r.lastIndex() ||
+		(m.PrevLogIndex >= r.snapshotIndex() && r.termAt(m.PrevLogIndex) != m.PrevLogTerm) {
+		resp.LastLogIndex = r.lastIndex()
+		if m.PrevLogIndex > 0 && m.PrevLogIndex-1 < resp.LastLogIndex {
+			resp.LastLogIndex = m.PrevLogIndex - 1
+		}
+		r.Unlock()
+		r.reply(m.Leader, appendEntriesResponseMsgType, resp)
+		return
+	}
+
+	truncated := false
+	appended := []*logEntry{}
+	for _, e := range m.Entries {
+		if e.Index <= r.snapshotIndex() {
+			continue
+		}
+		if e.Index <= r.lastIndex() {
+			if r.termAt(e.Index) == e.Term {
+				continue
+			}
+			r.log = r.log[:e.Index-r.snapshotIndex()-1]
+			truncated = true
+		}
+		r.log = append(r.log, e)
+		appended = append(appended, e)
+	}
+	// the entries are stored before they are acknowledged, the stored log
+	// is only rewritten when conflicting entries were removed
+	if truncated && r.persistLog() != nil {
+		r.Unlock()
+		return
+	}
+	if !truncated && len(appended) > 0 && r.persistEntries(appended) != nil {
+		r.log = r.log[:len(r.log)-len(appended)]
+		r.Unlock()
+		return
+	}
+	match := m.PrevLogIndex + uint64(len(m.Entries))
+	if m.LeaderCommit > r.commitIndex {
+		if m.LeaderCommit < match {
+			r.commit(m.LeaderCommit)
+		} else {
+			r.commit(match)
+		}
+	}
+	resp.Success = true
+	resp.MatchIndex = match
+	resp.LastLogIndex = r.lastIndex()
+	r.Unlock()
+
+	r.reply(m.Leader, appendEntriesResponseMsgType, resp)
+}
+
+func (r *raft) handleInstallSnapshot(m *installSnapshotMsg) {
+	r.Lock()
+	resp := &appendEntriesResponseMsg{
+		From: r.name,
+	}
+	if m.Term < r.term || m.Snapshot == nil {
+		resp.Term = r.term
+		resp.LastLogIndex = r.lastIndex()
+		r.Unlock()
+		r.reply(m.Leader, appendEntriesResponseMsgType, resp)
+		return
+	}
+	if m.Term > r.term || r.state != raftFollower {
+		r.stepDown(m.Term)
+		if r.persist() != nil {
+			r.Unlock()
+			return
+		}
+	}
+	r.leader = m.Leader
+	r.resetDeadline()
+	resp.Term = r.term
+
+	s := m.Snapshot
+	if s.LastIndex > r.snapshotIndex() {
+		// the snapshot is stored before it is acknowledged
+		if r.store != nil {
+			if err := r.store.saveSnapshot(s); err != nil {
+				r.tribe.logger.WithFields(log.Fields{
+					"_block": "raft-install-snapshot",
+					"index":  s.LastIndex,
+					"error":  err,
+				}).Error("failed to store the raft snapshot")
+				r.Unlock()
+				return
+			}
+		}
+		// entries following the snapshot are kept when they match it
+		if s.LastIndex < r.lastIndex() && r.termAt(s.LastIndex) == s.LastTerm {
+			r.log = append([]*logEntry{}, r.log[s.LastIndex-r.snapshotIndex():]...)
+		} else {
+			r.log = []*logEntry{}
+		}
+		r.snapshot = s
+		r.persistLog()
+		r.commit(s.LastIndex)
+	}
+	resp.Success = true
+	resp.MatchIndex = s.LastIndex
+	resp.LastLogIndex = r.lastIndex()
+	r.Unlock()
+
+	r.reply(m.Leader, appendEntriesResponseMsgType, resp)
+}
+
+func (r *raft) handleAppendEntriesResponse(m *appendEntriesResponseMsg) {
+	r.Lock()
+	defer r.Unlock()
+	if m.Term > r.term {
+		r.stepDown(m.Term)
+		return
+	}
+	if r.state != raftLeader || m.Term != r.term {
+		return
+	}
+	r.lastContact[m.From] = time.Now()
+	if m.Success {
+		if m.MatchIndex > r.matchIndex[m.From] {
+			r.matchIndex[m.From] = m.MatchIndex
+		}
+		r.nextIndex[m.From] = r.matchIndex[m.From] + 1
+		r.advanceCommit()
+		return
+	}
+	next := r.nextIndex[m.From]
+	if next > 1 {
+		next--
+	}
+	if m.LastLogIndex+1 < next {
+		next = m.LastLogIndex + 1
+	}
+	r.nextIndex[m.From] = next
+}
+
+func (r *raft) handlePropose(m *proposeMsg) {
+	resp := &proposeResponseMsg{UUID: m.UUID}
+	change, err := decodeAgreementChange(m.Msg)
+	if err != nil {
+		resp.Error = err.Error()
+	} else {
+		r.Lock()
+		isLeader := r.state == raftLeader
+		r.Unlock()
+		if !isLeader {
+			resp.Error = errNotLeader.Error()
+		} else if serr := r.proposeAsLeader(change); serr != nil {
+			resp.Error = serr.Error()
+		}
+	}
+	r.reply(m.From, proposeResponseMsgType, resp)
+}
+
+func (r *raft) handleProposeResponse(m *proposeResponseMsg) {
+	r.Lock()
+	defer r.Unlock()
+	if ch, ok := r.responses[m.UUID]; ok {
+		ch <- m.Error
+		delete(r.responses, m.UUID)
+	}
+}
+
+func (r *raft) reply(to string, mt msgType, m interface{}) {
+	buf, err := encodeMessage(mt, m)
+	if err != nil {
+		r.tribe.logger.WithFields(log.Fields{
+			"_block": "raft-reply",
+			"type":   mt.String(),
+		}).Error(err)
+		return
+	}
+	r.send(to, buf)
+}
+
+func (r *raft) send(to string, buf []byte) error {
+	for _, n := range r.tribe.memberlist.Members() {
+		if n.Name == to {
+			return r.sendTo(n, buf)
+		}
+	}
+	return serror.New(errUnknownMember, map[string]interface{}{"member-name": to})
+}
+
+func (r *raft) sendTo(n *memberlist.Node, buf []byte) error {
+	err := r.tribe.memberlist.SendToTCP(n, buf)
+	if err != nil {
+		r.tribe.logger.WithFields(log.Fields{
+			"_block":      "raft-send",
+			"member-name": n.Name,
+			"type":        msgType(buf[0]).String(),
+		}).Debugln(err)
+	}
+	return err
+}
+
+func (r *raft) status() *agreement.Status {
+	r.Lock()
+	defer r.Unlock()
+	s := &agreement.Status{
+		Mode:         agreement.LeaderMode,
+		Name:         r.name,
+		State:        r.state.String(),
+		Leader:       r.leader,
+		Term:         r.term,
+		LastIndex:    r.lastIndex(),
+		CommitIndex:  r.commitIndex,
+		AppliedIndex: r.lastApplied,
+		Voters:       make([]string, 0, len(r.voters)),
+	}
+	for name := range r.voters {
+		s.Voters = append(s.Voters, name)
+	}
+	sort.Strings(s.Voters)
+	if r.state != raftLeader {
+		return s
+	}
+	for _, n := range r.tribe.memberlist.Members() {
+		if n.Name == r.name {
+			continue
+		}
+		match := r.matchIndex[n.Name]
+		s.Replication = append(s.Replication, agreement.Replication{
+			Name:        n.Name,
+			Voter:       r.isVoter(n.Name),
+			MatchIndex:  match,
+			Lag:         r.lastIndex() - match,
+			LastContact: r.lastContact[n.Name],
+		})
+	}
+	sort.Sort(byReplicationName(s.Replication))
+	return s
+}
+
+type byReplicationName []agreement.Replication
+
+func (b byReplicationName) Len() int           { return len(b) }
+func (b byReplicationName) Less(i, j int) bool { return b[i].Name < b[j].Name }
+func (b byReplicationName) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
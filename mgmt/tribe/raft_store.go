@@ -0,0 +1,240 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tribe
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// raftStateFile is the name of the file in the raft directory holding
+	// the term and the vote
+	raftStateFile = "raft.json"
+	// raftLogFile is the name of the file in the raft directory the log
+	// entries following the snapshot are appended to, one per line
+	raftLogFile = "raft.log"
+	// raftSnapshotFile is the name of the file in the raft directory holding
+	// the snapshot replacing the start of the log
+	raftSnapshotFile = "raft-snapshot.json"
+)
+
+// raftPersistentState is the part of the raft state besides the log that
+// must survive a restart
+type raftPersistentState struct {
+	Term     uint64 `json:"term"`
+	VotedFor string `json:"voted_for"`
+}
+
+// raftStore keeps the persistent raft state in the raft directory.  Log
+// entries are appended to the log file so storing an entry does not depend on
+// the length of the log.  The state, the snapshot and a rewritten log are
+// replaced atomically so a crash leaves either the previous or the new
+// version.
+type raftStore struct {
+	dir string
+}
+
+// loadState returns the stored term and vote or an empty state when nothing
+// was stored yet
+func (s *raftStore) loadState() (*raftPersistentState, error) {
+	state := &raftPersistentState{}
+	b, err := ioutil.ReadFile(filepath.Join(s.dir, raftStateFile))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// saveState stores the term and the vote
+func (s *raftStore) saveState(state *raftPersistentState) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.replace(raftStateFile, b)
+}
+
+// loadSnapshot returns the stored snapshot or nil when none was taken yet
+func (s *raftStore) loadSnapshot() (*raftSnapshot, error) {
+	b, err := ioutil.ReadFile(filepath.Join(s.dir, raftSnapshotFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &raftSnapshot{}
+	if err := json.Unmarshal(b, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// saveSnapshot stores the snapshot.  The entries it replaces are only
+// dropped from the log file by the next rewrite of the log.
+func (s *raftStore) saveSnapshot(snapshot *raftSnapshot) error {
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return s.replace(raftSnapshotFile, b)
+}
+
+// loadLog returns the stored entries following the given index.  Entries up
+// to the index are covered by the snapshot and skipped, and an entry only
+// partially written when the member stopped is dropped.
+func (s *raftStore) loadLog(after uint64) ([]*logEntry, error) {
+	entries := []*logEntry{}
+	f, err := os.Open(filepath.Join(s.dir, raftLogFile))
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	dec := json.NewDecoder(bufio.NewReader(f))
+	next := after + 1
+	for {
+		e := &logEntry{}
+		if err := dec.Decode(e); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return entries, nil
+			}
+			return nil, err
+		}
+		if e.Index < next {
+			continue
+		}
+		if e.Index != next {
+			return nil, fmt.Errorf("found raft log entry %d where entry %d was expected", e.Index, next)
+		}
+		entries = append(entries, e)
+		next++
+	}
+}
+
+// appendLog appends the entries to the log file and syncs it.  The file is
+// truncated back to its previous size when the entries could not be stored.
+func (s *raftStore) appendLog(entries []*logEntry) error {
+	b, err := encodeLog(entries)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(s.dir, raftLogFile), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if err == nil {
+		err = f.Sync()
+	}
+	if err != nil {
+		f.Truncate(fi.Size())
+		return err
+	}
+	if fi.Size() == 0 {
+		// the file may have just been created
+		syncDir(s.dir)
+	}
+	return nil
+}
+
+// rewriteLog replaces the log file with the given entries.  It is used when
+// conflicting entries are removed or the log is compacted.
+func (s *raftStore) rewriteLog(entries []*logEntry) error {
+	b, err := encodeLog(entries)
+	if err != nil {
+		return err
+	}
+	return s.replace(raftLogFile, b)
+}
+
+// replace writes the data to a temporary file which is synced and then
+// renamed over the named file
+func (s *raftStore) replace(name string, b []byte) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(s.dir, name)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), filepath.Join(s.dir, name)); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	// sync the directory so the rename survives a crash
+	syncDir(s.dir)
+	return nil
+}
+
+func encodeLog(entries []*logEntry) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	enc := json.NewEncoder(buf)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
@@ -0,0 +1,311 @@
+// +build legacy
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tribe
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/mgmt/tribe/agreement"
+	"github.com/pborman/uuid"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func getLeaderModeTribes(numOfTribes, numOfVoters int) []*tribe {
+	dir, err := ioutil.TempDir("", "snap-tribe-raft")
+	if err != nil {
+		panic(err)
+	}
+	voters := []string{}
+	for i := 0; i < numOfVoters; i++ {
+		voters = append(voters, fmt.Sprintf("member-%v", i))
+	}
+	tribes := []*tribe{}
+	var seed string
+	for i := 0; i < numOfTribes; i++ {
+		conf := getTestConfig()
+		conf.Name = fmt.Sprintf("member-%v", i)
+		conf.Seed = seed
+		conf.Voters = voters
+		conf.RaftDir = filepath.Join(dir, conf.Name)
+		conf.LeaderHeartbeatInterval = 50 * time.Millisecond
+		conf.LeaderElectionTimeout = 300 * time.Millisecond
+		tr, err := New(conf)
+		if err != nil {
+			panic(err)
+		}
+		tr.SetTaskManager(&mockTaskManager{})
+		tribes = append(tribes, tr)
+		if i == 0 {
+			seed = fmt.Sprintf("127.0.0.1:%d", conf.BindPort)
+		}
+	}
+	waitFor(10*time.Second, func() bool {
+		for _, tr := range tribes {
+			if len(tr.memberlist.Members()) != numOfTribes {
+				return false
+			}
+		}
+		return true
+	})
+	for _, tr := range tribes {
+		tr.raft.start()
+	}
+	return tribes
+}
+
+// waitFor polls the condition until it holds or the timeout expires
+func waitFor(timeout time.Duration, cond func() bool) bool {
+	to := time.After(timeout)
+	for {
+		select {
+		case <-to:
+			return false
+		default:
+			if cond() {
+				return true
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+}
+
+// getLeader returns the leader all running members agree on
+func getLeader(tribes []*tribe) *tribe {
+	var leader *tribe
+	waitFor(10*time.Second, func() bool {
+		leader = nil
+		name := ""
+		for _, tr := range tribes {
+			s := tr.GetStatus()
+			if s.Leader == "" || (name != "" && s.Leader != name) {
+				return false
+			}
+			name = s.Leader
+			if s.State == "leader" {
+				leader = tr
+			}
+		}
+		return leader != nil
+	})
+	return leader
+}
+
+func TestTribeLeaderMode(t *testing.T) {
+	log.SetLevel(log.WarnLevel)
+	numOfTribes := 4
+	tribes := getLeaderModeTribes(numOfTribes, 3)
+	agreement1 := "agreement1"
+	plugin1 := agreement.Plugin{Name_: "plugin1", Version_: 1, Type_: core.ProcessorPluginType}
+	task1 := agreement.Task{ID: uuid.New()}
+	Convey("A tribe in leader mode is started", t, func() {
+		leader := getLeader(tribes)
+		So(leader, ShouldNotBeNil)
+		So(leader.config.Voters, ShouldContain, leader.memberlist.LocalNode().Name)
+
+		Convey("agreement changes are ordered by the leader", func() {
+			// the last member is not a voter and forwards its changes
+			learner := tribes[numOfTribes-1]
+			So(learner.GetStatus().State, ShouldEqual, "follower")
+			So(learner.AddAgreement(agreement1), ShouldBeNil)
+			So(learner.AddPlugin(agreement1, plugin1), ShouldBeNil)
+			So(learner.AddTask(agreement1, task1), ShouldBeNil)
+			So(learner.JoinAgreement(agreement1, learner.memberlist.LocalNode().Name), ShouldBeNil)
+			_, ok := learner.agreements[agreement1]
+			So(ok, ShouldBeTrue)
+
+			Convey("conflicting changes are rejected", func() {
+				serr := leader.AddAgreement(agreement1)
+				So(serr, ShouldNotBeNil)
+				So(serr.Error(), ShouldEqual, errAgreementAlreadyExists.Error())
+
+				Convey("every member applies the changes", func() {
+					ok := waitFor(10*time.Second, func() bool {
+						for _, tr := range tribes {
+							tr.mutex.RLock()
+							a, ok := tr.agreements[agreement1]
+							applied := ok && len(a.PluginAgreement.Plugins) == 1 &&
+								len(a.TaskAgreement.Tasks) == 1 && len(a.Members) == 1
+							tr.mutex.RUnlock()
+							if !applied {
+								return false
+							}
+						}
+						return true
+					})
+					So(ok, ShouldBeTrue)
+
+					Convey("the leader reports replication lag", func() {
+						ok := waitFor(5*time.Second, func() bool {
+							s := leader.GetStatus()
+							if len(s.Replication) != numOfTribes-1 {
+								return false
+							}
+							for _, r := range s.Replication {
+								if r.Lag != 0 {
+									return false
+								}
+							}
+							return true
+						})
+						So(ok, ShouldBeTrue)
+						s := leader.GetStatus()
+						So(s.Mode, ShouldEqual, agreement.LeaderMode)
+						So(s.CommitIndex, ShouldEqual, s.LastIndex)
+						So(s.Replication[numOfTribes-2].Voter, ShouldBeFalse)
+
+						Convey("a new leader is elected when the leader stops", func() {
+							leader.Stop()
+							remaining := []*tribe{}
+							for _, tr := range tribes {
+								if tr != leader {
+									remaining = append(remaining, tr)
+								}
+							}
+							newLeader := getLeader(remaining)
+							So(newLeader, ShouldNotBeNil)
+							So(newLeader, ShouldNotEqual, leader)
+							So(learner.RemoveAgreement(agreement1), ShouldBeNil)
+							ok := waitFor(5*time.Second, func() bool {
+								newLeader.mutex.RLock()
+								defer newLeader.mutex.RUnlock()
+								_, ok := newLeader.agreements[agreement1]
+								return !ok
+							})
+							So(ok, ShouldBeTrue)
+						})
+					})
+				})
+			})
+		})
+	})
+}
+
+func TestRaftPersistentState(t *testing.T) {
+	log.SetLevel(log.WarnLevel)
+	Convey("A voter in leader mode", t, func() {
+		dir, err := ioutil.TempDir("", "snap-tribe-raft")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		conf := getTestConfig()
+		conf.Name = "member-0"
+		conf.Voters = []string{"member-0", "member-1", "member-2"}
+
+		Convey("requires a raft directory", func() {
+			_, err := New(conf)
+			So(err, ShouldEqual, errRaftDirNotSet)
+		})
+
+		Convey("keeps its term, vote and log across restarts", func() {
+			conf.RaftDir = dir
+			tr, err := New(conf)
+			So(err, ShouldBeNil)
+			defer tr.memberlist.Shutdown()
+			r := tr.raft
+			r.handleRequestVote(&requestVoteMsg{Term: 3, Candidate: "member-1"})
+			r.handleAppendEntries(&appendEntriesMsg{
+				Term:    3,
+				Leader:  "member-1",
+				Entries: []*logEntry{{Term: 3, Index: 1, ID: "one"}},
+			})
+
+			restarted, err := newRaft(tr)
+			So(err, ShouldBeNil)
+			So(restarted.term, ShouldEqual, 3)
+			So(restarted.votedFor, ShouldEqual, "member-1")
+			So(restarted.log, ShouldHaveLength, 1)
+			So(restarted.log[0].ID, ShouldEqual, "one")
+
+			Convey("and does not vote twice in a term", func() {
+				restarted.handleRequestVote(&requestVoteMsg{Term: 3, Candidate: "member-2", LastLogIndex: 1, LastLogTerm: 3})
+				So(restarted.votedFor, ShouldEqual, "member-1")
+			})
+		})
+
+		Convey("replaces the applied log with a snapshot", func() {
+			conf.RaftDir = dir
+			conf.Voters = []string{"member-0"}
+			conf.LeaderHeartbeatInterval = 50 * time.Millisecond
+			conf.LeaderElectionTimeout = 300 * time.Millisecond
+			tr, err := New(conf)
+			So(err, ShouldBeNil)
+			defer tr.memberlist.Shutdown()
+			tr.SetTaskManager(&mockTaskManager{})
+			r := tr.raft
+			r.snapshotThreshold = 4
+			r.start()
+			So(getLeader([]*tribe{tr}), ShouldEqual, tr)
+			for i := 0; i < 6; i++ {
+				So(tr.AddAgreement(fmt.Sprintf("agreement%d", i)), ShouldBeNil)
+			}
+			So(tr.JoinAgreement("agreement0", "member-0"), ShouldBeNil)
+			r.stop()
+			So(r.snapshotIndex(), ShouldEqual, 8)
+			So(r.log, ShouldBeEmpty)
+			So(r.snapshot.Agreements, ShouldHaveLength, 6)
+			So(r.snapshot.Agreements[0].Members, ShouldResemble, []string{"member-0"})
+
+			Convey("which is restored after a restart", func() {
+				tr.mutex.Lock()
+				tr.agreements = map[string]*agreement.Agreement{}
+				tr.mutex.Unlock()
+				restarted, err := newRaft(tr)
+				So(err, ShouldBeNil)
+				So(restarted.snapshotIndex(), ShouldEqual, 8)
+				So(restarted.lastIndex(), ShouldEqual, 8)
+				tr.raft = restarted
+				restarted.start()
+				defer restarted.stop()
+				ok := waitFor(5*time.Second, func() bool {
+					tr.mutex.RLock()
+					defer tr.mutex.RUnlock()
+					a, ok := tr.agreements["agreement0"]
+					return len(tr.agreements) == 6 && ok && len(a.Members) == 1
+				})
+				So(ok, ShouldBeTrue)
+			})
+		})
+
+		Convey("ignores a partially written log entry", func() {
+			store := &raftStore{dir: dir}
+			So(store.appendLog([]*logEntry{{Term: 1, Index: 1, ID: "one"}, {Term: 1, Index: 2, ID: "two"}}), ShouldBeNil)
+			f, err := os.OpenFile(filepath.Join(dir, raftLogFile), os.O_WRONLY|os.O_APPEND, 0600)
+			So(err, ShouldBeNil)
+			f.WriteString(`{"Term":1,"Ind`)
+			f.Close()
+			entries, err := store.loadLog(0)
+			So(err, ShouldBeNil)
+			So(entries, ShouldHaveLength, 2)
+			entries, err = store.loadLog(1)
+			So(err, ShouldBeNil)
+			So(entries, ShouldHaveLength, 1)
+			So(entries[0].ID, ShouldEqual, "two")
+		})
+	})
+}
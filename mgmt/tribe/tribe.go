@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -60,6 +61,7 @@ var (
 	errMemberlistJoin                 = errors.New("Failed to join tribe")
 	errPluginCatalogNotSet            = errors.New("Plugin Catalog not set")
 	errTaskManagerNotSet              = errors.New("Task Manager not set")
+	errNotStarted                     = errors.New("Tribe not started")
 )
 
 var logger = log.WithFields(log.Fields{
@@ -89,6 +91,14 @@ type tribe struct {
 
 	workerQuitChan  chan struct{}
 	workerWaitGroup *sync.WaitGroup
+	reconciler      *worker.Reconciler
+
+	// raft orders agreement changes when the tribe runs in leader mode
+	raft *raft
+
+	// aggregateTimeout limits the requests to the members of an agreement
+	// when aggregating its state
+	aggregateTimeout time.Duration
 }
 
 func New(cfg *Config) (*tribe, error) {
@@ -120,6 +130,17 @@ func New(cfg *Config) (*tribe, error) {
 		workerWaitGroup: &sync.WaitGroup{},
 		config:          cfg,
 		EventManager:    gomit.NewEventController(),
+
+		aggregateTimeout: defaultAggregateTimeout,
+	}
+
+	if cfg.LeaderMode() {
+		r, err := newRaft(tribe)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		tribe.raft = r
 	}
 
 	tribe.broadcasts = &memberlist.TransmitLimitedQueue{
@@ -230,6 +251,21 @@ func (t *tribe) Start() error {
 		t.pluginCatalog,
 		t.taskManager,
 		t)
+	t.reconciler = worker.NewReconciler(
+		t.config.ReconcilePolicy,
+		t.config.ReconcileInterval.Duration,
+		t.memberlist.LocalNode().Name,
+		t.pluginWorkQueue,
+		t.taskWorkQueue,
+		t.workerQuitChan,
+		t.workerWaitGroup,
+		t.pluginCatalog,
+		t.taskManager,
+		t)
+	t.reconciler.Start()
+	if t.raft != nil {
+		t.raft.start()
+	}
 	return nil
 }
 
@@ -237,6 +273,9 @@ func (t *tribe) Stop() {
 	logger := t.logger.WithFields(log.Fields{
 		"_block": "stop",
 	})
+	if t.raft != nil {
+		t.raft.stop()
+	}
 	err := t.memberlist.Leave(1 * time.Second)
 	if err != nil {
 		logger.Error(err)
@@ -472,6 +511,9 @@ func (t *tribe) LeaveAgreement(agreementName, memberName string) serror.SnapErro
 		MemberName:    memberName,
 		Type:          leaveAgreementMsgType,
 	}
+	if t.raft != nil {
+		return t.raft.propose(msg)
+	}
 	if t.handleLeaveAgreement(msg) {
 		t.broadcast(leaveAgreementMsgType, msg, nil)
 	}
@@ -490,6 +532,9 @@ func (t *tribe) JoinAgreement(agreementName, memberName string) serror.SnapError
 		MemberName:    memberName,
 		Type:          joinAgreementMsgType,
 	}
+	if t.raft != nil {
+		return t.raft.propose(msg)
+	}
 	if t.handleJoinAgreement(msg) {
 		t.broadcast(joinAgreementMsgType, msg, nil)
 	}
@@ -515,6 +560,12 @@ func (t *tribe) AddPlugin(agreementName string, p agreement.Plugin) error {
 			Version int
 		}{Name: p.Name(), Type: p.Type_, Version: p.Version_},
 	})
+	if t.raft != nil {
+		if err := t.raft.propose(msg); err != nil {
+			return err
+		}
+		return nil
+	}
 	if t.handleAddPlugin(msg) {
 		t.broadcast(addPluginMsgType, msg, nil)
 	}
@@ -532,6 +583,12 @@ func (t *tribe) RemovePlugin(agreementName string, p agreement.Plugin) error {
 		UUID:          uuid.New(),
 		Type:          removePluginMsgType,
 	}
+	if t.raft != nil {
+		if err := t.raft.propose(msg); err != nil {
+			return err
+		}
+		return nil
+	}
 	if t.handleRemovePlugin(msg) {
 		t.broadcast(removePluginMsgType, msg, nil)
 	}
@@ -550,6 +607,43 @@ func (t *tribe) GetAgreements() map[string]*agreement.Agreement {
 	return t.agreements
 }
 
+// GetJoinedAgreements returns the names of the agreements the local member
+// has joined
+func (t *tribe) GetJoinedAgreements() []string {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	names := []string{}
+	for name, a := range t.agreements {
+		if _, ok := a.Members[t.memberlist.LocalNode().Name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// GetDrift compares the given agreement with the plugins and tasks the local
+// member is running and returns the differences
+func (t *tribe) GetDrift(name string) (*agreement.Drift, serror.SnapError) {
+	fields := log.Fields{
+		"agreement": name,
+	}
+	t.mutex.RLock()
+	if _, ok := t.agreements[name]; !ok {
+		t.mutex.RUnlock()
+		return nil, serror.New(errAgreementDoesNotExist, fields)
+	}
+	if !t.isMemberOfAgreement(name) {
+		t.mutex.RUnlock()
+		return nil, serror.New(errNotAMember, fields)
+	}
+	reconciler := t.reconciler
+	t.mutex.RUnlock()
+	if reconciler == nil {
+		return nil, serror.New(errNotStarted, fields)
+	}
+	return reconciler.Check(name)
+}
+
 func (t *tribe) AddTask(agreementName string, task agreement.Task) serror.SnapError {
 	if err := t.canAddTask(task, agreementName); err != nil {
 		return err
@@ -562,6 +656,9 @@ func (t *tribe) AddTask(agreementName string, task agreement.Task) serror.SnapEr
 		UUID:          uuid.New(),
 		Type:          addTaskMsgType,
 	}
+	if t.raft != nil {
+		return t.raft.propose(msg)
+	}
 	if t.handleAddTask(msg) {
 		t.broadcast(addTaskMsgType, msg, nil)
 	}
@@ -579,6 +676,9 @@ func (t *tribe) RemoveTask(agreementName string, task agreement.Task) serror.Sna
 		UUID:          uuid.New(),
 		Type:          removeTaskMsgType,
 	}
+	if t.raft != nil {
+		return t.raft.propose(msg)
+	}
 	if t.handleRemoveTask(msg) {
 		t.broadcast(removeTaskMsgType, msg, nil)
 	}
@@ -596,6 +696,9 @@ func (t *tribe) StopTask(agreementName string, task agreement.Task) serror.SnapE
 		UUID:          uuid.New(),
 		Type:          stopTaskMsgType,
 	}
+	if t.raft != nil {
+		return t.raft.propose(msg)
+	}
 	if t.handleStopTask(msg) {
 		t.broadcast(stopTaskMsgType, msg, nil)
 	}
@@ -614,6 +717,9 @@ func (t *tribe) StartTask(agreementName string, task agreement.Task) serror.Snap
 		UUID:          uuid.New(),
 		Type:          startTaskMsgType,
 	}
+	if t.raft != nil {
+		return t.raft.propose(msg)
+	}
 	if t.handleStartTask(msg) {
 		t.broadcast(startTaskMsgType, msg, nil)
 	}
@@ -633,6 +739,9 @@ func (t *tribe) AddAgreement(name string) serror.SnapError {
 		UUID:          uuid.New(),
 		Type:          addAgreementMsgType,
 	}
+	if t.raft != nil {
+		return t.raft.propose(msg)
+	}
 	if t.handleAddAgreement(msg) {
 		t.broadcast(addAgreementMsgType, msg, nil)
 	}
@@ -652,12 +761,27 @@ func (t *tribe) RemoveAgreement(name string) serror.SnapError {
 		UUID:          uuid.New(),
 		Type:          removeAgreementMsgType,
 	}
+	if t.raft != nil {
+		return t.raft.propose(msg)
+	}
 	if t.handleRemoveAgreement(msg) {
 		t.broadcast(removeAgreementMsgType, msg, nil)
 	}
 	return nil
 }
 
+// GetStatus returns how agreement changes are coordinated and, in leader
+// mode, the current leader and how far each member's log trails it.
+func (t *tribe) GetStatus() *agreement.Status {
+	if t.raft == nil {
+		return &agreement.Status{
+			Mode: agreement.GossipMode,
+			Name: t.memberlist.LocalNode().Name,
+		}
+	}
+	return t.raft.status()
+}
+
 func (t *tribe) TaskStateQuery(agreementName string, taskId string) core.TaskState {
 	resp := t.taskStateQuery(agreementName, taskId)
 
@@ -1426,6 +1550,130 @@ func (t *tribe) canStartStopRemoveTask(task agreement.Task, agreementName string
 	return nil
 }
 
+// canApply validates an agreement change against the current state.  It is
+// used by the leader before appending a change to the log.
+func (t *tribe) canApply(m msg) serror.SnapError {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	fields := log.Fields{
+		"agreement": m.Agreement(),
+	}
+	switch v := m.(type) {
+	case *agreementMsg:
+		switch v.Type {
+		case addAgreementMsgType:
+			if _, ok := t.agreements[v.AgreementName]; ok {
+				return serror.New(errAgreementAlreadyExists, fields)
+			}
+		case removeAgreementMsgType:
+			if _, ok := t.agreements[v.AgreementName]; !ok {
+				return serror.New(errAgreementDoesNotExist, fields)
+			}
+		case joinAgreementMsgType:
+			return t.canJoinAgreement(v.AgreementName, v.MemberName)
+		case leaveAgreementMsgType:
+			return t.canLeaveAgreement(v.AgreementName, v.MemberName)
+		}
+	case *pluginMsg:
+		if _, ok := t.agreements[v.AgreementName]; !ok {
+			return serror.New(errAgreementDoesNotExist, fields)
+		}
+	case *taskMsg:
+		task := agreement.Task{ID: v.TaskID}
+		if v.Type == addTaskMsgType {
+			return t.canAddTask(task, v.AgreementName)
+		}
+		return t.canStartStopRemoveTask(task, v.AgreementName)
+	}
+	return nil
+}
+
+// snapshotAgreements returns the agreements as stored in a raft snapshot.
+// Members that are waiting to join an agreement are included so the join is
+// not lost once the log entry it came from is compacted.
+func (t *tribe) snapshotAgreements() []snapshotAgreement {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	names := make([]string, 0, len(t.agreements))
+	for name := range t.agreements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	agreements := make([]snapshotAgreement, 0, len(names))
+	for _, name := range names {
+		a := t.agreements[name]
+		sa := snapshotAgreement{
+			Name:    name,
+			Plugins: []agreement.Plugin{},
+			Tasks:   []agreement.Task{},
+			Members: []string{},
+		}
+		if a.PluginAgreement != nil {
+			sa.Plugins = append(sa.Plugins, a.PluginAgreement.Plugins...)
+		}
+		if a.TaskAgreement != nil {
+			sa.Tasks = append(sa.Tasks, a.TaskAgreement.Tasks...)
+		}
+		for member := range a.Members {
+			sa.Members = append(sa.Members, member)
+		}
+		for _, intent := range t.intentBuffer {
+			if intent.GetType() == joinAgreementMsgType && intent.Agreement() == name {
+				sa.Members = append(sa.Members, intent.(*agreementMsg).MemberName)
+			}
+		}
+		sort.Strings(sa.Members)
+		agreements = append(agreements, sa)
+	}
+	return agreements
+}
+
+// restoreSnapshot replaces the agreements with the ones of a raft snapshot.
+// The plugins and tasks of the agreements the local member belongs to are
+// requested from the workers like on a join, and memberships of members that
+// are not known yet are kept as intents until they join.
+func (t *tribe) restoreSnapshot(s *raftSnapshot) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.clock.Update(s.LTime)
+	for _, m := range t.members {
+		m.PluginAgreement = nil
+		for name := range m.TaskAgreements {
+			delete(m.TaskAgreements, name)
+		}
+	}
+	t.intentBuffer = []msg{}
+	t.agreements = map[string]*agreement.Agreement{}
+	for _, sa := range s.Agreements {
+		a := agreement.New(sa.Name)
+		for _, p := range sa.Plugins {
+			a.PluginAgreement.Add(p)
+		}
+		for _, tsk := range sa.Tasks {
+			a.TaskAgreement.Add(tsk)
+		}
+		t.agreements[sa.Name] = a
+	}
+	for _, sa := range s.Agreements {
+		for _, member := range sa.Members {
+			msg := &agreementMsg{
+				LTime:         s.LTime,
+				UUID:          uuid.New(),
+				AgreementName: sa.Name,
+				MemberName:    member,
+				Type:          joinAgreementMsgType,
+			}
+			if err := t.joinAgreement(msg); err != nil {
+				t.addAgreementIntent(msg)
+			}
+		}
+	}
+	t.processIntents()
+}
+
 func (t *tribe) isMemberOfAgreement(name string) bool {
 	fields := log.Fields{
 		"agreement": name,
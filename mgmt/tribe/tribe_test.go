@@ -69,6 +69,12 @@ func (t *mockTask) SetDeadlineDuration(time.Duration)         { return }
 func (t *mockTask) SetTaskID(id string)                       { return }
 func (t *mockTask) SetStopOnFailure(int)                      { return }
 func (t *mockTask) GetStopOnFailure() int                     { return 0 }
+func (t *mockTask) SetOverlap(string, int)                    { return }
+func (t *mockTask) GetOverlap() (string, int)                 { return core.TaskOverlapSkip, 0 }
+func (t *mockTask) FireDurations() core.FireDurations         { return core.NewFireDurations() }
+func (t *mockTask) SetPriority(string)                        { return }
+func (t *mockTask) GetPriority() string                       { return core.TaskPriorityNormal }
+func (t *mockTask) History() []core.TaskRun                   { return nil }
 func (t *mockTask) Option(...core.TaskOption) core.TaskOption { return core.TaskDeadlineDuration(0) }
 func (t *mockTask) WMap() *wmap.WorkflowMap                   { return nil }
 func (t *mockTask) Schedule() schedule.Schedule               { return nil }
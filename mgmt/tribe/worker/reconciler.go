@@ -0,0 +1,394 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package worker
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/mgmt/tribe/agreement"
+)
+
+const (
+	// ReconcileReportOnly only reports the drift found
+	ReconcileReportOnly = "report"
+	// ReconcileEnforce queues the work needed to repair the drift found
+	ReconcileEnforce = "enforce"
+)
+
+type getsAgreements interface {
+	GetAgreement(name string) (*agreement.Agreement, serror.SnapError)
+	GetJoinedAgreements() []string
+	TaskStateQuery(agreementName string, taskID string) core.TaskState
+}
+
+// Reconciler periodically compares the plugins and tasks of the agreements
+// the local member has joined with what the member is actually running.
+// Depending on its policy the drift found is either only reported or
+// repaired by queueing the same work requests that agreement changes
+// produce.  An item is only queued again once the work repairing it is done.
+type Reconciler struct {
+	policy        string
+	interval      time.Duration
+	memberName    string
+	pluginManager ManagesPlugins
+	taskManager   ManagesTasks
+	agreements    getsAgreements
+	pluginWork    chan PluginRequest
+	taskWork      chan TaskRequest
+	quitChan      chan struct{}
+	waitGroup     *sync.WaitGroup
+	logger        *log.Entry
+	// pending holds the drift items with repair work queued or running
+	pending map[string]struct{}
+	// repaired holds the drift items whose repair succeeded by agreement
+	repaired map[string]map[string]*repairedItem
+	mutex    sync.Mutex
+}
+
+// repairedItem is the drift of an item whose repair succeeded.  It is
+// reported for one interval after the repair.
+type repairedItem struct {
+	plugin     *agreement.PluginDrift
+	task       *agreement.TaskDrift
+	repairedAt time.Time
+}
+
+// NewReconciler returns a reconciler for the local member memberName
+func NewReconciler(policy string,
+	interval time.Duration,
+	memberName string,
+	pluginQueue chan PluginRequest,
+	taskQueue chan TaskRequest,
+	quitChan chan struct{},
+	wg *sync.WaitGroup,
+	pm ManagesPlugins,
+	tm ManagesTasks,
+	am getsAgreements) *Reconciler {
+	return &Reconciler{
+		policy:        policy,
+		interval:      interval,
+		memberName:    memberName,
+		pluginManager: pm,
+		taskManager:   tm,
+		agreements:    am,
+		pluginWork:    pluginQueue,
+		taskWork:      taskQueue,
+		quitChan:      quitChan,
+		waitGroup:     wg,
+		pending:       map[string]struct{}{},
+		repaired:      map[string]map[string]*repairedItem{},
+		logger: log.WithFields(log.Fields{
+			"_module": "worker",
+			"policy":  policy,
+		}),
+	}
+}
+
+// Start checks the joined agreements every interval until the quit channel
+// is closed
+func (r *Reconciler) Start() {
+	logger := r.logger.WithField("_block", "start")
+	r.waitGroup.Add(1)
+	go func() {
+		defer r.waitGroup.Done()
+		logger.Debug("starting tribe reconciler")
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				for _, name := range r.agreements.GetJoinedAgreements() {
+					r.reconcile(name, r.policy == ReconcileEnforce)
+				}
+			case <-r.quitChan:
+				logger.Debug("stopping tribe reconciler")
+				return
+			}
+		}
+	}()
+}
+
+// Check returns the drift of the given agreement without repairing it.  The
+// items repaired during the last interval are reported as repaired.
+func (r *Reconciler) Check(name string) (*agreement.Drift, serror.SnapError) {
+	return r.reconcile(name, false)
+}
+
+func (r *Reconciler) reconcile(name string, repair bool) (*agreement.Drift, serror.SnapError) {
+	logger := r.logger.WithFields(log.Fields{
+		"_block":    "reconcile",
+		"agreement": name,
+	})
+	a, serr := r.agreements.GetAgreement(name)
+	if serr != nil {
+		logger.Debug(serr)
+		return nil, serr
+	}
+	drift := &agreement.Drift{
+		Agreement: name,
+		Member:    r.memberName,
+		Policy:    r.policy,
+		CheckedAt: time.Now(),
+		Plugins:   []agreement.PluginDrift{},
+		Tasks:     []agreement.TaskDrift{},
+	}
+	// keys holds the items of the agreement
+	keys := map[string]struct{}{}
+	if a.PluginAgreement != nil {
+		drift.Plugins = r.pluginDrift(name, a.PluginAgreement.Plugins, repair)
+		for _, p := range a.PluginAgreement.Plugins {
+			keys[pluginKey(p.TypeName(), p.Name(), p.Version())] = struct{}{}
+		}
+	}
+	if a.TaskAgreement != nil {
+		drift.Tasks = r.taskDrift(name, a.TaskAgreement.Tasks, repair)
+		for _, t := range a.TaskAgreement.Tasks {
+			keys[taskKey(t.ID)] = struct{}{}
+		}
+	}
+	r.addRepaired(name, drift, keys)
+	if !drift.InSync() {
+		logger.WithFields(log.Fields{
+			"plugins": len(drift.Plugins),
+			"tasks":   len(drift.Tasks),
+			"repair":  repair,
+		}).Warn("agreement drift detected")
+	}
+	return drift, nil
+}
+
+// addRepaired adds the items of the agreement repaired during the last
+// interval that do not drift again to drift.  Items that drift again, left
+// the agreement or were repaired earlier are forgotten.
+func (r *Reconciler) addRepaired(name string, drift *agreement.Drift, keys map[string]struct{}) {
+	drifting := map[string]struct{}{}
+	for _, d := range drift.Plugins {
+		drifting[pluginKey(d.Type, d.Name, d.Version)] = struct{}{}
+	}
+	for _, d := range drift.Tasks {
+		drifting[taskKey(d.ID)] = struct{}{}
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	items := r.repaired[name]
+	ordered := make([]string, 0, len(items))
+	for key, item := range items {
+		_, again := drifting[key]
+		_, member := keys[key]
+		if again || !member || time.Since(item.repairedAt) > r.interval {
+			delete(items, key)
+			continue
+		}
+		ordered = append(ordered, key)
+	}
+	if len(items) == 0 {
+		delete(r.repaired, name)
+	}
+	sort.Strings(ordered)
+	for _, key := range ordered {
+		item := items[key]
+		if item.plugin != nil {
+			drift.Plugins = append(drift.Plugins, *item.plugin)
+		} else {
+			drift.Tasks = append(drift.Tasks, *item.task)
+		}
+	}
+}
+
+func pluginKey(typ, name string, version int) string {
+	return fmt.Sprintf("plugin:%s:%s:%d", typ, name, version)
+}
+
+func taskKey(id string) string {
+	return "task:" + id
+}
+
+func (r *Reconciler) pluginDrift(name string, plugins []agreement.Plugin, repair bool) []agreement.PluginDrift {
+	drift := []agreement.PluginDrift{}
+	requests := []PluginRequest{}
+	catalog := r.pluginManager.PluginCatalog()
+	for _, p := range plugins {
+		if isLoaded(catalog, p) {
+			continue
+		}
+		drift = append(drift, agreement.PluginDrift{
+			Name:    p.Name(),
+			Version: p.Version(),
+			Type:    p.TypeName(),
+			Reason:  agreement.DriftMissing,
+		})
+		requests = append(requests, PluginRequest{
+			Plugin:      p,
+			RequestType: PluginLoadedType,
+		})
+	}
+	for idx := range drift {
+		d := &drift[idx]
+		repaired := *d
+		repaired.Repaired = true
+		d.Pending = r.repairItem(name, pluginKey(d.Type, d.Name, d.Version), repair, func(done func(error)) bool {
+			work := requests[idx]
+			work.done = done
+			select {
+			case r.pluginWork <- work:
+				return true
+			case <-r.quitChan:
+				return false
+			}
+		}, &repairedItem{plugin: &repaired})
+	}
+	return drift
+}
+
+func (r *Reconciler) taskDrift(name string, tasks []agreement.Task, repair bool) []agreement.TaskDrift {
+	// the state agreed on is queried from all the members of the
+	// agreement at once since each query waits for the responses
+	expected := make([]core.TaskState, len(tasks))
+	wg := sync.WaitGroup{}
+	for idx, t := range tasks {
+		wg.Add(1)
+		go func(idx int, id string) {
+			defer wg.Done()
+			expected[idx] = r.agreements.TaskStateQuery(name, id)
+		}(idx, t.ID)
+	}
+	wg.Wait()
+
+	drift := []agreement.TaskDrift{}
+	// requests holds the work repairing each drift item, if any
+	requests := []*TaskRequest{}
+	for idx, t := range tasks {
+		d := agreement.TaskDrift{
+			ID:            t.ID,
+			ExpectedState: expected[idx].String(),
+		}
+		task, err := r.taskManager.GetTask(t.ID)
+		if err != nil {
+			d.Reason = agreement.DriftMissing
+			drift = append(drift, d)
+			requests = append(requests, &TaskRequest{
+				Task: Task{
+					ID:            t.ID,
+					StartOnCreate: isRunning(expected[idx]),
+				},
+				RequestType: TaskCreatedType,
+			})
+			continue
+		}
+		actual := task.State()
+		d.ActualState = actual.String()
+		d.Reason = agreement.DriftState
+		work := &TaskRequest{Task: Task{ID: t.ID}}
+		switch {
+		case isRunning(expected[idx]) && actual == core.TaskStopped:
+			work.RequestType = TaskStartedType
+		case expected[idx] == core.TaskStopped && isRunning(actual):
+			work.RequestType = TaskStoppedType
+		case isRunning(expected[idx]) && actual == core.TaskDisabled:
+			// a disabled task can not be started again
+			drift = append(drift, d)
+			requests = append(requests, nil)
+			continue
+		default:
+			continue
+		}
+		drift = append(drift, d)
+		requests = append(requests, work)
+	}
+	for idx := range drift {
+		d := &drift[idx]
+		work := requests[idx]
+		repaired := *d
+		repaired.Repaired = true
+		d.Pending = r.repairItem(name, taskKey(d.ID), repair && work != nil, func(done func(error)) bool {
+			work.done = done
+			select {
+			case r.taskWork <- *work:
+				return true
+			case <-r.quitChan:
+				return false
+			}
+		}, &repairedItem{task: &repaired})
+	}
+	return drift
+}
+
+// repairItem queues the work repairing the drift item key of the agreement
+// name with queue, unless repair is false or work for the item is pending
+// already, and returns whether work for the item is pending.  repaired is
+// recorded once the work succeeded.
+func (r *Reconciler) repairItem(name, key string, repair bool, queue func(done func(error)) bool, repaired *repairedItem) bool {
+	r.mutex.Lock()
+	_, pending := r.pending[key]
+	if !repair || pending {
+		r.mutex.Unlock()
+		return pending
+	}
+	r.pending[key] = struct{}{}
+	r.mutex.Unlock()
+
+	logger := r.logger.WithFields(log.Fields{
+		"_block": "repair",
+		"item":   key,
+	})
+	done := func(err error) {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		delete(r.pending, key)
+		if err != nil {
+			logger.WithField("error", err).Warn("failed to repair agreement drift")
+			return
+		}
+		repaired.repairedAt = time.Now()
+		if r.repaired[name] == nil {
+			r.repaired[name] = map[string]*repairedItem{}
+		}
+		r.repaired[name][key] = repaired
+		logger.Info("agreement drift repaired")
+	}
+	if !queue(done) {
+		r.mutex.Lock()
+		delete(r.pending, key)
+		r.mutex.Unlock()
+		return false
+	}
+	return true
+}
+
+func isRunning(s core.TaskState) bool {
+	return s == core.TaskSpinning || s == core.TaskFiring
+}
+
+func isLoaded(catalog core.PluginCatalog, p core.Plugin) bool {
+	for _, item := range catalog {
+		if item.TypeName() == p.TypeName() &&
+			item.Name() == p.Name() &&
+			item.Version() == p.Version() {
+			return true
+		}
+	}
+	return false
+}
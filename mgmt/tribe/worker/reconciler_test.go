@@ -0,0 +1,238 @@
+// +build legacy
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package worker
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/serror"
+	"github.com/intelsdi-x/snap/mgmt/tribe/agreement"
+	"github.com/intelsdi-x/snap/pkg/schedule"
+	"github.com/intelsdi-x/snap/scheduler/wmap"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type mockCatalogedPlugin struct {
+	core.CatalogedPlugin
+	name    string
+	version int
+	typ     string
+}
+
+func (p *mockCatalogedPlugin) Name() string     { return p.name }
+func (p *mockCatalogedPlugin) Version() int     { return p.version }
+func (p *mockCatalogedPlugin) TypeName() string { return p.typ }
+
+type mockPluginManager struct {
+	catalog core.PluginCatalog
+}
+
+func (m *mockPluginManager) Load(*core.RequestedPlugin) (core.CatalogedPlugin, serror.SnapError) {
+	return nil, nil
+}
+func (m *mockPluginManager) Unload(plugin core.Plugin) (core.CatalogedPlugin, serror.SnapError) {
+	return nil, nil
+}
+func (m *mockPluginManager) PluginCatalog() core.PluginCatalog { return m.catalog }
+
+type mockTask struct {
+	core.Task
+	state core.TaskState
+}
+
+func (t *mockTask) State() core.TaskState { return t.state }
+
+type mockTaskManager struct {
+	tasks map[string]core.Task
+}
+
+func (m *mockTaskManager) GetTask(id string) (core.Task, error) {
+	if t, ok := m.tasks[id]; ok {
+		return t, nil
+	}
+	return nil, errors.New("task not found")
+}
+func (m *mockTaskManager) CreateTaskTribe(sch schedule.Schedule, wfMap *wmap.WorkflowMap, startOnCreate bool, opts ...core.TaskOption) (core.Task, core.TaskErrors) {
+	return nil, nil
+}
+func (m *mockTaskManager) StopTaskTribe(id string) []serror.SnapError  { return nil }
+func (m *mockTaskManager) StartTaskTribe(id string) []serror.SnapError { return nil }
+func (m *mockTaskManager) RemoveTaskTribe(id string) error             { return nil }
+
+type mockAgreements struct {
+	agreement *agreement.Agreement
+	states    map[string]core.TaskState
+}
+
+func (m *mockAgreements) GetAgreement(name string) (*agreement.Agreement, serror.SnapError) {
+	if m.agreement.Name != name {
+		return nil, serror.New(errors.New("Agreement does not exist"))
+	}
+	return m.agreement, nil
+}
+func (m *mockAgreements) GetJoinedAgreements() []string { return []string{m.agreement.Name} }
+func (m *mockAgreements) TaskStateQuery(agreementName string, taskID string) core.TaskState {
+	return m.states[taskID]
+}
+
+func TestReconciler(t *testing.T) {
+	a := agreement.New("agreement1")
+	a.PluginAgreement.Add(agreement.Plugin{Name_: "loaded", Version_: 1, Type_: core.CollectorPluginType})
+	a.PluginAgreement.Add(agreement.Plugin{Name_: "loaded", Version_: 2, Type_: core.CollectorPluginType})
+	a.TaskAgreement.Add(agreement.Task{ID: "in-sync"})
+	a.TaskAgreement.Add(agreement.Task{ID: "stopped"})
+	a.TaskAgreement.Add(agreement.Task{ID: "missing"})
+	pm := &mockPluginManager{
+		catalog: core.PluginCatalog{
+			&mockCatalogedPlugin{name: "loaded", version: 1, typ: "collector"},
+		},
+	}
+	tm := &mockTaskManager{
+		tasks: map[string]core.Task{
+			"in-sync": &mockTask{state: core.TaskSpinning},
+			"stopped": &mockTask{state: core.TaskStopped},
+		},
+	}
+	am := &mockAgreements{
+		agreement: a,
+		states: map[string]core.TaskState{
+			"in-sync": core.TaskSpinning,
+			"stopped": core.TaskFiring,
+			"missing": core.TaskSpinning,
+		},
+	}
+	Convey("Given a reconciler", t, func() {
+		pluginQueue := make(chan PluginRequest, 999)
+		taskQueue := make(chan TaskRequest, 999)
+		quit := make(chan struct{})
+		wg := &sync.WaitGroup{}
+
+		Convey("checking an agreement reports its drift", func() {
+			r := NewReconciler(ReconcileEnforce, time.Hour, "member1", pluginQueue, taskQueue, quit, wg, pm, tm, am)
+			drift, serr := r.Check("agreement1")
+			So(serr, ShouldBeNil)
+			So(drift.InSync(), ShouldBeFalse)
+			So(drift.Member, ShouldEqual, "member1")
+			So(drift.Plugins, ShouldHaveLength, 1)
+			So(drift.Plugins[0].Version, ShouldEqual, 2)
+			So(drift.Plugins[0].Reason, ShouldEqual, agreement.DriftMissing)
+			So(drift.Tasks, ShouldHaveLength, 2)
+			So(drift.Tasks[0].ID, ShouldEqual, "stopped")
+			So(drift.Tasks[0].Reason, ShouldEqual, agreement.DriftState)
+			So(drift.Tasks[0].ExpectedState, ShouldEqual, "Running")
+			So(drift.Tasks[0].ActualState, ShouldEqual, "Stopped")
+			So(drift.Tasks[1].ID, ShouldEqual, "missing")
+			So(drift.Tasks[1].Reason, ShouldEqual, agreement.DriftMissing)
+
+			Convey("without repairing it", func() {
+				So(drift.Plugins[0].Repaired, ShouldBeFalse)
+				So(len(pluginQueue), ShouldEqual, 0)
+				So(len(taskQueue), ShouldEqual, 0)
+			})
+		})
+
+		Convey("checking an unknown agreement returns an error", func() {
+			r := NewReconciler(ReconcileEnforce, time.Hour, "member1", pluginQueue, taskQueue, quit, wg, pm, tm, am)
+			_, serr := r.Check("agreement2")
+			So(serr, ShouldNotBeNil)
+		})
+
+		Convey("a reconciler enforcing the agreements repairs the drift", func() {
+			r := NewReconciler(ReconcileEnforce, 10*time.Millisecond, "member1", pluginQueue, taskQueue, quit, wg, pm, tm, am)
+			r.Start()
+			work := <-pluginQueue
+			So(work.RequestType, ShouldEqual, PluginLoadedType)
+			So(work.Plugin.Version(), ShouldEqual, 2)
+			start := <-taskQueue
+			So(start.Task.ID, ShouldEqual, "stopped")
+			So(start.RequestType, ShouldEqual, TaskStartedType)
+			create := <-taskQueue
+			So(create.Task.ID, ShouldEqual, "missing")
+			So(create.RequestType, ShouldEqual, TaskCreatedType)
+			So(create.Task.StartOnCreate, ShouldBeTrue)
+			close(quit)
+			wg.Wait()
+		})
+
+		Convey("an item is only queued again once its repair is done", func() {
+			plugins := &mockPluginManager{catalog: append(core.PluginCatalog{}, pm.catalog...)}
+			r := NewReconciler(ReconcileEnforce, time.Hour, "member1", pluginQueue, taskQueue, quit, wg, plugins, tm, am)
+			drift, serr := r.reconcile("agreement1", true)
+			So(serr, ShouldBeNil)
+			So(drift.Plugins[0].Pending, ShouldBeTrue)
+			So(drift.Plugins[0].Repaired, ShouldBeFalse)
+			So(len(pluginQueue), ShouldEqual, 1)
+			So(len(taskQueue), ShouldEqual, 2)
+
+			again, serr := r.reconcile("agreement1", true)
+			So(serr, ShouldBeNil)
+			So(again.Plugins[0].Pending, ShouldBeTrue)
+			So(len(pluginQueue), ShouldEqual, 1)
+			So(len(taskQueue), ShouldEqual, 2)
+			check, serr := r.Check("agreement1")
+			So(serr, ShouldBeNil)
+			So(check.Tasks[0].Pending, ShouldBeTrue)
+
+			work := <-pluginQueue
+			plugins.catalog = append(plugins.catalog, &mockCatalogedPlugin{name: "loaded", version: 2, typ: "collector"})
+			work.finish(nil)
+			start := <-taskQueue
+			start.finish(errors.New("failed to start task"))
+			<-taskQueue
+
+			Convey("and checking the agreement reports the repaired items", func() {
+				check, serr := r.Check("agreement1")
+				So(serr, ShouldBeNil)
+				So(check.Plugins, ShouldHaveLength, 1)
+				So(check.Plugins[0].Version, ShouldEqual, 2)
+				So(check.Plugins[0].Pending, ShouldBeFalse)
+				So(check.Plugins[0].Repaired, ShouldBeTrue)
+				So(check.Tasks[0].ID, ShouldEqual, "stopped")
+				So(check.Tasks[0].Repaired, ShouldBeFalse)
+				So(check.InSync(), ShouldBeFalse)
+			})
+
+			Convey("and only the items still drifting are queued", func() {
+				again, serr = r.reconcile("agreement1", true)
+				So(serr, ShouldBeNil)
+				So(len(pluginQueue), ShouldEqual, 0)
+				So(len(taskQueue), ShouldEqual, 1)
+				So(again.Plugins[0].Repaired, ShouldBeTrue)
+				So(again.Tasks[1].Pending, ShouldBeTrue)
+			})
+		})
+		Convey("a reconciler only reporting drift does not queue work", func() {
+			r := NewReconciler(ReconcileReportOnly, 10*time.Millisecond, "member1", pluginQueue, taskQueue, quit, wg, pm, tm, am)
+			r.Start()
+			time.Sleep(50 * time.Millisecond)
+			close(quit)
+			wg.Wait()
+			So(len(pluginQueue), ShouldEqual, 0)
+			So(len(taskQueue), ShouldEqual, 0)
+		})
+	})
+}
@@ -43,6 +43,11 @@ import (
 const (
 	retryDelay = 500 * time.Millisecond
 	retryLimit = 20
+	// a task agreed on is created once the plugins it needs are loaded,
+	// which can take long, so its creation is retried with a backoff up to
+	// maxCreateRetryDelay for about an hour
+	maxCreateRetryDelay = 30 * time.Second
+	createRetryLimit    = 130
 )
 
 const (
@@ -87,12 +92,28 @@ type PluginRequest struct {
 	Plugin      core.Plugin
 	RequestType PluginRequestType
 	retryCount  int
+	// done is called with the result once the request is done or given up
+	done func(error)
+}
+
+func (p PluginRequest) finish(err error) {
+	if p.done != nil {
+		p.done(err)
+	}
 }
 
 type TaskRequest struct {
 	Task        Task
 	RequestType TaskRequestType
 	retryCount  int
+	// done is called with the result once the request is done or given up
+	done func(error)
+}
+
+func (t TaskRequest) finish(err error) {
+	if t.done != nil {
+		t.done(err)
+	}
 }
 
 type Task struct {
@@ -198,39 +219,30 @@ func (w worker) start() {
 					"retries":      work.retryCount,
 				})
 				logger.Debug("received task work")
-				if work.RequestType == TaskStartedType {
-					if err := w.startTask(work.Task.ID); err != nil {
-						if work.retryCount < retryLimit {
-							logger.WithField("retry-count", work.retryCount).Debug("requeueing task start request")
-							work.retryCount++
-							time.Sleep(retryDelay)
-							w.taskWork <- work
-						}
-					}
+				var err error
+				switch work.RequestType {
+				case TaskStartedType:
+					err = w.startTask(work.Task.ID)
+				case TaskStoppedType:
+					err = w.stopTask(work.Task.ID)
+				case TaskCreatedType:
+					err = w.createTask(work.Task.ID, work.Task.StartOnCreate)
+				case TaskRemovedType:
+					err = w.removeTask(work.Task.ID)
 				}
-				if work.RequestType == TaskStoppedType {
-					if err := w.stopTask(work.Task.ID); err != nil {
-						if work.retryCount < retryLimit {
-							logger.WithField("retry-count", work.retryCount).Debug("requeueing task stop request")
-							work.retryCount++
-							time.Sleep(retryDelay)
-							w.taskWork <- work
-						}
-					}
+				if err != nil && work.RequestType == TaskCreatedType && work.retryCount < createRetryLimit {
+					logger.WithField("retry-count", work.retryCount).Debug("requeueing task creation request")
+					w.retryLater(work)
+					continue
 				}
-				if work.RequestType == TaskCreatedType {
-					w.createTask(work.Task.ID, work.Task.StartOnCreate)
-				}
-				if work.RequestType == TaskRemovedType {
-					if err := w.removeTask(work.Task.ID); err != nil {
-						if work.retryCount < retryLimit {
-							logger.WithField("retry-count", work.retryCount).Debug("requeueing request")
-							work.retryCount++
-							time.Sleep(retryDelay)
-							w.taskWork <- work
-						}
-					}
+				if err != nil && work.RequestType != TaskCreatedType && work.retryCount < retryLimit {
+					logger.WithField("retry-count", work.retryCount).Debug("requeueing task request")
+					work.retryCount++
+					time.Sleep(retryDelay)
+					w.taskWork <- work
+					continue
 				}
+				work.finish(err)
 			case <-w.quitChan:
 				logger.Infof("stopping tribe worker")
 				return
@@ -254,26 +266,21 @@ func (w worker) start() {
 					"request-type":   work.RequestType.String(),
 				})
 				logger.Debug("received plugin work")
-				if work.RequestType == PluginLoadedType {
-					if err := w.loadPlugin(work.Plugin); err != nil {
-						if work.retryCount < retryLimit {
-							logger.WithField("retry-count", work.retryCount).Debug("requeueing request")
-							work.retryCount++
-							time.Sleep(retryDelay)
-							w.pluginWork <- work
-						}
-					}
+				var err error
+				switch work.RequestType {
+				case PluginLoadedType:
+					err = w.loadPlugin(work.Plugin)
+				case PluginUnloadedType:
+					err = w.unloadPlugin(work.Plugin)
 				}
-				if work.RequestType == PluginUnloadedType {
-					if err := w.unloadPlugin(work.Plugin); err != nil {
-						if work.retryCount < retryLimit {
-							logger.WithField("retry-count", work.retryCount).Debug("requeueing request")
-							work.retryCount++
-							time.Sleep(retryDelay)
-							w.pluginWork <- work
-						}
-					}
+				if err != nil && work.retryCount < retryLimit {
+					logger.WithField("retry-count", work.retryCount).Debug("requeueing request")
+					work.retryCount++
+					time.Sleep(retryDelay)
+					w.pluginWork <- work
+					continue
 				}
+				work.finish(err)
 			case <-w.quitChan:
 				w.logger.Debug("stop tribe plugin worker")
 				return
@@ -282,6 +289,27 @@ func (w worker) start() {
 	}()
 }
 
+// retryLater requeues the task request once its backoff delay elapsed without
+// holding up the worker
+func (w worker) retryLater(work TaskRequest) {
+	delay := retryDelay << uint(work.retryCount)
+	if delay > maxCreateRetryDelay || delay <= 0 {
+		delay = maxCreateRetryDelay
+	}
+	work.retryCount++
+	go func() {
+		select {
+		case <-time.After(delay):
+		case <-w.quitChan:
+			return
+		}
+		select {
+		case w.taskWork <- work:
+		case <-w.quitChan:
+		}
+	}()
+}
+
 func (w worker) unloadPlugin(plugin core.Plugin) error {
 	logger := w.logger.WithFields(log.Fields{
 		"plugin-name":    plugin.Name(),
@@ -373,78 +401,80 @@ func (w worker) loadPlugin(plugin core.Plugin) error {
 	return errors.New("failed to find a member with the plugin")
 }
 
-func (w worker) createTask(taskID string, startOnCreate bool) {
+// createTask creates the task from its definition on one of the members of
+// the task agreement and returns an error when no member could provide it.
+// The worker retries the request with a backoff since the plugins of the
+// task may take long to load.
+func (w worker) createTask(taskID string, startOnCreate bool) error {
 	logger := w.logger.WithFields(log.Fields{
 		"task-id": taskID,
 		"_block":  "create-task",
 	})
-	done := false
 	_, err := w.taskManager.GetTask(taskID)
 	if err == nil {
-		return
+		return nil
 	}
-	for {
-		members, err := w.memberManager.GetTaskAgreementMembers()
+	members, err := w.memberManager.GetTaskAgreementMembers()
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+	// lastErr is the reason the last member could not provide the task
+	lastErr := errors.New("no member of the agreement has the task")
+	for _, member := range shuffle(members) {
+		uri := fmt.Sprintf("%s://%s:%s", member.GetRestProto(), member.GetAddr(), member.GetRestPort())
+		logger.Debugf("getting task %v from %v", taskID, uri)
+
+		c, err := client.New(uri, "v1", member.GetRestInsecureSkipVerify(), client.Password(w.memberManager.GetRequestPassword()))
 		if err != nil {
 			logger.Error(err)
+			lastErr = err
 			continue
 		}
-		for _, member := range shuffle(members) {
-			uri := fmt.Sprintf("%s://%s:%s", member.GetRestProto(), member.GetAddr(), member.GetRestPort())
-			logger.Debugf("getting task %v from %v", taskID, uri)
-
-			c, err := client.New(uri, "v1", member.GetRestInsecureSkipVerify(), client.Password(w.memberManager.GetRequestPassword()))
-			if err != nil {
-				logger.Error(err)
-				continue
-			}
 
-			taskResult := c.GetTask(taskID)
-			if taskResult.Err != nil {
-				logger.WithField("err", taskResult.Err.Error()).Debug("error getting task")
-				continue
-			}
-			// this block addresses the condition when we are creating and starting
-			// a task and the task is created but fails to start (deps were not yet met)
-			if startOnCreate {
-				if _, err := w.taskManager.GetTask(taskID); err == nil {
-					logger.Debug("starting task")
-					if errs := w.taskManager.StartTaskTribe(taskID); errs != nil {
-						fields := log.Fields{}
-						for idx, e := range errs {
-							fields[fmt.Sprintf("err-%d", idx)] = e.Error()
-						}
-						logger.WithFields(fields).Error("error starting task")
-						continue
+		taskResult := c.GetTask(taskID)
+		if taskResult.Err != nil {
+			logger.WithField("err", taskResult.Err.Error()).Debug("error getting task")
+			lastErr = taskResult.Err
+			continue
+		}
+		// this block addresses the condition when we are creating and starting
+		// a task and the task is created but fails to start (deps were not yet met)
+		if startOnCreate {
+			if _, err := w.taskManager.GetTask(taskID); err == nil {
+				logger.Debug("starting task")
+				if errs := w.taskManager.StartTaskTribe(taskID); errs != nil {
+					fields := log.Fields{}
+					for idx, e := range errs {
+						fields[fmt.Sprintf("err-%d", idx)] = e.Error()
 					}
-					done = true
-					break
-				}
-			}
-			logger.Debug("creating task")
-			opt := core.SetTaskID(taskID)
-			_, errs := w.taskManager.CreateTaskTribe(
-				getSchedule(taskResult.ScheduledTaskReturned.Schedule),
-				taskResult.Workflow,
-				startOnCreate,
-				opt)
-			if errs != nil && len(errs.Errors()) > 0 {
-				fields := log.Fields{}
-				for idx, e := range errs.Errors() {
-					fields[fmt.Sprintf("err-%d", idx)] = e
+					logger.WithFields(fields).Error("error starting task")
+					lastErr = errs[0]
+					continue
 				}
-				logger.WithFields(fields).Debug("error creating task")
-				continue
+				return nil
 			}
-			logger.Debugf("task created")
-			done = true
-			break
 		}
-		if done {
-			break
+		logger.Debug("creating task")
+		opt := core.SetTaskID(taskID)
+		_, errs := w.taskManager.CreateTaskTribe(
+			getSchedule(taskResult.ScheduledTaskReturned.Schedule),
+			taskResult.Workflow,
+			startOnCreate,
+			opt)
+		if errs != nil && len(errs.Errors()) > 0 {
+			fields := log.Fields{}
+			for idx, e := range errs.Errors() {
+				fields[fmt.Sprintf("err-%d", idx)] = e
+			}
+			logger.WithFields(fields).Debug("error creating task")
+			lastErr = errs.Errors()[0]
+			continue
 		}
-		time.Sleep(500 * time.Millisecond)
+		logger.Debugf("task created")
+		return nil
 	}
+	return fmt.Errorf("failed to create task %v: %v", taskID, lastErr)
 }
 
 func (w worker) startTask(taskID string) error {
@@ -27,6 +27,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	specaci "github.com/appc/spec/aci"
 	"github.com/appc/spec/schema"
@@ -45,6 +46,20 @@ var (
 	ErrNext = errors.New("Error iterating through tar file")
 	// ErrUntar - Error message for error untarring file
 	ErrUntar = errors.New("Error untarring file")
+	// ErrUnsafePath - Error message for a file outside of the extraction directory
+	ErrUnsafePath = errors.New("File path escapes the extraction directory")
+	// ErrLink - Error message for a symlink or hardlink in the archive
+	ErrLink = errors.New("Links are not allowed in plugin packages")
+	// ErrTooLarge - Error message for an archive over the extraction limits
+	ErrTooLarge = errors.New("Plugin package exceeds the extraction limits")
+)
+
+var (
+	// MaxExtractedSize is the most bytes extracted from a plugin package
+	MaxExtractedSize int64 = 1 << 30
+	// MaxEntries is the most files and directories extracted from a plugin
+	// package
+	MaxEntries = 10000
 )
 
 // Manifest returns the ImageManifest inside the ACI file
@@ -57,10 +72,13 @@ func Manifest(f io.ReadSeeker) (*schema.ImageManifest, error) {
 }
 
 // Extract expands the ACI file to a temporary directory, returning
-// the directory path where the ACI was expanded or an error
+// the directory path where the ACI was expanded or an error.
+// Only regular files and directories are extracted, header only entries like
+// pax global headers are skipped.  An error is returned, and nothing is left
+// behind, when a file would be written outside of the directory, for links,
+// and when the archive expands past MaxExtractedSize bytes or MaxEntries
+// files.
 func Extract(f io.ReadSeeker) (string, error) {
-	fileMode := os.FileMode(0755)
-
 	tr, err := specaci.NewCompressedTarReader(f)
 	if err != nil {
 		return "", err
@@ -72,41 +90,86 @@ func Extract(f io.ReadSeeker) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	for {
-		hdr, err := tr.Reader.Next()
+	if err := extract(tr.Reader, dir); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+func extract(tr *tar.Reader, dir string) error {
+	fileMode := os.FileMode(0755)
+	remaining := MaxExtractedSize
+	for entries := 0; ; entries++ {
+		hdr, err := tr.Next()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			return "", fmt.Errorf("%v\n%v", ErrNext, err)
+			return fmt.Errorf("%v\n%v", ErrNext, err)
+		}
+		if entries >= MaxEntries {
+			return fmt.Errorf("%v: more than %d entries", ErrTooLarge, MaxEntries)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeXGlobalHeader, tar.TypeXHeader, tar.TypeGNULongName, tar.TypeGNULongLink:
+			// header only entries have nothing to extract
+			continue
+		}
+		file, err := safeJoin(dir, hdr.Name)
+		if err != nil {
+			return err
 		}
-		file := filepath.Join(dir, hdr.Name)
 
 		switch hdr.Typeflag {
-		case tar.TypeReg:
-			w, err := os.Create(file)
-			if err != nil {
-				return "", fmt.Errorf("%v: %v\n%v", ErrCreatingFile, file, err)
+		case tar.TypeReg, tar.TypeRegA:
+			if hdr.Size > remaining {
+				return fmt.Errorf("%v: more than %d bytes", ErrTooLarge, MaxExtractedSize)
+			}
+			if err := os.MkdirAll(filepath.Dir(file), fileMode); err != nil {
+				return fmt.Errorf("%v: %v\n%v", ErrMkdirAll, file, err)
 			}
-			defer w.Close()
-			_, err = io.Copy(w, tr)
+			w, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, fileMode)
 			if err != nil {
-				return "", fmt.Errorf("%v: %v\n%v", ErrCopyingFile, file, err)
+				return fmt.Errorf("%v: %v\n%v", ErrCreatingFile, file, err)
+			}
+			// the header size is not trusted, at most one byte past the
+			// remaining budget is copied to detect an archive over the limit
+			n, err := io.CopyN(w, tr, remaining+1)
+			w.Close()
+			if err != nil && err != io.EOF {
+				return fmt.Errorf("%v: %v\n%v", ErrCopyingFile, file, err)
 			}
+			if n > remaining {
+				return fmt.Errorf("%v: more than %d bytes", ErrTooLarge, MaxExtractedSize)
+			}
+			remaining -= n
 			err = os.Chmod(file, fileMode)
 			if err != nil {
-				return "", fmt.Errorf("%v: %v\n%v", ErrChmod, file, err)
+				return fmt.Errorf("%v: %v\n%v", ErrChmod, file, err)
 			}
 		case tar.TypeDir:
 			err = os.MkdirAll(file, fileMode)
 			if err != nil {
-				return "", fmt.Errorf("%v: %v\n%v", ErrMkdirAll, file, err)
+				return fmt.Errorf("%v: %v\n%v", ErrMkdirAll, file, err)
 			}
+		case tar.TypeSymlink, tar.TypeLink:
+			return fmt.Errorf("%v: %v", ErrLink, hdr.Name)
 		default:
-			return "", fmt.Errorf("%v: %v", ErrUntar, hdr.Name)
+			return fmt.Errorf("%v: %v", ErrUntar, hdr.Name)
 		}
 	}
-	return dir, nil
+	return nil
+}
+
+// safeJoin joins the name of a file in an archive to the directory, returning
+// an error when the file would be outside of the directory
+func safeJoin(dir, name string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%v: %v", ErrUnsafePath, name)
+	}
+	return filepath.Join(dir, clean), nil
 }
 
 // Validate makes sure the archive is valid. Otherwise,
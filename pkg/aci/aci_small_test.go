@@ -0,0 +1,245 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type entry struct {
+	name     string
+	typeflag byte
+	body     string
+	linkname string
+}
+
+func archive(entries ...entry) *bytes.Reader {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for _, e := range entries {
+		hdr := &tar.Header{
+			Name:     e.name,
+			Typeflag: e.typeflag,
+			Mode:     0755,
+			Size:     int64(len(e.body)),
+			Linkname: e.linkname,
+		}
+		if e.typeflag != tar.TypeReg {
+			hdr.Size = 0
+		}
+		tw.WriteHeader(hdr)
+		if e.typeflag == tar.TypeReg {
+			tw.Write([]byte(e.body))
+		}
+	}
+	tw.Close()
+	gw.Close()
+	return bytes.NewReader(buf.Bytes())
+}
+
+func sum(s string) string {
+	b := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(b[:])
+}
+
+func TestExtract(t *testing.T) {
+	Convey("Extract", t, func() {
+		Convey("expands regular files and directories", func() {
+			dir, err := Extract(archive(
+				entry{name: "rootfs", typeflag: tar.TypeDir},
+				entry{name: "rootfs/bin/plugin", typeflag: tar.TypeReg, body: "plugin"},
+			))
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(dir)
+			b, err := ioutil.ReadFile(filepath.Join(dir, "rootfs", "bin", "plugin"))
+			So(err, ShouldBeNil)
+			So(string(b), ShouldEqual, "plugin")
+		})
+		Convey("returns an error for a path escaping the directory", func() {
+			_, err := Extract(archive(
+				entry{name: "rootfs/../../escaped", typeflag: tar.TypeReg, body: "x"},
+			))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, ErrUnsafePath.Error())
+		})
+		Convey("returns an error for an absolute path", func() {
+			_, err := Extract(archive(
+				entry{name: "/tmp/escaped", typeflag: tar.TypeReg, body: "x"},
+			))
+			So(err, ShouldNotBeNil)
+		})
+		Convey("returns an error for links", func() {
+			_, err := Extract(archive(
+				entry{name: "rootfs/link", typeflag: tar.TypeSymlink, linkname: "/etc"},
+			))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, ErrLink.Error())
+			_, err = Extract(archive(
+				entry{name: "rootfs/link", typeflag: tar.TypeLink, linkname: "/etc/passwd"},
+			))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, ErrLink.Error())
+		})
+		Convey("skips header only entries", func() {
+			dir, err := Extract(archive(
+				entry{name: "pax_global_header", typeflag: tar.TypeXGlobalHeader},
+				entry{name: "rootfs/a", typeflag: tar.TypeReg, body: "a"},
+			))
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(dir)
+			_, err = os.Stat(filepath.Join(dir, "pax_global_header"))
+			So(os.IsNotExist(err), ShouldBeTrue)
+			b, err := ioutil.ReadFile(filepath.Join(dir, "rootfs", "a"))
+			So(err, ShouldBeNil)
+			So(string(b), ShouldEqual, "a")
+		})
+		Convey("returns an error past the extraction limits", func() {
+			size, entries := MaxExtractedSize, MaxEntries
+			defer func() { MaxExtractedSize, MaxEntries = size, entries }()
+
+			MaxExtractedSize = 8
+			_, err := Extract(archive(
+				entry{name: "rootfs/a", typeflag: tar.TypeReg, body: "12345"},
+				entry{name: "rootfs/b", typeflag: tar.TypeReg, body: "12345"},
+			))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, ErrTooLarge.Error())
+
+			MaxExtractedSize, MaxEntries = size, 1
+			_, err = Extract(archive(
+				entry{name: "rootfs", typeflag: tar.TypeDir},
+				entry{name: "rootfs/a", typeflag: tar.TypeReg, body: "a"},
+			))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, ErrTooLarge.Error())
+		})
+	})
+}
+
+func TestSnapManifest(t *testing.T) {
+	Convey("Given a rootfs with a snap manifest", t, func() {
+		rootfs, err := ioutil.TempDir("", "rootfs")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(rootfs)
+		So(os.Mkdir(filepath.Join(rootfs, "bin"), 0755), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(rootfs, "bin", "plugin"), []byte("plugin"), 0755), ShouldBeNil)
+		m := &SnapManifest{
+			Type:            "collector",
+			Name:            "mock",
+			Version:         1,
+			RPCType:         "grpc",
+			MinSnapdVersion: "1.1.0",
+			Files:           map[string]string{"bin/plugin": sum("plugin")},
+		}
+		b, err := json.Marshal(m)
+		So(err, ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(rootfs, SnapManifestFile), b, 0644), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(rootfs, SnapManifestSignatureFile), []byte("signature"), 0644), ShouldBeNil)
+
+		Convey("ReadSnapManifest reads the manifest and its signature", func() {
+			rm, sig, err := ReadSnapManifest(rootfs)
+			So(err, ShouldBeNil)
+			So(rm, ShouldResemble, m)
+			So(string(sig), ShouldEqual, "signature")
+		})
+		Convey("SnapManifestFromImage reads them without extracting the image", func() {
+			b, err := json.Marshal(m)
+			So(err, ShouldBeNil)
+			raw, sig, err := SnapManifestFromImage(archive(
+				entry{name: "manifest", typeflag: tar.TypeReg, body: "{}"},
+				entry{name: "rootfs/" + SnapManifestFile, typeflag: tar.TypeReg, body: string(b)},
+				entry{name: "./rootfs/" + SnapManifestSignatureFile, typeflag: tar.TypeReg, body: "signature"},
+			))
+			So(err, ShouldBeNil)
+			So(raw, ShouldResemble, b)
+			So(string(sig), ShouldEqual, "signature")
+
+			_, _, err = SnapManifestFromImage(archive(
+				entry{name: "rootfs/" + SnapManifestFile, typeflag: tar.TypeReg, body: string(b)},
+				entry{name: "rootfs/" + SnapManifestFile, typeflag: tar.TypeReg, body: "{}"},
+			))
+			So(err, ShouldNotBeNil)
+
+			raw, sig, err = SnapManifestFromImage(archive(
+				entry{name: "rootfs/bin/plugin", typeflag: tar.TypeReg, body: "plugin"},
+			))
+			So(err, ShouldBeNil)
+			So(raw, ShouldBeNil)
+			So(sig, ShouldBeNil)
+		})
+		Convey("CheckExec compares against the exec of the manifest", func() {
+			m.Exec = "/bin/plugin"
+			So(m.CheckExec("/bin/plugin"), ShouldBeNil)
+			So(m.CheckExec("/bin/other"), ShouldNotBeNil)
+		})
+		Convey("Verify passes for matching files", func() {
+			So(m.Verify(rootfs), ShouldBeNil)
+		})
+		Convey("Verify returns an error for a modified file", func() {
+			So(ioutil.WriteFile(filepath.Join(rootfs, "bin", "plugin"), []byte("modified"), 0755), ShouldBeNil)
+			err := m.Verify(rootfs)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, ErrFileCheckSum.Error())
+		})
+		Convey("Verify returns an error for a file not listed", func() {
+			So(ioutil.WriteFile(filepath.Join(rootfs, "bin", "extra"), []byte("extra"), 0755), ShouldBeNil)
+			err := m.Verify(rootfs)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, ErrUnlistedFile.Error())
+		})
+		Convey("Verify returns an error for a missing file", func() {
+			So(os.Remove(filepath.Join(rootfs, "bin", "plugin")), ShouldBeNil)
+			err := m.Verify(rootfs)
+			So(err, ShouldNotBeNil)
+			So(strings.Contains(err.Error(), "bin/plugin"), ShouldBeTrue)
+		})
+		Convey("CheckSnapdVersion compares against the minimum version", func() {
+			So(m.CheckSnapdVersion("1.1.0"), ShouldBeNil)
+			So(m.CheckSnapdVersion("v1.2.0-10-gabcdef"), ShouldBeNil)
+			So(m.CheckSnapdVersion("2.0"), ShouldBeNil)
+			So(m.CheckSnapdVersion("unknown"), ShouldBeNil)
+			So(m.CheckSnapdVersion("1.0.9"), ShouldNotBeNil)
+		})
+	})
+	Convey("Given a rootfs without a snap manifest", t, func() {
+		rootfs, err := ioutil.TempDir("", "rootfs")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(rootfs)
+		m, sig, err := ReadSnapManifest(rootfs)
+		So(err, ShouldBeNil)
+		So(m, ShouldBeNil)
+		So(sig, ShouldBeNil)
+	})
+}
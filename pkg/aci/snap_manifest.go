@@ -0,0 +1,280 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aci
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	specaci "github.com/appc/spec/aci"
+)
+
+const (
+	// SnapManifestFile is the snap manifest of a plugin package, relative to
+	// the rootfs of the package
+	SnapManifestFile = "snap-manifest.json"
+	// SnapManifestSignatureFile is the armored detached signature of the
+	// snap manifest, relative to the rootfs of the package
+	SnapManifestSignatureFile = SnapManifestFile + ".asc"
+)
+
+var (
+	// ErrFileCheckSum - Error message for a file whose checksum does not match the snap manifest
+	ErrFileCheckSum = errors.New("CheckSum mismatch on a file of the plugin package")
+	// ErrUnlistedFile - Error message for a file not listed in the snap manifest
+	ErrUnlistedFile = errors.New("File of the plugin package not listed in the snap manifest")
+	// ErrSnapdVersion - Error message for a plugin package requiring a newer snapd
+	ErrSnapdVersion = errors.New("Plugin package requires a newer version of snapd")
+	// ErrExecMismatch - Error message for an image manifest whose exec does not match the snap manifest
+	ErrExecMismatch = errors.New("Exec of the plugin package does not match the snap manifest")
+)
+
+// maxSnapManifestSize is the most bytes read from the snap manifest or its
+// signature in a plugin package
+const maxSnapManifestSize = 1 << 20
+
+// SnapManifest is the snap specific metadata of a plugin package.  It
+// describes the plugin and lists the sha256 checksum of every file of the
+// package, so signing the manifest signs the whole package.
+type SnapManifest struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+	// RPCType is the RPC type of the plugin (native, jsonrpc or grpc)
+	RPCType string `json:"rpc_type,omitempty"`
+	// RequiredConfig are the config items the plugin requires
+	RequiredConfig []string `json:"required_config,omitempty"`
+	// MinSnapdVersion is the oldest version of snapd the plugin runs on
+	MinSnapdVersion string `json:"min_snapd_version,omitempty"`
+	// Exec is the program the image manifest runs.  The image manifest is
+	// outside of the rootfs so the exec is repeated here to be signed.
+	Exec string `json:"exec,omitempty"`
+	// Files maps the files of the package, relative to the rootfs, to their
+	// hex encoded sha256 checksum
+	Files map[string]string `json:"files"`
+}
+
+// ReadSnapManifest reads the snap manifest and its signature from the rootfs
+// of an extracted plugin package.  A nil manifest is returned for a package
+// without a snap manifest and a nil signature for an unsigned manifest.
+func ReadSnapManifest(rootfs string) (*SnapManifest, []byte, error) {
+	b, err := ioutil.ReadFile(filepath.Join(rootfs, SnapManifestFile))
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	m, err := ParseSnapManifest(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	signature, err := ioutil.ReadFile(filepath.Join(rootfs, SnapManifestSignatureFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+	return m, signature, nil
+}
+
+// SnapManifestFromImage returns the snap manifest, as stored, and its
+// signature from a plugin package without extracting it so the signature can
+// be verified before anything is written.  A nil manifest is returned for a
+// package without a snap manifest and a nil signature for an unsigned
+// manifest.
+func SnapManifestFromImage(f io.ReadSeeker) ([]byte, []byte, error) {
+	tr, err := specaci.NewCompressedTarReader(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tr.Close()
+
+	files := map[string][]byte{
+		"rootfs/" + SnapManifestFile:          nil,
+		"rootfs/" + SnapManifestSignatureFile: nil,
+	}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%v\n%v", ErrNext, err)
+		}
+		name := strings.TrimPrefix(filepath.ToSlash(filepath.Clean("/"+hdr.Name)), "/")
+		b, ok := files[name]
+		if !ok {
+			continue
+		}
+		if b != nil {
+			return nil, nil, fmt.Errorf("%v: %v is duplicated", ErrUntar, name)
+		}
+		var buf bytes.Buffer
+		n, err := io.CopyN(&buf, tr, maxSnapManifestSize+1)
+		if err != nil && err != io.EOF {
+			return nil, nil, fmt.Errorf("%v: %v\n%v", ErrCopyingFile, name, err)
+		}
+		if n > maxSnapManifestSize {
+			return nil, nil, fmt.Errorf("%v: %v is larger than %d bytes", ErrTooLarge, name, maxSnapManifestSize)
+		}
+		files[name] = buf.Bytes()
+		if files[name] == nil {
+			files[name] = []byte{}
+		}
+	}
+	manifest := files["rootfs/"+SnapManifestFile]
+	if manifest == nil {
+		return nil, nil, nil
+	}
+	return manifest, files["rootfs/"+SnapManifestSignatureFile], nil
+}
+
+// ParseSnapManifest parses a snap manifest
+func ParseSnapManifest(b []byte) (*SnapManifest, error) {
+	m := &SnapManifest{}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("Error reading the snap manifest: %v", err)
+	}
+	return m, nil
+}
+
+// CheckExec returns an error when the exec of the image manifest is not the
+// one of the snap manifest
+func (m *SnapManifest) CheckExec(exec string) error {
+	if m.Exec != exec {
+		return fmt.Errorf("%v: %v (snap manifest: %v)", ErrExecMismatch, exec, m.Exec)
+	}
+	return nil
+}
+
+// Verify checks the files of the extracted rootfs against the checksums of
+// the manifest.  An error is returned when a checksum does not match and when
+// a file of the rootfs is not listed.
+func (m *SnapManifest) Verify(rootfs string) error {
+	seen := map[string]bool{}
+	err := filepath.Walk(rootfs, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(rootfs, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == SnapManifestFile || rel == SnapManifestSignatureFile {
+			return nil
+		}
+		sum, ok := m.Files[rel]
+		if !ok {
+			return fmt.Errorf("%v: %v", ErrUnlistedFile, rel)
+		}
+		if err := checkFile(path, sum); err != nil {
+			return fmt.Errorf("%v: %v", err, rel)
+		}
+		seen[rel] = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for f := range m.Files {
+		if !seen[f] {
+			return fmt.Errorf("File listed in the snap manifest is missing from the plugin package: %v", f)
+		}
+	}
+	return nil
+}
+
+// CheckSnapdVersion returns an error when the version of snapd is older than
+// the minimum version of the manifest.  Versions which can not be compared,
+// like the version of a development build, pass the check.
+func (m *SnapManifest) CheckSnapdVersion(version string) error {
+	if m.MinSnapdVersion == "" {
+		return nil
+	}
+	have, ok := parseVersion(version)
+	if !ok {
+		return nil
+	}
+	want, ok := parseVersion(m.MinSnapdVersion)
+	if !ok {
+		return nil
+	}
+	for i := range want {
+		if have[i] != want[i] {
+			if have[i] < want[i] {
+				return fmt.Errorf("%v: %v < %v", ErrSnapdVersion, version, m.MinSnapdVersion)
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// parseVersion parses the major, minor and patch numbers of a version like
+// 1.2.3, v1.2 or 1.2.3-10-gabcdef
+func parseVersion(v string) ([3]int, bool) {
+	var version [3]int
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+ "); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) > 3 {
+		return version, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return version, false
+		}
+		version[i] = n
+	}
+	return version, true
+}
+
+func checkFile(path, sum string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	if !strings.EqualFold(hex.EncodeToString(h.Sum(nil)), sum) {
+		return ErrFileCheckSum
+	}
+	return nil
+}
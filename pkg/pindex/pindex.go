@@ -0,0 +1,239 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pindex reads plugin indexes and fetches the plugins they list.
+//
+// A plugin index is a static JSON or YAML catalog of plugins.  Each plugin is
+// listed with its type, name and version, the location of its binary (a URL
+// or a path), the sha256 checksum of the binary and optionally the location
+// of the armored detached signature of the binary.  An index is read from an
+// http(s) URL, from a file or from a local directory holding an index.json,
+// index.yaml or index.yml file, and the relative locations it lists are
+// resolved against the location of the index, so a local directory index
+// works offline.
+package pindex
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+var (
+	// ErrPluginNotFound - Error message for a plugin not listed in the index
+	ErrPluginNotFound = errors.New("Plugin not found in the index")
+	// ErrNoIndexFile - Error message for a directory without an index file
+	ErrNoIndexFile = errors.New("No index.json, index.yaml or index.yml file in the directory")
+	// ErrCheckSum - Error message for a plugin whose checksum does not match the index
+	ErrCheckSum = errors.New("CheckSum mismatch on the plugin fetched from the index")
+	// ErrNoLocation - Error message for a plugin listed without a url or a path
+	ErrNoLocation = errors.New("Plugin listed without a url or a path")
+)
+
+// indexFiles are the files an index is read from in a local directory
+var indexFiles = []string{"index.json", "index.yaml", "index.yml"}
+
+// Index is a catalog of plugins
+type Index struct {
+	Plugins []Entry `json:"plugins"`
+	// location is the URL or the file the index was read from
+	location string
+}
+
+// Entry is a plugin listed in an index
+type Entry struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Version int    `json:"version"`
+	// URL is the http(s) location of the plugin binary
+	URL string `json:"url,omitempty"`
+	// Path is the file of the plugin binary, relative to the directory of a
+	// local index
+	Path string `json:"path,omitempty"`
+	// CheckSum is the hex encoded sha256 checksum of the plugin binary
+	CheckSum string `json:"checksum"`
+	// Signature is the URL or the path of the armored detached signature of
+	// the plugin binary
+	Signature string `json:"signature,omitempty"`
+}
+
+// Read reads the index at the location, an http(s) URL, a file or a directory
+func Read(location string) (*Index, error) {
+	if isURL(location) {
+		b, err := get(location)
+		if err != nil {
+			return nil, err
+		}
+		return parse(location, b)
+	}
+	fi, err := os.Stat(location)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		for _, f := range indexFiles {
+			file := filepath.Join(location, f)
+			if _, err := os.Stat(file); err == nil {
+				return Read(file)
+			}
+		}
+		return nil, fmt.Errorf("%v: %v", ErrNoIndexFile, location)
+	}
+	b, err := ioutil.ReadFile(location)
+	if err != nil {
+		return nil, err
+	}
+	return parse(location, b)
+}
+
+// parse unmarshals the index read from the location.  YAML is a superset of
+// JSON so both are parsed as YAML.
+func parse(location string, b []byte) (*Index, error) {
+	idx := &Index{location: location}
+	if err := yaml.Unmarshal(b, idx); err != nil {
+		return nil, fmt.Errorf("Error reading the plugin index %v: %v", location, err)
+	}
+	return idx, nil
+}
+
+// Location returns the URL or the file the index was read from
+func (i *Index) Location() string {
+	return i.location
+}
+
+// Find returns the plugin of the type, name and version listed in the index.
+// The highest version listed is returned when the version is less than 1.
+func (i *Index) Find(pluginType, name string, version int) (*Entry, error) {
+	var found *Entry
+	for n, e := range i.Plugins {
+		if e.Type != pluginType || e.Name != name {
+			continue
+		}
+		if version > 0 && e.Version != version {
+			continue
+		}
+		if found == nil || e.Version > found.Version {
+			found = &i.Plugins[n]
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%v: %s/%s@%d", ErrPluginNotFound, pluginType, name, version)
+	}
+	return found, nil
+}
+
+// Fetch writes the binary of the plugin listed in the index to the directory
+// and returns its path with the signature of the plugin, nil when the plugin
+// is listed without one.  An error is returned when the checksum of the
+// binary does not match the one listed.
+func (i *Index) Fetch(e *Entry, dir string) (string, []byte, error) {
+	loc := e.Path
+	if e.URL != "" {
+		loc = e.URL
+	}
+	if loc == "" {
+		return "", nil, fmt.Errorf("%v: %s/%s@%d", ErrNoLocation, e.Type, e.Name, e.Version)
+	}
+	b, err := i.read(loc)
+	if err != nil {
+		return "", nil, err
+	}
+	sum := sha256.Sum256(b)
+	if !strings.EqualFold(hex.EncodeToString(sum[:]), e.CheckSum) {
+		return "", nil, fmt.Errorf("%v: %s/%s@%d", ErrCheckSum, e.Type, e.Name, e.Version)
+	}
+	var signature []byte
+	if e.Signature != "" {
+		if signature, err = i.read(e.Signature); err != nil {
+			return "", nil, err
+		}
+	}
+
+	// the extension is kept so plugin packages (.aci) are recognized on load
+	ext := filepath.Ext(loc)
+	if isURL(loc) {
+		if u, err := url.Parse(loc); err == nil {
+			ext = path.Ext(u.Path)
+		}
+	}
+	file := filepath.Join(dir, fmt.Sprintf("snap-plugin-%s-%s%s", e.Type, e.Name, ext))
+	if err := ioutil.WriteFile(file, b, 0700); err != nil {
+		return "", nil, err
+	}
+	if runtime.GOOS != "windows" {
+		// the mode given to WriteFile is subject to the umask
+		if err := os.Chmod(file, 0700); err != nil {
+			return "", nil, err
+		}
+	}
+	return file, signature, nil
+}
+
+// read reads the URL or the path resolved against the location of the index
+func (i *Index) read(loc string) ([]byte, error) {
+	if isURL(loc) {
+		return get(loc)
+	}
+	if isURL(i.location) {
+		base, err := url.Parse(i.location)
+		if err != nil {
+			return nil, err
+		}
+		ref, err := url.Parse(loc)
+		if err != nil {
+			return nil, err
+		}
+		return get(base.ResolveReference(ref).String())
+	}
+	if !filepath.IsAbs(loc) {
+		loc = filepath.Join(filepath.Dir(i.location), loc)
+	}
+	return ioutil.ReadFile(loc)
+}
+
+func isURL(loc string) bool {
+	return strings.HasPrefix(loc, "http://") || strings.HasPrefix(loc, "https://")
+}
+
+func get(u string) ([]byte, error) {
+	resp, err := http.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Error fetching %v: %v", u, resp.Status)
+	}
+	return ioutil.ReadAll(io.LimitReader(resp.Body, maxFetchSize))
+}
+
+// maxFetchSize is the most bytes read from a URL
+const maxFetchSize = 1 << 30
@@ -0,0 +1,161 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pindex
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var (
+	pluginBinary = []byte("#!/bin/sh\necho mock\n")
+	pluginAsc    = []byte("-----BEGIN PGP SIGNATURE-----\n")
+)
+
+func checkSum(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func yamlIndex(sum string) string {
+	return fmt.Sprintf(`plugins:
+- name: mock
+  type: collector
+  version: 1
+  path: bin/snap-plugin-collector-mock
+  checksum: %s
+- name: mock
+  type: collector
+  version: 2
+  path: bin/snap-plugin-collector-mock
+  checksum: %s
+  signature: bin/snap-plugin-collector-mock.asc
+- name: file
+  type: publisher
+  version: 3
+  path: bin/snap-plugin-collector-mock
+  checksum: 0000
+`, sum, sum)
+}
+
+func TestPluginIndex(t *testing.T) {
+	Convey("Given a local directory plugin index", t, func() {
+		dir, err := ioutil.TempDir("", "pindex")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		So(os.Mkdir(filepath.Join(dir, "bin"), 0755), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "bin", "snap-plugin-collector-mock"), pluginBinary, 0644), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "bin", "snap-plugin-collector-mock.asc"), pluginAsc, 0644), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "index.yaml"), []byte(yamlIndex(checkSum(pluginBinary))), 0644), ShouldBeNil)
+
+		idx, err := Read(dir)
+		So(err, ShouldBeNil)
+		So(idx.Plugins, ShouldHaveLength, 3)
+		So(idx.Location(), ShouldEqual, filepath.Join(dir, "index.yaml"))
+
+		Convey("Find returns the version requested", func() {
+			e, err := idx.Find("collector", "mock", 1)
+			So(err, ShouldBeNil)
+			So(e.Version, ShouldEqual, 1)
+		})
+		Convey("Find returns the latest version when none is requested", func() {
+			e, err := idx.Find("collector", "mock", 0)
+			So(err, ShouldBeNil)
+			So(e.Version, ShouldEqual, 2)
+		})
+		Convey("Find returns an error for a plugin not listed", func() {
+			_, err := idx.Find("collector", "mock", 3)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, ErrPluginNotFound.Error())
+		})
+		Convey("Fetch writes the plugin and reads its signature", func() {
+			out, err := ioutil.TempDir("", "pindex-out")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(out)
+			e, _ := idx.Find("collector", "mock", 2)
+			path, sig, err := idx.Fetch(e, out)
+			So(err, ShouldBeNil)
+			So(sig, ShouldResemble, pluginAsc)
+			b, err := ioutil.ReadFile(path)
+			So(err, ShouldBeNil)
+			So(b, ShouldResemble, pluginBinary)
+		})
+		Convey("Fetch returns an error on a checksum mismatch", func() {
+			out, err := ioutil.TempDir("", "pindex-out")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(out)
+			e, _ := idx.Find("publisher", "file", 3)
+			_, _, err = idx.Fetch(e, out)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, ErrCheckSum.Error())
+		})
+	})
+	Convey("Given a directory without an index file", t, func() {
+		dir, err := ioutil.TempDir("", "pindex")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		_, err = Read(dir)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldStartWith, ErrNoIndexFile.Error())
+	})
+	Convey("Given an HTTP plugin index", t, func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/index.json", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"plugins": [{"name": "mock", "type": "collector", "version": 1, "url": "bin/snap-plugin-collector-mock", "checksum": "%s"}]}`, checkSum(pluginBinary))
+		})
+		mux.HandleFunc("/bin/snap-plugin-collector-mock", func(w http.ResponseWriter, r *http.Request) {
+			w.Write(pluginBinary)
+		})
+		ts := httptest.NewServer(mux)
+		defer ts.Close()
+
+		idx, err := Read(ts.URL + "/index.json")
+		So(err, ShouldBeNil)
+		e, err := idx.Find("collector", "mock", 0)
+		So(err, ShouldBeNil)
+
+		Convey("Fetch resolves the plugin URL against the index URL", func() {
+			out, err := ioutil.TempDir("", "pindex-out")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(out)
+			path, sig, err := idx.Fetch(e, out)
+			So(err, ShouldBeNil)
+			So(sig, ShouldBeNil)
+			b, err := ioutil.ReadFile(path)
+			So(err, ShouldBeNil)
+			So(b, ShouldResemble, pluginBinary)
+		})
+		Convey("Read returns an error for an index not found", func() {
+			_, err := Read(ts.URL + "/missing.json")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,200 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package psigning
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+// oidEd25519 is the algorithm of an Ed25519 PKIX public key (RFC 8410)
+var oidEd25519 = asn1.ObjectIdentifier{1, 3, 101, 112}
+
+// pkixPublicKey is the ASN.1 structure of a PKIX public key
+type pkixPublicKey struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// Ed25519Verifier verifies raw Ed25519 signatures, binary or base64 encoded,
+// against a set of named public keys
+type Ed25519Verifier struct {
+	keySet
+}
+
+// NewEd25519Verifier returns an Ed25519Verifier for the public keys of the
+// paths.  A path is a key file or a directory of key files.  A key file holds
+// a PEM (PKIX) public key, a base64 encoded key or a binary key, and the name
+// of the key is the name of the file without its extension.
+func NewEd25519Verifier(paths []string) (*Ed25519Verifier, error) {
+	v := &Ed25519Verifier{}
+	for _, p := range paths {
+		if err := v.AddKeyPath(p); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// AddKeyPath adds the public key of the key file or the public keys of the
+// key files of the directory
+func (v *Ed25519Verifier) AddKeyPath(path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	files := []string{path}
+	if fi.IsDir() {
+		infos, err := ioutil.ReadDir(path)
+		if err != nil {
+			return err
+		}
+		files = files[:0]
+		for _, i := range infos {
+			if !i.IsDir() {
+				files = append(files, filepath.Join(path, i.Name()))
+			}
+		}
+	}
+	for _, f := range files {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		key, err := parseEd25519Key(b)
+		if err != nil {
+			return fmt.Errorf("%v: %v", err, f)
+		}
+		name := strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))
+		if _, err := v.add(ed25519Key(name, f, key), key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddKey adds the public key under the name
+func (v *Ed25519Verifier) AddKey(name string, key []byte) ([]Key, error) {
+	if name == "" {
+		return nil, errors.New("An Ed25519 key needs a name")
+	}
+	pub, err := parseEd25519Key(key)
+	if err != nil {
+		return nil, err
+	}
+	k, err := v.add(ed25519Key(name, name, pub), pub)
+	if err != nil {
+		return nil, err
+	}
+	return []Key{k}, nil
+}
+
+// Keys returns the keys of the verifier
+func (v *Ed25519Verifier) Keys() []Key {
+	return v.list()
+}
+
+// RevokeKey revokes the key of the id
+func (v *Ed25519Verifier) RevokeKey(id string) bool {
+	return v.revoke(id)
+}
+
+// Scheme returns the signature scheme of the verifier
+func (v *Ed25519Verifier) Scheme() string {
+	return SchemeEd25519
+}
+
+// Supports returns whether the signature is an Ed25519 signature
+func (v *Ed25519Verifier) Supports(signature []byte) bool {
+	return decodeEd25519Signature(signature) != nil
+}
+
+// Verify verifies the signature of the file against the keys of the verifier
+// which are not revoked and returns its signer
+func (v *Ed25519Verifier) Verify(signedFile string, signature []byte) (*Signer, error) {
+	sig := decodeEd25519Signature(signature)
+	if sig == nil {
+		return nil, ErrUnsupportedSignature
+	}
+	b, err := ioutil.ReadFile(signedFile)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v\n%v", ErrSignedFileNotFound, signedFile, err)
+	}
+	// keys are tried in the order they were added so the signer is the
+	// same across calls
+	for _, k := range v.trusted() {
+		if ed25519.Verify(k.value.(ed25519.PublicKey), b, sig) {
+			return &Signer{Scheme: SchemeEd25519, Identity: k.Identity, KeyID: k.ID}, nil
+		}
+	}
+	return nil, fmt.Errorf("%v\nno Ed25519 key matches the signature", ErrCheckSignature)
+}
+
+func ed25519Key(name, source string, key ed25519.PublicKey) Key {
+	return Key{
+		ID:       keyID(key),
+		Scheme:   SchemeEd25519,
+		Identity: name,
+		Source:   source,
+	}
+}
+
+func decodeEd25519Signature(signature []byte) []byte {
+	if len(signature) == ed25519.SignatureSize {
+		return signature
+	}
+	b, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature)))
+	if err != nil || len(b) != ed25519.SignatureSize {
+		return nil
+	}
+	return b
+}
+
+func parseEd25519Key(b []byte) (ed25519.PublicKey, error) {
+	if p, _ := pem.Decode(b); p != nil {
+		var key pkixPublicKey
+		if _, err := asn1.Unmarshal(p.Bytes, &key); err != nil {
+			return nil, fmt.Errorf("Error reading Ed25519 key: %v", err)
+		}
+		if !key.Algorithm.Algorithm.Equal(oidEd25519) || len(key.PublicKey.Bytes) != ed25519.PublicKeySize {
+			return nil, errors.New("Not an Ed25519 key")
+		}
+		return ed25519.PublicKey(key.PublicKey.Bytes), nil
+	}
+	if len(b) == ed25519.PublicKeySize {
+		return ed25519.PublicKey(b), nil
+	}
+	k, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(b)))
+	if err != nil || len(k) != ed25519.PublicKeySize {
+		return nil, errors.New("Not an Ed25519 key")
+	}
+	return ed25519.PublicKey(k), nil
+}
@@ -0,0 +1,158 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package psigning
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrKeyRevoked - Error message for adding a key which was revoked
+	ErrKeyRevoked = errors.New("Key was revoked")
+	// ErrNoKey - Error message for key material without any key
+	ErrNoKey = errors.New("No key found")
+)
+
+// Key describes a key trusted by a verifier
+type Key struct {
+	// ID identifies the key across the verifiers
+	ID string `json:"id"`
+	// Scheme is the signature scheme of the key
+	Scheme string `json:"scheme"`
+	// Identity is the identity of the signers of the key, as in Signer
+	Identity string `json:"identity"`
+	// Source is the file the key was read from, or the name the key was
+	// added with
+	Source string `json:"source"`
+	// Revoked is whether the key was revoked
+	Revoked bool `json:"revoked"`
+}
+
+// KeyStore is a Verifier whose trusted keys can be listed, added and revoked
+// at runtime.  Revoked keys are still listed but no longer verify signatures.
+type KeyStore interface {
+	Verifier
+	// Keys returns the keys of the verifier
+	Keys() []Key
+	// AddKey adds the keys of the key material, returning the keys added
+	AddKey(name string, key []byte) ([]Key, error)
+	// RevokeKey revokes the key of the id, returning whether the verifier
+	// has the key
+	RevokeKey(id string) bool
+}
+
+// keyID returns the id of the key of the bytes: the first 8 bytes of their
+// SHA256 sum
+func keyID(b []byte) string {
+	sum := sha256.Sum256(b)
+	return fmt.Sprintf("%X", sum[:8])
+}
+
+type trustedKey struct {
+	Key
+	value interface{}
+}
+
+// keySet holds the keys of a verifier
+type keySet struct {
+	mutex sync.RWMutex
+	keys  []*trustedKey
+}
+
+// add adds the key unless the set already has it.  A revoked key can not be
+// added back.
+func (s *keySet) add(k Key, value interface{}) (Key, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, t := range s.keys {
+		if t.ID == k.ID {
+			if t.Revoked {
+				return t.Key, fmt.Errorf("%v: %v", ErrKeyRevoked, k.ID)
+			}
+			return t.Key, nil
+		}
+	}
+	s.keys = append(s.keys, &trustedKey{Key: k, value: value})
+	return k, nil
+}
+
+// replace replaces the keys of the source with keys.  The revoked keys are
+// kept so they can not be added back.
+func (s *keySet) replace(source string, keys []*trustedKey) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	kept := make([]*trustedKey, 0, len(s.keys)+len(keys))
+	for _, t := range s.keys {
+		if t.Source != source || t.Revoked {
+			kept = append(kept, t)
+		}
+	}
+	for _, k := range keys {
+		found := false
+		for _, t := range kept {
+			if t.ID == k.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			kept = append(kept, k)
+		}
+	}
+	s.keys = kept
+}
+
+func (s *keySet) list() []Key {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	keys := make([]Key, len(s.keys))
+	for i, t := range s.keys {
+		keys[i] = t.Key
+	}
+	return keys
+}
+
+// trusted returns the keys which are not revoked
+func (s *keySet) trusted() []*trustedKey {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	var keys []*trustedKey
+	for _, t := range s.keys {
+		if !t.Revoked {
+			keys = append(keys, t)
+		}
+	}
+	return keys
+}
+
+func (s *keySet) revoke(id string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, t := range s.keys {
+		if t.ID == id {
+			t.Revoked = true
+			return true
+		}
+	}
+	return false
+}
@@ -47,13 +47,27 @@ var (
 
 //ValidateSignature is exported for plugin authoring
 func (s *SigningManager) ValidateSignature(keyringFiles []string, signedFile string, signature []byte) error {
+	checked, err := checkOpenPGPSignature(keyringFiles, signedFile, signature)
+	if err != nil {
+		return err
+	}
 	var signedby string
+	for k := range checked.Identities {
+		signedby = signedby + k
+	}
+	fmt.Printf("Signature made %v using RSA key ID %v\nGood signature from %v\n", time.Now().Format(time.RFC1123), checked.PrimaryKey.KeyIdShortString(), signedby)
+	return nil
+}
+
+// checkOpenPGPSignature checks the armored detached signature of the file
+// against the keyring files, returning the entity which signed the file
+func checkOpenPGPSignature(keyringFiles []string, signedFile string, signature []byte) (*openpgp.Entity, error) {
 	var e error
 	var checked *openpgp.Entity
 
 	signed, err := os.Open(signedFile)
 	if err != nil {
-		return fmt.Errorf("%v: %v\n%v", ErrSignedFileNotFound, signedFile, err)
+		return nil, fmt.Errorf("%v: %v\n%v", ErrSignedFileNotFound, signedFile, err)
 	}
 	defer signed.Close()
 
@@ -61,7 +75,7 @@ func (s *SigningManager) ValidateSignature(keyringFiles []string, signedFile str
 	for _, keyringFile := range keyringFiles {
 		keyringf, err := os.Open(keyringFile)
 		if err != nil {
-			return fmt.Errorf("%v: %v\n%v", ErrKeyringFileNotFound, keyringFile, err)
+			return nil, fmt.Errorf("%v: %v\n%v", ErrKeyringFileNotFound, keyringFile, err)
 		}
 		defer keyringf.Close()
 
@@ -71,20 +85,16 @@ func (s *SigningManager) ValidateSignature(keyringFiles []string, signedFile str
 			keyringf.Seek(0, 0)
 			keyring, err = openpgp.ReadKeyRing(keyringf)
 			if err != nil {
-				return fmt.Errorf("%v: %v\n%v", ErrUnableToReadKeyring, keyringFile, err)
+				return nil, fmt.Errorf("%v: %v\n%v", ErrUnableToReadKeyring, keyringFile, err)
 			}
 		}
 
 		//Check the armored detached signature
 		checked, e = openpgp.CheckArmoredDetachedSignature(keyring, signed, bytes.NewReader(signature))
 		if e == nil {
-			for k := range checked.Identities {
-				signedby = signedby + k
-			}
-			fmt.Printf("Signature made %v using RSA key ID %v\nGood signature from %v\n", time.Now().Format(time.RFC1123), checked.PrimaryKey.KeyIdShortString(), signedby)
-			return nil
+			return checked, nil
 		}
 		signed.Seek(0, 0)
 	}
-	return fmt.Errorf("%v\n%v", ErrCheckSignature, e)
+	return nil, fmt.Errorf("%v\n%v", ErrCheckSignature, e)
 }
@@ -0,0 +1,55 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package psigning
+
+import (
+	"path"
+)
+
+// TrustPolicyDefault is the key of the signers of the plugin types without
+// their own entry in a TrustPolicy
+const TrustPolicyDefault = "*"
+
+// TrustPolicy maps plugin types (collector, processor, publisher or "*" for
+// the other types) to the signers allowed to sign plugins of the type.
+// Signers are matched as "<scheme>:<identity>" against patterns with the
+// syntax of path.Match, for example "x509:CN=Acme Code Signing*" or
+// "ed25519:release".  Plugins of a type without signers are not restricted.
+type TrustPolicy map[string][]string
+
+// Allows returns whether the signer may sign plugins of the type
+func (t TrustPolicy) Allows(pluginType string, signer *Signer) bool {
+	patterns, ok := t[pluginType]
+	if !ok {
+		patterns, ok = t[TrustPolicyDefault]
+	}
+	if !ok || len(patterns) == 0 {
+		return true
+	}
+	if signer == nil {
+		return false
+	}
+	for _, p := range patterns {
+		if m, err := path.Match(p, signer.String()); err == nil && m {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,240 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package psigning
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+
+	"golang.org/x/crypto/openpgp"
+)
+
+const (
+	// SchemeOpenPGP is the scheme of armored detached OpenPGP signatures
+	SchemeOpenPGP = "openpgp"
+	// SchemeX509 is the scheme of signatures made with X.509 code signing
+	// certificates
+	SchemeX509 = "x509"
+	// SchemeEd25519 is the scheme of raw Ed25519 signatures
+	SchemeEd25519 = "ed25519"
+)
+
+var (
+	// ErrUnsupportedSignature - Error message for a signature no verifier supports
+	ErrUnsupportedSignature = errors.New("No verifier supports the signature")
+)
+
+// Signer identifies who signed a file
+type Signer struct {
+	// Scheme is the signature scheme (openpgp, x509 or ed25519)
+	Scheme string
+	// Identity is the identity of the signer in the scheme: the user IDs of
+	// the OpenPGP key, the subject of the X.509 certificate or the name of
+	// the Ed25519 key
+	Identity string
+	// KeyID is the id of the trusted key the signature was verified with:
+	// the OpenPGP key, the root CA certificate or the Ed25519 key
+	KeyID string
+}
+
+func (s *Signer) String() string {
+	return s.Scheme + ":" + s.Identity
+}
+
+// Verifier verifies the detached signatures of a signature scheme
+type Verifier interface {
+	// Scheme returns the signature scheme of the verifier
+	Scheme() string
+	// Supports returns whether the signature is in the format of the scheme
+	Supports(signature []byte) bool
+	// Verify verifies the signature of the file and returns its signer
+	Verify(signedFile string, signature []byte) (*Signer, error)
+}
+
+// Verify verifies the signature of the file with the first verifier which
+// supports the format of the signature
+func Verify(verifiers []Verifier, signedFile string, signature []byte) (*Signer, error) {
+	for _, v := range verifiers {
+		if v.Supports(signature) {
+			return v.Verify(signedFile, signature)
+		}
+	}
+	return nil, ErrUnsupportedSignature
+}
+
+// OpenPGPVerifier verifies armored detached OpenPGP signatures against the
+// keys of keyrings.  The keyring files are read again for each verification
+// so a key added to or removed from a keyring file is taken into account
+// without restarting snapd.
+type OpenPGPVerifier struct {
+	keySet
+	filesMutex   sync.Mutex
+	keyringFiles []string
+}
+
+// NewOpenPGPVerifier returns an OpenPGPVerifier for the keys of the keyring
+// files
+func NewOpenPGPVerifier(keyringFiles ...string) (*OpenPGPVerifier, error) {
+	v := &OpenPGPVerifier{}
+	for _, f := range keyringFiles {
+		if err := v.AddKeyringFile(f); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// AddKeyringFile adds the keys of the keyring file (armored or binary)
+func (v *OpenPGPVerifier) AddKeyringFile(keyringFile string) error {
+	if err := v.readKeyringFile(keyringFile); err != nil {
+		return err
+	}
+	v.filesMutex.Lock()
+	defer v.filesMutex.Unlock()
+	v.keyringFiles = append(v.keyringFiles, keyringFile)
+	return nil
+}
+
+// readKeyringFile replaces the keys read from the keyring file with its
+// current keys
+func (v *OpenPGPVerifier) readKeyringFile(keyringFile string) error {
+	b, err := ioutil.ReadFile(keyringFile)
+	if err != nil {
+		return fmt.Errorf("%v: %v\n%v", ErrKeyringFileNotFound, keyringFile, err)
+	}
+	keys, err := readKeyring(keyringFile, b)
+	if err != nil {
+		return err
+	}
+	v.replace(keyringFile, keys)
+	return nil
+}
+
+// AddKey adds the keys of the keyring (armored or binary)
+func (v *OpenPGPVerifier) AddKey(name string, key []byte) ([]Key, error) {
+	keys, err := readKeyring(name, key)
+	if err != nil {
+		return nil, err
+	}
+	added := make([]Key, 0, len(keys))
+	for _, k := range keys {
+		a, err := v.add(k.Key, k.value)
+		if err != nil {
+			return nil, err
+		}
+		added = append(added, a)
+	}
+	return added, nil
+}
+
+func readKeyring(source string, b []byte) ([]*trustedKey, error) {
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(b))
+	if err != nil {
+		keyring, err = openpgp.ReadKeyRing(bytes.NewReader(b))
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v\n%v", ErrUnableToReadKeyring, source, err)
+		}
+	}
+	if len(keyring) == 0 {
+		return nil, fmt.Errorf("%v: %v", ErrNoKey, source)
+	}
+	keys := make([]*trustedKey, 0, len(keyring))
+	for _, e := range keyring {
+		keys = append(keys, &trustedKey{
+			Key: Key{
+				ID:       openPGPKeyID(e),
+				Scheme:   SchemeOpenPGP,
+				Identity: openPGPIdentity(e),
+				Source:   source,
+			},
+			value: e,
+		})
+	}
+	return keys, nil
+}
+
+// Keys returns the keys of the verifier
+func (v *OpenPGPVerifier) Keys() []Key {
+	return v.list()
+}
+
+// RevokeKey revokes the key of the id
+func (v *OpenPGPVerifier) RevokeKey(id string) bool {
+	return v.revoke(id)
+}
+
+// Scheme returns the signature scheme of the verifier
+func (v *OpenPGPVerifier) Scheme() string {
+	return SchemeOpenPGP
+}
+
+// Supports returns whether the signature is an armored OpenPGP signature
+func (v *OpenPGPVerifier) Supports(signature []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(signature), []byte("-----BEGIN PGP SIGNATURE-----"))
+}
+
+// Verify verifies the signature of the file against the keys which are not
+// revoked and returns its signer
+func (v *OpenPGPVerifier) Verify(signedFile string, signature []byte) (*Signer, error) {
+	v.filesMutex.Lock()
+	for _, f := range v.keyringFiles {
+		if err := v.readKeyringFile(f); err != nil {
+			v.filesMutex.Unlock()
+			return nil, err
+		}
+	}
+	v.filesMutex.Unlock()
+	var keyring openpgp.EntityList
+	for _, k := range v.trusted() {
+		keyring = append(keyring, k.value.(*openpgp.Entity))
+	}
+	signed, err := os.Open(signedFile)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v\n%v", ErrSignedFileNotFound, signedFile, err)
+	}
+	defer signed.Close()
+	checked, err := openpgp.CheckArmoredDetachedSignature(keyring, signed, bytes.NewReader(signature))
+	if err != nil {
+		return nil, fmt.Errorf("%v\n%v", ErrCheckSignature, err)
+	}
+	return &Signer{Scheme: SchemeOpenPGP, Identity: openPGPIdentity(checked), KeyID: openPGPKeyID(checked)}, nil
+}
+
+func openPGPKeyID(e *openpgp.Entity) string {
+	return fmt.Sprintf("%016X", e.PrimaryKey.KeyId)
+}
+
+// openPGPIdentity returns the first user ID of the entity, or its key id
+func openPGPIdentity(e *openpgp.Entity) string {
+	var ids []string
+	for k := range e.Identities {
+		ids = append(ids, k)
+	}
+	if len(ids) == 0 {
+		return fmt.Sprintf("%X", e.PrimaryKey.KeyId)
+	}
+	sort.Strings(ids)
+	return ids[0]
+}
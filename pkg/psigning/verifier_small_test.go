@@ -0,0 +1,269 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package psigning
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/crypto/ed25519"
+)
+
+const (
+	signedFile = "snap-plugin-collector-mock1"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA() *testCA {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	der, _ := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	cert, _ := x509.ParseCertificate(der)
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) issue(serial int64, cn string, usage x509.ExtKeyUsage) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: cn, Organization: []string{"Acme"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, _ := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	cert, _ := x509.ParseCertificate(der)
+	return cert, key
+}
+
+func x509Bundle(file string, cert *x509.Certificate, key *ecdsa.PrivateKey) []byte {
+	b, _ := ioutil.ReadFile(file)
+	sum := sha256.Sum256(b)
+	sig, _ := key.Sign(rand.Reader, sum[:], crypto.SHA256)
+	out := pem.EncodeToMemory(&pem.Block{Type: PEMSignature, Bytes: sig})
+	return append(out, pem.EncodeToMemory(&pem.Block{Type: PEMCertificate, Bytes: cert.Raw})...)
+}
+
+func TestVerify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "psigning")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	content, _ := ioutil.ReadFile(signedFile)
+
+	Convey("OpenPGPVerifier", t, func() {
+		v, err := NewOpenPGPVerifier("pubring.gpg")
+		So(err, ShouldBeNil)
+		signature, err := ioutil.ReadFile(signedFile + ".asc")
+		So(err, ShouldBeNil)
+		So(v.Supports(signature), ShouldBeTrue)
+		So(v.Supports([]byte("signature")), ShouldBeFalse)
+		signer, err := v.Verify(signedFile, signature)
+		So(err, ShouldBeNil)
+		So(signer.Scheme, ShouldEqual, SchemeOpenPGP)
+		So(signer.Identity, ShouldNotBeEmpty)
+
+		Convey("lists the keys of the keyring", func() {
+			var found bool
+			for _, k := range v.Keys() {
+				So(k.Source, ShouldEqual, "pubring.gpg")
+				found = found || k.ID == signer.KeyID
+			}
+			So(found, ShouldBeTrue)
+		})
+		Convey("refuses the signatures of a revoked key", func() {
+			So(v.RevokeKey(signer.KeyID), ShouldBeTrue)
+			_, err := v.Verify(signedFile, signature)
+			So(err, ShouldNotBeNil)
+			b, _ := ioutil.ReadFile("pubring.gpg")
+			_, err = v.AddKey("pubring", b)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, ErrKeyRevoked.Error())
+		})
+		Convey("returns an error for a missing keyring file", func() {
+			_, err := NewOpenPGPVerifier("missing.gpg")
+			So(err, ShouldNotBeNil)
+		})
+		Convey("reads the keyring files again for each verification", func() {
+			b, err := ioutil.ReadFile("pubring.gpg")
+			So(err, ShouldBeNil)
+			keyring := filepath.Join(dir, "pubring.gpg")
+			So(ioutil.WriteFile(keyring, b, 0644), ShouldBeNil)
+			v, err := NewOpenPGPVerifier(keyring)
+			So(err, ShouldBeNil)
+			_, err = v.Verify(signedFile, signature)
+			So(err, ShouldBeNil)
+			So(os.Remove(keyring), ShouldBeNil)
+			_, err = v.Verify(signedFile, signature)
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Ed25519Verifier", t, func() {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		So(err, ShouldBeNil)
+		der, err := asn1.Marshal(pkixPublicKey{
+			Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidEd25519},
+			PublicKey: asn1.BitString{Bytes: pub, BitLength: len(pub) * 8},
+		})
+		So(err, ShouldBeNil)
+		keyFile := filepath.Join(dir, "release.pem")
+		So(ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644), ShouldBeNil)
+		v, err := NewEd25519Verifier([]string{dir})
+		So(err, ShouldBeNil)
+		sig := ed25519.Sign(priv, content)
+
+		Convey("verifies binary and base64 signatures", func() {
+			signer, err := v.Verify(signedFile, sig)
+			So(err, ShouldBeNil)
+			So(signer.String(), ShouldEqual, "ed25519:release")
+			b64 := []byte(base64.StdEncoding.EncodeToString(sig) + "\n")
+			So(v.Supports(b64), ShouldBeTrue)
+			_, err = v.Verify(signedFile, b64)
+			So(err, ShouldBeNil)
+		})
+		Convey("returns an error for another key", func() {
+			_, other, _ := ed25519.GenerateKey(rand.Reader)
+			_, err := v.Verify(signedFile, ed25519.Sign(other, content))
+			So(err, ShouldNotBeNil)
+		})
+		Convey("adds and revokes keys at runtime", func() {
+			otherPub, other, _ := ed25519.GenerateKey(rand.Reader)
+			keys, err := v.AddKey("nightly", []byte(base64.StdEncoding.EncodeToString(otherPub)))
+			So(err, ShouldBeNil)
+			So(keys, ShouldHaveLength, 1)
+			So(v.Keys(), ShouldHaveLength, 2)
+			signer, err := v.Verify(signedFile, ed25519.Sign(other, content))
+			So(err, ShouldBeNil)
+			So(signer.String(), ShouldEqual, "ed25519:nightly")
+			So(signer.KeyID, ShouldEqual, keys[0].ID)
+			So(v.RevokeKey(keys[0].ID), ShouldBeTrue)
+			So(v.RevokeKey("unknown"), ShouldBeFalse)
+			_, err = v.Verify(signedFile, ed25519.Sign(other, content))
+			So(err, ShouldNotBeNil)
+			So(v.Keys()[1].Revoked, ShouldBeTrue)
+		})
+		os.Remove(keyFile)
+	})
+
+	Convey("X509Verifier", t, func() {
+		ca := newTestCA()
+		caFile := filepath.Join(dir, "ca.pem")
+		So(ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0644), ShouldBeNil)
+		cert, key := ca.issue(2, "Acme Code Signing", x509.ExtKeyUsageCodeSigning)
+		revoked, revokedKey := ca.issue(3, "Acme Revoked", x509.ExtKeyUsageCodeSigning)
+		crl, err := ca.cert.CreateCRL(rand.Reader, ca.key, []pkix.RevokedCertificate{
+			{SerialNumber: big.NewInt(3), RevocationTime: time.Now()},
+		}, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+		So(err, ShouldBeNil)
+		crlFile := filepath.Join(dir, "ca.crl")
+		So(ioutil.WriteFile(crlFile, crl, 0644), ShouldBeNil)
+		v, err := NewX509Verifier([]string{caFile}, []string{crlFile})
+		So(err, ShouldBeNil)
+
+		Convey("verifies a signature chaining to the CA", func() {
+			bundle := x509Bundle(signedFile, cert, key)
+			So(v.Supports(bundle), ShouldBeTrue)
+			signer, err := v.Verify(signedFile, bundle)
+			So(err, ShouldBeNil)
+			So(signer.String(), ShouldEqual, "x509:CN=Acme Code Signing,O=Acme")
+			So(signer.KeyID, ShouldEqual, v.Keys()[0].ID)
+		})
+		Convey("returns an error once the CA certificate is revoked", func() {
+			So(v.RevokeKey(v.Keys()[0].ID), ShouldBeTrue)
+			_, err := v.Verify(signedFile, x509Bundle(signedFile, cert, key))
+			So(err, ShouldNotBeNil)
+		})
+		Convey("returns an error for a revoked certificate", func() {
+			_, err := v.Verify(signedFile, x509Bundle(signedFile, revoked, revokedKey))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, ErrCertificateRevoked.Error())
+		})
+		Convey("returns an error for a certificate not allowing code signing", func() {
+			server, serverKey := ca.issue(4, "Acme Server", x509.ExtKeyUsageServerAuth)
+			_, err := v.Verify(signedFile, x509Bundle(signedFile, server, serverKey))
+			So(err, ShouldNotBeNil)
+		})
+		Convey("returns an error for a certificate of another CA", func() {
+			other, otherKey := newTestCA().issue(2, "Acme Code Signing", x509.ExtKeyUsageCodeSigning)
+			_, err := v.Verify(signedFile, x509Bundle(signedFile, other, otherKey))
+			So(err, ShouldNotBeNil)
+		})
+		Convey("returns an error for a signature of another file", func() {
+			_, err := v.Verify("pubring.gpg", x509Bundle(signedFile, cert, key))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, ErrCheckSignature.Error())
+		})
+		Convey("Verify picks the verifier by signature format", func() {
+			pgp, err := NewOpenPGPVerifier("pubring.gpg")
+			So(err, ShouldBeNil)
+			signer, err := Verify([]Verifier{pgp, v}, signedFile, x509Bundle(signedFile, cert, key))
+			So(err, ShouldBeNil)
+			So(signer.Scheme, ShouldEqual, SchemeX509)
+			_, err = Verify([]Verifier{pgp, v}, signedFile, []byte("unknown"))
+			So(err, ShouldEqual, ErrUnsupportedSignature)
+		})
+	})
+
+	Convey("TrustPolicy", t, func() {
+		policy := TrustPolicy{
+			"collector": {"x509:CN=Acme Code Signing*", "openpgp:*"},
+			"*":         {"ed25519:release"},
+		}
+		So(policy.Allows("collector", &Signer{Scheme: SchemeX509, Identity: "CN=Acme Code Signing,O=Acme"}), ShouldBeTrue)
+		So(policy.Allows("collector", &Signer{Scheme: SchemeOpenPGP, Identity: "snap"}), ShouldBeTrue)
+		So(policy.Allows("collector", &Signer{Scheme: SchemeEd25519, Identity: "release"}), ShouldBeFalse)
+		So(policy.Allows("publisher", &Signer{Scheme: SchemeEd25519, Identity: "release"}), ShouldBeTrue)
+		So(policy.Allows("publisher", &Signer{Scheme: SchemeX509, Identity: "CN=Acme Code Signing,O=Acme"}), ShouldBeFalse)
+		So(policy.Allows("publisher", nil), ShouldBeFalse)
+		So(TrustPolicy{}.Allows("publisher", &Signer{Scheme: SchemeEd25519, Identity: "any"}), ShouldBeTrue)
+	})
+}
@@ -0,0 +1,272 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package psigning
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+const (
+	// PEMSignature is the type of the PEM block holding the signature of an
+	// X.509 signature bundle
+	PEMSignature = "SIGNATURE"
+	// PEMCertificate is the type of the PEM blocks holding the signing
+	// certificate and the intermediate certificates of an X.509 signature
+	// bundle
+	PEMCertificate = "CERTIFICATE"
+)
+
+var (
+	// ErrNoCertificate - Error message for an X.509 signature without a signing certificate
+	ErrNoCertificate = errors.New("X.509 signature without a signing certificate")
+	// ErrCertificateRevoked - Error message for a revoked certificate
+	ErrCertificateRevoked = errors.New("Certificate revoked")
+	// ErrCRLExpired - Error message for a CRL past its next update
+	ErrCRLExpired = errors.New("CRL is past its next update")
+	// ErrUnsupportedKey - Error message for a certificate key which can not sign
+	ErrUnsupportedKey = errors.New("Unsupported certificate public key")
+)
+
+// X509Verifier verifies signatures made with X.509 code signing certificates.
+// The signature is a PEM bundle of a SIGNATURE block, the signature of the
+// file, followed by CERTIFICATE blocks, the signing certificate then the
+// intermediate certificates.  The signing certificate must chain to one of the
+// CA certificates, allow code signing and, like the intermediate
+// certificates, must not be revoked by the CRLs of its issuer.
+type X509Verifier struct {
+	keySet
+	crls []*pkix.CertificateList
+}
+
+// NewX509Verifier returns an X509Verifier trusting the PEM certificates of the
+// CA files and checking certificates against the CRL files (PEM or DER)
+func NewX509Verifier(caFiles []string, crlFiles []string) (*X509Verifier, error) {
+	v := &X509Verifier{}
+	for _, f := range caFiles {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := v.addCertificates(f, b); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range crlFiles {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		// ParseCRL reads PEM as well as DER CRLs
+		crl, err := x509.ParseCRL(b)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading CRL file %v: %v", f, err)
+		}
+		v.crls = append(v.crls, crl)
+	}
+	return v, nil
+}
+
+// AddKey adds the PEM CA certificates
+func (v *X509Verifier) AddKey(name string, key []byte) ([]Key, error) {
+	return v.addCertificates(name, key)
+}
+
+func (v *X509Verifier) addCertificates(source string, b []byte) ([]Key, error) {
+	var keys []Key
+	rest := bytes.TrimSpace(b)
+	for {
+		var p *pem.Block
+		p, rest = pem.Decode(rest)
+		if p == nil {
+			break
+		}
+		if p.Type != PEMCertificate {
+			continue
+		}
+		cert, err := x509.ParseCertificate(p.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading CA certificate %v: %v", source, err)
+		}
+		k, err := v.add(Key{
+			ID:       keyID(cert.Raw),
+			Scheme:   SchemeX509,
+			Identity: subjectString(cert.Subject),
+			Source:   source,
+		}, cert)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("No PEM certificate in CA file: %v", source)
+	}
+	return keys, nil
+}
+
+// Keys returns the CA certificates of the verifier
+func (v *X509Verifier) Keys() []Key {
+	return v.list()
+}
+
+// RevokeKey revokes the CA certificate of the id
+func (v *X509Verifier) RevokeKey(id string) bool {
+	return v.revoke(id)
+}
+
+// Scheme returns the signature scheme of the verifier
+func (v *X509Verifier) Scheme() string {
+	return SchemeX509
+}
+
+// Supports returns whether the signature is an X.509 signature bundle
+func (v *X509Verifier) Supports(signature []byte) bool {
+	p, _ := pem.Decode(bytes.TrimSpace(signature))
+	return p != nil && p.Type == PEMSignature
+}
+
+// Verify verifies the signature of the file and returns its signer
+func (v *X509Verifier) Verify(signedFile string, signature []byte) (*Signer, error) {
+	sig, leaf, intermediates, err := parseX509Bundle(signature)
+	if err != nil {
+		return nil, err
+	}
+	roots := x509.NewCertPool()
+	for _, k := range v.trusted() {
+		roots.AddCert(k.value.(*x509.Certificate))
+	}
+	chains, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v\n%v", ErrCheckSignature, err)
+	}
+	if err := v.checkRevocation(chains[0]); err != nil {
+		return nil, err
+	}
+
+	b, err := ioutil.ReadFile(signedFile)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v\n%v", ErrSignedFileNotFound, signedFile, err)
+	}
+	var algo x509.SignatureAlgorithm
+	switch leaf.PublicKey.(type) {
+	case *rsa.PublicKey:
+		algo = x509.SHA256WithRSA
+	case *ecdsa.PublicKey:
+		algo = x509.ECDSAWithSHA256
+	default:
+		return nil, ErrUnsupportedKey
+	}
+	if err := leaf.CheckSignature(algo, b, sig); err != nil {
+		return nil, fmt.Errorf("%v\n%v", ErrCheckSignature, err)
+	}
+	root := chains[0][len(chains[0])-1]
+	return &Signer{Scheme: SchemeX509, Identity: subjectString(leaf.Subject), KeyID: keyID(root.Raw)}, nil
+}
+
+// checkRevocation returns an error when a certificate of the chain is revoked
+// by a CRL of its issuer
+func (v *X509Verifier) checkRevocation(chain []*x509.Certificate) error {
+	for i := 0; i < len(chain)-1; i++ {
+		cert, issuer := chain[i], chain[i+1]
+		for _, crl := range v.crls {
+			if issuer.CheckCRLSignature(crl) != nil {
+				continue
+			}
+			if !crl.TBSCertList.NextUpdate.IsZero() && crl.HasExpired(time.Now()) {
+				return fmt.Errorf("%v: %v", ErrCRLExpired, subjectString(issuer.Subject))
+			}
+			for _, r := range crl.TBSCertList.RevokedCertificates {
+				if r.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+					return fmt.Errorf("%v: %v", ErrCertificateRevoked, subjectString(cert.Subject))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// subjectString returns the distinguished name of the subject, most specific
+// attribute first, like CN=Acme Code Signing,O=Acme
+func subjectString(n pkix.Name) string {
+	var rdns []string
+	add := func(attr string, values ...string) {
+		for _, v := range values {
+			if v != "" {
+				rdns = append(rdns, attr+"="+v)
+			}
+		}
+	}
+	add("CN", n.CommonName)
+	add("SERIALNUMBER", n.SerialNumber)
+	add("POSTALCODE", n.PostalCode...)
+	add("STREET", n.StreetAddress...)
+	add("ST", n.Province...)
+	add("L", n.Locality...)
+	add("OU", n.OrganizationalUnit...)
+	add("O", n.Organization...)
+	add("C", n.Country...)
+	return strings.Join(rdns, ",")
+}
+
+func parseX509Bundle(signature []byte) ([]byte, *x509.Certificate, *x509.CertPool, error) {
+	var sig []byte
+	var leaf *x509.Certificate
+	intermediates := x509.NewCertPool()
+	rest := bytes.TrimSpace(signature)
+	for {
+		var p *pem.Block
+		p, rest = pem.Decode(rest)
+		if p == nil {
+			break
+		}
+		switch p.Type {
+		case PEMSignature:
+			sig = p.Bytes
+		case PEMCertificate:
+			cert, err := x509.ParseCertificate(p.Bytes)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if leaf == nil {
+				leaf = cert
+			} else {
+				intermediates.AddCert(cert)
+			}
+		}
+	}
+	if leaf == nil {
+		return nil, nil, nil, ErrNoCertificate
+	}
+	return sig, leaf, intermediates, nil
+}
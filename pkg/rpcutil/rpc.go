@@ -20,20 +20,45 @@ limitations under the License.
 package rpcutil
 
 import (
+	"crypto/tls"
 	"fmt"
+	"net"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 // GetClientConnection returns a grcp.ClientConn that is unsecured
-// TODO: Add TLS security to connection
 func GetClientConnection(addr string, port int) (*grpc.ClientConn, error) {
+	return GetClientConnectionWithTLS(addr, port, nil)
+}
+
+// GetClientConnectionWithTLS returns a grpc.ClientConn secured with the TLS
+// configuration, or an unsecured one if the configuration is nil
+func GetClientConnectionWithTLS(addr string, port int, tlsConfig *tls.Config) (*grpc.ClientConn, error) {
+	return dial(fmt.Sprintf("%v:%v", addr, port), tlsConfig)
+}
+
+// GetUnixClientConnectionWithTLS returns a grpc.ClientConn to the Unix socket
+// of the path, secured with the TLS configuration when it is not nil
+func GetUnixClientConnectionWithTLS(path string, tlsConfig *tls.Config) (*grpc.ClientConn, error) {
+	return dial(path, tlsConfig, grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+		return net.DialTimeout("unix", addr, timeout)
+	}))
+}
+
+func dial(target string, tlsConfig *tls.Config, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	grpcDialOpts := []grpc.DialOption{
 		grpc.WithTimeout(2 * time.Second),
 	}
-	grpcDialOpts = append(grpcDialOpts, grpc.WithInsecure())
-	conn, err := grpc.Dial(fmt.Sprintf("%v:%v", addr, port), grpcDialOpts...)
+	if tlsConfig != nil {
+		grpcDialOpts = append(grpcDialOpts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		grpcDialOpts = append(grpcDialOpts, grpc.WithInsecure())
+	}
+	grpcDialOpts = append(grpcDialOpts, opts...)
+	conn, err := grpc.Dial(target, grpcDialOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,137 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// CatchUpSkip - missed intervals are skipped (the default)
+	CatchUpSkip = "skip"
+	// CatchUpOnce - the task fires once for all the missed intervals, with the fire which is due
+	CatchUpOnce = "once"
+	// CatchUpBackfill - the task fires once for each missed interval
+	CatchUpBackfill = "backfill"
+)
+
+// CatchUpSchedule is implemented by the schedules which can tell the
+// intervals they missed so a task can catch up on them
+type CatchUpSchedule interface {
+	Schedule
+	// Returns the catch-up policy of the schedule
+	CatchUpPolicy() string
+	// Returns the number of intervals after last and up to now and the
+	// times of the latest of them, at most max, oldest first.  It is called
+	// once Wait returned, with the time of the fire before the wait as last
+	// and the time Wait returned as now, so the interval due now is counted.
+	MissedIntervals(last, now time.Time, max int) (uint, []time.Time)
+}
+
+// ValidateCatchUp returns an error if policy is not a catch-up policy
+func ValidateCatchUp(policy string) error {
+	switch policy {
+	case "", CatchUpSkip, CatchUpOnce, CatchUpBackfill:
+		return nil
+	}
+	return fmt.Errorf("Catch-up policy '%s' is not valid, must be one of %s, %s or %s", policy, CatchUpSkip, CatchUpOnce, CatchUpBackfill)
+}
+
+// missedOnInterval returns the intervals of i after last and up to now (and up
+// to stop if it is set) like waitOnInterval counts them.  Excluded intervals
+// are not missed.  The intervals are counted arithmetically and only the
+// slots returned are walked through so a long gap costs nothing more.
+func missedOnInterval(last, now time.Time, i time.Duration, stop *time.Time, excl []Exclusion, max int) (uint, []time.Time) {
+	if (last == time.Time{}) || i <= 0 {
+		return 0, nil
+	}
+	end := now
+	if stop != nil && stop.Before(end) {
+		end = *stop
+	}
+	if !end.After(last) {
+		return 0, nil
+	}
+	n := end.Sub(last) / i
+	missed := uint(n) - excludedSlots(last, i, n, excl)
+	// walk back from the latest interval, jumping over the exclusions
+	var slots []time.Time
+	for k := n; k >= 1 && (max < 0 || len(slots) < max); k-- {
+		slot := last.Add(k * i)
+		if e, ok := excludedBy(excl, slot); ok {
+			// the last interval before the start of the exclusion
+			k = slotsBefore(last, i, e.Start) + 1
+			continue
+		}
+		slots = append(slots, slot)
+	}
+	for l, r := 0, len(slots)-1; l < r; l, r = l+1, r-1 {
+		slots[l], slots[r] = slots[r], slots[l]
+	}
+	return missed, slots
+}
+
+// slotsBefore returns the number of intervals of i after last which are
+// before t
+func slotsBefore(last time.Time, i time.Duration, t time.Time) time.Duration {
+	if !t.After(last) {
+		return 0
+	}
+	return (t.Sub(last) - 1) / i
+}
+
+// excludedSlots returns how many of the first n intervals of i after last
+// are within the exclusions, counting the intervals within overlapping
+// exclusions once
+func excludedSlots(last time.Time, i, n time.Duration, excl []Exclusion) uint {
+	if len(excl) == 0 {
+		return 0
+	}
+	sorted := make(exclusionsByStart, len(excl))
+	copy(sorted, excl)
+	sort.Sort(sorted)
+	var count time.Duration
+	// the intervals up to k were counted already
+	var k time.Duration
+	for _, e := range sorted {
+		from := slotsBefore(last, i, e.Start) + 1
+		to := slotsBefore(last, i, e.Stop)
+		if from <= k {
+			from = k + 1
+		}
+		if to > n {
+			to = n
+		}
+		if to >= from {
+			count += to - from + 1
+			k = to
+		}
+	}
+	return uint(count)
+}
+
+// exclusionsByStart sorts exclusions by their start
+type exclusionsByStart []Exclusion
+
+func (e exclusionsByStart) Len() int           { return len(e) }
+func (e exclusionsByStart) Swap(a, b int)      { e[a], e[b] = e[b], e[a] }
+func (e exclusionsByStart) Less(a, b int) bool { return e[a].Start.Before(e[b].Start) }
@@ -0,0 +1,77 @@
+// +build legacy
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMissedIntervals(t *testing.T) {
+	last := time.Date(2016, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := last.Add(time.Minute*5 + time.Second*30)
+
+	Convey("Simple schedule", t, func() {
+		s := NewSimpleSchedule(time.Minute)
+		Convey("returns the missed intervals oldest first", func() {
+			missed, slots := s.MissedIntervals(last, now, 10)
+			So(missed, ShouldEqual, 5)
+			So(slots, ShouldHaveLength, 5)
+			So(slots[0], ShouldResemble, last.Add(time.Minute))
+			So(slots[4], ShouldResemble, last.Add(time.Minute*5))
+		})
+		Convey("returns at most max of the latest intervals", func() {
+			missed, slots := s.MissedIntervals(last, now, 2)
+			So(missed, ShouldEqual, 5)
+			So(slots, ShouldResemble, []time.Time{last.Add(time.Minute * 4), last.Add(time.Minute * 5)})
+		})
+		Convey("misses nothing before a first run", func() {
+			missed, slots := s.MissedIntervals(time.Time{}, now, 10)
+			So(missed, ShouldEqual, 0)
+			So(slots, ShouldBeEmpty)
+		})
+		Convey("counts the intervals of a long gap without the excluded ones", func() {
+			s := NewSimpleSchedule(time.Second)
+			s.Exclusions = []Exclusion{
+				{Start: last.AddDate(1, 0, 0), Stop: last.AddDate(2, 0, 0)},
+				{Start: last.AddDate(1, 6, 0), Stop: last.AddDate(3, 0, 0)},
+				{Start: last.AddDate(10, 0, 0).Add(-time.Second), Stop: last.AddDate(10, 0, 0)},
+			}
+			end := last.AddDate(10, 0, 0)
+			missed, slots := s.MissedIntervals(last, end.Add(time.Second), 2)
+			So(missed, ShouldEqual, uint(end.Sub(last)/time.Second)-uint(s.Exclusions[1].Stop.Sub(s.Exclusions[0].Start)/time.Second))
+			So(slots, ShouldResemble, []time.Time{end, end.Add(time.Second)})
+		})
+		Convey("validates its catch-up policy", func() {
+			s.CatchUp = CatchUpBackfill
+			So(s.Validate(), ShouldBeNil)
+			s.CatchUp = "replay"
+			So(s.Validate(), ShouldNotBeNil)
+		})
+	})
+
+	Convey("Windowed schedule", t, func() {
+		start := last.Add(-time.Minute * 2)
+		stop := last.Add(time.Minute * 3)
+		s := NewWindowedSchedule(time.Minute, &start, &stop)
+		Convey("does not miss intervals after its stop time", func() {
+			missed, slots := s.MissedIntervals(last, now, 10)
+			So(missed, ShouldEqual, 3)
+			So(slots[2], ShouldResemble, stop)
+		})
+		Convey("counts from its start time before a first run", func() {
+			missed, _ := s.MissedIntervals(time.Time{}, last, 10)
+			So(missed, ShouldEqual, 2)
+		})
+	})
+
+	Convey("Cron schedule", t, func() {
+		s := NewCronSchedule("TZ=UTC 0 */2 * * * *")
+		So(s.Validate(), ShouldBeNil)
+		missed, slots := s.MissedIntervals(last, now, 1)
+		So(missed, ShouldEqual, 2)
+		So(slots, ShouldResemble, []time.Time{last.Add(time.Minute * 4)})
+	})
+}
@@ -20,6 +20,8 @@ package schedule
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/robfig/cron"
@@ -30,22 +32,53 @@ var ErrMissingCronEntry = errors.New("Cron entry is missing")
 
 // CronSchedule is a schedule that waits as long as specified in cron entry
 type CronSchedule struct {
+	// CatchUp is the policy for the missed intervals (see CatchUpSkip)
+	CatchUp string
+	// Splay is the bound of a random delay added to each time matching the
+	// cron entry so many tasks with the same entry do not all fire at once
+	Splay time.Duration
+	// Exclusions are periods of time during which the schedule does not fire
+	Exclusions []Exclusion
+
 	entry    string
 	enabled  bool
 	state    ScheduleState
-	schedule *cron.Cron
+	schedule cron.Schedule
+	location *time.Location
 }
 
 // NewCronSchedule creates and starts new cron schedule and returns an instance of CronSchedule
 func NewCronSchedule(entry string) *CronSchedule {
-	schedule := cron.New()
 	return &CronSchedule{
-		entry:    entry,
-		schedule: schedule,
-		enabled:  false,
+		entry:   entry,
+		enabled: false,
 	}
 }
 
+// ParseCronEntry parses a cron entry which can start with the timezone the
+// entry is in, as TZ=<zone> (for instance "TZ=UTC 0 30 * * * *").  Without
+// a timezone the entry is in local time.
+func ParseCronEntry(entry string) (cron.Schedule, *time.Location, error) {
+	loc := time.Local
+	fields := strings.Fields(entry)
+	if len(fields) > 0 && strings.HasPrefix(fields[0], "TZ=") {
+		var err error
+		loc, err = time.LoadLocation(strings.TrimPrefix(fields[0], "TZ="))
+		if err != nil {
+			return nil, nil, fmt.Errorf("Invalid cron entry timezone: %v", err)
+		}
+		entry = strings.Join(fields[1:], " ")
+	}
+	if entry == "" {
+		return nil, nil, ErrMissingCronEntry
+	}
+	sch, err := cron.Parse(entry)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sch, loc, nil
+}
+
 // Entry returns the cron schedule entry
 func (c *CronSchedule) Entry() string {
 	return c.entry
@@ -61,15 +94,66 @@ func (c *CronSchedule) Validate() error {
 	if c.entry == "" {
 		return ErrMissingCronEntry
 	}
-	_, err := cron.Parse(c.entry)
+	_, _, err := ParseCronEntry(c.entry)
 	if err != nil {
 		return err
 	}
-	return nil
+	if c.Splay < 0 {
+		return ErrInvalidSplay
+	}
+	if err := validateExclusions(c.Exclusions); err != nil {
+		return err
+	}
+	return ValidateCatchUp(c.CatchUp)
+}
+
+// CatchUpPolicy returns the policy for the missed intervals
+func (c *CronSchedule) CatchUpPolicy() string {
+	return c.CatchUp
+}
+
+// MissedIntervals returns the times matching the cron entry missed since last
+func (c *CronSchedule) MissedIntervals(last, now time.Time, max int) (uint, []time.Time) {
+	if (last == time.Time{}) {
+		return 0, nil
+	}
+	s, loc, err := ParseCronEntry(c.entry)
+	if err != nil {
+		return 0, nil
+	}
+	var missed uint
+	var slots []time.Time
+	for next := c.next(s, last.In(loc)); !next.IsZero() && !next.After(now); next = c.next(s, next) {
+		missed++
+		slots = append(slots, next)
+		if max >= 0 && len(slots) > max {
+			slots = slots[1:]
+		}
+	}
+	return missed, slots
+}
+
+// next returns the first time after t matching the cron entry which is not
+// excluded
+func (c *CronSchedule) next(s cron.Schedule, t time.Time) time.Time {
+	next := s.Next(t)
+	for !next.IsZero() {
+		e, ok := excludedBy(c.Exclusions, next)
+		if !ok {
+			break
+		}
+		next = s.Next(e.Stop.In(next.Location()).Add(-time.Nanosecond))
+	}
+	return next
 }
 
 // Wait waits as long as specified in cron entry
 func (c *CronSchedule) Wait(last time.Time) Response {
+	return c.WaitCancel(last, nil)
+}
+
+// WaitCancel waits like Wait unless cancel is closed first
+func (c *CronSchedule) WaitCancel(last time.Time, cancel <-chan struct{}) Response {
 	var err error
 	now := time.Now()
 
@@ -79,7 +163,7 @@ func (c *CronSchedule) Wait(last time.Time) Response {
 	}
 	// schedule not enabled, either due to first run or invalid cron entry
 	if !c.enabled {
-		err = c.schedule.AddFunc(c.entry, func() {})
+		c.schedule, c.location, err = ParseCronEntry(c.entry)
 		if err != nil {
 			c.state = Error
 		} else {
@@ -89,20 +173,23 @@ func (c *CronSchedule) Wait(last time.Time) Response {
 
 	var misses uint
 	if c.enabled {
-		s := c.schedule.Entries()[0].Schedule
+		s := c.schedule
+		now = now.In(c.location)
 
 		// calculate misses
-		for next := last; next.Before(now); {
-			next = s.Next(next)
-			if next.After(now) {
+		for next := last.In(c.location); next.Before(now); {
+			next = c.next(s, next)
+			if next.IsZero() || next.After(now) {
 				break
 			}
 			misses++
 		}
 
 		// wait
-		waitTime := s.Next(now)
-		time.Sleep(waitTime.Sub(now))
+		waitTime := c.next(s, now)
+		if !sleep(waitTime.Sub(now)+randomSplay(c.Splay), cancel) {
+			return nil
+		}
 	}
 
 	return &CronScheduleResponse{
@@ -0,0 +1,105 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidExclusion - Error message for an exclusion which does not stop after it starts
+	ErrInvalidExclusion = errors.New("Exclusion must stop after it starts")
+	// ErrInvalidSplay - Error message for a splay which is negative or not smaller than the interval
+	ErrInvalidSplay = errors.New("Splay must not be negative and must be smaller than the interval")
+
+	splayRand  = rand.New(rand.NewSource(time.Now().UnixNano()))
+	splayMutex = &sync.Mutex{}
+)
+
+// Exclusion is a period of time, a maintenance window for instance, during
+// which a schedule does not fire.  The exclusion starts at Start and ends
+// right before Stop.
+type Exclusion struct {
+	Start time.Time
+	Stop  time.Time
+}
+
+// Validate returns an error if the exclusion does not stop after it starts
+func (e Exclusion) Validate() error {
+	if !e.Stop.After(e.Start) {
+		return ErrInvalidExclusion
+	}
+	return nil
+}
+
+// Contains returns whether t is within the exclusion
+func (e Exclusion) Contains(t time.Time) bool {
+	return !t.Before(e.Start) && t.Before(e.Stop)
+}
+
+func validateExclusions(excl []Exclusion) error {
+	for _, e := range excl {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// excludedBy returns the exclusion t is within if any
+func excludedBy(excl []Exclusion, t time.Time) (Exclusion, bool) {
+	for _, e := range excl {
+		if e.Contains(t) {
+			return e, true
+		}
+	}
+	return Exclusion{}, false
+}
+
+// skipExclusions sleeps past the intervals of i which are excluded starting
+// with the interval at t and returns the time of the first interval which is
+// not excluded.  It returns false when cancel is closed before.
+func skipExclusions(t time.Time, i time.Duration, excl []Exclusion, cancel <-chan struct{}) (time.Time, bool) {
+	for {
+		e, ok := excludedBy(excl, t)
+		if !ok {
+			return t, true
+		}
+		// the first interval at or after the stop of the exclusion
+		n := (e.Stop.Sub(t) + i - 1) / i
+		t = t.Add(n * i)
+		if !sleep(t.Sub(time.Now()), cancel) {
+			return t, false
+		}
+	}
+}
+
+// randomSplay returns a random duration in [0, max)
+func randomSplay(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	splayMutex.Lock()
+	defer splayMutex.Unlock()
+	return time.Duration(splayRand.Int63n(int64(max)))
+}
@@ -0,0 +1,93 @@
+// +build legacy
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCronEntryTimezone(t *testing.T) {
+	Convey("Cron entry", t, func() {
+		Convey("with a timezone fires in that timezone", func() {
+			s, loc, err := ParseCronEntry("TZ=America/New_York 0 0 12 * * *")
+			So(err, ShouldBeNil)
+			// noon in New York is 16:00 UTC in summer
+			from := time.Date(2016, 7, 1, 0, 0, 0, 0, time.UTC)
+			So(s.Next(from.In(loc)).UTC(), ShouldResemble, time.Date(2016, 7, 1, 16, 0, 0, 0, time.UTC))
+		})
+		Convey("with an unknown timezone is not valid", func() {
+			So(NewCronSchedule("TZ=Mars/Olympus 0 0 12 * * *").Validate(), ShouldNotBeNil)
+		})
+		Convey("with only a timezone is not valid", func() {
+			So(NewCronSchedule("TZ=UTC").Validate(), ShouldEqual, ErrMissingCronEntry)
+		})
+	})
+}
+
+func TestExclusions(t *testing.T) {
+	start := time.Date(2016, 1, 1, 2, 0, 0, 0, time.UTC)
+	maintenance := Exclusion{Start: start, Stop: start.Add(time.Hour * 2)}
+
+	Convey("Exclusion", t, func() {
+		So(maintenance.Contains(start), ShouldBeTrue)
+		So(maintenance.Contains(maintenance.Stop), ShouldBeFalse)
+		So(Exclusion{Start: start, Stop: start}.Validate(), ShouldEqual, ErrInvalidExclusion)
+	})
+
+	Convey("Cron schedule with an exclusion", t, func() {
+		s := NewCronSchedule("TZ=UTC 0 0 * * * *")
+		s.Exclusions = []Exclusion{maintenance}
+		So(s.Validate(), ShouldBeNil)
+		sch, _, err := ParseCronEntry(s.Entry())
+		So(err, ShouldBeNil)
+		So(s.next(sch, start.Add(-time.Minute)), ShouldResemble, maintenance.Stop)
+
+		Convey("does not miss the excluded times", func() {
+			missed, slots := s.MissedIntervals(start.Add(-time.Minute), start.Add(time.Hour*3), 10)
+			So(missed, ShouldEqual, 2)
+			So(slots, ShouldResemble, []time.Time{maintenance.Stop, maintenance.Stop.Add(time.Hour)})
+		})
+	})
+
+	Convey("Simple schedule with an exclusion", t, func() {
+		s := NewSimpleSchedule(time.Hour)
+		s.Exclusions = []Exclusion{maintenance}
+		So(s.Validate(), ShouldBeNil)
+		missed, _ := s.MissedIntervals(start.Add(-time.Minute), start.Add(time.Hour*3), 10)
+		So(missed, ShouldEqual, 1)
+		next, ok := skipExclusions(start.Add(time.Minute), time.Hour, s.Exclusions, nil)
+		So(ok, ShouldBeTrue)
+		So(next, ShouldResemble, start.Add(time.Hour*2+time.Minute))
+	})
+}
+
+func TestSplay(t *testing.T) {
+	Convey("Splay", t, func() {
+		Convey("is random within its bound", func() {
+			for i := 0; i < 100; i++ {
+				d := randomSplay(time.Second)
+				So(d, ShouldBeGreaterThanOrEqualTo, 0)
+				So(d, ShouldBeLessThan, time.Second)
+			}
+			So(randomSplay(0), ShouldEqual, 0)
+		})
+		Convey("must be smaller than the interval of a simple schedule", func() {
+			s := NewSimpleSchedule(time.Second)
+			s.Splay = time.Second
+			So(s.Validate(), ShouldEqual, ErrInvalidSplay)
+			s.Splay = time.Millisecond * 10
+			So(s.Validate(), ShouldBeNil)
+		})
+		Convey("delays a simple schedule", func() {
+			s := NewSimpleSchedule(time.Millisecond * 20)
+			s.Splay = time.Millisecond * 10
+			before := time.Now()
+			s.Wait(time.Time{})
+			So(time.Since(before), ShouldBeGreaterThanOrEqualTo, time.Millisecond*20)
+			So(s.splayed, ShouldBeLessThan, time.Millisecond*10)
+		})
+	})
+}
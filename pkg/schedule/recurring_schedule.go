@@ -0,0 +1,333 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// How far ahead the next opening of a window is looked for
+const windowSearchDays = 5 * 366
+
+var (
+	// ErrMissingWindows - Error message for a recurring schedule without windows
+	ErrMissingWindows = errors.New("Recurring schedule must have at least one window")
+	// ErrWindowNeverOpens - Error message for a recurring schedule whose windows never open
+	ErrWindowNeverOpens = errors.New("Recurring schedule windows never open")
+)
+
+// Window is a window of time which opens every day or on given days of the
+// week, optionally in given weeks of the month only.  For instance the window
+// of every weekday from 09:00 to 17:00 or the window of the first Sunday of
+// each month.
+type Window struct {
+	// Days of the week the window opens on, every day if empty
+	Days []time.Weekday
+	// Weeks of the month the window opens in, every week if empty.  Week n
+	// holds the days n*7-6 to n*7 of the month and week -1 the last 7 days.
+	Weeks []int
+	// Start is the time of day the window opens at (from midnight)
+	Start time.Duration
+	// Stop is the time of day the window closes at (from midnight), on the
+	// next day if it is not after Start
+	Stop time.Duration
+	// Location is the timezone of the window, local time if it is nil
+	Location *time.Location
+}
+
+// Validate returns an error if the window is not valid
+func (w Window) Validate() error {
+	if w.Start < 0 || w.Start >= 24*time.Hour || w.Stop < 0 || w.Stop >= 24*time.Hour {
+		return errors.New("Window start and stop must be times of day")
+	}
+	if w.Start == w.Stop {
+		return errors.New("Window must not start and stop at the same time")
+	}
+	for _, d := range w.Days {
+		if d < time.Sunday || d > time.Saturday {
+			return fmt.Errorf("Window day %d is not a day of the week", d)
+		}
+	}
+	for _, wk := range w.Weeks {
+		if wk != -1 && (wk < 1 || wk > 5) {
+			return fmt.Errorf("Window week %d is not valid, must be 1 to 5 or -1", wk)
+		}
+	}
+	return nil
+}
+
+func (w Window) location() *time.Location {
+	if w.Location == nil {
+		return time.Local
+	}
+	return w.Location
+}
+
+// opensOn returns whether the window opens on the day of t
+func (w Window) opensOn(t time.Time) bool {
+	if len(w.Days) > 0 {
+		found := false
+		for _, d := range w.Days {
+			if d == t.Weekday() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(w.Weeks) == 0 {
+		return true
+	}
+	// the number of days in the month of t
+	last := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	for _, wk := range w.Weeks {
+		if wk == -1 && t.Day() > last-7 {
+			return true
+		}
+		if wk > 0 && (t.Day()+6)/7 == wk {
+			return true
+		}
+	}
+	return false
+}
+
+// occurrence returns when the window opening on the day of t opens and closes
+func (w Window) occurrence(t time.Time) (time.Time, time.Time) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	open := atTimeOfDay(day, w.Start)
+	stopDay := day
+	if w.Stop <= w.Start {
+		stopDay = day.AddDate(0, 0, 1)
+	}
+	return open, atTimeOfDay(stopDay, w.Stop)
+}
+
+// atTimeOfDay returns the time of day d on the day starting at day
+func atTimeOfDay(day time.Time, d time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, int(d), day.Location())
+}
+
+// current returns the occurrence of the window t is within if any
+func (w Window) current(t time.Time) (time.Time, time.Time, bool) {
+	t = t.In(w.location())
+	// an occurrence can open the day before and close on the day of t
+	for _, day := range []time.Time{t.AddDate(0, 0, -1), t} {
+		if !w.opensOn(day) {
+			continue
+		}
+		open, stop := w.occurrence(day)
+		if !t.Before(open) && t.Before(stop) {
+			return open, stop, true
+		}
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// nextOpen returns when the window opens next after t or zero if it does not
+// open in the next years
+func (w Window) nextOpen(t time.Time) time.Time {
+	t = t.In(w.location())
+	for i := 0; i < windowSearchDays; i++ {
+		day := t.AddDate(0, 0, i)
+		if !w.opensOn(day) {
+			continue
+		}
+		if open, _ := w.occurrence(day); open.After(t) {
+			return open
+		}
+	}
+	return time.Time{}
+}
+
+// DormantSchedule is implemented by the schedules which do not fire outside
+// of windows of time
+type DormantSchedule interface {
+	Schedule
+	// Returns whether the schedule is outside of its windows at the given
+	// time and when the next window opens
+	DormantUntil(time.Time) (time.Time, bool)
+}
+
+// RecurringSchedule is a schedule that fires on an interval within windows
+// which open and close again and again.  Outside of its windows the schedule
+// is dormant.
+type RecurringSchedule struct {
+	Interval time.Duration
+	Windows  []Window
+	// CatchUp is the policy for the missed intervals (see CatchUpSkip)
+	CatchUp string
+
+	state ScheduleState
+}
+
+// NewRecurringSchedule returns an instance of RecurringSchedule given the
+// interval and the windows
+func NewRecurringSchedule(i time.Duration, windows ...Window) *RecurringSchedule {
+	return &RecurringSchedule{
+		Interval: i,
+		Windows:  windows,
+	}
+}
+
+// GetState returns the schedule state
+func (r *RecurringSchedule) GetState() ScheduleState {
+	return r.state
+}
+
+// Validate returns an error if the interval is not greater than zero or if
+// the windows are missing, not valid or never open
+func (r *RecurringSchedule) Validate() error {
+	if r.Interval <= 0 {
+		return ErrInvalidInterval
+	}
+	if len(r.Windows) == 0 {
+		return ErrMissingWindows
+	}
+	for _, w := range r.Windows {
+		if err := w.Validate(); err != nil {
+			return err
+		}
+	}
+	if r.nextOpen(time.Now()).IsZero() {
+		return ErrWindowNeverOpens
+	}
+	return ValidateCatchUp(r.CatchUp)
+}
+
+// current returns the occurrence of a window t is within if any.  When
+// windows overlap the one closing last is returned.
+func (r *RecurringSchedule) current(t time.Time) (time.Time, time.Time, bool) {
+	var open, stop time.Time
+	found := false
+	for _, w := range r.Windows {
+		if o, s, ok := w.current(t); ok && (!found || s.After(stop)) {
+			open, stop, found = o, s, true
+		}
+	}
+	return open, stop, found
+}
+
+// nextOpen returns when a window opens next after t or zero if none opens
+func (r *RecurringSchedule) nextOpen(t time.Time) time.Time {
+	var next time.Time
+	for _, w := range r.Windows {
+		if o := w.nextOpen(t); !o.IsZero() && (next.IsZero() || o.Before(next)) {
+			next = o
+		}
+	}
+	return next
+}
+
+// next returns the first time the schedule fires after t, the intervals
+// counted from the opening of the window, or zero if it never fires again
+func (r *RecurringSchedule) next(t time.Time) time.Time {
+	if open, stop, ok := r.current(t); ok {
+		slot := open.Add((t.Sub(open)/r.Interval + 1) * r.Interval)
+		if slot.Before(stop) {
+			return slot
+		}
+	}
+	return r.nextOpen(t)
+}
+
+// DormantUntil returns whether the schedule is outside of its windows at t
+// and when the next window opens
+func (r *RecurringSchedule) DormantUntil(t time.Time) (time.Time, bool) {
+	if _, _, ok := r.current(t); ok {
+		return time.Time{}, false
+	}
+	return r.nextOpen(t), true
+}
+
+// CatchUpPolicy returns the policy for the missed intervals
+func (r *RecurringSchedule) CatchUpPolicy() string {
+	return r.CatchUp
+}
+
+// MissedIntervals returns the intervals within the windows missed since last
+func (r *RecurringSchedule) MissedIntervals(last, now time.Time, max int) (uint, []time.Time) {
+	if (last == time.Time{}) {
+		return 0, nil
+	}
+	var missed uint
+	var slots []time.Time
+	for next := r.next(last); !next.IsZero() && !next.After(now); next = r.next(next) {
+		missed++
+		slots = append(slots, next)
+		if max >= 0 && len(slots) > max {
+			slots = slots[1:]
+		}
+	}
+	return missed, slots
+}
+
+// Wait waits for the next interval within a window
+func (r *RecurringSchedule) Wait(last time.Time) Response {
+	return r.WaitCancel(last, nil)
+}
+
+// WaitCancel waits like Wait unless cancel is closed first
+func (r *RecurringSchedule) WaitCancel(last time.Time, cancel <-chan struct{}) Response {
+	now := time.Now()
+	var missed uint
+	if (last != time.Time{}) {
+		missed, _ = r.MissedIntervals(last, now, 0)
+	}
+	next := r.next(now)
+	if next.IsZero() {
+		r.state = Ended
+		return &RecurringScheduleResponse{state: r.GetState(), missed: missed, lastTime: time.Now()}
+	}
+	if !sleep(next.Sub(now), cancel) {
+		return nil
+	}
+	return &RecurringScheduleResponse{state: r.GetState(), missed: missed, lastTime: time.Now()}
+}
+
+// RecurringScheduleResponse a response from RecurringSchedule conforming to ScheduleResponse interface
+type RecurringScheduleResponse struct {
+	state    ScheduleState
+	missed   uint
+	lastTime time.Time
+}
+
+// State returns the state of the Schedule
+func (r *RecurringScheduleResponse) State() ScheduleState {
+	return r.state
+}
+
+// Error returns last error
+func (r *RecurringScheduleResponse) Error() error {
+	return nil
+}
+
+// Missed returns any missed intervals
+func (r *RecurringScheduleResponse) Missed() uint {
+	return r.missed
+}
+
+// LastTime returns the last response time
+func (r *RecurringScheduleResponse) LastTime() time.Time {
+	return r.lastTime
+}
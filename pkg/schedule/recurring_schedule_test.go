@@ -0,0 +1,73 @@
+// +build legacy
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRecurringSchedule(t *testing.T) {
+	weekdays := Window{
+		Days:     []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Start:    9 * time.Hour,
+		Stop:     17 * time.Hour,
+		Location: time.UTC,
+	}
+	// Friday 2016-07-01
+	friday := time.Date(2016, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	Convey("Recurring Schedule", t, func() {
+		Convey("fires on its interval within a window", func() {
+			s := NewRecurringSchedule(time.Hour, weekdays)
+			So(s.Validate(), ShouldBeNil)
+			So(s.next(friday.Add(9*time.Hour+time.Minute)), ShouldResemble, friday.Add(10*time.Hour))
+			_, dormant := s.DormantUntil(friday.Add(12 * time.Hour))
+			So(dormant, ShouldBeFalse)
+		})
+
+		Convey("is dormant until its next window opens", func() {
+			s := NewRecurringSchedule(time.Hour, weekdays)
+			// the last interval of Friday is at 16:00, the next one on Monday
+			monday := friday.AddDate(0, 0, 3).Add(9 * time.Hour)
+			So(s.next(friday.Add(16*time.Hour)), ShouldResemble, monday)
+			until, dormant := s.DormantUntil(friday.Add(18 * time.Hour))
+			So(dormant, ShouldBeTrue)
+			So(until, ShouldResemble, monday)
+		})
+
+		Convey("supports windows closing the next day", func() {
+			s := NewRecurringSchedule(time.Hour, Window{Start: 22 * time.Hour, Stop: 2 * time.Hour, Location: time.UTC})
+			So(s.Validate(), ShouldBeNil)
+			_, dormant := s.DormantUntil(friday.Add(time.Hour))
+			So(dormant, ShouldBeFalse)
+			So(s.next(friday.Add(time.Hour)), ShouldResemble, friday.Add(22*time.Hour))
+		})
+
+		Convey("supports weeks of the month", func() {
+			firstSunday := Window{Days: []time.Weekday{time.Sunday}, Weeks: []int{1}, Start: 0, Stop: 6 * time.Hour, Location: time.UTC}
+			s := NewRecurringSchedule(time.Hour, firstSunday)
+			So(s.nextOpen(friday), ShouldResemble, time.Date(2016, 7, 3, 0, 0, 0, 0, time.UTC))
+			So(s.nextOpen(time.Date(2016, 7, 4, 0, 0, 0, 0, time.UTC)), ShouldResemble, time.Date(2016, 8, 7, 0, 0, 0, 0, time.UTC))
+			lastFriday := Window{Days: []time.Weekday{time.Friday}, Weeks: []int{-1}, Start: 0, Stop: 6 * time.Hour, Location: time.UTC}
+			s = NewRecurringSchedule(time.Hour, lastFriday)
+			So(s.nextOpen(friday), ShouldResemble, time.Date(2016, 7, 29, 0, 0, 0, 0, time.UTC))
+		})
+
+		Convey("counts the missed intervals within its windows only", func() {
+			s := NewRecurringSchedule(time.Hour, weekdays)
+			missed, slots := s.MissedIntervals(friday.Add(15*time.Hour), friday.AddDate(0, 0, 3).Add(10*time.Hour+time.Minute), 10)
+			So(missed, ShouldEqual, 3)
+			So(slots[2], ShouldResemble, friday.AddDate(0, 0, 3).Add(10*time.Hour))
+		})
+
+		Convey("invalid schedule", func() {
+			So(NewRecurringSchedule(time.Hour).Validate(), ShouldEqual, ErrMissingWindows)
+			So(NewRecurringSchedule(0, weekdays).Validate(), ShouldEqual, ErrInvalidInterval)
+			So(NewRecurringSchedule(time.Hour, Window{Start: time.Hour, Stop: time.Hour}).Validate(), ShouldNotBeNil)
+			So(NewRecurringSchedule(time.Hour, Window{Weeks: []int{6}, Stop: time.Hour}).Validate(), ShouldNotBeNil)
+		})
+	})
+}
@@ -36,6 +36,15 @@ type Schedule interface {
 	Wait(time.Time) Response
 }
 
+// CancelableSchedule is implemented by the schedules whose wait can be
+// canceled
+type CancelableSchedule interface {
+	Schedule
+	// Blocks like Wait until time to fire or until cancel is closed, in
+	// which case it returns nil
+	WaitCancel(last time.Time, cancel <-chan struct{}) Response
+}
+
 // Response interface defines the behavior of schedule response
 type Response interface {
 	// Contains any errors captured during a schedule.Wait()
@@ -48,10 +57,34 @@ type Response interface {
 	LastTime() time.Time
 }
 
-func waitOnInterval(last time.Time, i time.Duration) (uint, time.Time) {
+// sleep pauses for d unless cancel is closed first, in which case it returns
+// false
+func sleep(d time.Duration, cancel <-chan struct{}) bool {
+	if d <= 0 {
+		select {
+		case <-cancel:
+			return false
+		default:
+			return true
+		}
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-cancel:
+		return false
+	}
+}
+
+// waitOnInterval waits for the next interval of i after last and returns the
+// intervals missed and the time it returned.  It returns false when cancel
+// is closed before.
+func waitOnInterval(last time.Time, i time.Duration, cancel <-chan struct{}) (uint, time.Time, bool) {
 	if (last == time.Time{}) {
-		time.Sleep(i)
-		return uint(0), time.Now()
+		ok := sleep(i, cancel)
+		return uint(0), time.Now(), ok
 	}
 	// Get the difference in time.Duration since last in nanoseconds (int64)
 	timeDiff := time.Since(last).Nanoseconds()
@@ -63,6 +96,6 @@ func waitOnInterval(last time.Time, i time.Duration) (uint, time.Time) {
 	missed := (timeDiff - remainder) / nanoInterval // timeDiff.Nanoseconds() % s.Interval.Nanoseconds()
 	waitDuration := nanoInterval - remainder
 	// Wait until predicted interval fires
-	time.Sleep(time.Duration(waitDuration))
-	return uint(missed), time.Now()
+	ok := sleep(time.Duration(waitDuration), cancel)
+	return uint(missed), time.Now(), ok
 }
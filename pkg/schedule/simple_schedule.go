@@ -7,7 +7,18 @@ import (
 // SimpleSchedule is a schedule that only implements an endless repeating interval
 type SimpleSchedule struct {
 	Interval time.Duration
-	state    ScheduleState
+	// CatchUp is the policy for the missed intervals (see CatchUpSkip)
+	CatchUp string
+	// Splay is the bound of a random delay added to each interval so many
+	// tasks with the same interval do not all fire at once
+	Splay time.Duration
+	// Exclusions are periods of time during which the schedule does not fire
+	Exclusions []Exclusion
+
+	state ScheduleState
+	// the random delay added to the last interval and to the one before
+	splayed     time.Duration
+	lastSplayed time.Duration
 }
 
 // NewSimpleSchedule returns the SimpleSchedule given the time interval
@@ -28,13 +39,53 @@ func (s *SimpleSchedule) Validate() error {
 	if s.Interval <= 0 {
 		return ErrInvalidInterval
 	}
-	return nil
+	if s.Splay < 0 || s.Splay >= s.Interval {
+		return ErrInvalidSplay
+	}
+	if err := validateExclusions(s.Exclusions); err != nil {
+		return err
+	}
+	return ValidateCatchUp(s.CatchUp)
+}
+
+// CatchUpPolicy returns the policy for the missed intervals
+func (s *SimpleSchedule) CatchUpPolicy() string {
+	return s.CatchUp
+}
+
+// MissedIntervals returns the intervals missed since last.  The random delays
+// added to the intervals at last and now are left out.
+func (s *SimpleSchedule) MissedIntervals(last, now time.Time, max int) (uint, []time.Time) {
+	if (last != time.Time{}) {
+		last = last.Add(-s.lastSplayed)
+	}
+	return missedOnInterval(last, now.Add(-s.splayed), s.Interval, nil, s.Exclusions, max)
 }
 
 // Wait returns the SimpleSchedule state, misses and the last schedule ran
 func (s *SimpleSchedule) Wait(last time.Time) Response {
-	m, t := waitOnInterval(last, s.Interval)
-	return &SimpleScheduleResponse{state: s.GetState(), missed: m, lastTime: t}
+	return s.WaitCancel(last, nil)
+}
+
+// WaitCancel waits like Wait unless cancel is closed first
+func (s *SimpleSchedule) WaitCancel(last time.Time, cancel <-chan struct{}) Response {
+	// the intervals are counted from the last one without its splay
+	if (last != time.Time{}) {
+		last = last.Add(-s.splayed)
+	}
+	m, t, ok := waitOnInterval(last, s.Interval, cancel)
+	if !ok {
+		return nil
+	}
+	if _, ok = skipExclusions(t, s.Interval, s.Exclusions, cancel); !ok {
+		return nil
+	}
+	s.lastSplayed = s.splayed
+	s.splayed = randomSplay(s.Splay)
+	if !sleep(s.splayed, cancel) {
+		return nil
+	}
+	return &SimpleScheduleResponse{state: s.GetState(), missed: m, lastTime: time.Now()}
 }
 
 // SimpleScheduleResponse a response from SimpleSchedule conforming to ScheduleResponse interface
@@ -35,6 +35,15 @@ func TestSimpleSchedule(t *testing.T) {
 			So(afterMS, ShouldBeLessThan, shouldWait+10)
 		})
 
+		Convey("test WaitCancel()", func() {
+			s := NewSimpleSchedule(time.Hour)
+			cancel := make(chan struct{})
+			close(cancel)
+			before := time.Now()
+			So(s.WaitCancel(time.Now(), cancel), ShouldBeNil)
+			So(time.Since(before), ShouldBeLessThan, time.Second)
+		})
+
 		Convey("invalid schedule", func() {
 			s := NewSimpleSchedule(0)
 			err := s.Validate()
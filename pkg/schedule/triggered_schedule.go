@@ -0,0 +1,189 @@
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// TriggerOnSuccess - the triggering task completed a run without errors
+	TriggerOnSuccess = "success"
+	// TriggerOnEnd - the schedule of the triggering task ended
+	TriggerOnEnd = "end"
+	// TriggerOnDisabled - the triggering task was disabled
+	TriggerOnDisabled = "disabled"
+)
+
+// ErrMissingTriggers - Error message for a triggered schedule without triggers
+var ErrMissingTriggers = errors.New("Triggered schedule must have at least one trigger")
+
+// Trigger makes a TriggeredSchedule fire when the task it refers to emits
+// the given event (one of TriggerOnSuccess, TriggerOnEnd or TriggerOnDisabled).
+type Trigger struct {
+	Task string `json:"task"`
+	On   string `json:"on"`
+}
+
+// TriggeredSchedule is a schedule that fires each time it is triggered by
+// one of its triggers instead of on an interval.  Triggers must not be
+// changed directly once the schedule is in use by a task.
+type TriggeredSchedule struct {
+	Triggers []Trigger
+
+	state     ScheduleState
+	mutex     *sync.Mutex
+	pending   bool
+	triggered chan struct{}
+}
+
+// NewTriggeredSchedule returns an instance of TriggeredSchedule given its triggers
+func NewTriggeredSchedule(triggers ...Trigger) *TriggeredSchedule {
+	return &TriggeredSchedule{
+		Triggers:  triggers,
+		mutex:     &sync.Mutex{},
+		triggered: make(chan struct{}),
+	}
+}
+
+// GetState returns the schedule state
+func (t *TriggeredSchedule) GetState() ScheduleState {
+	return t.state
+}
+
+// Validate returns an error if the schedule has no triggers or if a trigger
+// is not valid
+func (t *TriggeredSchedule) Validate() error {
+	if len(t.Triggers) == 0 {
+		return ErrMissingTriggers
+	}
+	for _, tr := range t.Triggers {
+		if tr.Task == "" {
+			return errors.New("Trigger is missing the task it refers to")
+		}
+		switch tr.On {
+		case TriggerOnSuccess, TriggerOnEnd, TriggerOnDisabled:
+		default:
+			return fmt.Errorf("Trigger on '%s' is not valid, must be one of %s, %s or %s", tr.On, TriggerOnSuccess, TriggerOnEnd, TriggerOnDisabled)
+		}
+	}
+	return nil
+}
+
+// GetTriggers returns a copy of the triggers of the schedule
+func (t *TriggeredSchedule) GetTriggers() []Trigger {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	triggers := make([]Trigger, len(t.Triggers))
+	copy(triggers, t.Triggers)
+	return triggers
+}
+
+// TriggeredBy returns whether the schedule has a trigger on the given event
+// of the given task
+func (t *TriggeredSchedule) TriggeredBy(task, on string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	for _, tr := range t.Triggers {
+		if tr.Task == task && tr.On == on {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveTriggers removes the triggers referring to the given task and returns
+// how many were removed
+func (t *TriggeredSchedule) RemoveTriggers(task string) int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	triggers := make([]Trigger, 0, len(t.Triggers))
+	for _, tr := range t.Triggers {
+		if tr.Task != task {
+			triggers = append(triggers, tr)
+		}
+	}
+	removed := len(t.Triggers) - len(triggers)
+	t.Triggers = triggers
+	return removed
+}
+
+// Trigger makes the schedule fire.  A trigger received while the schedule is
+// not waiting makes the next Wait return immediately.
+func (t *TriggeredSchedule) Trigger() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.pending = true
+	// wake every waiter and start a new generation
+	close(t.triggered)
+	t.triggered = make(chan struct{})
+}
+
+// Wait blocks until the schedule is triggered
+func (t *TriggeredSchedule) Wait(last time.Time) Response {
+	return t.WaitCancel(last, nil)
+}
+
+// WaitCancel waits like Wait unless cancel is closed first
+func (t *TriggeredSchedule) WaitCancel(last time.Time, cancel <-chan struct{}) Response {
+	t.mutex.Lock()
+	if !t.pending {
+		triggered := t.triggered
+		t.mutex.Unlock()
+		select {
+		case <-triggered:
+		case <-cancel:
+			return nil
+		}
+		t.mutex.Lock()
+	}
+	t.pending = false
+	t.mutex.Unlock()
+	return &TriggeredScheduleResponse{state: t.GetState(), lastTime: time.Now()}
+}
+
+// TriggeredScheduleResponse a response from TriggeredSchedule conforming to ScheduleResponse interface
+type TriggeredScheduleResponse struct {
+	state    ScheduleState
+	lastTime time.Time
+}
+
+// State returns the state of the Schedule
+func (t *TriggeredScheduleResponse) State() ScheduleState {
+	return t.state
+}
+
+// Error returns last error
+func (t *TriggeredScheduleResponse) Error() error {
+	return nil
+}
+
+// Missed returns any missed intervals, a triggered schedule never misses any
+func (t *TriggeredScheduleResponse) Missed() uint {
+	return 0
+}
+
+// LastTime returns the last response time
+func (t *TriggeredScheduleResponse) LastTime() time.Time {
+	return t.lastTime
+}
@@ -0,0 +1,68 @@
+// +build legacy
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTriggeredSchedule(t *testing.T) {
+	Convey("Triggered Schedule", t, func() {
+		Convey("test Wait() returns when triggered", func() {
+			s := NewTriggeredSchedule(Trigger{Task: "inventory", On: TriggerOnSuccess})
+			So(s.Validate(), ShouldBeNil)
+
+			rc := make(chan Response)
+			go func() {
+				rc <- s.Wait(time.Time{})
+			}()
+			select {
+			case <-rc:
+				t.Fatal("schedule fired without a trigger")
+			case <-time.After(50 * time.Millisecond):
+			}
+			s.Trigger()
+			select {
+			case r := <-rc:
+				So(r.State(), ShouldEqual, Active)
+				So(r.Missed(), ShouldEqual, 0)
+			case <-time.After(time.Second):
+				t.Fatal("schedule did not fire when triggered")
+			}
+		})
+
+		Convey("test Wait() returns for a trigger received before waiting", func() {
+			s := NewTriggeredSchedule(Trigger{Task: "inventory", On: TriggerOnEnd})
+			s.Trigger()
+			r := s.Wait(time.Now())
+			So(r.State(), ShouldEqual, Active)
+		})
+
+		Convey("test WaitCancel() returns nil once canceled", func() {
+			s := NewTriggeredSchedule(Trigger{Task: "inventory", On: TriggerOnEnd})
+			cancel := make(chan struct{})
+			close(cancel)
+			So(s.WaitCancel(time.Now(), cancel), ShouldBeNil)
+		})
+
+		Convey("test RemoveTriggers()", func() {
+			s := NewTriggeredSchedule(Trigger{Task: "inventory", On: TriggerOnEnd}, Trigger{Task: "cleanup", On: TriggerOnSuccess})
+			So(s.TriggeredBy("inventory", TriggerOnEnd), ShouldBeTrue)
+			So(s.RemoveTriggers("inventory"), ShouldEqual, 1)
+			So(s.TriggeredBy("inventory", TriggerOnEnd), ShouldBeFalse)
+			So(s.GetTriggers(), ShouldResemble, []Trigger{{Task: "cleanup", On: TriggerOnSuccess}})
+		})
+
+		Convey("invalid schedule", func() {
+			s := NewTriggeredSchedule()
+			So(s.Validate(), ShouldEqual, ErrMissingTriggers)
+			s = NewTriggeredSchedule(Trigger{On: TriggerOnDisabled})
+			So(s.Validate(), ShouldNotBeNil)
+			s = NewTriggeredSchedule(Trigger{Task: "inventory", On: "start"})
+			So(s.Validate(), ShouldNotBeNil)
+		})
+	})
+}
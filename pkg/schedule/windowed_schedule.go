@@ -15,7 +15,9 @@ type WindowedSchedule struct {
 	Interval  time.Duration
 	StartTime *time.Time
 	StopTime  *time.Time
-	state     ScheduleState
+	// CatchUp is the policy for the missed intervals (see CatchUpSkip)
+	CatchUp string
+	state   ScheduleState
 }
 
 // NewWindowedSchedule returns an instance of WindowedSchedule given duration,
@@ -49,12 +51,31 @@ func (w *WindowedSchedule) Validate() error {
 	if w.Interval <= 0 {
 		return ErrInvalidInterval
 	}
-	return nil
+	return ValidateCatchUp(w.CatchUp)
+}
+
+// CatchUpPolicy returns the policy for the missed intervals
+func (w *WindowedSchedule) CatchUpPolicy() string {
+	return w.CatchUp
+}
+
+// MissedIntervals returns the intervals of the window missed since last.  If
+// last is not set the intervals are counted from the start of the window.
+func (w *WindowedSchedule) MissedIntervals(last, now time.Time, max int) (uint, []time.Time) {
+	if (last == time.Time{}) && w.StartTime != nil {
+		last = *w.StartTime
+	}
+	return missedOnInterval(last, now, w.Interval, w.StopTime, nil, max)
 }
 
 // Wait waits the window interval and return.
 // Otherwise, it exits with a completed state
 func (w *WindowedSchedule) Wait(last time.Time) Response {
+	return w.WaitCancel(last, nil)
+}
+
+// WaitCancel waits like Wait unless cancel is closed first
+func (w *WindowedSchedule) WaitCancel(last time.Time, cancel <-chan struct{}) Response {
 	// Do we even have a specific start time?
 	if w.StartTime != nil {
 		// Wait till it is time to start if before the window start
@@ -64,7 +85,9 @@ func (w *WindowedSchedule) Wait(last time.Time) Response {
 				"_block":         "windowed-wait",
 				"sleep-duration": wait,
 			}).Debug("Waiting for window to start")
-			time.Sleep(wait)
+			if !sleep(wait, cancel) {
+				return nil
+			}
 		}
 		if (last == time.Time{}) {
 			logger.WithFields(log.Fields{
@@ -96,7 +119,10 @@ func (w *WindowedSchedule) Wait(last time.Time) Response {
 				"last":     last,
 				"interval": w.Interval,
 			}).Debug("waiting for interval")
-			m, _ = waitOnInterval(last, w.Interval)
+			var ok bool
+			if m, _, ok = waitOnInterval(last, w.Interval, cancel); !ok {
+				return nil
+			}
 		} else {
 			w.state = Ended
 			m = 0
@@ -108,7 +134,10 @@ func (w *WindowedSchedule) Wait(last time.Time) Response {
 			"interval": w.Interval,
 		}).Debug("waiting for interval")
 		// This has no end like a simple schedule
-		m, _ = waitOnInterval(last, w.Interval)
+		var ok bool
+		if m, _, ok = waitOnInterval(last, w.Interval, cancel); !ok {
+			return nil
+		}
 
 	}
 	return &WindowedScheduleResponse{
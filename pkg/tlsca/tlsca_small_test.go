@@ -0,0 +1,120 @@
+// +build small
+
+/*
+http://www.apache.org/licenses/LICENSE-2.0.txt
+
+
+Copyright 2016 Intel Corporation
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsca
+
+import (
+	"crypto/tls"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// handshake connects a client of the client config to a server of the server
+// config and returns the error of the client reading from the server, which
+// only writes once it verified the client
+func handshake(server, client *tls.Config) error {
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", server)
+	if err != nil {
+		return err
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if conn.(*tls.Conn).Handshake() == nil {
+			conn.Write([]byte{1})
+		}
+	}()
+	conn, err := tls.Dial("tcp", ln.Addr().String(), client)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = conn.Read(make([]byte, 1))
+	return err
+}
+
+func writeFiles(dir string, ca *CA, cert *Certificate) (string, string, string) {
+	certPath := filepath.Join(dir, "server.crt")
+	keyPath := filepath.Join(dir, "server.key")
+	caPath := filepath.Join(dir, "ca.crt")
+	So(ioutil.WriteFile(certPath, cert.CertPEM, 0600), ShouldBeNil)
+	So(ioutil.WriteFile(keyPath, cert.KeyPEM, 0600), ShouldBeNil)
+	So(ioutil.WriteFile(caPath, ca.CertPEM(), 0644), ShouldBeNil)
+	return certPath, keyPath, caPath
+}
+
+func TestCA(t *testing.T) {
+	Convey("Given a CA", t, func() {
+		ca, err := New("test CA", time.Hour)
+		So(err, ShouldBeNil)
+		dir, err := ioutil.TempDir("", "tlsca")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		server, err := ca.Issue(pkix.Name{CommonName: "plugin"}, []string{"a.plugin.snap"})
+		So(err, ShouldBeNil)
+		client, err := ca.Issue(pkix.Name{CommonName: "snapd"}, nil)
+		So(err, ShouldBeNil)
+		serverConfig, err := ServerConfig(writeFiles(dir, ca, server))
+		So(err, ShouldBeNil)
+
+		Convey("It issues certificates verifying against it", func() {
+			So(server.Cert.Subject.CommonName, ShouldEqual, "plugin")
+			So(server.Cert.DNSNames, ShouldResemble, []string{"a.plugin.snap"})
+			So(server.Cert.CheckSignatureFrom(ca.cert), ShouldBeNil)
+		})
+		Convey("A client of the server name handshakes", func() {
+			clientConfig, err := ca.ClientConfig(client, "a.plugin.snap")
+			So(err, ShouldBeNil)
+			So(handshake(serverConfig, clientConfig), ShouldBeNil)
+		})
+		Convey("A client of another server name fails to handshake", func() {
+			clientConfig, err := ca.ClientConfig(client, "b.plugin.snap")
+			So(err, ShouldBeNil)
+			So(handshake(serverConfig, clientConfig), ShouldNotBeNil)
+		})
+		Convey("A client with a certificate of another CA fails to handshake", func() {
+			other, err := New("other CA", time.Hour)
+			So(err, ShouldBeNil)
+			otherClient, err := other.Issue(pkix.Name{CommonName: "snapd"}, nil)
+			So(err, ShouldBeNil)
+			clientConfig, err := ca.ClientConfig(otherClient, "a.plugin.snap")
+			So(err, ShouldBeNil)
+			So(handshake(serverConfig, clientConfig), ShouldNotBeNil)
+		})
+		Convey("A CA file without certificates is rejected", func() {
+			empty := filepath.Join(dir, "empty.crt")
+			So(ioutil.WriteFile(empty, []byte("none"), 0644), ShouldBeNil)
+			_, err := ServerConfig(filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key"), empty)
+			So(err, ShouldEqual, ErrNoCACert)
+		})
+	})
+}
@@ -37,6 +37,18 @@ const (
 type Config struct {
 	WorkManagerQueueSize uint `json:"work_manager_queue_size"yaml:"work_manager_queue_size"`
 	WorkManagerPoolSize  uint `json:"work_manager_pool_size"yaml:"work_manager_pool_size"`
+	// WorkManagerClasses overrides the settings of the priority classes of
+	// the tasks, keyed by the name of the class
+	WorkManagerClasses map[string]*WorkClassConfig `json:"work_manager_classes" yaml:"work_manager_classes"`
+}
+
+// WorkClassConfig holds the settings of a priority class passed in through
+// the SNAP config file.  The settings left out default to the weight of the
+// class and the work manager queue and pool sizes.
+type WorkClassConfig struct {
+	Weight    uint `json:"weight" yaml:"weight"`
+	QueueSize uint `json:"queue_size" yaml:"queue_size"`
+	PoolSize  uint `json:"pool_size" yaml:"pool_size"`
 }
 
 const (
@@ -51,6 +63,33 @@ const (
 					"work_manager_pool_size" : {
 						"type": "integer",
 						"minimum": 1
+					},
+					"work_manager_classes" : {
+						"type": ["object", "null"],
+						"properties" : {
+							"high": { "$ref": "#/definitions/scheduler_work_class" },
+							"normal": { "$ref": "#/definitions/scheduler_work_class" },
+							"low": { "$ref": "#/definitions/scheduler_work_class" }
+						},
+						"additionalProperties": false
+					}
+				},
+				"additionalProperties": false
+			},
+			"scheduler_work_class": {
+				"type": ["object", "null"],
+				"properties" : {
+					"weight" : {
+						"type": "integer",
+						"minimum": 1
+					},
+					"queue_size" : {
+						"type": "integer",
+						"minimum": 1
+					},
+					"pool_size" : {
+						"type": "integer",
+						"minimum": 1
 					}
 				},
 				"additionalProperties": false
@@ -88,6 +127,10 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 			if err := json.Unmarshal(v, &(c.WorkManagerPoolSize)); err != nil {
 				return fmt.Errorf("%v (while parsing 'scheduler::work_manager_pool_size')", err)
 			}
+		case "work_manager_classes":
+			if err := json.Unmarshal(v, &(c.WorkManagerClasses)); err != nil {
+				return fmt.Errorf("%v (while parsing 'scheduler::work_manager_classes')", err)
+			}
 		default:
 			return fmt.Errorf("Unrecognized key '%v' in global config file while parsing 'scheduler'", k)
 		}
@@ -70,6 +70,11 @@ func TestSchedulerConfigJSON(t *testing.T) {
 		Convey("WorkManagerPoolSize should equal 2", func() {
 			So(cfg.WorkManagerPoolSize, ShouldEqual, 2)
 		})
+		Convey("The low work class pool size should equal 1", func() {
+			So(cfg.WorkManagerClasses, ShouldContainKey, "low")
+			So(cfg.WorkManagerClasses["low"].PoolSize, ShouldEqual, 1)
+			So(cfg.WorkManagerClasses["low"].QueueSize, ShouldEqual, 0)
+		})
 	})
 
 }
@@ -94,6 +99,11 @@ func TestSchedulerConfigYaml(t *testing.T) {
 		Convey("WorkManagerPoolSize should equal 2", func() {
 			So(cfg.WorkManagerPoolSize, ShouldEqual, 2)
 		})
+		Convey("The low work class pool size should equal 1", func() {
+			So(cfg.WorkManagerClasses, ShouldContainKey, "low")
+			So(cfg.WorkManagerClasses["low"].PoolSize, ShouldEqual, 1)
+			So(cfg.WorkManagerClasses["low"].QueueSize, ShouldEqual, 0)
+		})
 	})
 
 }
@@ -338,6 +338,7 @@ type subscriptionManager struct {
 	Fail                 bool
 	SubscribeCallCount   int
 	UnsubscribeCallCount int
+	UpdateCallCount      int
 }
 
 func (m *subscriptionManager) SubscribeDeps(taskID string, reqs []core.RequestedMetric, cps []core.SubscribedPlugin, cdt *cdata.ConfigDataTree) []serror.SnapError {
@@ -352,3 +353,11 @@ func (m *subscriptionManager) UnsubscribeDeps(taskID string) []serror.SnapError
 	m.UnsubscribeCallCount += 1
 	return nil
 }
+
+func (m *subscriptionManager) UpdateDeps(taskID string, reqs []core.RequestedMetric, cps []core.SubscribedPlugin, cdt *cdata.ConfigDataTree) []serror.SnapError {
+	if m.Fail {
+		return []serror.SnapError{serror.New(errors.New("error"))}
+	}
+	m.UpdateCallCount += 1
+	return nil
+}
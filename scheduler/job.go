@@ -86,6 +86,8 @@ type job interface {
 	Type() jobType
 	TypeString() string
 	TaskID() string
+	// Priority returns the priority class of the task of the job
+	Priority() string
 	Run()
 	Metrics() []core.Metric
 }
@@ -97,19 +99,21 @@ type coreJob struct {
 	name      string
 	version   int
 	taskID    string
+	priority  string
 	jtype     jobType
 	deadline  time.Time
 	starttime time.Time
 	errors    []error
 }
 
-func newCoreJob(t jobType, deadline time.Time, taskID string, name string, version int) *coreJob {
+func newCoreJob(t jobType, deadline time.Time, taskID string, priority string, name string, version int) *coreJob {
 	return &coreJob{
 		jtype:     t,
 		name:      name,
 		version:   version,
 		deadline:  deadline,
 		taskID:    taskID,
+		priority:  priority,
 		errors:    make([]error, 0),
 		starttime: time.Now(),
 	}
@@ -163,6 +167,10 @@ func (c *coreJob) TaskID() string {
 	return c.taskID
 }
 
+func (c *coreJob) Priority() string {
+	return c.priority
+}
+
 type collectorJob struct {
 	*coreJob
 	collector      collectsMetrics
@@ -178,13 +186,14 @@ func newCollectorJob(
 	collector collectsMetrics,
 	cdt *cdata.ConfigDataTree,
 	taskID string,
+	priority string,
 	tags map[string]map[string]string,
 ) job {
 	return &collectorJob{
 		collector:      collector,
 		metricTypes:    metricTypes,
 		metrics:        []core.Metric{},
-		coreJob:        newCoreJob(collectJobType, time.Now().Add(deadlineDuration), taskID, "", 0),
+		coreJob:        newCoreJob(collectJobType, time.Now().Add(deadlineDuration), taskID, priority, "", 0),
 		configDataTree: cdt,
 		tags:           tags,
 	}
@@ -279,7 +288,7 @@ func newProcessJob(parentJob job, pluginName string, pluginVersion int, contentT
 	return &processJob{
 		parentJob: parentJob,
 		metrics:   []core.Metric{},
-		coreJob:   newCoreJob(processJobType, parentJob.Deadline(), taskID, pluginName, pluginVersion),
+		coreJob:   newCoreJob(processJobType, parentJob.Deadline(), taskID, parentJob.Priority(), pluginName, pluginVersion),
 		config:    config,
 		processor: processor,
 	}
@@ -328,7 +337,7 @@ func newPublishJob(parentJob job, pluginName string, pluginVersion int, contentT
 	return &publisherJob{
 		parentJob: parentJob,
 		publisher: publisher,
-		coreJob:   newCoreJob(publishJobType, parentJob.Deadline(), taskID, pluginName, pluginVersion),
+		coreJob:   newCoreJob(publishJobType, parentJob.Deadline(), taskID, parentJob.Priority(), pluginName, pluginVersion),
 		config:    config,
 	}
 }
@@ -22,15 +22,39 @@ package scheduler
 import (
 	"errors"
 	"sync"
+
+	"github.com/intelsdi-x/snap/core"
 )
 
 var (
 	errQueueEmpty    = errors.New("queue empty")
+	errQueueBusy     = errors.New("queue busy")
 	errLimitExceeded = errors.New("limit exceeded")
 )
 
+// The weights of the priority classes unless they are set
+var defaultClassWeights = map[string]uint{
+	core.TaskPriorityHigh:   4,
+	core.TaskPriorityNormal: 2,
+	core.TaskPriorityLow:    1,
+}
+
 type jobHandler func(queuedJob)
 
+/*
+   The queue is a weighted fair queue across tasks: each task is a flow of
+   jobs and the jobs are worked in the order of their start tags.  The start
+   tag of a job is the virtual time or, if the task still has jobs ahead of
+   it, the start tag of the previous job of the task plus 1/weight, where the
+   weight is the one of the priority class of the task.  A task with a large
+   fan-out then cannot hold back the jobs of the other tasks and the tasks of
+   a class of weight 4 get 4 times the share of a class of weight 1.
+
+   The jobs of each class can wait up to its limit in the queue and use up to
+   its pool size of workers at once (no limit when zero).  The classes share
+   the size of the queue, so all together their jobs never wait beyond it.
+*/
+
 type queue struct {
 	Event chan queuedJob
 	Err   chan *queuingError
@@ -38,11 +62,31 @@ type queue struct {
 	handler jobHandler
 	limit   uint
 	kill    chan struct{}
-	items   []queuedJob
+	wake    chan struct{}
+	items   []*queueItem
+	classes map[string]*queueClass
+	flows   map[string]float64
+	vtime   float64
+	seq     uint64
 	mutex   *sync.Mutex
 	status  queueStatus
 }
 
+type queueItem struct {
+	job   queuedJob
+	class string
+	start float64
+	seq   uint64
+}
+
+type queueClass struct {
+	weight   uint
+	limit    uint
+	poolSize uint
+	queued   uint
+	running  uint
+}
+
 type queueStatus int
 
 const (
@@ -68,7 +112,10 @@ func newQueue(limit uint, handler jobHandler) *queue {
 		handler: handler,
 		limit:   limit,
 		kill:    make(chan struct{}),
-		items:   []queuedJob{},
+		wake:    make(chan struct{}, 1),
+		items:   []*queueItem{},
+		classes: make(map[string]*queueClass),
+		flows:   make(map[string]float64),
 		mutex:   &sync.Mutex{},
 		status:  queueStopped,
 	}
@@ -99,12 +146,24 @@ func (q *queue) Stop() {
 	}
 }
 
+// SetClass sets the weight, the limit and the pool size of a priority class
+func (q *queue) SetClass(wc core.WorkClass) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	c := q.class(wc.Name)
+	c.weight = wc.Weight
+	c.limit = wc.QueueSize
+	c.poolSize = wc.PoolSize
+	q.signal()
+}
+
 /*
    Below is the private, internal functionality of the queue.
    These functions are not thread-safe, and should not be used
    outside the queue itself.  The only interaction between a queue
    and outside consumers should be through the Event chan, the
-   Err chan, Start(), or Stop().
+   Err chan, Start(), SetClass() or Stop().
 */
 
 func (q *queue) start() {
@@ -129,6 +188,7 @@ func (q *queue) start() {
 				continue
 			}
 			q.mutex.Unlock()
+			q.signal()
 
 		case <-q.kill:
 			// this "officially" closes the Event channel.
@@ -146,16 +206,57 @@ func (q *queue) start() {
 func (q *queue) handle() {
 	for {
 		item, err := q.pop()
-		if err == errQueueEmpty {
-			q.mutex.Lock()
-			q.status = queueRunning
-			q.mutex.Unlock()
+		switch err {
+		case errQueueEmpty:
 			return
+		case errQueueBusy:
+			// every class with a queued job uses all of its workers
+			select {
+			case <-q.wake:
+				continue
+			case <-q.kill:
+				return
+			}
 		}
-		q.handler(item)
+		class := item.class
+		item.job.Promise().AndThen(func([]error) { q.release(class) })
+		q.handler(item.job)
 	}
 }
 
+// signal wakes up the handling loop waiting for a worker
+func (q *queue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// release frees the worker used by a job of the class
+func (q *queue) release(class string) {
+	q.mutex.Lock()
+	q.class(class).running--
+	q.mutex.Unlock()
+	q.signal()
+}
+
+// class returns the priority class of the given name, created with the
+// defaults if it is not set
+func (q *queue) class(name string) *queueClass {
+	if name == "" {
+		name = core.TaskPriorityNormal
+	}
+	c, ok := q.classes[name]
+	if !ok {
+		c = &queueClass{weight: defaultClassWeights[name], limit: q.limit}
+		if c.weight == 0 {
+			c.weight = 1
+		}
+		q.classes[name] = c
+	}
+	return c
+}
+
 func (q *queue) length() int {
 	return len(q.items)
 }
@@ -165,26 +266,72 @@ func (q *queue) push(j queuedJob) error {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
 
-	if q.limit == 0 || uint(q.length())+1 <= q.limit {
-		q.items = append(q.items, j)
-		return nil
+	if q.limit != 0 && uint(q.length())+1 > q.limit {
+		return errLimitExceeded
+	}
+	c := q.class(j.Job().Priority())
+	if c.limit != 0 && c.queued+1 > c.limit {
+		return errLimitExceeded
+	}
+	flow := j.Job().TaskID()
+	start := q.vtime
+	if last, ok := q.flows[flow]; ok && last > start {
+		start = last
 	}
-	return errLimitExceeded
+	q.flows[flow] = start + 1/float64(c.weight)
+	q.seq++
+	q.items = append(q.items, &queueItem{
+		job:   j,
+		class: j.Job().Priority(),
+		start: start,
+		seq:   q.seq,
+	})
+	c.queued++
+	return nil
 }
 
-func (q *queue) pop() (queuedJob, error) {
+// pop returns the job with the lowest start tag among the classes which have
+// a worker left.  An empty queue is no longer worked.
+func (q *queue) pop() (*queueItem, error) {
 
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
 
-	var j queuedJob
-
 	if q.length() == 0 {
-		return j, errQueueEmpty
+		if q.status == queueWorking {
+			q.status = queueRunning
+		}
+		return nil, errQueueEmpty
 	}
 
-	j = q.items[0]
-	q.items = q.items[1:]
+	next := -1
+	for i, item := range q.items {
+		c := q.class(item.class)
+		if c.poolSize != 0 && c.running >= c.poolSize {
+			continue
+		}
+		if next < 0 || item.start < q.items[next].start ||
+			(item.start == q.items[next].start && item.seq < q.items[next].seq) {
+			next = i
+		}
+	}
+	if next < 0 {
+		return nil, errQueueBusy
+	}
 
-	return j, nil
+	item := q.items[next]
+	q.items = append(q.items[:next], q.items[next+1:]...)
+	c := q.class(item.class)
+	c.queued--
+	c.running++
+	if item.start > q.vtime {
+		q.vtime = item.start
+	}
+	// forget the tasks which are not ahead of the virtual time anymore
+	for flow, last := range q.flows {
+		if last <= q.vtime {
+			delete(q.flows, flow)
+		}
+	}
+	return item, nil
 }
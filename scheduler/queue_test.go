@@ -27,6 +27,7 @@ import (
 	"time"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/intelsdi-x/snap/core"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -84,7 +85,7 @@ func TestQueue(t *testing.T) {
 		q := newQueue(3, func(queuedJob) { time.Sleep(1 * time.Second) })
 		q.Start()
 		for i := 0; i < 5; i++ {
-			q.Event <- newQueuedJob(&collectorJob{})
+			q.Event <- newQueuedJob(&collectorJob{coreJob: &coreJob{}})
 		}
 		err := <-q.Err
 		So(err, ShouldNotBeNil)
@@ -98,7 +99,121 @@ func TestQueue(t *testing.T) {
 		q.Stop()
 		time.Sleep(10 * time.Millisecond)
 		So(func() { q.kill <- struct{}{} }, ShouldPanic)
-		So(func() { q.Event <- newQueuedJob(&collectorJob{}) }, ShouldPanic)
+		So(func() { q.Event <- newQueuedJob(&collectorJob{coreJob: &coreJob{}}) }, ShouldPanic)
 	})
 
+	Convey("it shares the workers fairly between the tasks", t, func() {
+		q := newQueue(10, func(queuedJob) {})
+		for i := 0; i < 4; i++ {
+			So(q.push(newQueuedJob(newPriorityJob("a", core.TaskPriorityNormal))), ShouldBeNil)
+		}
+		So(q.push(newQueuedJob(newPriorityJob("b", core.TaskPriorityNormal))), ShouldBeNil)
+		So(popTaskIDs(q), ShouldResemble, []string{"a", "b", "a", "a", "a"})
+	})
+
+	Convey("it favors the tasks of the classes of a higher weight", t, func() {
+		q := newQueue(10, func(queuedJob) {})
+		for i := 0; i < 2; i++ {
+			So(q.push(newQueuedJob(newPriorityJob("low", core.TaskPriorityLow))), ShouldBeNil)
+		}
+		for i := 0; i < 4; i++ {
+			So(q.push(newQueuedJob(newPriorityJob("high", core.TaskPriorityHigh))), ShouldBeNil)
+		}
+		So(popTaskIDs(q), ShouldResemble, []string{"low", "high", "high", "high", "high", "low"})
+	})
+
+	Convey("it limits the jobs of a class", t, func() {
+		q := newQueue(10, func(queuedJob) {})
+		q.SetClass(core.WorkClass{Name: core.TaskPriorityLow, Weight: 1, QueueSize: 1, PoolSize: 1})
+		So(q.push(newQueuedJob(newPriorityJob("a", core.TaskPriorityLow))), ShouldBeNil)
+		So(q.push(newQueuedJob(newPriorityJob("b", core.TaskPriorityLow))), ShouldEqual, errLimitExceeded)
+		So(q.push(newQueuedJob(newPriorityJob("c", core.TaskPriorityHigh))), ShouldBeNil)
+
+		Convey("to its pool size of workers", func() {
+			So(q.push(newQueuedJob(newPriorityJob("b", core.TaskPriorityHigh))), ShouldBeNil)
+			item, err := q.pop()
+			So(err, ShouldBeNil)
+			So(item.class, ShouldEqual, core.TaskPriorityLow)
+			So(q.push(newQueuedJob(newPriorityJob("b", core.TaskPriorityLow))), ShouldBeNil)
+			So(popTaskIDs(q), ShouldResemble, []string{"c", "b"})
+			_, err = q.pop()
+			So(err, ShouldEqual, errQueueBusy)
+			q.release(core.TaskPriorityLow)
+			So(popTaskIDs(q), ShouldResemble, []string{"b"})
+		})
+	})
+	Convey("it limits the jobs of all the classes to the queue size", t, func() {
+		q := newQueue(3, func(queuedJob) {})
+		So(q.push(newQueuedJob(newPriorityJob("a", core.TaskPriorityLow))), ShouldBeNil)
+		So(q.push(newQueuedJob(newPriorityJob("b", core.TaskPriorityNormal))), ShouldBeNil)
+		So(q.push(newQueuedJob(newPriorityJob("c", core.TaskPriorityHigh))), ShouldBeNil)
+		So(q.push(newQueuedJob(newPriorityJob("d", core.TaskPriorityHigh))), ShouldEqual, errLimitExceeded)
+		_, err := q.pop()
+		So(err, ShouldBeNil)
+		So(q.push(newQueuedJob(newPriorityJob("d", core.TaskPriorityHigh))), ShouldBeNil)
+	})
+
+	Convey("it keeps the jobs of a class within its pool size while another class is saturated", t, func() {
+		var mutex sync.Mutex
+		running := map[string]int{}
+		maxRunning := map[string]int{}
+		release := make(chan struct{})
+		q := newQueue(40, func(j queuedJob) {
+			// the jobs are worked in the background like by the workers
+			go func() {
+				class := j.Job().Priority()
+				mutex.Lock()
+				running[class]++
+				if running[class] > maxRunning[class] {
+					maxRunning[class] = running[class]
+				}
+				mutex.Unlock()
+				<-release
+				time.Sleep(time.Millisecond)
+				mutex.Lock()
+				running[class]--
+				mutex.Unlock()
+				j.Promise().Complete([]error{})
+			}()
+		})
+		q.SetClass(core.WorkClass{Name: core.TaskPriorityLow, Weight: 1, QueueSize: 20, PoolSize: 2})
+		q.SetClass(core.WorkClass{Name: core.TaskPriorityHigh, Weight: 4, QueueSize: 20, PoolSize: 4})
+		q.Start()
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			for _, class := range []string{core.TaskPriorityLow, core.TaskPriorityHigh} {
+				wg.Add(1)
+				qj := newQueuedJob(newPriorityJob(class, class))
+				qj.Promise().AndThen(func([]error) { wg.Done() })
+				q.Event <- qj
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		mutex.Lock()
+		So(running[core.TaskPriorityLow], ShouldEqual, 2)
+		So(running[core.TaskPriorityHigh], ShouldEqual, 4)
+		mutex.Unlock()
+		close(release)
+		wg.Wait()
+		So(maxRunning[core.TaskPriorityLow], ShouldEqual, 2)
+		So(maxRunning[core.TaskPriorityHigh], ShouldEqual, 4)
+		q.Stop()
+	})
+}
+
+func newPriorityJob(taskID, priority string) *collectorJob {
+	return &collectorJob{coreJob: &coreJob{taskID: taskID, priority: priority}}
+}
+
+// popTaskIDs pops the jobs from the queue until it is empty or busy and
+// returns the ids of their tasks
+func popTaskIDs(q *queue) []string {
+	ids := []string{}
+	for {
+		item, err := q.pop()
+		if err != nil {
+			return ids
+		}
+		ids = append(ids, item.job.Job().TaskID())
+	}
 }
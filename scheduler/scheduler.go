@@ -20,6 +20,8 @@ limitations under the License.
 package scheduler
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -73,6 +75,10 @@ const (
 	schedulerStarted
 )
 
+// taskParamsSuffix marks the files holding the parameter values of the
+// autodiscovered task file with the same name
+const taskParamsSuffix = ".params"
+
 type depGroupMap map[string]struct {
 	requestedMetrics  []core.RequestedMetric
 	subscribedPlugins []core.SubscribedPlugin
@@ -92,6 +98,7 @@ type managesMetrics interface {
 	ValidateDeps([]core.RequestedMetric, []core.SubscribedPlugin, *cdata.ConfigDataTree) []serror.SnapError
 	SubscribeDeps(string, []core.RequestedMetric, []core.SubscribedPlugin, *cdata.ConfigDataTree) []serror.SnapError
 	UnsubscribeDeps(string) []serror.SnapError
+	UpdateDeps(string, []core.RequestedMetric, []core.SubscribedPlugin, *cdata.ConfigDataTree) []serror.SnapError
 }
 
 type collectsMetrics interface {
@@ -199,8 +206,18 @@ func autoDiscoverTasks(taskFiles []os.FileInfo, fullPath string,
 			}
 			defer f.Close()
 		}
+		params, err := readTaskParams(fullPath, file.Name())
+		if err != nil {
+			log.WithFields(log.Fields{
+				"_block":           "autoDiscoverTasks",
+				"_module":          "scheduler",
+				"autodiscoverpath": fullPath,
+				"task":             file.Name(),
+			}).Error("Reading task parameters ", err)
+			continue
+		}
 		mode := true
-		task, err := core.CreateTaskFromContent(f, &mode, fp)
+		task, err := core.CreateTaskFromTemplate(f, params, &mode, fp)
 		if err != nil {
 			log.WithFields(log.Fields{
 				"_block":           "autoDiscoverTasks",
@@ -224,6 +241,41 @@ func autoDiscoverTasks(taskFiles []os.FileInfo, fullPath string,
 	}
 }
 
+// isTaskParamsFile returns true for the files holding the parameter values of
+// an autodiscovered task (e.g. task.params.yaml for task.yaml).
+func isTaskParamsFile(name string) bool {
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	return strings.HasSuffix(strings.ToLower(base), taskParamsSuffix)
+}
+
+// readTaskParams reads the parameter values of an autodiscovered task file
+// from the params file next to it.  It returns nil if there is none.
+func readTaskParams(fullPath, taskFile string) (map[string]interface{}, error) {
+	base := strings.TrimSuffix(taskFile, filepath.Ext(taskFile))
+	for _, ext := range []string{".json", ".yaml", ".yml"} {
+		b, err := ioutil.ReadFile(path.Join(fullPath, base+taskParamsSuffix+ext))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		// YAML is a superset of JSON so both are converted
+		js, err := yaml.YAMLToJSON(b)
+		if err != nil {
+			return nil, err
+		}
+		params := map[string]interface{}{}
+		d := json.NewDecoder(bytes.NewReader(js))
+		d.UseNumber()
+		if err := d.Decode(&params); err != nil {
+			return nil, err
+		}
+		return params, nil
+	}
+	return nil, nil
+}
+
 // New returns an instance of the scheduler
 // The MetricManager must be set before the scheduler can be started.
 // The MetricManager must be started before it can be used.
@@ -253,6 +305,42 @@ func New(cfg *Config) *scheduler {
 	// we are setting the size of the queue and number of workers for
 	// collect, process and publish consistently for now
 	s.workManager = newWorkManager(opts...)
+	for _, name := range core.TaskPriorities {
+		c, ok := cfg.WorkManagerClasses[name]
+		if !ok || c == nil {
+			continue
+		}
+		wc := core.WorkClass{
+			Name:      name,
+			Weight:    defaultClassWeights[name],
+			QueueSize: cfg.WorkManagerQueueSize,
+			PoolSize:  cfg.WorkManagerPoolSize,
+		}
+		if c.Weight != 0 {
+			wc.Weight = c.Weight
+		}
+		if c.QueueSize != 0 {
+			wc.QueueSize = c.QueueSize
+		}
+		if c.PoolSize != 0 {
+			wc.PoolSize = c.PoolSize
+		}
+		if err := s.workManager.SetWorkClass(wc); err != nil {
+			schedulerLogger.WithFields(log.Fields{
+				"_block": "New",
+				"_error": err.Error(),
+				"class":  name,
+			}).Error("error setting work class")
+			continue
+		}
+		schedulerLogger.WithFields(log.Fields{
+			"_block":     "New",
+			"class":      name,
+			"weight":     wc.Weight,
+			"queue-size": wc.QueueSize,
+			"pool-size":  wc.PoolSize,
+		}).Info("Setting work class")
+	}
 	s.workManager.Start()
 	s.eventManager.RegisterHandler(HandlerRegistrationName, s)
 
@@ -310,6 +398,12 @@ func (s *scheduler) createTask(sch schedule.Schedule, wfMap *wmap.WorkflowMap, s
 		f.Error("schedule passed not valid")
 		return nil, te
 	}
+	if err := s.resolveTriggers("", sch); err != nil {
+		te.errs = append(te.errs, serror.New(err))
+		f := buildErrorsLog(te.Errors(), logger)
+		f.Error("schedule triggers not valid")
+		return nil, te
+	}
 
 	// Generate a workflow from the workflow map
 	wf, err := wmapToWorkflow(wfMap)
@@ -382,6 +476,323 @@ func (s *scheduler) createTask(sch schedule.Schedule, wfMap *wmap.WorkflowMap, s
 	return task, te
 }
 
+// UpdateTask changes the schedule and/or the workflow of an existing task
+// while keeping its ID.  A nil schedule or workflow map leaves that part of
+// the task unchanged and the provided options are applied afterwards.  The
+// new workflow is validated like the workflow of a new task.  If the task is
+// running its subscriptions are swapped to the dependencies of the new
+// workflow once its running fires are done and before it fires again; if
+// that fails the task is left unchanged.
+func (s *scheduler) UpdateTask(id string, sch schedule.Schedule, wfMap *wmap.WorkflowMap, opts ...core.TaskOption) (core.Task, core.TaskErrors) {
+	logger := schedulerLogger.WithFields(log.Fields{
+		"_block":  "update-task",
+		"task-id": id,
+	})
+	// Create a container for task errors
+	te := &taskErrors{
+		errs: make([]serror.SnapError, 0),
+	}
+
+	t, err := s.getTask(id)
+	if err != nil {
+		te.errs = append(te.errs, serror.New(err))
+		f := buildErrorsLog(te.Errors(), logger)
+		f.Error("error updating task")
+		return nil, te
+	}
+
+	// Ensure the schedule is valid at this point and time.
+	if sch != nil {
+		if err := sch.Validate(); err != nil {
+			te.errs = append(te.errs, serror.New(err))
+			f := buildErrorsLog(te.Errors(), logger)
+			f.Error("schedule passed not valid")
+			return nil, te
+		}
+		if err := s.resolveTriggers(id, sch); err != nil {
+			te.errs = append(te.errs, serror.New(err))
+			f := buildErrorsLog(te.Errors(), logger)
+			f.Error("schedule triggers not valid")
+			return nil, te
+		}
+	}
+
+	var wf *schedulerWorkflow
+	var mgrs managers
+	if wfMap != nil {
+		// Generate a workflow from the workflow map
+		wf, err = wmapToWorkflow(wfMap)
+		if err != nil {
+			te.errs = append(te.errs, serror.New(err))
+			f := buildErrorsLog(te.Errors(), logger)
+			f.Error("Unable to generate workflow from workflow map")
+			return nil, te
+		}
+		wf.eventEmitter = s.eventManager
+		mgrs = newManagers(s.metricManager)
+		if err := createTaskClients(&mgrs, wf); err != nil {
+			te.errs = append(te.errs, serror.New(err))
+			f := buildErrorsLog(te.Errors(), logger)
+			f.Error("Unable to create task clients")
+			return nil, te
+		}
+
+		// Group dependencies by the node they live on
+		// and validate them.
+		depGroups := getWorkflowPlugins(wf.processNodes, wf.publishNodes, wf.metrics)
+		for k, group := range depGroups {
+			manager, err := mgrs.Get(k)
+			if err != nil {
+				te.errs = append(te.errs, serror.New(err))
+				return nil, te
+			}
+			errs := manager.ValidateDeps(group.requestedMetrics, group.subscribedPlugins, wf.configTree)
+			if len(errs) > 0 {
+				te.errs = append(te.errs, errs...)
+				return nil, te
+			}
+		}
+	}
+
+	// Hold the task lock so the task cannot fire while it is being changed
+	t.Lock()
+	if wf != nil {
+		if t.state == core.TaskSpinning || t.state == core.TaskFiring {
+			// fires running in the background still use the
+			// subscriptions of the current workflow
+			t.waitForFires()
+			if errs := swapDeps(t.id, t.workflow, t.RemoteManagers, wf, mgrs); len(errs) > 0 {
+				t.Unlock()
+				te.errs = append(te.errs, errs...)
+				f := buildErrorsLog(te.Errors(), logger)
+				f.Error("Unable to swap task subscriptions")
+				return nil, te
+			}
+		}
+		t.workflow = wf
+		t.RemoteManagers = mgrs
+	}
+	if sch != nil {
+		t.scheduleMutex.Lock()
+		t.schedule = sch
+		t.scheduleMutex.Unlock()
+		t.reschedule()
+	}
+	t.Unlock()
+	t.Option(opts...)
+
+	logger.WithFields(log.Fields{
+		"task-state":       t.State(),
+		"schedule-updated": sch != nil,
+		"workflow-updated": wfMap != nil,
+	}).Info("task updated")
+	return t, te
+}
+
+// ValidateTask validates a schedule and a workflow map the way CreateTask does
+// without creating a task or subscribing to any plugin.  Every error found is
+// returned with a "path" field locating the part of the task manifest it
+// relates to.  A nil schedule is not validated.
+func (s *scheduler) ValidateTask(sch schedule.Schedule, wfMap *wmap.WorkflowMap) core.TaskErrors {
+	logger := schedulerLogger.WithFields(log.Fields{
+		"_block": "validate-task",
+	})
+	// Create a container for task errors
+	te := &taskErrors{
+		errs: make([]serror.SnapError, 0),
+	}
+
+	if sch != nil {
+		if err := sch.Validate(); err != nil {
+			te.errs = append(te.errs, serror.New(err, map[string]interface{}{"path": "schedule"}))
+		} else if err := s.resolveTriggers("", sch); err != nil {
+			te.errs = append(te.errs, serror.New(err, map[string]interface{}{"path": "schedule.triggers"}))
+		}
+	}
+
+	// Generate a workflow from the workflow map
+	wf, err := wmapToWorkflow(wfMap)
+	if err != nil {
+		path := "workflow.collect"
+		if err == ErrNoMetricsInCollectNode {
+			path = "workflow.collect.metrics"
+		}
+		te.errs = append(te.errs, serror.New(err, map[string]interface{}{"path": path}))
+		f := buildErrorsLog(te.Errors(), logger)
+		f.Debug("task not valid")
+		return te
+	}
+	mgrs := newManagers(s.metricManager)
+	if err := createTaskClients(&mgrs, wf); err != nil {
+		te.errs = append(te.errs, serror.New(err, map[string]interface{}{"path": "workflow.collect"}))
+		f := buildErrorsLog(te.Errors(), logger)
+		f.Debug("task not valid")
+		return te
+	}
+
+	// Validate the dependencies of each requested metric and each plugin
+	// separately so the errors can be tied to their place in the workflow.
+	local, _ := mgrs.Get("")
+	for _, m := range wf.metrics {
+		errs := local.ValidateDeps([]core.RequestedMetric{m}, nil, wf.configTree)
+		te.errs = append(te.errs, setErrorsPath(errs, fmt.Sprintf("workflow.collect.metrics[%q]", m.Namespace().String()))...)
+	}
+	te.errs = append(te.errs, validateNodeDeps(wf.processNodes, wf.publishNodes, wf.configTree, &mgrs, "workflow.collect")...)
+
+	if len(te.errs) > 0 {
+		f := buildErrorsLog(te.Errors(), logger)
+		f.Debug("task not valid")
+	}
+	return te
+}
+
+func validateNodeDeps(prnodes []*processNode, pbnodes []*publishNode, configTree *cdata.ConfigDataTree, mgrs *managers, parent string) []serror.SnapError {
+	var serrs []serror.SnapError
+	validate := func(p core.SubscribedPlugin, target, at string) {
+		manager, err := mgrs.Get(target)
+		if err != nil {
+			serrs = append(serrs, serror.New(err, map[string]interface{}{"path": at}))
+			return
+		}
+		errs := manager.ValidateDeps(nil, []core.SubscribedPlugin{p}, configTree)
+		serrs = append(serrs, setErrorsPath(errs, at)...)
+	}
+	for i, pr := range prnodes {
+		prPath := fmt.Sprintf("%s.process[%d]", parent, i)
+		validate(pr, pr.Target, prPath)
+		serrs = append(serrs, validateNodeDeps(pr.ProcessNodes, pr.PublishNodes, configTree, mgrs, prPath)...)
+	}
+	for i, pb := range pbnodes {
+		validate(pb, pb.Target, fmt.Sprintf("%s.publish[%d]", parent, i))
+	}
+	return serrs
+}
+
+// setErrorsPath adds the given path to the fields of the errors.
+func setErrorsPath(errs []serror.SnapError, at string) []serror.SnapError {
+	for _, e := range errs {
+		fields := map[string]interface{}{"path": at}
+		for k, v := range e.Fields() {
+			fields[k] = v
+		}
+		e.SetFields(fields)
+	}
+	return errs
+}
+
+// resolveTriggers makes the triggers of a triggered schedule refer to tasks by
+// ID.  A trigger can refer to a task by ID or by name as long as the name is
+// unique.  An error is returned if a task is not found or if the triggers
+// would make the task with the given ID trigger itself through a cycle of
+// triggered tasks.  Other schedules are left as they are.
+func (s *scheduler) resolveTriggers(id string, sch schedule.Schedule) error {
+	ts, ok := sch.(*schedule.TriggeredSchedule)
+	if !ok {
+		return nil
+	}
+	tasks := s.tasks.Table()
+	for i, tr := range ts.Triggers {
+		ref, err := findTriggerTask(tasks, tr.Task)
+		if err != nil {
+			return err
+		}
+		ts.Triggers[i].Task = ref
+	}
+	// A new task cannot be referred to by any trigger yet
+	if id == "" {
+		return nil
+	}
+	visited := map[string]bool{}
+	var walk func(triggers []schedule.Trigger) error
+	walk = func(triggers []schedule.Trigger) error {
+		for _, tr := range triggers {
+			if tr.Task == id {
+				return fmt.Errorf("Triggers of task %s form a cycle", id)
+			}
+			if visited[tr.Task] {
+				continue
+			}
+			visited[tr.Task] = true
+			if t, ok := tasks[tr.Task]; ok {
+				if ts, ok := t.Schedule().(*schedule.TriggeredSchedule); ok {
+					if err := walk(ts.GetTriggers()); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}
+	return walk(ts.Triggers)
+}
+
+func findTriggerTask(tasks map[string]*task, ref string) (string, error) {
+	if _, ok := tasks[ref]; ok {
+		return ref, nil
+	}
+	var found []string
+	for id, t := range tasks {
+		if t.GetName() == ref {
+			found = append(found, id)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return "", fmt.Errorf("Trigger refers to unknown task '%s'", ref)
+	case 1:
+		return found[0], nil
+	default:
+		return "", fmt.Errorf("Trigger refers to task name '%s' used by %d tasks, use the task id instead", ref, len(found))
+	}
+}
+
+// fireTriggers triggers the running tasks with a triggered schedule waiting
+// on the given event of the task with the given ID.
+func (s *scheduler) fireTriggers(id, on string) {
+	for _, t := range s.tasks.Table() {
+		ts, ok := t.Schedule().(*schedule.TriggeredSchedule)
+		if !ok || !ts.TriggeredBy(id, on) {
+			continue
+		}
+		if state := t.State(); state == core.TaskSpinning || state == core.TaskFiring {
+			schedulerLogger.WithFields(log.Fields{
+				"_block":     "fire-triggers",
+				"task-id":    t.ID(),
+				"trigger-id": id,
+				"trigger-on": on,
+			}).Debug("triggering task")
+			ts.Trigger()
+		}
+	}
+}
+
+// removeTriggers removes the triggers referring to the removed task with the
+// given ID from the schedules of the other tasks.  A task left without any
+// trigger could never fire again so it is disabled.
+func (s *scheduler) removeTriggers(id string) {
+	for _, t := range s.tasks.Table() {
+		ts, ok := t.Schedule().(*schedule.TriggeredSchedule)
+		if !ok {
+			continue
+		}
+		n := ts.RemoveTriggers(id)
+		if n == 0 {
+			continue
+		}
+		remaining := len(ts.GetTriggers())
+		schedulerLogger.WithFields(log.Fields{
+			"_block":     "remove-triggers",
+			"task-id":    t.ID(),
+			"trigger-id": id,
+			"removed":    n,
+			"remaining":  remaining,
+		}).Warn("removed triggers referring to a removed task")
+		if remaining == 0 {
+			t.disableWith(fmt.Errorf("%v (the last one, %s, was removed)", ErrTaskTriggersRemoved, id))
+		}
+	}
+}
+
 // RemoveTask given a tasks id.  The task must be stopped.
 // Can return errors ErrTaskNotFound and ErrTaskNotStopped.
 func (s *scheduler) RemoveTask(id string) error {
@@ -410,7 +821,11 @@ func (s *scheduler) removeTask(id, source string) error {
 	}
 
 	defer s.eventManager.Emit(event)
-	return s.tasks.remove(t)
+	if err := s.tasks.remove(t); err != nil {
+		return err
+	}
+	s.removeTriggers(t.id)
+	return nil
 }
 
 // GetTasks returns a copy of the tasks in a map where the task id is the key
@@ -632,6 +1047,33 @@ func (s *scheduler) EnableTask(id string) (core.Task, error) {
 	return t, nil
 }
 
+// GetWorkClasses returns the settings the jobs of the tasks of each priority
+// class are queued and worked with
+func (s *scheduler) GetWorkClasses() []core.WorkClass {
+	return s.workManager.WorkClasses()
+}
+
+// SetWorkClass changes the weight, queue size and pool size of a priority
+// class at runtime
+func (s *scheduler) SetWorkClass(wc core.WorkClass) error {
+	if err := s.workManager.SetWorkClass(wc); err != nil {
+		schedulerLogger.WithFields(log.Fields{
+			"_block": "set-work-class",
+			"_error": err.Error(),
+			"class":  wc.Name,
+		}).Error("error setting work class")
+		return err
+	}
+	schedulerLogger.WithFields(log.Fields{
+		"_block":     "set-work-class",
+		"class":      wc.Name,
+		"weight":     wc.Weight,
+		"queue-size": wc.QueueSize,
+		"pool-size":  wc.PoolSize,
+	}).Info("work class set")
+	return nil
+}
+
 // Start starts the scheduler
 func (s *scheduler) Start() error {
 	if s.metricManager == nil {
@@ -684,6 +1126,10 @@ func (s *scheduler) Start() error {
 				if !strings.HasSuffix(fname, ".json") && !strings.HasSuffix(fname, ".yaml") && !strings.HasSuffix(fname, ".yml") {
 					continue
 				}
+				// parameter values are read along with their task file
+				if isTaskParamsFile(fname) {
+					continue
+				}
 				taskFiles = append(taskFiles, file)
 			}
 			autoDiscoverTasks(taskFiles, fullPath, s.CreateTask)
@@ -786,6 +1232,23 @@ func (s *scheduler) HandleGomitEvent(e gomit.Event) {
 			}
 		}
 		s.taskWatcherColl.handleTaskDisabled(v.TaskID, v.Why)
+		s.fireTriggers(v.TaskID, schedule.TriggerOnDisabled)
+	case *scheduler_event.TaskEndedEvent:
+		log.WithFields(log.Fields{
+			"_module":         "scheduler-events",
+			"_block":          "handle-events",
+			"event-namespace": e.Namespace(),
+			"task-id":         v.TaskID,
+		}).Debug("event received")
+		s.fireTriggers(v.TaskID, schedule.TriggerOnEnd)
+	case *scheduler_event.TaskRunSucceededEvent:
+		log.WithFields(log.Fields{
+			"_module":         "scheduler-events",
+			"_block":          "handle-events",
+			"event-namespace": e.Namespace(),
+			"task-id":         v.TaskID,
+		}).Debug("event received")
+		s.fireTriggers(v.TaskID, schedule.TriggerOnSuccess)
 	default:
 		log.WithFields(log.Fields{
 			"_module":         "scheduler-events",
@@ -803,6 +1266,62 @@ func (s *scheduler) getTask(id string) (*task, error) {
 	return task, nil
 }
 
+// swapDeps moves the subscriptions of a running task from the dependencies of
+// its current workflow to the dependencies of its new workflow.  Nodes used by
+// both workflows update the subscription in place so that plugins needed by
+// both are not restarted.  If a subscription cannot be changed the ones which
+// were already changed are restored.
+func swapDeps(id string, oldWf *schedulerWorkflow, oldMgrs managers, newWf *schedulerWorkflow, newMgrs managers) []serror.SnapError {
+	oldGroups := getWorkflowPlugins(oldWf.processNodes, oldWf.publishNodes, oldWf.metrics)
+	newGroups := getWorkflowPlugins(newWf.processNodes, newWf.publishNodes, newWf.metrics)
+
+	var swapped []string
+	for k, group := range newGroups {
+		var errs []serror.SnapError
+		mgr, err := newMgrs.Get(k)
+		if err != nil {
+			errs = append(errs, serror.New(err))
+		} else if _, ok := oldGroups[k]; ok {
+			errs = mgr.UpdateDeps(id, group.requestedMetrics, group.subscribedPlugins, newWf.configTree)
+		} else {
+			errs = mgr.SubscribeDeps(id, group.requestedMetrics, group.subscribedPlugins, newWf.configTree)
+		}
+		if len(errs) > 0 {
+			for _, key := range swapped {
+				if og, ok := oldGroups[key]; ok {
+					if mgr, err := oldMgrs.Get(key); err == nil {
+						errs = append(errs, mgr.UpdateDeps(id, og.requestedMetrics, og.subscribedPlugins, oldWf.configTree)...)
+					}
+				} else if mgr, err := newMgrs.Get(key); err == nil {
+					errs = append(errs, mgr.UnsubscribeDeps(id)...)
+				}
+			}
+			return errs
+		}
+		swapped = append(swapped, k)
+	}
+
+	// The new workflow is in place at this point so failing to release the
+	// nodes it no longer uses is only logged.
+	for k := range oldGroups {
+		if _, ok := newGroups[k]; ok {
+			continue
+		}
+		mgr, err := oldMgrs.Get(k)
+		if err != nil {
+			continue
+		}
+		if errs := mgr.UnsubscribeDeps(id); len(errs) > 0 {
+			buildErrorsLog(errs, schedulerLogger.WithFields(log.Fields{
+				"_block":  "swap-deps",
+				"task-id": id,
+				"node":    k,
+			})).Warn("unable to unsubscribe dependencies no longer used by task")
+		}
+	}
+	return nil
+}
+
 func getWorkflowPlugins(prnodes []*processNode, pbnodes []*publishNode, requestedMetrics []core.RequestedMetric) depGroupMap {
 	depGroup := depGroupMap{}
 	// Add metrics to depGroup map under local host(signified by empty string)
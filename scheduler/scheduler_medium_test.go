@@ -74,6 +74,10 @@ func (m *mockMetricManager) UnsubscribeDeps(taskID string) []serror.SnapError {
 	return nil
 }
 
+func (m *mockMetricManager) UpdateDeps(taskID string, reqs []core.RequestedMetric, prs []core.SubscribedPlugin, ctree *cdata.ConfigDataTree) []serror.SnapError {
+	return nil
+}
+
 func (m *mockMetricManager) SetAutodiscoverPaths(paths []string) {
 	m.autodiscoverPaths = paths
 }
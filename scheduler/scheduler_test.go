@@ -24,6 +24,9 @@ package scheduler
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -76,6 +79,10 @@ func (m *mockMetricManager) UnsubscribeDeps(taskID string) []serror.SnapError {
 	return nil
 }
 
+func (m *mockMetricManager) UpdateDeps(taskID string, reqs []core.RequestedMetric, prs []core.SubscribedPlugin, ctree *cdata.ConfigDataTree) []serror.SnapError {
+	return nil
+}
+
 func (m *mockMetricManager) SetAutodiscoverPaths(paths []string) {
 	m.autodiscoverPaths = paths
 }
@@ -303,6 +310,213 @@ func TestScheduler(t *testing.T) {
 			So(t.state, ShouldEqual, core.TaskDisabled)
 		})
 	})
+	Convey("UpdateTask", t, func() {
+		c := new(mockMetricManager)
+		s := New(GetDefaultConfig())
+		s.SetMetricManager(c)
+		So(s.Start(), ShouldBeNil)
+		w := wmap.NewWorkflowMap()
+		w.CollectNode.AddMetric("/foo/bar", 1)
+		tsk, te := s.CreateTask(schedule.NewSimpleSchedule(time.Second*1), w, false)
+		So(te.Errors(), ShouldBeEmpty)
+
+		Convey("changes the schedule and options of a task keeping its id", func() {
+			utsk, te := s.UpdateTask(tsk.ID(), schedule.NewSimpleSchedule(time.Second*5), nil, core.SetTaskName("updated"))
+			So(te.Errors(), ShouldBeEmpty)
+			So(utsk.ID(), ShouldEqual, tsk.ID())
+			So(utsk.GetName(), ShouldEqual, "updated")
+			So(utsk.Schedule().(*schedule.SimpleSchedule).Interval, ShouldEqual, time.Second*5)
+			So(utsk.WMap(), ShouldEqual, w)
+		})
+
+		Convey("changes the workflow of a task", func() {
+			w2 := wmap.NewWorkflowMap()
+			w2.CollectNode.AddMetric("/foo/baz", 2)
+			utsk, te := s.UpdateTask(tsk.ID(), nil, w2)
+			So(te.Errors(), ShouldBeEmpty)
+			So(utsk.WMap(), ShouldEqual, w2)
+			So(utsk.Schedule().(*schedule.SimpleSchedule).Interval, ShouldEqual, time.Second*1)
+		})
+
+		Convey("returns an error when the task does not exist", func() {
+			_, te := s.UpdateTask("1234", schedule.NewSimpleSchedule(time.Second*5), nil)
+			So(te.Errors(), ShouldNotBeEmpty)
+			So(te.Errors()[0].Error(), ShouldContainSubstring, ErrTaskNotFound.Error())
+		})
+
+		Convey("returns an error when the schedule does not validate", func() {
+			_, te := s.UpdateTask(tsk.ID(), schedule.NewSimpleSchedule(0), nil)
+			So(te.Errors(), ShouldNotBeEmpty)
+			So(tsk.Schedule().(*schedule.SimpleSchedule).Interval, ShouldEqual, time.Second*1)
+		})
+
+		Convey("returns an error and keeps the workflow when it does not validate", func() {
+			c.failValidatingMetrics = true
+			w2 := wmap.NewWorkflowMap()
+			w2.CollectNode.AddMetric("/foo/baz", 2)
+			_, te := s.UpdateTask(tsk.ID(), nil, w2)
+			So(te.Errors(), ShouldNotBeEmpty)
+			So(tsk.WMap(), ShouldEqual, w)
+		})
+	})
+
+	Convey("ValidateTask", t, func() {
+		c := new(mockMetricManager)
+		s := New(GetDefaultConfig())
+		s.SetMetricManager(c)
+		w := wmap.NewWorkflowMap()
+		w.CollectNode.AddMetric("/foo/bar", 1)
+		pr := wmap.NewProcessNode("passthru", 1)
+		pr.Add(wmap.NewPublishNode("file", 1))
+		w.CollectNode.Add(pr)
+
+		Convey("returns no errors for a valid task", func() {
+			te := s.ValidateTask(schedule.NewSimpleSchedule(time.Second), w)
+			So(te.Errors(), ShouldBeEmpty)
+			So(s.GetTasks(), ShouldBeEmpty)
+		})
+
+		Convey("returns every error with its path", func() {
+			c.failValidatingMetrics = true
+			te := s.ValidateTask(schedule.NewSimpleSchedule(0), w)
+			So(te.Errors(), ShouldHaveLength, 4)
+			paths := []interface{}{}
+			for _, e := range te.Errors() {
+				paths = append(paths, e.Fields()["path"])
+			}
+			So(paths, ShouldResemble, []interface{}{
+				"schedule",
+				`workflow.collect.metrics["/foo/bar"]`,
+				"workflow.collect.process[0]",
+				"workflow.collect.process[0].publish[0]",
+			})
+			So(s.GetTasks(), ShouldBeEmpty)
+		})
+
+		Convey("returns an error for a workflow without metrics", func() {
+			te := s.ValidateTask(schedule.NewSimpleSchedule(time.Second), wmap.NewWorkflowMap())
+			So(te.Errors(), ShouldHaveLength, 1)
+			So(te.Errors()[0].Fields()["path"], ShouldEqual, "workflow.collect.metrics")
+		})
+	})
+
+	Convey("UpdateTask on a running task", t, func() {
+		c := &subscriptionManager{}
+		s := New(GetDefaultConfig())
+		s.SetMetricManager(c)
+		So(s.Start(), ShouldBeNil)
+		w := wmap.NewWorkflowMap()
+		w.CollectNode.AddMetric("/foo/bar", 1)
+		tsk, te := s.CreateTask(schedule.NewSimpleSchedule(time.Hour), w, true)
+		So(te.Errors(), ShouldBeEmpty)
+		So(c.SubscribeCallCount, ShouldEqual, 1)
+		w2 := wmap.NewWorkflowMap()
+		w2.CollectNode.AddMetric("/foo/baz", 2)
+
+		Convey("swaps the subscriptions of the task", func() {
+			_, te := s.UpdateTask(tsk.ID(), nil, w2)
+			So(te.Errors(), ShouldBeEmpty)
+			So(c.UpdateCallCount, ShouldEqual, 1)
+			So(c.UnsubscribeCallCount, ShouldEqual, 0)
+			So(tsk.WMap(), ShouldEqual, w2)
+			So(tsk.State(), ShouldEqual, core.TaskSpinning)
+		})
+
+		Convey("keeps the workflow when the subscriptions cannot be swapped", func() {
+			c.Fail = true
+			_, te := s.UpdateTask(tsk.ID(), nil, w2)
+			So(te.Errors(), ShouldNotBeEmpty)
+			So(tsk.WMap(), ShouldEqual, w)
+		})
+
+		Convey("waits on the updated schedule", func() {
+			_, te := s.UpdateTask(tsk.ID(), schedule.NewSimpleSchedule(time.Millisecond*10), nil)
+			So(te.Errors(), ShouldBeEmpty)
+			time.Sleep(100 * time.Millisecond)
+			So(tsk.HitCount(), ShouldBeGreaterThan, 0)
+		})
+
+		Convey("keeps running after max-failures is set to 0", func() {
+			_, te := s.UpdateTask(tsk.ID(), schedule.NewSimpleSchedule(time.Millisecond*10), nil, core.OptionStopOnFailure(0))
+			So(te.Errors(), ShouldBeEmpty)
+			So(tsk.GetStopOnFailure(), ShouldEqual, DefaultStopOnFailure)
+			time.Sleep(100 * time.Millisecond)
+			So(tsk.HitCount(), ShouldBeGreaterThan, 0)
+			So(tsk.State(), ShouldNotEqual, core.TaskDisabled)
+		})
+		c.Fail = false
+		s.StopTask(tsk.ID())
+	})
+
+	Convey("Triggered tasks", t, func() {
+		c := &subscriptionManager{}
+		s := New(GetDefaultConfig())
+		s.SetMetricManager(c)
+		So(s.Start(), ShouldBeNil)
+		w := wmap.NewWorkflowMap()
+		w.CollectNode.AddMetric("/foo/bar", 1)
+		first, te := s.CreateTask(schedule.NewSimpleSchedule(time.Hour), w, false, core.SetTaskName("first"))
+		So(te.Errors(), ShouldBeEmpty)
+
+		Convey("refer to their triggering task by id", func() {
+			sch := schedule.NewTriggeredSchedule(schedule.Trigger{Task: "first", On: schedule.TriggerOnSuccess})
+			tsk, te := s.CreateTask(sch, w, false)
+			So(te.Errors(), ShouldBeEmpty)
+			So(tsk.Schedule().(*schedule.TriggeredSchedule).Triggers[0].Task, ShouldEqual, first.ID())
+		})
+
+		Convey("cannot refer to an unknown task", func() {
+			sch := schedule.NewTriggeredSchedule(schedule.Trigger{Task: "second", On: schedule.TriggerOnSuccess})
+			_, te := s.CreateTask(sch, w, false)
+			So(te.Errors(), ShouldNotBeEmpty)
+			So(te.Errors()[0].Error(), ShouldEqual, "Trigger refers to unknown task 'second'")
+		})
+
+		Convey("cannot form a cycle", func() {
+			sch := schedule.NewTriggeredSchedule(schedule.Trigger{Task: first.ID(), On: schedule.TriggerOnEnd})
+			tsk, te := s.CreateTask(sch, w, false)
+			So(te.Errors(), ShouldBeEmpty)
+			sch = schedule.NewTriggeredSchedule(schedule.Trigger{Task: tsk.ID(), On: schedule.TriggerOnEnd})
+			_, te = s.UpdateTask(first.ID(), sch, nil)
+			So(te.Errors(), ShouldNotBeEmpty)
+			So(te.Errors()[0].Error(), ShouldContainSubstring, "form a cycle")
+		})
+
+		Convey("fire when triggered", func() {
+			sch := schedule.NewTriggeredSchedule(schedule.Trigger{Task: first.ID(), On: schedule.TriggerOnSuccess})
+			tsk, te := s.CreateTask(sch, w, true)
+			So(te.Errors(), ShouldBeEmpty)
+			s.fireTriggers(first.ID(), schedule.TriggerOnEnd)
+			time.Sleep(50 * time.Millisecond)
+			So(tsk.HitCount(), ShouldEqual, 0)
+			s.fireTriggers(first.ID(), schedule.TriggerOnSuccess)
+			time.Sleep(50 * time.Millisecond)
+			So(tsk.HitCount(), ShouldEqual, 1)
+			s.StopTask(tsk.ID())
+		})
+
+		Convey("lose the triggers referring to a removed task", func() {
+			second, te := s.CreateTask(schedule.NewSimpleSchedule(time.Hour), w, false)
+			So(te.Errors(), ShouldBeEmpty)
+			sch := schedule.NewTriggeredSchedule(
+				schedule.Trigger{Task: first.ID(), On: schedule.TriggerOnSuccess},
+				schedule.Trigger{Task: second.ID(), On: schedule.TriggerOnSuccess})
+			tsk, te := s.CreateTask(sch, w, true)
+			So(te.Errors(), ShouldBeEmpty)
+			So(s.RemoveTask(first.ID()), ShouldBeNil)
+			So(sch.GetTriggers(), ShouldHaveLength, 1)
+			So(tsk.State(), ShouldEqual, core.TaskSpinning)
+
+			Convey("and are disabled once they have none left", func() {
+				So(s.RemoveTask(second.ID()), ShouldBeNil)
+				So(sch.GetTriggers(), ShouldBeEmpty)
+				So(tsk.State(), ShouldEqual, core.TaskDisabled)
+				So(tsk.LastFailureMessage(), ShouldContainSubstring, ErrTaskTriggersRemoved.Error())
+				So(tsk.LastFailureMessage(), ShouldContainSubstring, second.ID())
+			})
+		})
+	})
+
 	Convey("Stop()", t, func() {
 		Convey("Should set scheduler state to SchedulerStopped", func() {
 			scheduler := New(GetDefaultConfig())
@@ -323,3 +537,61 @@ func TestScheduler(t *testing.T) {
 	})
 
 }
+
+func TestAutoDiscoverTaskParams(t *testing.T) {
+	Convey("Autodiscovered tasks", t, func() {
+		dir, err := ioutil.TempDir("", "snap-autodiscover")
+		So(err, ShouldBeNil)
+		Reset(func() {
+			os.RemoveAll(dir)
+		})
+		manifest := []byte(`---
+version: 1
+parameters:
+  interval:
+    default: 10s
+  db: {}
+schedule:
+  type: simple
+  interval: ${interval}
+workflow:
+  collect:
+    metrics:
+      /intel/${db}/foo: {}
+`)
+		So(ioutil.WriteFile(filepath.Join(dir, "task.yaml"), manifest, 0644), ShouldBeNil)
+		var interval time.Duration
+		var metrics []string
+		fp := func(sch schedule.Schedule, wfMap *wmap.WorkflowMap, startOnCreate bool, opts ...core.TaskOption) (core.Task, core.TaskErrors) {
+			interval = sch.(*schedule.SimpleSchedule).Interval
+			for ns := range wfMap.CollectNode.Metrics {
+				metrics = append(metrics, ns)
+			}
+			return &task{id: "1"}, nil
+		}
+		files := func() []os.FileInfo {
+			fi, err := os.Stat(filepath.Join(dir, "task.yaml"))
+			So(err, ShouldBeNil)
+			return []os.FileInfo{fi}
+		}
+
+		Convey("are expanded with the values of their params file", func() {
+			So(ioutil.WriteFile(filepath.Join(dir, "task.params.yaml"), []byte("db: snap\ninterval: 1m\n"), 0644), ShouldBeNil)
+			autoDiscoverTasks(files(), dir, fp)
+			So(interval, ShouldEqual, time.Minute)
+			So(metrics, ShouldResemble, []string{"/intel/snap/foo"})
+		})
+
+		Convey("are not created when a parameter has no value", func() {
+			autoDiscoverTasks(files(), dir, fp)
+			So(metrics, ShouldBeNil)
+		})
+
+		Convey("params files are recognized", func() {
+			So(isTaskParamsFile("task.params.yaml"), ShouldBeTrue)
+			So(isTaskParamsFile("task.PARAMS.json"), ShouldBeTrue)
+			So(isTaskParamsFile("task.yaml"), ShouldBeFalse)
+			So(isTaskParamsFile("params.yaml"), ShouldBeFalse)
+		})
+	})
+}
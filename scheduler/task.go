@@ -43,6 +43,12 @@ const (
 	DefaultDeadlineDuration = time.Second * 5
 	// DefaultStopOnFailure is used to set the number of failures before a task is disabled
 	DefaultStopOnFailure = 10
+	// MaxBackfillRuns is the most runs a task makes to backfill the intervals
+	// its schedule missed, older intervals are skipped
+	MaxBackfillRuns = 100
+	// taskHistorySize is the number of the last fires of a task it keeps the
+	// records of
+	taskHistorySize = 20
 )
 
 var (
@@ -58,6 +64,9 @@ var (
 	ErrTaskDisabledOnFailures = errors.New("Task disabled due to consecutive failures")
 	// ErrTaskNotDisabled - The error message for task must be disabled
 	ErrTaskNotDisabled = errors.New("Task must be disabled")
+	// ErrTaskTriggersRemoved - The error message for a triggered task disabled
+	// since the tasks triggering it were removed
+	ErrTaskTriggersRemoved = errors.New("Task disabled since the tasks triggering it were removed")
 )
 
 type task struct {
@@ -65,9 +74,8 @@ type task struct {
 
 	id                 string
 	name               string
-	schResponseChan    chan schedule.Response
 	killChan           chan struct{}
-	schedule           schedule.Schedule
+	rescheduleChan     chan struct{}
 	workflow           *schedulerWorkflow
 	state              core.TaskState
 	creationTime       time.Time
@@ -84,6 +92,69 @@ type task struct {
 	stopOnFailure      int
 	eventEmitter       gomit.Emitter
 	RemoteManagers     managers
+
+	// consecutiveFailures is protected by failureMutex
+	consecutiveFailures int
+	// disabledChan signals the task was disabled while it was running
+	disabledChan chan struct{}
+
+	// scheduleMutex protects schedule so other tasks can read it while the
+	// task fires.  It is changed while holding the task lock as well.
+	scheduleMutex sync.Mutex
+	schedule      schedule.Schedule
+
+	fireMutex     sync.Mutex // protects the fields below
+	overlap       string
+	maxConcurrent int
+	fires         int
+	lastFire      *taskFire
+	fireDurations core.FireDurations
+	priority      string
+	// running counts the fires running a workflow in the background and
+	// fireDone is signaled each time one of them is done
+	running  int
+	fireDone *sync.Cond
+	// history holds the records of the last fires from the oldest
+	history []core.TaskRun
+}
+
+// taskFire is a single fire of a task
+type taskFire struct {
+	start time.Time
+	// logical is the time of the missed interval a backfilling fire runs for
+	logical  time.Time
+	canceled chan struct{}
+	// failed is protected by the failureMutex of the task
+	failed bool
+
+	nodesMutex sync.Mutex // protects nodes
+	nodes      []core.TaskRunNode
+}
+
+func newTaskFire(logical time.Time) *taskFire {
+	return &taskFire{
+		start:    time.Now(),
+		logical:  logical,
+		canceled: make(chan struct{}),
+	}
+}
+
+// recordNode adds the record of a stage of the workflow to the fire
+func (f *taskFire) recordNode(n core.TaskRunNode) {
+	f.nodesMutex.Lock()
+	defer f.nodesMutex.Unlock()
+	f.nodes = append(f.nodes, n)
+}
+
+// isCanceled returns whether the fire was canceled.  A canceled fire does not
+// submit its next jobs.
+func (f *taskFire) isCanceled() bool {
+	select {
+	case <-f.canceled:
+		return true
+	default:
+		return false
+	}
 }
 
 //NewTask creates a Task
@@ -104,7 +175,7 @@ func newTask(s schedule.Schedule, wf *schedulerWorkflow, m *workManager, mm mana
 	task := &task{
 		id:               taskID,
 		name:             name,
-		schResponseChan:  make(chan schedule.Response),
+		rescheduleChan:   make(chan struct{}, 1),
 		schedule:         s,
 		state:            core.TaskStopped,
 		creationTime:     time.Now(),
@@ -115,7 +186,9 @@ func newTask(s schedule.Schedule, wf *schedulerWorkflow, m *workManager, mm mana
 		stopOnFailure:    DefaultStopOnFailure,
 		eventEmitter:     emitter,
 		RemoteManagers:   mgrs,
+		fireDurations:    core.NewFireDurations(),
 	}
+	task.fireDone = sync.NewCond(&task.fireMutex)
 	//set options
 	for _, opt := range opts {
 		opt(task)
@@ -199,7 +272,13 @@ func (t *task) Status() WorkflowState {
 	return t.workflow.State()
 }
 
+// SetStopOnFailure sets the number of consecutive failures after which the
+// task is disabled, -1 for never.  0 sets the default limit as it does when
+// the task is created.
 func (t *task) SetStopOnFailure(v int) {
+	if v == 0 {
+		v = DefaultStopOnFailure
+	}
 	t.stopOnFailure = v
 }
 
@@ -211,21 +290,82 @@ func (t *task) GetStopOnFailure() int {
 	return t.stopOnFailure
 }
 
+// SetOverlap sets what the task does when it is due to fire while it is still
+// firing and how many times it can fire at once
+func (t *task) SetOverlap(policy string, maxConcurrent int) {
+	t.fireMutex.Lock()
+	defer t.fireMutex.Unlock()
+	t.overlap = policy
+	t.maxConcurrent = maxConcurrent
+}
+
+// GetOverlap returns the overlap policy of the task and how many times it can
+// fire at once
+func (t *task) GetOverlap() (string, int) {
+	t.fireMutex.Lock()
+	defer t.fireMutex.Unlock()
+	if t.overlap == "" {
+		return core.TaskOverlapSkip, 0
+	}
+	return t.overlap, t.maxConcurrent
+}
+
+// SetPriority sets the priority class the jobs of the task are queued with
+func (t *task) SetPriority(priority string) {
+	t.fireMutex.Lock()
+	defer t.fireMutex.Unlock()
+	t.priority = priority
+}
+
+// GetPriority returns the priority class of the task
+func (t *task) GetPriority() string {
+	t.fireMutex.Lock()
+	defer t.fireMutex.Unlock()
+	if t.priority == "" {
+		return core.TaskPriorityNormal
+	}
+	return t.priority
+}
+
+// FireDurations returns the histogram of the durations of the fires of the
+// task
+func (t *task) FireDurations() core.FireDurations {
+	t.fireMutex.Lock()
+	defer t.fireMutex.Unlock()
+	return t.fireDurations.Copy()
+}
+
+// History returns the records of the last fires of the task from the most
+// recent
+func (t *task) History() []core.TaskRun {
+	t.fireMutex.Lock()
+	defer t.fireMutex.Unlock()
+	runs := make([]core.TaskRun, 0, len(t.history))
+	for i := len(t.history) - 1; i >= 0; i-- {
+		runs = append(runs, t.history[i].Copy())
+	}
+	return runs
+}
+
 // Spin will start a task spinning in its own routine while it waits for its
 // schedule.
 func (t *task) Spin() {
 	// We need to lock long enough to change state
 	t.Lock()
 	defer t.Unlock()
-	// Reset the lastFireTime at each Spin.
-	// This ensures misses are tracked only forward of the point
-	// in time that a task starts spinning. E.g. stopping a task,
-	// waiting a period of time, and starting the task won't show
-	// misses for the interval while stopped.
-	t.lastFireTime = time.Now()
 	if t.state == core.TaskStopped {
+		// Reset the lastFireTime at each start.
+		// This ensures misses are tracked only forward of the point
+		// in time that a task starts spinning. E.g. stopping a task,
+		// waiting a period of time, and starting the task won't show
+		// or catch up on misses for the interval while stopped.
+		t.lastFireTime = time.Now()
 		t.state = core.TaskSpinning
 		t.killChan = make(chan struct{})
+		t.disabledChan = make(chan struct{}, 1)
+		t.failureMutex.Lock()
+		t.consecutiveFailures = 0
+		t.failureMutex.Unlock()
 		// spin in a goroutine
 		go t.spin()
 	}
@@ -267,57 +407,42 @@ func (t *task) WMap() *wmap.WorkflowMap {
 }
 
 func (t *task) Schedule() schedule.Schedule {
+	t.scheduleMutex.Lock()
+	defer t.scheduleMutex.Unlock()
 	return t.schedule
 }
 
 func (t *task) spin() {
-	var consecutiveFailures int
 	for {
 		taskLogger.Debug("task spin loop")
 		// Start go routine to wait on schedule
-		go t.waitForSchedule()
+		schResponseChan := make(chan schedule.Response)
+		cancelChan := make(chan struct{})
+		waitDone := make(chan struct{})
+		t.Lock()
+		sch := t.Schedule()
+		last := t.lastFireTime
+		t.Unlock()
+		go func() {
+			t.waitForSchedule(sch, last, schResponseChan, cancelChan)
+			close(waitDone)
+		}()
+		// stopWaiting cancels the wait and returns once it has stopped
+		stopWaiting := func() {
+			close(cancelChan)
+			<-waitDone
+		}
 		// wait here on
 		//  schResponseChan - response from schedule
+		//  rescheduleChan - signals the schedule of the task was updated
+		//  disabledChan - signals a fire in the background disabled the task
 		//  killChan - signals task needs to be stopped
 		select {
-		case sr := <-t.schResponseChan:
+		case sr := <-schResponseChan:
 			switch sr.State() {
 			// If response show this schedule is stil active we fire
 			case schedule.Active:
-				t.missedIntervals += sr.Missed()
-				t.lastFireTime = time.Now()
-				t.hitCount++
-				t.fire()
-				if t.lastFailureTime == t.lastFireTime {
-					consecutiveFailures++
-					taskLogger.WithFields(log.Fields{
-						"_block":                    "spin",
-						"task-id":                   t.id,
-						"task-name":                 t.name,
-						"consecutive failures":      consecutiveFailures,
-						"consecutive failure limit": t.stopOnFailure,
-						"error":                     t.lastFailureMessage,
-					}).Warn("Task failed")
-				} else {
-					consecutiveFailures = 0
-				}
-				if t.stopOnFailure >= 0 && consecutiveFailures >= t.stopOnFailure {
-					taskLogger.WithFields(log.Fields{
-						"_block":               "spin",
-						"task-id":              t.id,
-						"task-name":            t.name,
-						"consecutive failures": consecutiveFailures,
-						"error":                t.lastFailureMessage,
-					}).Error(ErrTaskDisabledOnFailures)
-					// You must lock on state change for tasks
-					t.Lock()
-					t.state = core.TaskDisabled
-					t.Unlock()
-					// Send task disabled event
-					event := new(scheduler_event.TaskDisabledEvent)
-					event.TaskID = t.id
-					event.Why = fmt.Sprintf("Task disabled with error: %s", t.lastFailureMessage)
-					defer t.eventEmitter.Emit(event)
+				if !t.fireDue(sch, last, sr) {
 					return
 				}
 
@@ -327,6 +452,10 @@ func (t *task) spin() {
 				t.Lock()
 				t.state = core.TaskEnded
 				t.Unlock()
+				// Send task ended event
+				event := new(scheduler_event.TaskEndedEvent)
+				event.TaskID = t.id
+				defer t.eventEmitter.Emit(event)
 				return //spin
 
 			// Schedule has errored
@@ -338,7 +467,15 @@ func (t *task) spin() {
 				return //spin
 
 			}
+		case <-t.rescheduleChan:
+			// Stop waiting on the previous schedule and wait on the
+			// updated one
+			stopWaiting()
+		case <-t.disabledChan:
+			stopWaiting()
+			return
 		case <-t.killChan:
+			stopWaiting()
 			// Only here can it truly be stopped
 			t.Lock()
 			t.state = core.TaskStopped
@@ -349,31 +486,362 @@ func (t *task) spin() {
 	}
 }
 
-func (t *task) fire() {
+// run fires the task.  The fire runs in the background when background is
+// true and the overlap policy of the task lets its fires overlap, otherwise
+// run waits for the fire to end.  It returns false when the task was disabled
+// after too many consecutive failures.
+func (t *task) run(logical time.Time, background bool) bool {
+	t.fireMutex.Lock()
+	policy, max := t.overlap, t.maxConcurrent
+	if !background || (policy != core.TaskOverlapConcurrent && policy != core.TaskOverlapCancel) {
+		t.fireMutex.Unlock()
+		tf := newTaskFire(logical)
+		t.lastFireTime = tf.start
+		t.hitCount++
+		t.fire(tf)
+		if !t.fired(tf) {
+			t.disable()
+			return false
+		}
+		return true
+	}
+	f := taskLogger.WithFields(log.Fields{
+		"_block":    "run",
+		"task-id":   t.id,
+		"task-name": t.name,
+		"overlap":   policy,
+		"running":   t.fires,
+	})
+	if policy == core.TaskOverlapConcurrent && t.fires >= max {
+		t.fireMutex.Unlock()
+		f.WithField("max-concurrent", max).Debug("Task is firing too many times at once, missing the interval")
+		t.missedIntervals++
+		return true
+	}
+	if policy == core.TaskOverlapCancel && t.lastFire != nil && !t.lastFire.isCanceled() {
+		// the job the fire is running (the collect for instance) is not
+		// interrupted, only the next ones are dropped
+		f.Debug("Task is still firing, canceling the running fire")
+		close(t.lastFire.canceled)
+	}
+	tf := newTaskFire(logical)
+	t.fires++
+	t.lastFire = tf
+	t.fireMutex.Unlock()
+	t.lastFireTime = tf.start
+	t.hitCount++
+	go func() {
+		t.fireInBackground(tf)
+		if !t.fired(tf) {
+			t.disable()
+		}
+	}()
+	return true
+}
+
+// fired records the duration of a fire and keeps count of the consecutive
+// failures of the task.  It returns false when the task has to be disabled
+// after too many consecutive failures.  A canceled fire is not counted.
+func (t *task) fired(tf *taskFire) bool {
+	t.recordRun(tf)
+	if tf.isCanceled() {
+		return true
+	}
+	t.fireMutex.Lock()
+	t.fireDurations.Observe(time.Since(tf.start))
+	t.fireMutex.Unlock()
+
+	t.failureMutex.Lock()
+	failed := tf.failed
+	if failed {
+		t.consecutiveFailures++
+	} else {
+		t.consecutiveFailures = 0
+	}
+	consecutiveFailures := t.consecutiveFailures
+	t.failureMutex.Unlock()
+
+	if failed {
+		taskLogger.WithFields(log.Fields{
+			"_block":                    "spin",
+			"task-id":                   t.id,
+			"task-name":                 t.name,
+			"consecutive failures":      consecutiveFailures,
+			"consecutive failure limit": t.stopOnFailure,
+			"error":                     t.lastFailureMessage,
+		}).Warn("Task failed")
+	} else {
+		// Send task run succeeded event
+		event := new(scheduler_event.TaskRunSucceededEvent)
+		event.TaskID = t.id
+		t.eventEmitter.Emit(event)
+	}
+	return !(failed && t.stopOnFailure >= 0 && consecutiveFailures >= t.stopOnFailure)
+}
+
+// disable disables a running task after too many consecutive failures and
+// makes it stop spinning
+func (t *task) disable() {
+	t.Lock()
+	running := t.state == core.TaskSpinning || t.state == core.TaskFiring
+	if running {
+		t.state = core.TaskDisabled
+		select {
+		case t.disabledChan <- struct{}{}:
+		default:
+		}
+	}
+	t.Unlock()
+	if !running {
+		return
+	}
+	taskLogger.WithFields(log.Fields{
+		"_block":    "spin",
+		"task-id":   t.id,
+		"task-name": t.name,
+		"error":     t.lastFailureMessage,
+	}).Error(ErrTaskDisabledOnFailures)
+	// Send task disabled event
+	event := new(scheduler_event.TaskDisabledEvent)
+	event.TaskID = t.id
+	event.Why = fmt.Sprintf("Task disabled with error: %s", t.lastFailureMessage)
+	t.eventEmitter.Emit(event)
+}
+
+// disableWith disables the task, running or not, and records err as its last
+// failure.  A running task stops spinning.
+func (t *task) disableWith(err error) {
+	t.failureMutex.Lock()
+	t.lastFailureTime = time.Now()
+	t.lastFailureMessage = err.Error()
+	t.failureMutex.Unlock()
+
+	t.Lock()
+	if t.state == core.TaskDisabled {
+		t.Unlock()
+		return
+	}
+	if t.state == core.TaskSpinning || t.state == core.TaskFiring {
+		select {
+		case t.disabledChan <- struct{}{}:
+		default:
+		}
+	}
+	t.state = core.TaskDisabled
+	t.Unlock()
+	taskLogger.WithFields(log.Fields{
+		"_block":    "disable",
+		"task-id":   t.id,
+		"task-name": t.name,
+	}).Error(err)
+	// Send task disabled event
+	event := new(scheduler_event.TaskDisabledEvent)
+	event.TaskID = t.id
+	event.Why = fmt.Sprintf("Task disabled with error: %s", err)
+	t.eventEmitter.Emit(event)
+}
+
+// fireDue fires the task for the interval due now once the wait on sch since
+// the fire at last returned with sr, after catching up on the intervals
+// missed meanwhile.  It returns false when the task was disabled.
+func (t *task) fireDue(sch schedule.Schedule, last time.Time, sr schedule.Response) bool {
+	if !t.catchUp(sch, last, sr) {
+		return false
+	}
+	return t.run(time.Time{}, true)
+}
+
+// catchUp runs the task for the intervals its schedule missed since the fire
+// at last according to the catch-up policy of the schedule, once the wait
+// for the interval due now returned with sr.  The missed intervals are
+// counted on the wall clock, which unlike the clock the schedule waits on
+// includes the time the host was suspended.  With the once policy the fire
+// due now stands for all of them so catchUp does not run the task.  A
+// backfilling run is given the time of the interval it runs for.  The runs
+// do not overlap.  It returns false when the task was disabled.
+func (t *task) catchUp(sch schedule.Schedule, last time.Time, sr schedule.Response) bool {
+	cs, ok := sch.(schedule.CatchUpSchedule)
+	var policy string
+	if ok {
+		policy = cs.CatchUpPolicy()
+	}
+	var max int
+	switch policy {
+	case schedule.CatchUpOnce:
+		// the fire due now is the one for all the missed intervals
+		max = 0
+	case schedule.CatchUpBackfill:
+		max = MaxBackfillRuns
+	default:
+		t.missedIntervals += sr.Missed()
+		return true
+	}
+	if last.IsZero() {
+		return true
+	}
+	// the interval due now is counted as well but it is not missed
+	missed, slots := cs.MissedIntervals(last.Round(0), time.Now().Round(0), max+1)
+	if missed <= 1 {
+		return true
+	}
+	missed--
+	slots = slots[:len(slots)-1]
+	f := taskLogger.WithFields(log.Fields{
+		"_block":           "catch-up",
+		"task-id":          t.id,
+		"task-name":        t.name,
+		"catch-up":         policy,
+		"missed-intervals": missed,
+		"runs":             len(slots),
+	})
+	if policy == schedule.CatchUpOnce {
+		f.Debug("Catching up on missed intervals with the due fire")
+		return true
+	}
+	if uint(len(slots)) < missed {
+		f.Warn("Too many missed intervals to backfill, skipping the oldest")
+	} else {
+		f.Debug("Catching up on missed intervals")
+	}
+	t.missedIntervals += missed - uint(len(slots))
+	for _, slot := range slots {
+		select {
+		case <-t.killChan:
+			// the task is being stopped
+			return true
+		default:
+		}
+		if !t.run(slot, false) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *task) fire(tf *taskFire) {
 	t.Lock()
 	defer t.Unlock()
 
 	t.state = core.TaskFiring
-	t.workflow.Start(t)
+	t.workflow.Start(t, tf)
 	t.state = core.TaskSpinning
 }
 
-func (t *task) waitForSchedule() {
+// recordRun adds the record of a fire to the history of the task, dropping
+// the oldest record once the history holds taskHistorySize records
+func (t *task) recordRun(tf *taskFire) {
+	t.failureMutex.Lock()
+	failed := tf.failed
+	t.failureMutex.Unlock()
+
+	tf.nodesMutex.Lock()
+	run := core.TaskRun{
+		FireTime:    tf.start,
+		LogicalTime: tf.logical,
+		Duration:    time.Since(tf.start),
+		Failed:      failed,
+		Canceled:    tf.isCanceled(),
+		Nodes:       tf.nodes,
+	}
+	tf.nodesMutex.Unlock()
+
+	t.fireMutex.Lock()
+	defer t.fireMutex.Unlock()
+	t.history = append(t.history, run)
+	if len(t.history) > taskHistorySize {
+		t.history = t.history[len(t.history)-taskHistorySize:]
+	}
+}
+
+// fireInBackground fires the task without holding the task lock so the fire
+// can overlap with the other fires of the task
+func (t *task) fireInBackground(tf *taskFire) {
+	t.Lock()
+	if t.state == core.TaskSpinning {
+		t.state = core.TaskFiring
+	}
+	wf := t.workflow
+	t.fireMutex.Lock()
+	t.running++
+	t.fireMutex.Unlock()
+	t.Unlock()
+
+	wf.Start(t, tf)
+
+	t.fireMutex.Lock()
+	t.running--
+	t.fireDone.Broadcast()
+	t.fireMutex.Unlock()
+
+	t.Lock()
+	t.fireMutex.Lock()
+	t.fires--
+	if t.lastFire == tf {
+		t.lastFire = nil
+	}
+	if t.fires == 0 && t.state == core.TaskFiring {
+		t.state = core.TaskSpinning
+	}
+	t.fireMutex.Unlock()
+	t.Unlock()
+}
+
+// waitForSchedule waits on the schedule and sends its response unless the
+// wait is canceled first.  A schedule whose wait can not be canceled waits in
+// its own goroutine so that canceling does not have to wait for it.
+func (t *task) waitForSchedule(sch schedule.Schedule, last time.Time, schResponseChan chan<- schedule.Response, cancelChan <-chan struct{}) {
+	var sr schedule.Response
+	if cs, ok := sch.(schedule.CancelableSchedule); ok {
+		if sr = cs.WaitCancel(last, cancelChan); sr == nil {
+			return
+		}
+	} else {
+		waitChan := make(chan schedule.Response, 1)
+		go func() {
+			waitChan <- sch.Wait(last)
+		}()
+		select {
+		case <-cancelChan:
+			return
+		case sr = <-waitChan:
+		}
+	}
 	select {
-	case <-t.killChan:
-		return
-	case t.schResponseChan <- t.schedule.Wait(t.lastFireTime):
+	case <-cancelChan:
+	case schResponseChan <- sr:
+	}
+}
+
+// waitForFires blocks until the fires running in the background are done.
+// The caller must hold the task lock so that no other fire starts meanwhile.
+func (t *task) waitForFires() {
+	t.fireMutex.Lock()
+	defer t.fireMutex.Unlock()
+	for t.running > 0 {
+		t.fireDone.Wait()
+	}
+}
+
+// reschedule makes a spinning task wait on its current schedule.  The caller
+// must hold the task lock.
+func (t *task) reschedule() {
+	select {
+	case t.rescheduleChan <- struct{}{}:
+	default:
+		// a reschedule is already pending
 	}
 }
 
-// RecordFailure updates the failed runs and last failure properties
-func (t *task) RecordFailure(e []error) {
+// RecordFailure updates the failed runs and last failure properties and marks
+// the fire as failed
+func (t *task) RecordFailure(tf *taskFire, e []error) {
 	// We synchronize this update to ensure it is atomic
 	t.failureMutex.Lock()
 	defer t.failureMutex.Unlock()
 	t.failedRuns++
-	t.lastFailureTime = t.lastFireTime
+	t.lastFailureTime = tf.start
 	t.lastFailureMessage = e[len(e)-1].Error()
+	tf.failed = true
 }
 
 type taskCollection struct {
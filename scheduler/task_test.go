@@ -22,10 +22,13 @@ limitations under the License.
 package scheduler
 
 import (
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/intelsdi-x/snap/core"
+	"github.com/intelsdi-x/snap/core/ctypes"
 	"github.com/intelsdi-x/snap/pkg/schedule"
 	"github.com/intelsdi-x/snap/scheduler/wmap"
 
@@ -198,3 +201,279 @@ func TestTask(t *testing.T) {
 
 	})
 }
+
+type taggedMetricManager struct {
+	mockMetricManager
+	tags []map[string]map[string]string
+}
+
+func (m *taggedMetricManager) CollectMetrics(id string, tags map[string]map[string]string) ([]core.Metric, []error) {
+	m.tags = append(m.tags, tags)
+	return nil, nil
+}
+
+func TestTaskCatchUp(t *testing.T) {
+	log.SetLevel(log.FatalLevel)
+	Convey("Task catching up on missed intervals", t, func() {
+		wf, errs := wmapToWorkflow(wmap.Sample())
+		So(errs, ShouldBeEmpty)
+		c := &taggedMetricManager{}
+		sch := schedule.NewSimpleSchedule(time.Hour)
+		task, err := newTask(sch, wf, newWorkManager(), c, emitter)
+		So(err, ShouldBeNil)
+		// the host was suspended for the two intervals before the one due now
+		last := time.Now().Add(-time.Hour*3 - time.Minute)
+		sr := &schedule.SimpleScheduleResponse{}
+
+		Convey("skips them by default", func() {
+			So(task.fireDue(sch, last, sr), ShouldBeTrue)
+			So(task.hitCount, ShouldEqual, 1)
+			So(c.tags[0]["/"], ShouldNotContainKey, core.STD_TAG_LOGICAL_TIMESTAMP)
+		})
+
+		Convey("fires once for all of them", func() {
+			sch.CatchUp = schedule.CatchUpOnce
+			So(task.fireDue(sch, last, sr), ShouldBeTrue)
+			So(task.hitCount, ShouldEqual, 1)
+			So(task.missedIntervals, ShouldEqual, 0)
+			So(c.tags, ShouldHaveLength, 1)
+			So(c.tags[0]["/"], ShouldNotContainKey, core.STD_TAG_LOGICAL_TIMESTAMP)
+		})
+
+		Convey("fires for each of them with its time", func() {
+			sch.CatchUp = schedule.CatchUpBackfill
+			So(task.fireDue(sch, last, sr), ShouldBeTrue)
+			So(task.hitCount, ShouldEqual, 3)
+			So(task.missedIntervals, ShouldEqual, 0)
+			So(c.tags, ShouldHaveLength, 3)
+			So(c.tags[0]["/"][core.STD_TAG_LOGICAL_TIMESTAMP], ShouldEqual, last.Add(time.Hour).Format(time.RFC3339Nano))
+			So(c.tags[1]["/"][core.STD_TAG_LOGICAL_TIMESTAMP], ShouldEqual, last.Add(time.Hour*2).Format(time.RFC3339Nano))
+			So(c.tags[2]["/"], ShouldNotContainKey, core.STD_TAG_LOGICAL_TIMESTAMP)
+		})
+
+		Convey("does not catch up on the interval due now", func() {
+			sch.CatchUp = schedule.CatchUpBackfill
+			So(task.fireDue(sch, time.Now().Add(-time.Hour-time.Minute), sr), ShouldBeTrue)
+			So(task.hitCount, ShouldEqual, 1)
+		})
+
+		Convey("does not catch up before the first fire", func() {
+			sch.CatchUp = schedule.CatchUpBackfill
+			So(task.fireDue(sch, time.Time{}, sr), ShouldBeTrue)
+			So(task.hitCount, ShouldEqual, 1)
+		})
+	})
+}
+
+type blockingMetricManager struct {
+	mockMetricManager
+	sync.Mutex
+	release    chan struct{}
+	collecting int
+	published  int
+}
+
+func (m *blockingMetricManager) CollectMetrics(string, map[string]map[string]string) ([]core.Metric, []error) {
+	m.Lock()
+	m.collecting++
+	m.Unlock()
+	<-m.release
+	return nil, nil
+}
+
+func (m *blockingMetricManager) PublishMetrics([]core.Metric, map[string]ctypes.ConfigValue, string, string, int) []error {
+	m.Lock()
+	m.published++
+	m.Unlock()
+	return nil
+}
+
+func (m *blockingMetricManager) counts() (int, int) {
+	m.Lock()
+	defer m.Unlock()
+	return m.collecting, m.published
+}
+
+// eventually returns whether cond becomes true within a second
+func eventually(cond func() bool) bool {
+	for i := 0; i < 100; i++ {
+		if cond() {
+			return true
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	return false
+}
+
+func TestTaskOverlap(t *testing.T) {
+	log.SetLevel(log.FatalLevel)
+	Convey("Task due to fire while it is still firing", t, func() {
+		wf, errs := wmapToWorkflow(wmap.Sample())
+		So(errs, ShouldBeEmpty)
+		c := &blockingMetricManager{release: make(chan struct{})}
+		task, err := newTask(schedule.NewSimpleSchedule(time.Hour), wf, newWorkManager(CollectWkrSizeOption(3)), c, emitter)
+		So(err, ShouldBeNil)
+		task.state = core.TaskSpinning
+		running := func() int {
+			task.fireMutex.Lock()
+			defer task.fireMutex.Unlock()
+			return task.fires
+		}
+
+		Convey("waits for the fire to end by default", func() {
+			close(c.release)
+			So(task.run(time.Time{}, true), ShouldBeTrue)
+			So(running(), ShouldEqual, 0)
+			So(task.FireDurations().Count, ShouldEqual, 1)
+			policy, _ := task.GetOverlap()
+			So(policy, ShouldEqual, core.TaskOverlapSkip)
+		})
+
+		Convey("fires up to max-concurrent times at once", func() {
+			task.Option(core.OptionOverlap(core.TaskOverlapConcurrent, 2))
+			So(task.run(time.Time{}, true), ShouldBeTrue)
+			So(task.run(time.Time{}, true), ShouldBeTrue)
+			So(eventually(func() bool { n, _ := c.counts(); return n == 2 }), ShouldBeTrue)
+			So(task.State(), ShouldEqual, core.TaskFiring)
+			So(task.run(time.Time{}, true), ShouldBeTrue)
+			So(task.missedIntervals, ShouldEqual, 1)
+			So(task.hitCount, ShouldEqual, 2)
+			close(c.release)
+			So(eventually(func() bool { return running() == 0 }), ShouldBeTrue)
+			So(task.State(), ShouldEqual, core.TaskSpinning)
+			So(task.FireDurations().Count, ShouldEqual, 2)
+		})
+
+		Convey("cancels the running fire", func() {
+			task.Option(core.OptionOverlap(core.TaskOverlapCancel, 0))
+			So(task.run(time.Time{}, true), ShouldBeTrue)
+			So(eventually(func() bool { n, _ := c.counts(); return n == 1 }), ShouldBeTrue)
+			So(task.run(time.Time{}, true), ShouldBeTrue)
+			close(c.release)
+			So(eventually(func() bool { return running() == 0 }), ShouldBeTrue)
+			// the canceled fire does not publish and is not recorded
+			_, published := c.counts()
+			So(published, ShouldEqual, 1)
+			So(task.hitCount, ShouldEqual, 2)
+			So(task.FireDurations().Count, ShouldEqual, 1)
+		})
+	})
+}
+
+// blockingSchedule is a schedule whose Wait only returns once released
+type blockingSchedule struct {
+	*schedule.SimpleSchedule
+	release chan struct{}
+}
+
+func (b *blockingSchedule) Wait(time.Time) schedule.Response {
+	<-b.release
+	return b.SimpleSchedule.Wait(time.Time{})
+}
+
+func TestTaskWaitForSchedule(t *testing.T) {
+	log.SetLevel(log.FatalLevel)
+	Convey("Task waiting on its schedule", t, func() {
+		wf, errs := wmapToWorkflow(wmap.Sample())
+		So(errs, ShouldBeEmpty)
+		sch := &blockingSchedule{SimpleSchedule: schedule.NewSimpleSchedule(time.Millisecond), release: make(chan struct{})}
+		task, err := newTask(sch, wf, newWorkManager(), &mockMetricManager{}, emitter)
+		So(err, ShouldBeNil)
+		defer close(sch.release)
+
+		Convey("stops waiting once the wait is canceled", func() {
+			cancelChan := make(chan struct{})
+			done := make(chan struct{})
+			go func() {
+				task.waitForSchedule(sch, time.Time{}, make(chan schedule.Response), cancelChan)
+				close(done)
+			}()
+			close(cancelChan)
+			So(eventually(func() bool {
+				select {
+				case <-done:
+					return true
+				default:
+					return false
+				}
+			}), ShouldBeTrue)
+		})
+	})
+	Convey("Task with a fire running in the background", t, func() {
+		wf, errs := wmapToWorkflow(wmap.Sample())
+		So(errs, ShouldBeEmpty)
+		c := &blockingMetricManager{release: make(chan struct{})}
+		task, err := newTask(schedule.NewSimpleSchedule(time.Hour), wf, newWorkManager(), c, emitter)
+		So(err, ShouldBeNil)
+		task.state = core.TaskSpinning
+		task.Option(core.OptionOverlap(core.TaskOverlapConcurrent, 2))
+		So(task.run(time.Time{}, true), ShouldBeTrue)
+		So(eventually(func() bool { n, _ := c.counts(); return n == 1 }), ShouldBeTrue)
+
+		Convey("can be waited for while holding the task lock", func() {
+			done := make(chan struct{})
+			go func() {
+				task.Lock()
+				task.waitForFires()
+				task.Unlock()
+				close(done)
+			}()
+			time.Sleep(50 * time.Millisecond)
+			select {
+			case <-done:
+				t.Fatal("waitForFires returned while a fire was running")
+			default:
+			}
+			close(c.release)
+			<-done
+			_, published := c.counts()
+			So(published, ShouldEqual, 1)
+		})
+	})
+}
+
+type failingPublishManager struct {
+	mockMetricManager
+}
+
+func (m *failingPublishManager) PublishMetrics([]core.Metric, map[string]ctypes.ConfigValue, string, string, int) []error {
+	return []error{errors.New("publish failed")}
+}
+
+func TestTaskHistory(t *testing.T) {
+	log.SetLevel(log.FatalLevel)
+	Convey("Task history", t, func() {
+		wf, errs := wmapToWorkflow(wmap.Sample())
+		So(errs, ShouldBeEmpty)
+		task, err := newTask(schedule.NewSimpleSchedule(time.Hour), wf, newWorkManager(), &failingPublishManager{}, emitter)
+		So(err, ShouldBeNil)
+		task.state = core.TaskSpinning
+		task.SetStopOnFailure(-1)
+		So(task.History(), ShouldBeEmpty)
+
+		Convey("records the stages of each fire", func() {
+			So(task.run(time.Time{}, false), ShouldBeTrue)
+			h := task.History()
+			So(h, ShouldHaveLength, 1)
+			So(h[0].Failed, ShouldBeTrue)
+			So(h[0].Nodes, ShouldHaveLength, 2)
+			So(h[0].Nodes[0].Type, ShouldEqual, "collector")
+			So(h[0].Nodes[0].Errors, ShouldBeEmpty)
+			So(h[0].Nodes[1].Type, ShouldEqual, "publisher")
+			So(h[0].Nodes[1].Name, ShouldEqual, "rabbitmq")
+			So(h[0].Nodes[1].Version, ShouldEqual, 5)
+			So(h[0].Nodes[1].Errors, ShouldResemble, []string{"publish failed"})
+		})
+
+		Convey("keeps the records of the last fires from the most recent", func() {
+			for i := 0; i < taskHistorySize+2; i++ {
+				task.run(time.Time{}, false)
+			}
+			task.run(time.Now().Add(-time.Hour), false)
+			h := task.History()
+			So(h, ShouldHaveLength, taskHistorySize)
+			So(h[0].LogicalTime.IsZero(), ShouldBeFalse)
+			So(h[1].LogicalTime.IsZero(), ShouldBeTrue)
+		})
+	})
+}
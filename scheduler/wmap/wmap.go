@@ -28,7 +28,6 @@ import (
 	yaml "gopkg.in/yaml.v2"
 
 	"github.com/intelsdi-x/snap/core/cdata"
-	"github.com/intelsdi-x/snap/core/ctypes"
 	"github.com/intelsdi-x/snap/pkg/stringutils"
 )
 
@@ -462,25 +461,12 @@ func (m Metric) Version() int {
 func configtoConfigDataNode(cmap map[string]interface{}, ns string) (*cdata.ConfigDataNode, error) {
 	cdn := cdata.NewNode()
 	for ck, cv := range cmap {
-		switch v := cv.(type) {
-		case string:
-			cdn.AddItem(ck, ctypes.ConfigValueStr{Value: v})
-		case int:
-			cdn.AddItem(ck, ctypes.ConfigValueInt{Value: v})
-		case float64:
-			//working around the fact that json decodes numbers to floats
-			//if we can convert the number to an int without loss it will be an int
-			if v == float64(int(v)) {
-				cdn.AddItem(ck, ctypes.ConfigValueInt{Value: int(v)})
-			} else {
-				cdn.AddItem(ck, ctypes.ConfigValueFloat{Value: v})
-			}
-		case bool:
-			cdn.AddItem(ck, ctypes.ConfigValueBool{Value: v})
-		default:
+		v, err := cdata.ParseConfigValue(cv)
+		if err != nil {
 			// TODO make sure this is covered in tests!!!
-			return nil, errors.New(fmt.Sprintf("Cannot convert config value to config data node: %s=>%+v", ns, v))
+			return nil, errors.New(fmt.Sprintf("Cannot convert config value to config data node: %s=>%+v", ns, cv))
 		}
+		cdn.AddItem(ck, v)
 	}
 	return cdn, nil
 }
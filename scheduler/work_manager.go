@@ -19,7 +19,11 @@ limitations under the License.
 
 package scheduler
 
-import "sync"
+import (
+	"sync"
+
+	"github.com/intelsdi-x/snap/core"
+)
 
 /*
 
@@ -56,11 +60,17 @@ type workManager struct {
 	collectWkrSize uint
 	publishWkrSize uint
 	processWkrSize uint
+	// the pool sizes the work manager was configured with, the pools are not
+	// shrunk below them for the priority classes
+	collectMinWkrs uint
+	publishMinWkrs uint
+	processMinWkrs uint
 	collectchan    chan queuedJob
 	publishchan    chan queuedJob
 	processchan    chan queuedJob
 	kill           chan struct{}
 	mutex          *sync.Mutex
+	classes        map[string]core.WorkClass
 }
 
 type workManagerState int
@@ -149,12 +159,16 @@ func newWorkManager(opts ...workManagerOption) *workManager {
 		processchan:    make(chan queuedJob),
 		kill:           make(chan struct{}),
 		mutex:          &sync.Mutex{},
+		classes:        make(map[string]core.WorkClass),
 	}
 
 	//set options
 	for _, opt := range opts {
 		opt(wm)
 	}
+	wm.collectMinWkrs = wm.collectWkrSize
+	wm.publishMinWkrs = wm.publishWkrSize
+	wm.processMinWkrs = wm.processWkrSize
 
 	wm.collectq = newQueue(wm.collectQSize, wm.sendToWorker)
 	wm.publishq = newQueue(wm.publishQSize, wm.sendToWorker)
@@ -239,6 +253,7 @@ func (w *workManager) AddCollectWorker() {
 	go nw.start()
 	w.collectWkrs = append(w.collectWkrs, nw)
 	w.collectWkrSize++
+	w.collectMinWkrs++
 }
 
 // AddPublishWorker adds a new worker to
@@ -248,6 +263,7 @@ func (w *workManager) AddPublishWorker() {
 	go nw.start()
 	w.publishWkrs = append(w.publishWkrs, nw)
 	w.publishWkrSize++
+	w.publishMinWkrs++
 }
 
 // AddProcessWorker adds a new worker to
@@ -257,6 +273,84 @@ func (w *workManager) AddProcessWorker() {
 	go nw.start()
 	w.processWkrs = append(w.processWkrs, nw)
 	w.processWkrSize++
+	w.processMinWkrs++
+}
+
+// SetWorkClass sets the weight, queue size and pool size of the jobs of the
+// tasks of a priority class in the collect, process and publish queues.  The
+// worker pools grow to the largest pool size of the classes set but do not
+// shrink below their configured size, the pool size of a class only limits
+// the workers its jobs take in the queues.
+func (w *workManager) SetWorkClass(wc core.WorkClass) error {
+	if err := wc.Validate(); err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.classes[wc.Name] = wc
+	w.collectq.SetClass(wc)
+	w.processq.SetClass(wc)
+	w.publishq.SetClass(wc)
+
+	var size uint
+	for _, c := range w.classes {
+		if c.PoolSize > size {
+			size = c.PoolSize
+		}
+	}
+	w.collectWkrSize = maxUint(w.collectMinWkrs, size)
+	w.collectWkrs = resizePool(w.collectWkrs, w.collectchan, w.collectWkrSize)
+	w.processWkrSize = maxUint(w.processMinWkrs, size)
+	w.processWkrs = resizePool(w.processWkrs, w.processchan, w.processWkrSize)
+	w.publishWkrSize = maxUint(w.publishMinWkrs, size)
+	w.publishWkrs = resizePool(w.publishWkrs, w.publishchan, w.publishWkrSize)
+	return nil
+}
+
+// WorkClasses returns the settings of the priority classes from the highest.
+// A class which was not set has its default weight, the queue size of the
+// collect queue and the whole collect worker pool.
+func (w *workManager) WorkClasses() []core.WorkClass {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	classes := make([]core.WorkClass, 0, len(core.TaskPriorities))
+	for _, name := range core.TaskPriorities {
+		wc, ok := w.classes[name]
+		if !ok {
+			wc = core.WorkClass{
+				Name:      name,
+				Weight:    defaultClassWeights[name],
+				QueueSize: w.collectQSize,
+				PoolSize:  w.collectWkrSize,
+			}
+		}
+		classes = append(classes, wc)
+	}
+	return classes
+}
+
+func maxUint(a, b uint) uint {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// resizePool starts or kills workers so the pool has size workers
+func resizePool(wkrs []*worker, rcv chan queuedJob, size uint) []*worker {
+	for uint(len(wkrs)) < size {
+		nw := newWorker(rcv)
+		go nw.start()
+		wkrs = append(wkrs, nw)
+	}
+	for uint(len(wkrs)) > size {
+		close(wkrs[len(wkrs)-1].kamikaze)
+		wkrs = wkrs[:len(wkrs)-1]
+	}
+	return wkrs
 }
 
 // sendToWorker is the handler given to the queue.
@@ -85,6 +85,7 @@ func (mj *mockJob) StartTime() time.Time { return mj.starttime }
 func (mj *mockJob) Deadline() time.Time  { return mj.deadline }
 func (mj *mockJob) Type() jobType        { return collectJobType }
 func (mj *mockJob) TypeString() string   { return "" }
+func (mj *mockJob) Priority() string     { return "" }
 func (mj *mockJob) TaskID() string       { return "" }
 
 // Complete the first incomplete rendez-vous (if there is one)
@@ -251,4 +252,38 @@ func TestWorkerManager(t *testing.T) {
 			So(mgr.collectWkrSize, ShouldEqual, len(mgr.collectWkrs))
 		})
 	})
+	Convey("SetWorkClass()", t, func() {
+		mgr := newWorkManager(CollectQSizeOption(10), CollectWkrSizeOption(2))
+		Convey("it returns the defaults of the classes not set", func() {
+			classes := mgr.WorkClasses()
+			So(classes, ShouldHaveLength, 3)
+			So(classes[0], ShouldResemble, core.WorkClass{Name: core.TaskPriorityHigh, Weight: 4, QueueSize: 10, PoolSize: 2})
+			So(classes[2], ShouldResemble, core.WorkClass{Name: core.TaskPriorityLow, Weight: 1, QueueSize: 10, PoolSize: 2})
+		})
+		Convey("it sets a class and resizes the worker pools", func() {
+			wc := core.WorkClass{Name: core.TaskPriorityHigh, Weight: 8, QueueSize: 20, PoolSize: 4}
+			So(mgr.SetWorkClass(wc), ShouldBeNil)
+			So(mgr.WorkClasses()[0], ShouldResemble, wc)
+			So(mgr.collectq.classes[core.TaskPriorityHigh].weight, ShouldEqual, 8)
+			So(mgr.publishq.classes[core.TaskPriorityHigh].limit, ShouldEqual, 20)
+			So(mgr.collectWkrs, ShouldHaveLength, 4)
+			So(mgr.processWkrs, ShouldHaveLength, 4)
+			So(mgr.publishWkrSize, ShouldEqual, 4)
+
+			wc.PoolSize = 1
+			So(mgr.SetWorkClass(wc), ShouldBeNil)
+			So(mgr.collectWkrs, ShouldHaveLength, 2)
+			So(mgr.processWkrs, ShouldHaveLength, 1)
+		})
+		Convey("it does not shrink the worker pools for a small class", func() {
+			So(mgr.SetWorkClass(core.WorkClass{Name: core.TaskPriorityLow, Weight: 1, QueueSize: 10, PoolSize: 1}), ShouldBeNil)
+			So(mgr.collectWkrs, ShouldHaveLength, 2)
+			So(mgr.collectq.classes[core.TaskPriorityLow].poolSize, ShouldEqual, 1)
+			So(mgr.WorkClasses()[0].PoolSize, ShouldEqual, 2)
+		})
+		Convey("it returns an error for an invalid class", func() {
+			So(mgr.SetWorkClass(core.WorkClass{Name: "urgent", Weight: 1, QueueSize: 1, PoolSize: 1}), ShouldNotBeNil)
+			So(mgr.SetWorkClass(core.WorkClass{Name: core.TaskPriorityLow, Weight: 1, QueueSize: 1}), ShouldNotBeNil)
+		})
+	})
 }
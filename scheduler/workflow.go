@@ -23,6 +23,7 @@ import (
 	"errors"
 	"strings"
 	"sync"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/intelsdi-x/gomit"
@@ -173,7 +174,10 @@ func convertPublishNode(pu []wmap.PublishWorkflowMapNode) ([]*publishNode, error
 }
 
 type schedulerWorkflow struct {
-	state WorkflowState
+	// stateMutex protects state since the fires of a task with the
+	// concurrent or cancel overlap policy start the workflow at once
+	stateMutex sync.Mutex
+	state      WorkflowState
 	// Metrics to collect
 	metrics []core.RequestedMetric
 	// The config data tree for collectors
@@ -238,22 +242,43 @@ func (p *publishNode) TypeName() string {
 
 type wfContentTypes map[string]map[string][]string
 
-// Start starts a workflow
-func (s *schedulerWorkflow) Start(t *task) {
+// Start starts a workflow for a fire of the task.  The logical time of a fire
+// backfilling a missed interval is added as a tag to the collected metrics,
+// their timestamps stay the time they are collected at.
+// Once the fire is canceled no more jobs are submitted, the job already
+// submitted (the collect for instance) runs to its end.
+func (s *schedulerWorkflow) Start(t *task, tf *taskFire) {
 	workflowLogger.WithFields(log.Fields{
 		"_block":    "workflow-start",
 		"task-id":   t.id,
 		"task-name": t.name,
 	}).Debug("Starting workflow")
+	s.stateMutex.Lock()
 	s.state = WorkflowStarted
-	j := newCollectorJob(s.metrics, t.deadlineDuration, t.metricsManager, t.workflow.configTree, t.id, s.tags)
+	s.stateMutex.Unlock()
+	tags := s.tags
+	if !tf.logical.IsZero() {
+		tags = make(map[string]map[string]string, len(s.tags)+1)
+		for ns, nsTags := range s.tags {
+			tags[ns] = nsTags
+		}
+		rootTags := map[string]string{}
+		for k, v := range s.tags["/"] {
+			rootTags[k] = v
+		}
+		rootTags[core.STD_TAG_LOGICAL_TIMESTAMP] = tf.logical.Format(time.RFC3339Nano)
+		tags["/"] = rootTags
+	}
+	j := newCollectorJob(s.metrics, t.deadlineDuration, t.metricsManager, s.configTree, t.id, t.GetPriority(), tags)
 
 	// dispatch 'collect' job to be worked
 	// Block until the job has been either run or skipped.
+	start := time.Now()
 	errors := t.manager.Work(j).Promise().Await()
+	tf.recordNode(runNode(j.TypeString(), "", 0, start, len(j.Metrics()), errors))
 
 	if len(errors) > 0 {
-		t.RecordFailure(errors)
+		t.RecordFailure(tf, errors)
 		event := new(scheduler_event.MetricCollectionFailedEvent)
 		event.TaskID = t.id
 		event.Errors = errors
@@ -268,24 +293,35 @@ func (s *schedulerWorkflow) Start(t *task) {
 	defer s.eventEmitter.Emit(event)
 
 	// walk through the tree and dispatch work
-	workJobs(s.processNodes, s.publishNodes, t, j)
+	workJobs(s.processNodes, s.publishNodes, t, tf, j)
 }
 
 func (s *schedulerWorkflow) State() WorkflowState {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
 	return s.state
 }
 
 func (s *schedulerWorkflow) StateString() string {
-	return WorkflowStateLookup[s.state]
+	return WorkflowStateLookup[s.State()]
 }
 
 // workJobs takes a slice of process and publish nodes and submits jobs for each for a task.
 // It then iterates down any process nodes to submit their child node jobs for the task
-func workJobs(prs []*processNode, pus []*publishNode, t *task, pj job) {
+func workJobs(prs []*processNode, pus []*publishNode, t *task, tf *taskFire, pj job) {
 	// optimize for no jobs
 	if len(prs) == 0 && len(pus) == 0 {
 		return
 	}
+	if tf.isCanceled() {
+		workflowLogger.WithFields(log.Fields{
+			"_block":           "work-jobs",
+			"task-id":          t.id,
+			"task-name":        t.name,
+			"parent-node-type": pj.TypeString(),
+		}).Debug("Fire canceled, not submitting the next jobs")
+		return
+	}
 	// Create waitgroup to block until all jobs are submitted
 	wg := &sync.WaitGroup{}
 	workflowLogger.WithFields(log.Fields{
@@ -301,14 +337,14 @@ func workJobs(prs []*processNode, pus []*publishNode, t *task, pj job) {
 		// increment the wait group (before starting goroutine to prevent a race condition)
 		wg.Add(1)
 		// Start goroutine to submit the process job
-		go submitProcessJob(pj, t, wg, pr)
+		go submitProcessJob(pj, t, tf, wg, pr)
 	}
 	// range over the publish jobs and call submitPublishJob
 	for _, pu := range pus {
 		// increment the wait group (before starting goroutine to prevent a race condition)
 		wg.Add(1)
 		// Start goroutine to submit the process job
-		go submitPublishJob(pj, t, wg, pu)
+		go submitPublishJob(pj, t, tf, wg, pu)
 	}
 	// Wait until all job submisson goroutines are done
 	wg.Wait()
@@ -322,13 +358,14 @@ func workJobs(prs []*processNode, pus []*publishNode, t *task, pj job) {
 	}).Debug("Batch submission complete")
 }
 
-func submitProcessJob(pj job, t *task, wg *sync.WaitGroup, pr *processNode) {
+func submitProcessJob(pj job, t *task, tf *taskFire, wg *sync.WaitGroup, pr *processNode) {
 	// Decrement the waitgroup
 	defer wg.Done()
 	// Create a new process job
 	mgr, err := t.RemoteManagers.Get(pr.Target)
 	if err != nil {
-		t.RecordFailure([]error{err})
+		t.RecordFailure(tf, []error{err})
+		tf.recordNode(runNode(pr.TypeName(), pr.Name(), pr.Version(), time.Now(), 0, []error{err}))
 		workflowLogger.WithFields(log.Fields{
 			"_block":           "submit-prblish-job",
 			"task-id":          t.id,
@@ -349,12 +386,14 @@ func submitProcessJob(pj job, t *task, wg *sync.WaitGroup, pr *processNode) {
 		"parent-node-type": pj.TypeString(),
 	}).Debug("Submitting process job")
 	// Submit the job against the task.managesWork
+	start := time.Now()
 	errors := t.manager.Work(j).Promise().Await()
+	tf.recordNode(runNode(pr.TypeName(), pr.Name(), pr.Version(), start, len(j.Metrics()), errors))
 	// Check for errors and update the task
 	if len(errors) != 0 {
 		// Record the failures in the task
 		// note: this function is thread safe against t
-		t.RecordFailure(errors)
+		t.RecordFailure(tf, errors)
 		workflowLogger.WithFields(log.Fields{
 			"_block":           "submit-process-job",
 			"task-id":          t.id,
@@ -374,16 +413,17 @@ func submitProcessJob(pj job, t *task, wg *sync.WaitGroup, pr *processNode) {
 		"parent-node-type": pj.TypeString(),
 	}).Debug("Process job completed")
 	// Iterate into any child process or publish nodes
-	workJobs(pr.ProcessNodes, pr.PublishNodes, t, j)
+	workJobs(pr.ProcessNodes, pr.PublishNodes, t, tf, j)
 }
 
-func submitPublishJob(pj job, t *task, wg *sync.WaitGroup, pu *publishNode) {
+func submitPublishJob(pj job, t *task, tf *taskFire, wg *sync.WaitGroup, pu *publishNode) {
 	// Decrement the waitgroup
 	defer wg.Done()
 	// Create a new process job
 	mgr, err := t.RemoteManagers.Get(pu.Target)
 	if err != nil {
-		t.RecordFailure([]error{err})
+		t.RecordFailure(tf, []error{err})
+		tf.recordNode(runNode(pu.TypeName(), pu.Name(), pu.Version(), time.Now(), 0, []error{err}))
 		workflowLogger.WithFields(log.Fields{
 			"_block":           "submit-publish-job",
 			"task-id":          t.id,
@@ -404,12 +444,14 @@ func submitPublishJob(pj job, t *task, wg *sync.WaitGroup, pu *publishNode) {
 		"parent-node-type": pj.TypeString(),
 	}).Debug("Submitting publish job")
 	// Submit the job against the task.managesWork
+	start := time.Now()
 	errors := t.manager.Work(j).Promise().Await()
+	tf.recordNode(runNode(pu.TypeName(), pu.Name(), pu.Version(), start, len(pj.Metrics()), errors))
 	// Check for errors and update the task
 	if len(errors) != 0 {
 		// Record the failures in the task
 		// note: this function is thread safe against t
-		t.RecordFailure(errors)
+		t.RecordFailure(tf, errors)
 		workflowLogger.WithFields(log.Fields{
 			"_block":           "submit-publish-job",
 			"task-id":          t.id,
@@ -431,3 +473,19 @@ func submitPublishJob(pj job, t *task, wg *sync.WaitGroup, pu *publishNode) {
 	// Publish nodes cannot contain child nodes (publish is a terminal node)
 	// so unlike process nodes there is not a call to workJobs here for child nodes.
 }
+
+// runNode returns the record of a stage of the workflow of a fire whose job
+// was submitted at start
+func runNode(typ, name string, version int, start time.Time, metricCount int, errs []error) core.TaskRunNode {
+	n := core.TaskRunNode{
+		Type:        typ,
+		Name:        name,
+		Version:     version,
+		Duration:    time.Since(start),
+		MetricCount: metricCount,
+	}
+	for _, e := range errs {
+		n.Errors = append(n.Errors, e.Error())
+	}
+	return n
+}
@@ -324,7 +324,7 @@ func TestWorkJobs(t *testing.T) {
 	Convey("Test speed and concurrency of TestWorkJobs\n", t, func() {
 		Convey("submit multiple jobs\n", func() {
 			m1 := &Mock1{queue: make(map[string]int)}
-			pj := newCollectorJob(nil, time.Second*1, m1, nil, "", nil)
+			pj := newCollectorJob(nil, time.Second*1, m1, nil, "", "", nil)
 			prs := make([]*processNode, 0)
 			pus := make([]*publishNode, 0)
 			counter := 0
@@ -337,14 +337,14 @@ func TestWorkJobs(t *testing.T) {
 				prs = append(prs, pr)
 				pus = append(pus, pu)
 			}
-			workJobs(prs, pus, t, pj)
+			workJobs(prs, pus, t, newTaskFire(time.Time{}), pj)
 			So(t.failedRuns, ShouldEqual, 0)
 			So(m1.queue["processor"], ShouldEqual, 3)
 			So(m1.queue["publisher"], ShouldEqual, 3)
 		})
 		Convey("submit multiple jobs with nesting", func() {
 			m2 := &Mock1{queue: make(map[string]int)}
-			pj := newCollectorJob(nil, time.Second*1, m2, nil, "", nil)
+			pj := newCollectorJob(nil, time.Second*1, m2, nil, "", "", nil)
 			prs := make([]*processNode, 0)
 			pus := make([]*publishNode, 0)
 			counter := 0
@@ -373,7 +373,7 @@ func TestWorkJobs(t *testing.T) {
 				pr.ProcessNodes = cprs
 				pr.PublishNodes = cpus
 			}
-			workJobs(prs, pus, t, pj)
+			workJobs(prs, pus, t, newTaskFire(time.Time{}), pj)
 			So(t.failedRuns, ShouldEqual, 0)
 			// (3*3)+3
 			So(m2.queue["processor"], ShouldEqual, 12)
@@ -385,7 +385,7 @@ func TestWorkJobs(t *testing.T) {
 			m3 := &Mock1{queue: make(map[string]int)}
 			// make the 13th job fail
 			m3.errorIndex = 13
-			pj := newCollectorJob(nil, time.Second*1, m3, nil, "", nil)
+			pj := newCollectorJob(nil, time.Second*1, m3, nil, "", "", nil)
 			prs := make([]*processNode, 0)
 			pus := make([]*publishNode, 0)
 			counter := 0
@@ -414,7 +414,7 @@ func TestWorkJobs(t *testing.T) {
 				pr.ProcessNodes = cprs
 				pr.PublishNodes = cpus
 			}
-			workJobs(prs, pus, t, pj)
+			workJobs(prs, pus, t, newTaskFire(time.Time{}), pj)
 			So(t.failedRuns, ShouldEqual, 1)
 			So(t.lastFailureMessage, ShouldEqual, "I am an error")
 			// (3*3)+3
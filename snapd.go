@@ -46,6 +46,7 @@ import (
 	"github.com/intelsdi-x/snap/mgmt/tribe"
 	"github.com/intelsdi-x/snap/mgmt/tribe/agreement"
 	"github.com/intelsdi-x/snap/pkg/cfgfile"
+	"github.com/intelsdi-x/snap/pkg/psigning"
 	"github.com/intelsdi-x/snap/scheduler"
 )
 
@@ -187,6 +188,10 @@ type managesTribe interface {
 	LeaveAgreement(agreementName, memberName string) serror.SnapError
 	GetMembers() []string
 	GetMember(name string) *agreement.Member
+	GetStatus() *agreement.Status
+	GetDrift(name string) (*agreement.Drift, serror.SnapError)
+	GetAgreementTasks(name string) ([]agreement.MemberTasks, serror.SnapError)
+	GetAgreementPlugins(name string) ([]agreement.MemberPlugins, serror.SnapError)
 }
 
 func main() {
@@ -293,6 +298,7 @@ func action(ctx *cli.Context) error {
 	setMaxProcs(cfg.GoMaxProcs)
 
 	c := control.New(cfg.Control)
+	c.SetSnapdVersion(gitversion)
 
 	coreModules = []coreModule{}
 
@@ -340,6 +346,7 @@ func action(ctx *cli.Context) error {
 		}
 		r.BindMetricManager(c)
 		r.BindConfigManager(c.Config)
+		r.BindTrustManager(c)
 		r.BindTaskManager(s)
 
 		//Rest Authentication
@@ -385,12 +392,38 @@ func action(ctx *cli.Context) error {
 	// Keyring checking for trust levels 1 and 2
 	if cfg.Control.PluginTrust > 0 {
 		keyrings := filepath.SplitList(cfg.Control.KeyringPaths)
-		if len(keyrings) == 0 {
+		if len(keyrings) == 0 && cfg.Control.X509CAFiles == "" && cfg.Control.Ed25519KeyPaths == "" {
 			log.WithFields(
 				log.Fields{
 					"block":   "main",
 					"_module": "snapd",
-				}).Fatal("need keyring file when trust is on (--keyring-file or -k)")
+				}).Fatal("need keyring file, X.509 CA file or Ed25519 key when trust is on (--keyring-paths, --x509-ca-files or --ed25519-key-paths)")
+		}
+		if cfg.Control.X509CAFiles != "" {
+			v, err := psigning.NewX509Verifier(filepath.SplitList(cfg.Control.X509CAFiles), filepath.SplitList(cfg.Control.X509CRLFiles))
+			if err != nil {
+				log.WithFields(
+					log.Fields{
+						"block":   "main",
+						"_module": "snapd",
+						"error":   err.Error(),
+					}).Fatal("unable to read X.509 CA or CRL files")
+			}
+			log.Info("adding X.509 CA files: ", cfg.Control.X509CAFiles)
+			c.AddSignatureVerifier(v)
+		}
+		if cfg.Control.Ed25519KeyPaths != "" {
+			v, err := psigning.NewEd25519Verifier(filepath.SplitList(cfg.Control.Ed25519KeyPaths))
+			if err != nil {
+				log.WithFields(
+					log.Fields{
+						"block":   "main",
+						"_module": "snapd",
+						"error":   err.Error(),
+					}).Fatal("unable to read Ed25519 keys")
+			}
+			log.Info("adding Ed25519 keys from: ", cfg.Control.Ed25519KeyPaths)
+			c.AddSignatureVerifier(v)
 		}
 		for _, k := range keyrings {
 			keyringPath, err := filepath.Abs(k)
@@ -446,7 +479,15 @@ func action(ctx *cli.Context) error {
 						}
 						f.Close()
 						log.Info("adding keyring file: ", keyringPath+"/"+keyringFile.Name())
-						c.SetKeyringFile(keyringPath + "/" + keyringFile.Name())
+						if err := c.SetKeyringFile(keyringPath + "/" + keyringFile.Name()); err != nil {
+							log.WithFields(
+								log.Fields{
+									"block":       "main",
+									"_module":     "snapd",
+									"error":       err.Error(),
+									"keyringPath": keyringPath,
+								}).Fatal("unable to read keyring file")
+						}
 					}
 				}
 			} else {
@@ -462,7 +503,15 @@ func action(ctx *cli.Context) error {
 				}
 				f.Close()
 				log.Info("adding keyring file ", keyringPath)
-				c.SetKeyringFile(keyringPath)
+				if err := c.SetKeyringFile(keyringPath); err != nil {
+					log.WithFields(
+						log.Fields{
+							"block":       "main",
+							"_module":     "snapd",
+							"error":       err.Error(),
+							"keyringPath": keyringPath,
+						}).Fatal("unable to read keyring file")
+				}
 			}
 		}
 	}
@@ -792,6 +841,10 @@ func applyCmdLineFlags(cfg *Config, ctx *cli.Context) {
 	cfg.Control.PluginTrust = setIntVal(cfg.Control.PluginTrust, ctx, "plugin-trust")
 	cfg.Control.AutoDiscoverPath = setStringVal(cfg.Control.AutoDiscoverPath, ctx, "auto-discover")
 	cfg.Control.KeyringPaths = setStringVal(cfg.Control.KeyringPaths, ctx, "keyring-paths")
+	cfg.Control.PluginIndex = setStringVal(cfg.Control.PluginIndex, ctx, "plugin-index")
+	cfg.Control.X509CAFiles = setStringVal(cfg.Control.X509CAFiles, ctx, "x509-ca-files")
+	cfg.Control.X509CRLFiles = setStringVal(cfg.Control.X509CRLFiles, ctx, "x509-crl-files")
+	cfg.Control.Ed25519KeyPaths = setStringVal(cfg.Control.Ed25519KeyPaths, ctx, "ed25519-key-paths")
 	cfg.Control.CacheExpiration = jsonutil.Duration{setDurationVal(cfg.Control.CacheExpiration.Duration, ctx, "cache-expiration")}
 	cfg.Control.ListenAddr = setStringVal(cfg.Control.ListenAddr, ctx, "control-listen-addr")
 	cfg.Control.ListenPort = setIntVal(cfg.Control.ListenPort, ctx, "control-listen-port")
@@ -813,6 +866,15 @@ func applyCmdLineFlags(cfg *Config, ctx *cli.Context) {
 	cfg.Tribe.BindAddr = setStringVal(cfg.Tribe.BindAddr, ctx, "tribe-addr")
 	cfg.Tribe.BindPort = setIntVal(cfg.Tribe.BindPort, ctx, "tribe-port")
 	cfg.Tribe.Seed = setStringVal(cfg.Tribe.Seed, ctx, "tribe-seed")
+	if voters := ctx.String("tribe-voters"); voters != "" {
+		cfg.Tribe.Voters = nil
+		for _, v := range strings.Split(voters, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				cfg.Tribe.Voters = append(cfg.Tribe.Voters, v)
+			}
+		}
+	}
+	cfg.Tribe.RaftDir = setStringVal(cfg.Tribe.RaftDir, ctx, "tribe-raft-dir")
 	// check to see if we have duplicate port definitions (check the various
 	// combinations of the config file and command-line parameter values that
 	// could be used to define the port and make sure we only have one)